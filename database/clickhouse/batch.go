@@ -0,0 +1,246 @@
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+var structColumnsCache sync.Map
+
+// Batch is a columnar insert of rows of type T into one table, sent as a single block.
+type Batch[T any] struct {
+	ctx     context.Context
+	conn    driver.Conn
+	query   string
+	batch   driver.Batch
+	pending int
+	sent    int64
+}
+
+// NewBatch prepares a batch insert of structs of type T into table.
+//
+// Columns are the fields of T, named by their `ch` tag or, without a tag, by the field name.
+// Fields tagged `ch:"-"` and unexported fields are skipped, and embedded structs are flattened.
+// Columns of the table that T does not have get their default values.
+//
+// Parameters:
+//   - ctx: Context for the batch, optionally created with NewContext (e.g., WithAsyncInsert)
+//   - client: Client opened with Open
+//   - table: Table name, optionally qualified with the database
+//
+// Returns:
+//   - *Batch[T]: Batch to append rows to
+//   - error: Error if the client is not opened, T is not a struct or the table does not match
+//
+// Example:
+//
+//	type Event struct {
+//	    Time   time.Time `ch:"time"`
+//	    UserID uint64    `ch:"user_id"`
+//	    Name   string    `ch:"name"`
+//	}
+//
+//	batch, err := clickhouse.NewBatch[Event](ctx, &client, "events")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, event := range events {
+//	    if err := batch.Append(event); err != nil {
+//	        batch.Abort()
+//	        log.Fatal(err)
+//	    }
+//	}
+//	err = batch.Send()
+func NewBatch[T any](ctx context.Context, client *Client, table string) (*Batch[T], error) {
+	conn, err := client.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := structColumns(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Batch[T]{
+		ctx:   ctx,
+		conn:  conn,
+		query: "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ")",
+	}
+	if err := b.prepare(); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Append adds rows to the batch.
+//
+// Returns:
+//   - error: Error if a row cannot be converted to its column types
+func (b *Batch[T]) Append(rows ...T) error {
+	if b.batch == nil {
+		if err := b.prepare(); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		if err := b.batch.AppendStruct(&row); err != nil {
+			return err
+		}
+		b.pending++
+	}
+
+	return nil
+}
+
+// Send sends the appended rows to the server as one block and releases the connection of the
+// batch. The batch can be reused afterwards; further rows are sent by the next Send.
+//
+// Returns:
+//   - error: Error if the insert fails
+func (b *Batch[T]) Send() error {
+	if b.batch == nil {
+		return nil
+	}
+	if b.pending == 0 {
+		return b.Abort()
+	}
+
+	batch := b.batch
+	b.batch = nil
+
+	if err := batch.Send(); err != nil {
+		return err
+	}
+	b.sent += int64(b.pending)
+	b.pending = 0
+
+	return nil
+}
+
+// Abort discards the rows appended since the last Send.
+//
+// Returns:
+//   - error: Error if the batch cannot be aborted
+func (b *Batch[T]) Abort() error {
+	if b.batch == nil {
+		return nil
+	}
+
+	batch := b.batch
+	b.batch = nil
+	b.pending = 0
+
+	return batch.Abort()
+}
+
+// Pending returns the number of rows appended since the last Send.
+func (b *Batch[T]) Pending() int {
+	return b.pending
+}
+
+// Sent returns the number of rows sent by this batch.
+func (b *Batch[T]) Sent() int64 {
+	return b.sent
+}
+
+func (b *Batch[T]) prepare() error {
+	batch, err := b.conn.PrepareBatch(b.ctx, b.query)
+	if err != nil {
+		return err
+	}
+	b.batch = batch
+
+	return nil
+}
+
+// InsertAll inserts every row of rows into table, sending a block every batchSize rows.
+//
+// Parameters:
+//   - ctx: Context for the insert, optionally created with NewContext
+//   - client: Client opened with Open
+//   - table: Table name, optionally qualified with the database
+//   - rows: Rows to insert, e.g., slices.Values(events)
+//   - batchSize: Rows per block; zero or negative sends all rows as one block
+//
+// Returns:
+//   - int64: Number of rows sent
+//   - error: Error if preparing, appending or sending fails; rows of the failed block are not inserted
+//
+// Example:
+//
+//	count, err := clickhouse.InsertAll(ctx, &client, "events", slices.Values(events), 100000)
+func InsertAll[T any](ctx context.Context, client *Client, table string, rows iter.Seq[T], batchSize int) (int64, error) {
+	batch, err := NewBatch[T](ctx, client, table)
+	if err != nil {
+		return 0, err
+	}
+
+	for row := range rows {
+		if err := batch.Append(row); err != nil {
+			return batch.Sent(), errors.Join(err, batch.Abort())
+		}
+
+		if batchSize > 0 && batch.Pending() >= batchSize {
+			if err := batch.Send(); err != nil {
+				return batch.Sent(), err
+			}
+		}
+	}
+
+	err = batch.Send()
+
+	return batch.Sent(), err
+}
+
+func structColumns(t reflect.Type) ([]string, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, errors.New("batch rows must be structs, got " + t.String())
+	}
+
+	if columns, ok := structColumnsCache.Load(t); ok {
+		return columns.([]string), nil
+	}
+
+	columns := collectStructColumns(t)
+	if len(columns) == 0 {
+		return nil, errors.New(t.String() + " has no columns")
+	}
+
+	structColumnsCache.Store(t, columns)
+
+	return columns, nil
+}
+
+func collectStructColumns(t reflect.Type) []string {
+	columns := []string{}
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		name := field.Name
+		if tag := field.Tag.Get("ch"); tag != "" {
+			name = tag
+		}
+
+		switch {
+		case name == "-", !field.IsExported() && !field.Anonymous:
+			continue
+		case field.Anonymous:
+			if field.Type.Kind() == reflect.Struct {
+				columns = append(columns, collectStructColumns(field.Type)...)
+			}
+		default:
+			columns = append(columns, name)
+		}
+	}
+
+	return columns
+}
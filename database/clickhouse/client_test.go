@@ -0,0 +1,303 @@
+package clickhouse_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/clickhouse"
+	"github.com/common-library/go/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testcontainers_clickhouse "github.com/testcontainers/testcontainers-go/modules/clickhouse"
+)
+
+var dsn string
+
+type Event struct {
+	Time   time.Time `ch:"time"`
+	UserID uint64    `ch:"user_id"`
+	Name   string    `ch:"name"`
+	Ignore string    `ch:"-"`
+}
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	container, err := testcontainers_clickhouse.Run(ctx,
+		testutil.ClickHouseImage,
+		testcontainers_clickhouse.WithUsername("testuser"),
+		testcontainers_clickhouse.WithPassword("testpass"),
+		testcontainers_clickhouse.WithDatabase("testdb"),
+	)
+	if err != nil {
+		fmt.Printf("Failed to setup ClickHouse container: %v\n", err)
+		os.Exit(1)
+	}
+
+	dsn, err = container.ConnectionString(ctx)
+	if err != nil {
+		fmt.Printf("Failed to get ClickHouse connection string: %v\n", err)
+		container.Terminate(ctx)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+
+	container.Terminate(ctx)
+
+	os.Exit(code)
+}
+
+func newClient(t *testing.T) *clickhouse.Client {
+	t.Helper()
+
+	client := &clickhouse.Client{}
+	require.NoError(t, client.Open(dsn, 10))
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func createEventTable(t *testing.T, client *clickhouse.Client, table string) {
+	t.Helper()
+
+	ctx := context.Background()
+	require.NoError(t, client.Exec(ctx, "DROP TABLE IF EXISTS "+table))
+	require.NoError(t, client.Exec(ctx, "CREATE TABLE "+table+` (
+		time DateTime,
+		user_id UInt64,
+		name String,
+		source String DEFAULT 'batch'
+	) ENGINE = MergeTree ORDER BY (user_id, time)`))
+}
+
+func events(count int) []Event {
+	result := []Event{}
+	for i := range count {
+		result = append(result, Event{
+			Time:   time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC),
+			UserID: uint64(i % 3),
+			Name:   fmt.Sprintf("event-%03d", i),
+		})
+	}
+
+	return result
+}
+
+func TestClient_OpenAndClose(t *testing.T) {
+	client := &clickhouse.Client{}
+
+	assert.ErrorContains(t, client.Ping(context.Background()), "please call Open first")
+	assert.ErrorContains(t, client.Exec(context.Background(), "SELECT 1"), "please call Open first")
+	_, err := clickhouse.Query[int](context.Background(), client, "SELECT 1")
+	assert.Error(t, err)
+	assert.Nil(t, client.Conn())
+
+	require.NoError(t, client.Open(dsn, 5))
+	assert.NoError(t, client.Ping(context.Background()))
+	assert.NotNil(t, client.Conn())
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, client.Close())
+
+	assert.Error(t, client.Open("clickhouse://invalid host", 1))
+}
+
+func TestBatch(t *testing.T) {
+	client := newClient(t)
+	createEventTable(t, client, "test_batch")
+	ctx := context.Background()
+
+	batch, err := clickhouse.NewBatch[Event](ctx, client, "test_batch")
+	require.NoError(t, err)
+
+	require.NoError(t, batch.Append(events(10)...))
+	assert.Equal(t, 10, batch.Pending())
+	require.NoError(t, batch.Send())
+	assert.Equal(t, int64(10), batch.Sent())
+
+	require.NoError(t, batch.Append(events(5)...))
+	require.NoError(t, batch.Abort())
+	require.NoError(t, batch.Send())
+	assert.Equal(t, int64(10), batch.Sent())
+
+	count, err := clickhouse.QueryAll[uint64](ctx, client, "SELECT count() FROM test_batch")
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10}, count)
+
+	sources, err := clickhouse.QueryAll[string](ctx, client, "SELECT DISTINCT source FROM test_batch")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"batch"}, sources)
+
+	_, err = clickhouse.NewBatch[int](ctx, client, "test_batch")
+	assert.Error(t, err)
+
+	_, err = clickhouse.NewBatch[Event](ctx, client, "missing_table")
+	assert.Error(t, err)
+}
+
+func TestInsertAll(t *testing.T) {
+	client := newClient(t)
+	createEventTable(t, client, "test_insert_all")
+	ctx := context.Background()
+
+	count, err := clickhouse.InsertAll(ctx, client, "test_insert_all", slices.Values(events(25)), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), count)
+
+	count, err = clickhouse.InsertAll(ctx, client, "test_insert_all", slices.Values([]Event{}), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	total, err := clickhouse.QueryAll[uint64](ctx, client, "SELECT count() FROM test_insert_all")
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{25}, total)
+}
+
+func TestAsyncInsert(t *testing.T) {
+	client := newClient(t)
+	createEventTable(t, client, "test_async_insert")
+
+	ctx := clickhouse.NewContext(context.Background(), clickhouse.WithAsyncInsert(true), clickhouse.WithQueryID("test-async-insert-batch"))
+	count, err := clickhouse.InsertAll(ctx, client, "test_async_insert", slices.Values(events(3)), 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	ctx = clickhouse.NewContext(context.Background(), clickhouse.WithAsyncInsert(true), clickhouse.WithQueryID("test-async-insert-exec"))
+	require.NoError(t, client.Exec(ctx, "INSERT INTO test_async_insert (time, user_id, name) VALUES (?, ?, ?)",
+		time.Now(), uint64(9), "async"))
+
+	total, err := clickhouse.QueryAll[uint64](context.Background(), client, "SELECT count() FROM test_async_insert")
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{4}, total)
+
+	require.NoError(t, client.Exec(context.Background(), "SYSTEM FLUSH LOGS"))
+	for _, queryID := range []string{"test-async-insert-batch", "test-async-insert-exec"} {
+		asyncInserts, err := clickhouse.QueryAll[uint64](context.Background(), client,
+			"SELECT ProfileEvents['AsyncInsertQuery'] FROM system.query_log WHERE query_id = ? AND type = 'QueryFinish'", queryID)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1}, asyncInserts, queryID)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	client := newClient(t)
+	createEventTable(t, client, "test_query")
+	ctx := context.Background()
+
+	_, err := clickhouse.InsertAll(ctx, client, "test_query", slices.Values(events(30)), 0)
+	require.NoError(t, err)
+
+	rows, err := clickhouse.Query[Event](ctx, client, "SELECT time, user_id, name FROM test_query WHERE user_id = ? ORDER BY name", uint64(1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"time", "user_id", "name"}, rows.Columns())
+
+	result := slices.Collect(rows.All())
+	require.NoError(t, rows.Err())
+	require.Len(t, result, 10)
+	assert.Equal(t, "event-001", result[0].Name)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC), result[0].Time.UTC())
+
+	rows, err = clickhouse.Query[Event](ctx, client, "SELECT time, user_id, name FROM test_query ORDER BY name")
+	require.NoError(t, err)
+	first := []Event{}
+	for event := range rows.All() {
+		first = append(first, event)
+		if len(first) == 3 {
+			break
+		}
+	}
+	assert.Len(t, first, 3)
+	assert.NoError(t, rows.Close())
+
+	names, err := clickhouse.QueryAll[string](ctx, client, "SELECT name FROM test_query WHERE user_id = 2 ORDER BY name LIMIT 2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"event-002", "event-005"}, names)
+
+	times, err := clickhouse.QueryAll[time.Time](ctx, client, "SELECT max(time) FROM test_query")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 29, 0, time.UTC), times[0].UTC())
+
+	rows, err = clickhouse.Query[Event](ctx, client, "SELECT time, user_id, name, source FROM test_query")
+	require.NoError(t, err)
+	for range rows.All() {
+	}
+	assert.Error(t, rows.Err())
+
+	_, err = clickhouse.QueryAll[Event](ctx, client, "SELECT * FROM missing_table")
+	assert.Error(t, err)
+}
+
+func TestQueryOptions(t *testing.T) {
+	client := newClient(t)
+	createEventTable(t, client, "test_query_options")
+
+	_, err := clickhouse.InsertAll(context.Background(), client, "test_query_options", slices.Values(events(30)), 0)
+	require.NoError(t, err)
+
+	progress := atomic.Uint64{}
+	ctx := clickhouse.NewContext(context.Background(),
+		clickhouse.WithQueryID("test-query-options"),
+		clickhouse.WithSettings(map[string]any{"max_block_size": 10}),
+		clickhouse.WithParameters(map[string]string{"prefix": "event-00"}),
+		clickhouse.WithProgress(func(p *clickhouse.Progress) {
+			progress.Add(p.Rows)
+		}),
+	)
+
+	names, err := clickhouse.QueryAll[string](ctx, client, "SELECT name FROM test_query_options WHERE startsWith(name, {prefix:String}) ORDER BY name")
+	require.NoError(t, err)
+	assert.Len(t, names, 10)
+	assert.Equal(t, uint64(30), progress.Load())
+
+	ctx = clickhouse.NewContext(context.Background(), clickhouse.WithSettings(map[string]any{"max_result_rows": 1, "result_overflow_mode": "throw"}))
+	_, err = clickhouse.QueryAll[string](ctx, client, "SELECT name FROM test_query_options")
+	assert.Error(t, err)
+}
+
+func TestQueryOptionsMergeSettings(t *testing.T) {
+	client := newClient(t)
+
+	ctx := clickhouse.NewContext(context.Background(),
+		clickhouse.WithSettings(map[string]any{"max_threads": 3}),
+		clickhouse.WithAsyncInsert(true),
+	)
+	ctx = clickhouse.NewContext(ctx, clickhouse.WithSettings(map[string]any{"max_block_size": 10}))
+
+	settings, err := clickhouse.QueryAll[string](ctx, client, `
+		SELECT name || '=' || value FROM system.settings
+		WHERE name IN ('async_insert', 'max_block_size', 'max_threads', 'wait_for_async_insert')
+		ORDER BY name`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"async_insert=1", "max_block_size=10", "max_threads=3", "wait_for_async_insert=1"}, settings)
+}
+
+func TestExternalTable(t *testing.T) {
+	client := newClient(t)
+	createEventTable(t, client, "test_external_table")
+
+	_, err := clickhouse.InsertAll(context.Background(), client, "test_external_table", slices.Values(events(30)), 0)
+	require.NoError(t, err)
+
+	allowed, err := clickhouse.NewExternalTable("allowed_users",
+		[]clickhouse.Column{{Name: "user_id", Type: "UInt64"}, {Name: "label", Type: "String"}},
+		[][]any{{uint64(2), "two"}})
+	require.NoError(t, err)
+
+	ctx := clickhouse.NewContext(context.Background(), clickhouse.WithExternalTables(allowed))
+	labels, err := clickhouse.QueryAll[string](ctx, client,
+		"SELECT DISTINCT a.label FROM test_external_table AS e INNER JOIN allowed_users AS a ON e.user_id = a.user_id")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"two"}, labels)
+
+	_, err = clickhouse.NewExternalTable("invalid", []clickhouse.Column{{Name: "id", Type: "NotAType"}}, nil)
+	assert.Error(t, err)
+
+	_, err = clickhouse.NewExternalTable("mismatch", []clickhouse.Column{{Name: "id", Type: "UInt64"}}, [][]any{{"not a number"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package clickhouse
+
+import (
+	"context"
+	"maps"
+
+	clickhouse_go "github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/ext"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+)
+
+// Progress reports the rows and bytes a query has read and written so far.
+type Progress = clickhouse_go.Progress
+
+// ExternalTable is a temporary table sent along with a query.
+type ExternalTable = ext.Table
+
+// QueryOption configures a single query, insert or batch.
+type QueryOption struct {
+	apply clickhouse_go.QueryOption
+
+	// settings are merged by NewContext, since the settings of clickhouse-go replace each other.
+	settings map[string]any
+}
+
+// settingsKey is the context key of the settings merged by NewContext.
+type settingsKey struct{}
+
+// Column is the name and ClickHouse type of a column of an external table.
+type Column struct {
+	Name string
+	Type string
+}
+
+// NewContext returns a context that carries per-query options to Exec, Query, QueryAll and
+// NewBatch.
+//
+// The settings of WithSettings and WithAsyncInsert are merged with each other and with those of
+// a context already created with NewContext; a later option wins for a setting given twice.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - options: Query options such as WithSettings and WithProgress
+//
+// Returns:
+//   - context.Context: Context to pass to the query
+//
+// Example:
+//
+//	ctx = clickhouse.NewContext(ctx,
+//	    clickhouse.WithQueryID("report-2024-01"),
+//	    clickhouse.WithSettings(map[string]any{"max_execution_time": 60, "max_threads": 8}),
+//	    clickhouse.WithProgress(func(p *clickhouse.Progress) {
+//	        log.Printf("read %d rows", p.Rows)
+//	    }),
+//	)
+//	rows, err := clickhouse.Query[Report](ctx, &client, "SELECT ...")
+func NewContext(ctx context.Context, options ...QueryOption) context.Context {
+	settings, merge := ctx.Value(settingsKey{}).(map[string]any)
+	settings = maps.Clone(settings)
+
+	apply := []clickhouse_go.QueryOption{}
+	for _, option := range options {
+		if option.settings != nil {
+			if settings == nil {
+				settings = map[string]any{}
+			}
+
+			maps.Copy(settings, option.settings)
+			merge = true
+		} else if option.apply != nil {
+			apply = append(apply, option.apply)
+		}
+	}
+
+	if merge {
+		apply = append(apply, clickhouse_go.WithSettings(clickhouse_go.Settings(settings)))
+		ctx = context.WithValue(ctx, settingsKey{}, settings)
+	}
+
+	return clickhouse_go.Context(ctx, apply...)
+}
+
+// WithSettings sets ClickHouse settings for the query (e.g., "max_execution_time").
+func WithSettings(settings map[string]any) QueryOption {
+	option := QueryOption{settings: map[string]any{}}
+	maps.Copy(option.settings, settings)
+
+	return option
+}
+
+// WithParameters sets server-side query parameters, referenced as {name:Type} in the query.
+func WithParameters(parameters map[string]string) QueryOption {
+	return QueryOption{apply: clickhouse_go.WithParameters(clickhouse_go.Parameters(parameters))}
+}
+
+// WithQueryID sets the query ID, e.g., to find the query in system.query_log or to kill it.
+func WithQueryID(queryID string) QueryOption {
+	return QueryOption{apply: clickhouse_go.WithQueryID(queryID)}
+}
+
+// WithProgress sets a callback invoked as the server reports the progress of the query.
+func WithProgress(callback func(progress *Progress)) QueryOption {
+	return QueryOption{apply: clickhouse_go.WithProgress(callback)}
+}
+
+// WithExternalTables sends tables along with the query, which it can read like temporary tables.
+func WithExternalTables(tables ...*ExternalTable) QueryOption {
+	return QueryOption{apply: clickhouse_go.WithExternalTable(tables...)}
+}
+
+// WithAsyncInsert makes the inserts of Exec and NewBatch asynchronous: the server buffers them
+// with other inserts and flushes them together. If wait is true, an insert returns once the
+// buffer is flushed.
+//
+// It sets the async_insert and wait_for_async_insert settings, which NewContext merges with
+// those of WithSettings.
+func WithAsyncInsert(wait bool) QueryOption {
+	waitForAsyncInsert := 0
+	if wait {
+		waitForAsyncInsert = 1
+	}
+
+	return WithSettings(map[string]any{"async_insert": 1, "wait_for_async_insert": waitForAsyncInsert})
+}
+
+// NewExternalTable creates an external table with the given columns and rows.
+//
+// Parameters:
+//   - name: Table name used in the query
+//   - columns: Column names and ClickHouse types
+//   - rows: Rows with one value per column
+//
+// Returns:
+//   - *ExternalTable: Table to pass to WithExternalTables
+//   - error: Error if a column type is invalid or a row does not match the columns
+//
+// Example:
+//
+//	allowed, err := clickhouse.NewExternalTable("allowed_users",
+//	    []clickhouse.Column{{Name: "user_id", Type: "UInt64"}},
+//	    [][]any{{uint64(1)}, {uint64(7)}})
+//
+//	ctx = clickhouse.NewContext(ctx, clickhouse.WithExternalTables(allowed))
+//	rows, err := clickhouse.Query[Event](ctx, &client,
+//	    "SELECT * FROM events WHERE user_id IN (SELECT user_id FROM allowed_users)")
+func NewExternalTable(name string, columns []Column, rows [][]any) (*ExternalTable, error) {
+	definitions := []func(*ext.Table) error{}
+	for _, c := range columns {
+		definitions = append(definitions, ext.Column(c.Name, column.Type(c.Type)))
+	}
+
+	table, err := ext.NewTable(name, definitions...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if err := table.Append(row...); err != nil {
+			return nil, err
+		}
+	}
+
+	return table, nil
+}
@@ -0,0 +1,152 @@
+package clickhouse
+
+import (
+	"context"
+	"database/sql"
+	"iter"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+// Rows streams the result rows of a query as values of type T.
+type Rows[T any] struct {
+	rows driver.Rows
+	err  error
+}
+
+// Query runs a SELECT and returns its rows for streaming, without loading the whole result
+// into memory.
+//
+// When T is a struct, columns are mapped to fields by their `ch` tag or, without a tag, by the
+// field name. Otherwise the query must return a single column.
+//
+// Parameters:
+//   - ctx: Context for the query, optionally created with NewContext
+//   - client: Client opened with Open
+//   - query: SQL query (use ? for parameter placeholders)
+//   - args: Optional query parameters
+//
+// Returns:
+//   - *Rows[T]: Rows to iterate with All; call Close when stopping early
+//   - error: Error if the client is not opened or the query fails
+//
+// Example:
+//
+//	rows, err := clickhouse.Query[Event](ctx, &client, "SELECT time, user_id, name FROM events WHERE day = ?", day)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer rows.Close()
+//
+//	for event := range rows.All() {
+//	    fmt.Println(event.Name)
+//	}
+//	if err := rows.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+func Query[T any](ctx context.Context, client *Client, query string, args ...any) (*Rows[T], error) {
+	conn, err := client.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rows[T]{rows: rows}, nil
+}
+
+// QueryAll runs a SELECT and returns all of its rows.
+//
+// Column mapping follows the same rules as Query.
+//
+// Parameters:
+//   - ctx: Context for the query, optionally created with NewContext
+//   - client: Client opened with Open
+//   - query: SQL query (use ? for parameter placeholders)
+//   - args: Optional query parameters
+//
+// Returns:
+//   - []T: Result rows, empty if the query returns no rows
+//   - error: Error if the client is not opened, the query fails or a row cannot be scanned
+//
+// Example:
+//
+//	names, err := clickhouse.QueryAll[string](ctx, &client, "SELECT DISTINCT name FROM events")
+func QueryAll[T any](ctx context.Context, client *Client, query string, args ...any) ([]T, error) {
+	rows, err := Query[T](ctx, client, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []T{}
+	for row := range rows.All() {
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// All returns a sequence of the rows. The rows are closed when the sequence ends, and the
+// error that ended it, if any, is returned by Err.
+func (r *Rows[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		defer r.Close()
+
+		for r.rows.Next() {
+			var row T
+			if err := r.scan(&row); err != nil {
+				r.err = err
+				return
+			}
+
+			if !yield(row) {
+				return
+			}
+		}
+
+		r.err = r.rows.Err()
+	}
+}
+
+// Columns returns the column names of the result.
+func (r *Rows[T]) Columns() []string {
+	return r.rows.Columns()
+}
+
+// Err returns the error that ended the iteration, or nil if all rows were read.
+func (r *Rows[T]) Err() error {
+	return r.err
+}
+
+// Close releases the connection of the query. It is safe to call Close multiple times.
+func (r *Rows[T]) Close() error {
+	return r.rows.Close()
+}
+
+func (r *Rows[T]) scan(row *T) error {
+	if isStructTarget(reflect.TypeFor[T]()) {
+		return r.rows.ScanStruct(row)
+	}
+
+	return r.rows.Scan(row)
+}
+
+// isStructTarget reports whether rows are scanned into the fields of t rather than into t
+// itself, as for time.Time, big.Int and sql.Scanner implementations.
+func isStructTarget(t reflect.Type) bool {
+	switch {
+	case t.Kind() != reflect.Struct:
+		return false
+	case t == reflect.TypeFor[time.Time](), t == reflect.TypeFor[big.Int]():
+		return false
+	default:
+		return !reflect.PointerTo(t).Implements(reflect.TypeFor[sql.Scanner]())
+	}
+}
@@ -0,0 +1,389 @@
+package dbmate_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	amacneil_dbmate "github.com/amacneil/dbmate/v2/pkg/dbmate"
+	"github.com/amacneil/dbmate/v2/pkg/dbutil"
+	dbmate_mysql "github.com/amacneil/dbmate/v2/pkg/driver/mysql"
+	"github.com/common-library/go/database/dbmate"
+	sqlclient "github.com/common-library/go/database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"modernc.org/sqlite"
+)
+
+// sqliteDriver is a minimal dbmate driver on modernc.org/sqlite, so the Migrator can be tested
+// without cgo or containers.
+type sqliteDriver struct {
+	path  string
+	table string
+}
+
+// lockCalls records the calls of the GET_LOCK and RELEASE_LOCK functions registered on sqlite.
+var lockCalls = struct {
+	sync.Mutex
+	calls []string
+}{}
+
+func init() {
+	amacneil_dbmate.RegisterDriver(func(config amacneil_dbmate.DriverConfig) amacneil_dbmate.Driver {
+		return &sqliteDriver{path: config.DatabaseURL.Opaque + config.DatabaseURL.Path, table: config.MigrationsTableName}
+	}, "sqlite")
+
+	for _, name := range []string{"get_lock", "release_lock"} {
+		sqlite.MustRegisterScalarFunction(name, -1, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			lockCalls.Lock()
+			defer lockCalls.Unlock()
+
+			lockCalls.calls = append(lockCalls.calls, fmt.Sprintf("%s(%v)", name, args[0]))
+			return int64(1), nil
+		})
+	}
+}
+
+func (d *sqliteDriver) Open() (*sql.DB, error)             { return sql.Open("sqlite", d.path) }
+func (d *sqliteDriver) DatabaseExists() (bool, error)      { return true, nil }
+func (d *sqliteDriver) CreateDatabase() error              { return nil }
+func (d *sqliteDriver) DropDatabase() error                { return nil }
+func (d *sqliteDriver) DumpSchema(*sql.DB) ([]byte, error) { return nil, nil }
+func (d *sqliteDriver) Ping() error                        { return nil }
+func (d *sqliteDriver) QueryError(query string, err error) error {
+	return &amacneil_dbmate.QueryError{Err: err, Query: query}
+}
+
+func (d *sqliteDriver) MigrationsTableExists(db *sql.DB) (bool, error) {
+	count := 0
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", d.table).Scan(&count)
+
+	return count == 1, err
+}
+
+func (d *sqliteDriver) CreateMigrationsTable(db *sql.DB) error {
+	_, err := db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version VARCHAR(128) PRIMARY KEY)", d.table))
+	return err
+}
+
+func (d *sqliteDriver) SelectMigrations(db *sql.DB, limit int) (map[string]bool, error) {
+	rows, err := db.Query(fmt.Sprintf("SELECT version FROM %s", d.table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	migrations := map[string]bool{}
+	for rows.Next() {
+		version := ""
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		migrations[version] = true
+	}
+
+	return migrations, rows.Err()
+}
+
+func (d *sqliteDriver) InsertMigration(db dbutil.Transaction, version string) error {
+	_, err := db.Exec(fmt.Sprintf("INSERT INTO %s (version) VALUES (?)", d.table), version)
+	return err
+}
+
+func (d *sqliteDriver) DeleteMigration(db dbutil.Transaction, version string) error {
+	_, err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE version = ?", d.table), version)
+	return err
+}
+
+var migrations = fstest.MapFS{
+	"migrations/20240101000000_create_users.sql": {Data: []byte(`-- migrate:up
+CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+
+-- migrate:down
+DROP TABLE users;
+`)},
+	"migrations/20240102000000_create_orders.sql": {Data: []byte(`-- migrate:up
+CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL);
+
+-- migrate:down
+DROP TABLE orders;
+`)},
+	"migrations/20240103000000_add_email.sql": {Data: []byte(`-- migrate:up
+ALTER TABLE users ADD COLUMN email TEXT;
+
+-- migrate:down
+ALTER TABLE users DROP COLUMN email;
+`)},
+	"migrations/README.md": {Data: []byte("not a migration")},
+}
+
+func tableExists(t *testing.T, connection *sql.DB, table string) bool {
+	count := 0
+	require.NoError(t, connection.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&count))
+
+	return count == 1
+}
+
+func TestMigratorNotInitialized(t *testing.T) {
+	ctx := context.Background()
+	migrator := dbmate.Migrator{}
+
+	_, err := migrator.Status(ctx)
+	assert.EqualError(t, err, "please call Initialize first")
+
+	_, err = migrator.Up(ctx)
+	assert.EqualError(t, err, "please call Initialize first")
+
+	_, err = migrator.Down(ctx)
+	assert.EqualError(t, err, "please call Initialize first")
+
+	_, err = migrator.RollbackTo(ctx, "0")
+	assert.EqualError(t, err, "please call Initialize first")
+
+	assert.NoError(t, migrator.Finalize())
+}
+
+func TestMigratorInitialize(t *testing.T) {
+	migrator := dbmate.Migrator{}
+
+	assert.Error(t, migrator.Initialize("unknown://localhost/db", migrations, "migrations"))
+	assert.Error(t, migrator.Initialize("://invalid", migrations, "migrations"))
+
+	client := sqlclient.Client{}
+	assert.EqualError(t, migrator.InitializeWithClient(&client, migrations, "migrations"), "please call Open first")
+}
+
+func TestMigratorUpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "migrator.db")
+
+	migrator := dbmate.Migrator{}
+	require.NoError(t, migrator.Initialize("sqlite:"+path, migrations, "migrations"))
+	defer migrator.Finalize()
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []dbmate.Status{
+		{Version: "20240101000000", FileName: "20240101000000_create_users.sql", Applied: false},
+		{Version: "20240102000000", FileName: "20240102000000_create_orders.sql", Applied: false},
+		{Version: "20240103000000", FileName: "20240103000000_add_email.sql", Applied: false},
+	}, statuses)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20240101000000_create_users.sql", "20240102000000_create_orders.sql", "20240103000000_add_email.sql"}, applied)
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Applied, status.FileName)
+	}
+
+	rolledBack, err := migrator.Down(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "20240103000000_add_email.sql", rolledBack)
+
+	connection, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer connection.Close()
+
+	_, err = connection.Exec("INSERT INTO users (id, name) VALUES (1, 'alice')")
+	require.NoError(t, err)
+	_, err = connection.Exec("INSERT INTO users (id, name, email) VALUES (2, 'bob', 'bob@example.com')")
+	assert.Error(t, err)
+
+	rolledBack2, err := migrator.RollbackTo(ctx, "0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20240102000000_create_orders.sql", "20240101000000_create_users.sql"}, rolledBack2)
+	assert.False(t, tableExists(t, connection, "users"))
+
+	_, err = migrator.Down(ctx)
+	assert.EqualError(t, err, "no migrations to rollback")
+}
+
+func TestMigratorRollbackTo(t *testing.T) {
+	ctx := context.Background()
+
+	migrator := dbmate.Migrator{}
+	require.NoError(t, migrator.Initialize("sqlite:"+filepath.Join(t.TempDir(), "migrator.db"), migrations, "migrations"))
+	defer migrator.Finalize()
+
+	_, err := migrator.Up(ctx)
+	require.NoError(t, err)
+
+	_, err = migrator.RollbackTo(ctx, "20991231000000")
+	assert.EqualError(t, err, "unknown migration version 20991231000000")
+
+	rolledBack, err := migrator.RollbackTo(ctx, "20240101000000")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20240103000000_add_email.sql", "20240102000000_create_orders.sql"}, rolledBack)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+	assert.False(t, statuses[2].Applied)
+}
+
+func TestMigratorFailedMigration(t *testing.T) {
+	ctx := context.Background()
+
+	broken := fstest.MapFS{
+		"20240101000000_create_users.sql": migrations["migrations/20240101000000_create_users.sql"],
+		"20240102000000_broken.sql":       {Data: []byte("-- migrate:up\nCREATE TABLE broken (;\n\n-- migrate:down\n")},
+	}
+
+	migrator := dbmate.Migrator{}
+	require.NoError(t, migrator.Initialize("sqlite:"+filepath.Join(t.TempDir(), "migrator.db"), broken, "."))
+	defer migrator.Finalize()
+
+	applied, err := migrator.Up(ctx)
+	assert.ErrorContains(t, err, "20240102000000_broken.sql")
+	assert.Equal(t, []string{"20240101000000_create_users.sql"}, applied)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestMigratorDryRun(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "migrator.db")
+
+	migrator := dbmate.Migrator{}
+	require.NoError(t, migrator.Initialize("sqlite:"+path, migrations, "migrations"))
+	defer migrator.Finalize()
+
+	output := bytes.Buffer{}
+	migrator.SetDryRun(&output)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, 3)
+	assert.Equal(t, "-- up: 20240101000000_create_users.sql\n"+
+		"-- migrate:up\n"+
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);\n"+
+		"-- up: 20240102000000_create_orders.sql\n"+
+		"-- migrate:up\n"+
+		"CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL);\n"+
+		"-- up: 20240103000000_add_email.sql\n"+
+		"-- migrate:up\n"+
+		"ALTER TABLE users ADD COLUMN email TEXT;\n", output.String())
+
+	connection, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer connection.Close()
+	assert.False(t, tableExists(t, connection, "users"))
+	assert.False(t, tableExists(t, connection, "schema_migrations"))
+
+	migrator.SetDryRun(nil)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	output.Reset()
+	migrator.SetDryRun(&output)
+
+	rolledBack, err := migrator.Down(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "20240103000000_add_email.sql", rolledBack)
+	assert.Equal(t, "-- down: 20240103000000_add_email.sql\n-- migrate:down\nALTER TABLE users DROP COLUMN email;\n", output.String())
+	assert.True(t, tableExists(t, connection, "users"))
+}
+
+func TestMigratorWithClient(t *testing.T) {
+	ctx := context.Background()
+
+	client := sqlclient.Client{}
+	require.NoError(t, client.Open(sqlclient.DriverSQLite, filepath.Join(t.TempDir(), "client.db"), 1))
+	defer client.Close()
+
+	migrator := dbmate.Migrator{}
+	require.NoError(t, migrator.InitializeWithClient(&client, migrations, "migrations"))
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, 3)
+
+	require.NoError(t, migrator.Finalize())
+
+	err = client.Execute("INSERT INTO users (id, name, email) VALUES (1, 'alice', 'alice@example.com')")
+	assert.NoError(t, err)
+}
+
+// singleConnectionDriver opens sqlite with a pool of one connection, as an application limiting
+// its pool would.
+type singleConnectionDriver struct {
+	sqliteDriver
+}
+
+func (d *singleConnectionDriver) Open() (*sql.DB, error) {
+	connection, err := d.sqliteDriver.Open()
+	if err == nil {
+		connection.SetMaxOpenConns(1)
+	}
+
+	return connection, err
+}
+
+func TestMigratorLockWithSingleConnection(t *testing.T) {
+	// The mysql scheme takes the advisory lock; its driver is replaced by sqlite for this test.
+	amacneil_dbmate.RegisterDriver(func(config amacneil_dbmate.DriverConfig) amacneil_dbmate.Driver {
+		return &singleConnectionDriver{sqliteDriver{path: config.DatabaseURL.Opaque + config.DatabaseURL.Path, table: config.MigrationsTableName}}
+	}, "mysql")
+	t.Cleanup(func() { amacneil_dbmate.RegisterDriver(dbmate_mysql.NewDriver, "mysql") })
+
+	migrator := dbmate.Migrator{}
+	require.NoError(t, migrator.Initialize("mysql:"+filepath.Join(t.TempDir(), "locked.db"), migrations, "migrations"))
+	defer migrator.Finalize()
+
+	migrate := func(fn func() error) {
+		t.Helper()
+
+		done := make(chan error, 1)
+		go func() { done <- fn() }()
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("migration deadlocked on the locked connection")
+		}
+	}
+
+	lockCalls.Lock()
+	lockCalls.calls = nil
+	lockCalls.Unlock()
+
+	migrate(func() error {
+		applied, err := migrator.Up(context.Background())
+		assert.Len(t, applied, 3)
+		return err
+	})
+	migrate(func() error {
+		_, err := migrator.Down(context.Background())
+		return err
+	})
+
+	statuses, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, false}, []bool{statuses[0].Applied, statuses[1].Applied, statuses[2].Applied})
+
+	lockCalls.Lock()
+	defer lockCalls.Unlock()
+	assert.Equal(t, []string{
+		"get_lock(dbmate:schema_migrations)", "release_lock(dbmate:schema_migrations)",
+		"get_lock(dbmate:schema_migrations)", "release_lock(dbmate:schema_migrations)",
+	}, lockCalls.calls)
+}
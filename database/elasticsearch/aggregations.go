@@ -0,0 +1,189 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Aggregations are the aggregation results of a search, keyed by aggregation name.
+//
+// The results stay raw JSON until they are read with one of the typed accessors, so any
+// aggregation type can be decoded with Decode.
+type Aggregations map[string]json.RawMessage
+
+// MetricAggregation is the result of a single-value metric aggregation such as avg, sum, min, max,
+// cardinality or value_count.
+type MetricAggregation struct {
+	// Value is the result, nil when no document had a value
+	Value *float64 `json:"value"`
+
+	// ValueAsString is the formatted result, set for date fields and formatted aggregations
+	ValueAsString string `json:"value_as_string,omitempty"`
+}
+
+// StatsAggregation is the result of a stats aggregation.
+type StatsAggregation struct {
+	Count int64    `json:"count"`
+	Min   *float64 `json:"min"`
+	Max   *float64 `json:"max"`
+	Avg   *float64 `json:"avg"`
+	Sum   float64  `json:"sum"`
+}
+
+// BucketAggregation is the result of a multi-bucket aggregation such as terms, histogram,
+// date_histogram, range or filters.
+type BucketAggregation struct {
+	// DocCountErrorUpperBound is the maximum count error of terms aggregations
+	DocCountErrorUpperBound int64 `json:"doc_count_error_upper_bound"`
+
+	// SumOtherDocCount is the number of documents in buckets not returned by terms aggregations
+	SumOtherDocCount int64 `json:"sum_other_doc_count"`
+
+	// Buckets are the buckets; keyed results are returned sorted by key
+	Buckets []Bucket `json:"-"`
+}
+
+// Bucket is a bucket of a bucket aggregation, or the result of a single-bucket aggregation such as
+// filter, nested, global or missing.
+type Bucket struct {
+	// Key is the bucket key: a string, or a json.Number for numeric keys
+	Key any
+
+	// KeyAsString is the formatted key, set for date and formatted keys
+	KeyAsString string
+
+	// DocCount is the number of documents in the bucket
+	DocCount int64
+
+	// From and To are the bounds of range buckets
+	From, To *float64
+
+	// Aggregations are the results of the sub-aggregations
+	Aggregations Aggregations
+}
+
+// Decode decodes the named aggregation into target.
+//
+// Parameters:
+//   - name: Name of the aggregation in the request
+//   - target: Pointer to the value to decode into
+//
+// Returns error if the aggregation is missing or cannot be decoded.
+func (a Aggregations) Decode(name string, target any) error {
+	raw, ok := a[name]
+	if !ok {
+		return fmt.Errorf("aggregation %q not found", name)
+	}
+
+	if err := decodeNumbers(raw, target); err != nil {
+		return fmt.Errorf("aggregation %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// Metric returns the named single-value metric aggregation.
+func (a Aggregations) Metric(name string) (MetricAggregation, error) {
+	metric := MetricAggregation{}
+	err := a.Decode(name, &metric)
+	return metric, err
+}
+
+// Stats returns the named stats aggregation.
+func (a Aggregations) Stats(name string) (StatsAggregation, error) {
+	stats := StatsAggregation{}
+	err := a.Decode(name, &stats)
+	return stats, err
+}
+
+// Buckets returns the named multi-bucket aggregation.
+func (a Aggregations) Buckets(name string) (BucketAggregation, error) {
+	buckets := BucketAggregation{}
+	err := a.Decode(name, &buckets)
+	return buckets, err
+}
+
+// Bucket returns the named single-bucket aggregation.
+func (a Aggregations) Bucket(name string) (Bucket, error) {
+	bucket := Bucket{}
+	err := a.Decode(name, &bucket)
+	return bucket, err
+}
+
+// UnmarshalJSON decodes the buckets of both the array and the keyed form.
+func (b *BucketAggregation) UnmarshalJSON(data []byte) error {
+	type plain BucketAggregation
+	result := struct {
+		*plain
+		Buckets json.RawMessage `json:"buckets"`
+	}{plain: (*plain)(b)}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	b.Buckets = nil
+	switch {
+	case len(result.Buckets) == 0:
+		return fmt.Errorf("missing buckets")
+	case result.Buckets[0] == '[':
+		return decodeNumbers(result.Buckets, &b.Buckets)
+	default:
+		keyed := map[string]Bucket{}
+		if err := decodeNumbers(result.Buckets, &keyed); err != nil {
+			return err
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(keyed)) {
+			bucket := keyed[key]
+			bucket.Key = key
+			b.Buckets = append(b.Buckets, bucket)
+		}
+
+		return nil
+	}
+}
+
+// UnmarshalJSON decodes the bucket fields and collects the other objects as sub-aggregations.
+func (b *Bucket) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*b = Bucket{Aggregations: Aggregations{}}
+	for name, value := range fields {
+		var err error
+		switch name {
+		case "key":
+			err = decodeNumbers(value, &b.Key)
+		case "key_as_string":
+			err = json.Unmarshal(value, &b.KeyAsString)
+		case "doc_count":
+			err = json.Unmarshal(value, &b.DocCount)
+		case "from":
+			err = json.Unmarshal(value, &b.From)
+		case "to":
+			err = json.Unmarshal(value, &b.To)
+		default:
+			if len(value) != 0 && value[0] == '{' {
+				b.Aggregations[name] = value
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("bucket field %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func decodeNumbers(data []byte, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
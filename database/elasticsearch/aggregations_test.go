@@ -0,0 +1,78 @@
+package elasticsearch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/common-library/go/database/elasticsearch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregations(t *testing.T) {
+	aggregations := elasticsearch.Aggregations{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"avg_price": {"value": 12.5},
+		"empty": {"value": null},
+		"price_stats": {"count": 2, "min": 10, "max": 15, "avg": 12.5, "sum": 25},
+		"categories": {
+			"doc_count_error_upper_bound": 0,
+			"sum_other_doc_count": 3,
+			"buckets": [
+				{"key": "books", "doc_count": 2, "max_price": {"value": 15}},
+				{"key": 9007199254740993, "doc_count": 1}
+			]
+		},
+		"price_ranges": {
+			"buckets": {
+				"expensive": {"from": 100, "doc_count": 0},
+				"cheap": {"to": 100, "doc_count": 2}
+			}
+		},
+		"in_stock": {"doc_count": 1, "names": {"buckets": []}}
+	}`), &aggregations))
+
+	metric, err := aggregations.Metric("avg_price")
+	require.NoError(t, err)
+	assert.Equal(t, 12.5, *metric.Value)
+
+	metric, err = aggregations.Metric("empty")
+	require.NoError(t, err)
+	assert.Nil(t, metric.Value)
+
+	stats, err := aggregations.Stats("price_stats")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.Count)
+	assert.Equal(t, 15.0, *stats.Max)
+	assert.Equal(t, 25.0, stats.Sum)
+
+	categories, err := aggregations.Buckets("categories")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), categories.SumOtherDocCount)
+	require.Len(t, categories.Buckets, 2)
+	assert.Equal(t, "books", categories.Buckets[0].Key)
+	assert.Equal(t, json.Number("9007199254740993"), categories.Buckets[1].Key)
+	maxPrice, err := categories.Buckets[0].Aggregations.Metric("max_price")
+	require.NoError(t, err)
+	assert.Equal(t, 15.0, *maxPrice.Value)
+
+	ranges, err := aggregations.Buckets("price_ranges")
+	require.NoError(t, err)
+	require.Len(t, ranges.Buckets, 2)
+	assert.Equal(t, "cheap", ranges.Buckets[0].Key)
+	assert.Equal(t, 100.0, *ranges.Buckets[0].To)
+	assert.Nil(t, ranges.Buckets[0].From)
+	assert.Equal(t, "expensive", ranges.Buckets[1].Key)
+
+	inStock, err := aggregations.Bucket("in_stock")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), inStock.DocCount)
+	names, err := inStock.Aggregations.Buckets("names")
+	require.NoError(t, err)
+	assert.Empty(t, names.Buckets)
+
+	_, err = aggregations.Metric("missing")
+	assert.ErrorContains(t, err, "not found")
+	_, err = aggregations.Buckets("avg_price")
+	assert.ErrorContains(t, err, "missing buckets")
+}
@@ -0,0 +1,122 @@
+package elasticsearch
+
+import (
+	"context"
+	"time"
+)
+
+// BulkIndexer batches document operations into _bulk requests.
+//
+// Items are buffered and sent when the buffer reaches BulkIndexerConfig.FlushBytes or
+// BulkIndexerConfig.FlushCount, or when BulkIndexerConfig.FlushInterval passes. Requests are sent
+// by a bounded number of workers; when all of them are busy Add blocks, which keeps producers from
+// outrunning the cluster. Items rejected with 429 Too Many Requests are retried with exponential
+// backoff. The outcome of every item is reported to its callbacks.
+type BulkIndexer interface {
+	// Add queues an item, blocking while all workers are busy. It fails once ctx is done or the indexer is closed.
+	Add(ctx context.Context, item BulkItem) error
+
+	// Flush sends the buffered items and waits until all queued items have completed.
+	Flush(ctx context.Context) error
+
+	// Close flushes the buffered items, waits for them and stops the workers. Add fails afterwards.
+	// If ctx is done first, the requests and retries in progress are canceled, the items not yet
+	// sent fail, and the error of ctx is returned.
+	Close(ctx context.Context) error
+
+	// Stats returns the counters of the indexer.
+	Stats() BulkIndexerStats
+}
+
+// BulkIndexerConfig configures a BulkIndexer. Zero values select the defaults.
+type BulkIndexerConfig struct {
+	// FlushBytes is the request body size that triggers a flush (default 5 MiB)
+	FlushBytes int
+
+	// FlushCount is the number of items that triggers a flush (default 1000)
+	FlushCount int
+
+	// FlushInterval is the longest time items wait in the buffer (default 30s)
+	FlushInterval time.Duration
+
+	// Workers is the number of concurrent _bulk requests (default runtime.NumCPU())
+	Workers int
+
+	// MaxRetries is the number of retries of items rejected with 429 (default 3, negative disables retries)
+	MaxRetries int
+
+	// RetryBackoff is the wait before the first retry, doubled for every further retry (default 1s)
+	RetryBackoff time.Duration
+
+	// Refresh is the refresh parameter of the requests: "true", "false", "wait_for" or "" for the server default
+	Refresh string
+
+	// OnError is called with errors of whole requests (can be nil); the items are also reported to their OnFailure
+	OnError func(err error)
+}
+
+// BulkItem is a document operation of a BulkIndexer.
+type BulkItem struct {
+	// Action is "index" (default), "create", "update" or "delete"
+	Action string
+
+	// Index is the target index
+	Index string
+
+	// DocumentID is the document identifier; it may be empty for "index" to generate one
+	DocumentID string
+
+	// Body is the JSON document, or the update body (e.g., {"doc": {...}}) for "update"; ignored for "delete"
+	Body string
+
+	// OnSuccess is called when the item succeeded (can be nil)
+	OnSuccess func(item BulkItem, response BulkItemResponse)
+
+	// OnFailure is called when the item failed, after any retries (can be nil)
+	OnFailure func(item BulkItem, response BulkItemResponse, err error)
+}
+
+// BulkItemResponse is the result of one item of a _bulk request.
+type BulkItemResponse struct {
+	// Index is the index of the document
+	Index string `json:"_index"`
+
+	// DocumentID is the identifier of the document, including generated ones
+	DocumentID string `json:"_id"`
+
+	// Status is the HTTP status of the item
+	Status int `json:"status"`
+
+	// Result is the outcome, such as "created", "updated", "deleted" or "not_found"
+	Result string `json:"result"`
+
+	// Error describes the failure of the item, nil on success
+	Error *BulkItemError `json:"error,omitempty"`
+}
+
+// BulkItemError is the error of a failed bulk item.
+type BulkItemError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// BulkIndexerStats are the counters of a BulkIndexer.
+type BulkIndexerStats struct {
+	// Added is the number of items added
+	Added uint64
+
+	// Flushed is the number of items sent, counting every retry
+	Flushed uint64
+
+	// Succeeded is the number of items that succeeded
+	Succeeded uint64
+
+	// Failed is the number of items that failed
+	Failed uint64
+
+	// Retried is the number of item retries after 429 responses
+	Retried uint64
+
+	// Requests is the number of _bulk requests
+	Requests uint64
+}
@@ -6,9 +6,13 @@
 // Features:
 //   - Multi-version support (v7, v8, v9)
 //   - Document operations (index, exists, delete)
+//   - Bulk indexing with size, count and interval flushing, bounded workers and 429 retries
 //   - Index management (create, delete, exists)
-//   - Template management
-//   - Search operations
+//   - Template management (legacy, composable index and component templates)
+//   - Aliases with atomic swaps, rollover and zero-downtime reindex
+//   - ILM policies and snapshot/restore
+//   - Search operations with a version-neutral query builder (see the query package)
+//   - Deep paging with typed hits and aggregations (point in time on v8/v9, scroll on v7)
 //   - Force merge support
 //
 // Example:
@@ -20,7 +24,10 @@
 //	client.Index("myindex", "doc1", `{"field":"value"}`)
 package elasticsearch
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type ClientInterface interface {
 	Initialize(addresses []string, timeout time.Duration, cloudID, apiKey, username, password, certificateFingerprint string, caCert []byte) error
@@ -40,7 +47,36 @@ type ClientInterface interface {
 	IndicesPutTemplate(name, body string) error
 	IndicesDeleteTemplate(name string) error
 
+	IndicesExistsIndexTemplate(name string) (bool, error)
+	IndicesPutIndexTemplate(name, body string) error
+	IndicesDeleteIndexTemplate(name string) error
+
+	ClusterExistsComponentTemplate(name string) (bool, error)
+	ClusterPutComponentTemplate(name, body string) error
+	ClusterDeleteComponentTemplate(name string) error
+
+	IndicesUpdateAliases(actions []AliasAction) error
+	IndicesGetAlias(alias string) ([]string, error)
+
+	IndicesRollover(alias, newIndex, body string) (RolloverResult, error)
+
 	IndicesForcemerge(indices []string) error
 
+	Reindex(ctx context.Context, body string, pollInterval time.Duration) (ReindexResult, error)
+
+	ILMPutLifecycle(policy, body string) error
+	ILMGetLifecycle(policy string) (string, error)
+	ILMDeleteLifecycle(policy string) error
+
+	SnapshotCreateRepository(repository, body string) error
+	SnapshotDeleteRepository(repository string) error
+	SnapshotCreate(repository, snapshot, body string, waitForCompletion bool) error
+	SnapshotRestore(repository, snapshot, body string, waitForCompletion bool) error
+	SnapshotDelete(repository, snapshot string) error
+
 	Search(index, body string) (string, error)
+
+	BulkIndexer(config BulkIndexerConfig) (BulkIndexer, error)
+
+	OpenSearchCursor(ctx context.Context, index, body string, config SearchIteratorConfig) (SearchCursor, error)
 }
@@ -0,0 +1,433 @@
+// Package esbulk implements the elasticsearch.BulkIndexer shared by the v7, v8 and v9 clients.
+package esbulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/common-library/go/database/elasticsearch"
+)
+
+const (
+	defaultFlushBytes    = 5 * 1024 * 1024
+	defaultFlushCount    = 1000
+	defaultFlushInterval = 30 * time.Second
+	defaultMaxRetries    = 3
+	defaultRetryBackoff  = time.Second
+)
+
+// Do sends a _bulk request body and returns the status code and body of the response.
+type Do func(ctx context.Context, body []byte) (int, []byte, error)
+
+type entry struct {
+	item    elasticsearch.BulkItem
+	data    []byte
+	attempt int
+}
+
+type bulkResponse struct {
+	Items []map[string]elasticsearch.BulkItemResponse `json:"items"`
+}
+
+type bulkIndexer struct {
+	config elasticsearch.BulkIndexerConfig
+	do     Do
+
+	mutex       sync.Mutex
+	buffer      []entry
+	bufferBytes int
+	closed      bool
+	pending     int
+	idle        chan struct{}
+
+	// senders counts the calls of send, which must return before batches is closed.
+	senders sync.WaitGroup
+
+	// ctx is canceled by Close to abort the requests and retries in progress.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	batches     chan []entry
+	stop        chan struct{}
+	flusherDone chan struct{}
+	workers     sync.WaitGroup
+
+	added, flushed, succeeded, failed, retried, requests atomic.Uint64
+}
+
+// New creates a BulkIndexer sending its requests with do and starts its workers.
+//
+// Zero values of config are replaced by the defaults documented on elasticsearch.BulkIndexerConfig.
+func New(config elasticsearch.BulkIndexerConfig, do Do) elasticsearch.BulkIndexer {
+	if config.FlushBytes <= 0 {
+		config.FlushBytes = defaultFlushBytes
+	}
+	if config.FlushCount <= 0 {
+		config.FlushCount = defaultFlushCount
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaultFlushInterval
+	}
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	} else if config.MaxRetries == 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaultRetryBackoff
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &bulkIndexer{
+		config:      config,
+		do:          do,
+		ctx:         ctx,
+		cancel:      cancel,
+		idle:        make(chan struct{}),
+		batches:     make(chan []entry),
+		stop:        make(chan struct{}),
+		flusherDone: make(chan struct{}),
+	}
+	close(b.idle)
+
+	for range config.Workers {
+		b.workers.Add(1)
+		go func() {
+			defer b.workers.Done()
+
+			for batch := range b.batches {
+				b.process(batch)
+				b.done()
+			}
+		}()
+	}
+
+	go b.flushPeriodically()
+
+	return b
+}
+
+func (b *bulkIndexer) Add(ctx context.Context, item elasticsearch.BulkItem) error {
+	data, err := encode(item)
+	if err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	if b.closed {
+		b.mutex.Unlock()
+		return errors.New("bulk indexer is closed")
+	}
+
+	b.added.Add(1)
+	b.buffer = append(b.buffer, entry{item: item, data: data})
+	b.bufferBytes += len(data)
+
+	var batch []entry
+	if b.bufferBytes >= b.config.FlushBytes || len(b.buffer) >= b.config.FlushCount {
+		batch = b.takeLocked()
+	}
+	b.mutex.Unlock()
+
+	return b.send(ctx, batch)
+}
+
+func (b *bulkIndexer) Flush(ctx context.Context) error {
+	b.mutex.Lock()
+	batch := b.takeLocked()
+	b.mutex.Unlock()
+
+	if err := b.send(ctx, batch); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	idle := b.idle
+	b.mutex.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *bulkIndexer) Close(ctx context.Context) error {
+	b.mutex.Lock()
+	if b.closed {
+		b.mutex.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mutex.Unlock()
+
+	close(b.stop)
+	<-b.flusherDone
+
+	// The workers are stopped even if ctx ends the flush, since a second Close returns early.
+	// Canceling aborts the requests in progress and fails the batches still being sent, so
+	// that no send is left to write to the closed channel.
+	err := b.Flush(ctx)
+	if err != nil {
+		b.cancel()
+	}
+
+	b.senders.Wait()
+	close(b.batches)
+	b.workers.Wait()
+	b.cancel()
+
+	return err
+}
+
+func (b *bulkIndexer) Stats() elasticsearch.BulkIndexerStats {
+	return elasticsearch.BulkIndexerStats{
+		Added:     b.added.Load(),
+		Flushed:   b.flushed.Load(),
+		Succeeded: b.succeeded.Load(),
+		Failed:    b.failed.Load(),
+		Retried:   b.retried.Load(),
+		Requests:  b.requests.Load(),
+	}
+}
+
+// takeLocked empties the buffer and counts the batch as pending and its send as in progress. It
+// returns nil for an empty buffer.
+func (b *bulkIndexer) takeLocked() []entry {
+	if len(b.buffer) == 0 {
+		return nil
+	}
+
+	batch := b.buffer
+	b.buffer = nil
+	b.bufferBytes = 0
+
+	if b.pending == 0 {
+		b.idle = make(chan struct{})
+	}
+	b.pending++
+	b.senders.Add(1)
+
+	return batch
+}
+
+// done marks a pending batch as completed.
+func (b *bulkIndexer) done() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.pending--
+	if b.pending == 0 {
+		close(b.idle)
+	}
+}
+
+// send hands a batch taken with takeLocked to the workers, blocking while all of them are busy.
+func (b *bulkIndexer) send(ctx context.Context, batch []entry) error {
+	if batch == nil {
+		return nil
+	}
+	defer b.senders.Done()
+
+	select {
+	case b.batches <- batch:
+		return nil
+	case <-ctx.Done():
+		b.fail(batch, elasticsearch.BulkItemResponse{}, ctx.Err())
+		b.done()
+		return ctx.Err()
+	case <-b.ctx.Done():
+		b.fail(batch, elasticsearch.BulkItemResponse{}, b.ctx.Err())
+		b.done()
+		return b.ctx.Err()
+	}
+}
+
+func (b *bulkIndexer) flushPeriodically() {
+	defer close(b.flusherDone)
+
+	ticker := time.NewTicker(b.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			b.mutex.Lock()
+			batch := b.takeLocked()
+			b.mutex.Unlock()
+
+			b.send(context.Background(), batch)
+		}
+	}
+}
+
+// process sends a batch and retries the items rejected with 429 until they succeed or run out of
+// retries. The batch fails once Close cancels the indexer.
+func (b *bulkIndexer) process(batch []entry) {
+	for len(batch) != 0 {
+		if err := b.ctx.Err(); err != nil {
+			b.fail(batch, elasticsearch.BulkItemResponse{}, err)
+			return
+		}
+
+		batch = b.request(batch)
+		if len(batch) == 0 {
+			return
+		}
+
+		b.retried.Add(uint64(len(batch)))
+
+		timer := time.NewTimer(b.config.RetryBackoff << (batch[0].attempt - 1))
+		select {
+		case <-timer.C:
+		case <-b.ctx.Done():
+			timer.Stop()
+		}
+	}
+}
+
+// request sends one _bulk request and returns the entries to retry.
+func (b *bulkIndexer) request(batch []entry) []entry {
+	body := bytes.Buffer{}
+	for _, entry := range batch {
+		body.Write(entry.data)
+	}
+
+	b.requests.Add(1)
+	b.flushed.Add(uint64(len(batch)))
+
+	status, data, err := b.do(b.ctx, body.Bytes())
+	if err == nil && status == http.StatusTooManyRequests {
+		return b.retry(batch, elasticsearch.BulkItemResponse{Status: status}, errors.New("too many requests"))
+	} else if err == nil && (status < 200 || status > 299) {
+		err = fmt.Errorf("bulk request failed - status : (%d), body : (%s)", status, data)
+	}
+
+	response := bulkResponse{}
+	if err == nil {
+		if err = json.Unmarshal(data, &response); err == nil && len(response.Items) != len(batch) {
+			err = fmt.Errorf("bulk response has %d items for %d requests", len(response.Items), len(batch))
+		}
+	}
+
+	if err != nil {
+		if b.config.OnError != nil {
+			b.config.OnError(err)
+		}
+		b.fail(batch, elasticsearch.BulkItemResponse{Status: status}, err)
+		return nil
+	}
+
+	retries := []entry{}
+	for i, current := range batch {
+		for _, itemResponse := range response.Items[i] {
+			switch {
+			case itemResponse.Status >= 200 && itemResponse.Status <= 299:
+				b.succeeded.Add(1)
+				if current.item.OnSuccess != nil {
+					current.item.OnSuccess(current.item, itemResponse)
+				}
+			case itemResponse.Status == http.StatusTooManyRequests && current.attempt < b.config.MaxRetries:
+				current.attempt++
+				retries = append(retries, current)
+			default:
+				b.fail([]entry{current}, itemResponse, itemError(itemResponse))
+			}
+		}
+	}
+
+	return retries
+}
+
+// retry returns the entries that have retries left and fails the others.
+func (b *bulkIndexer) retry(batch []entry, response elasticsearch.BulkItemResponse, err error) []entry {
+	retries := []entry{}
+	for _, current := range batch {
+		if current.attempt < b.config.MaxRetries {
+			current.attempt++
+			retries = append(retries, current)
+		} else {
+			b.fail([]entry{current}, response, err)
+		}
+	}
+
+	return retries
+}
+
+func (b *bulkIndexer) fail(batch []entry, response elasticsearch.BulkItemResponse, err error) {
+	for _, entry := range batch {
+		b.failed.Add(1)
+
+		if entry.item.OnFailure != nil {
+			itemResponse := response
+			itemResponse.Index, itemResponse.DocumentID = entry.item.Index, entry.item.DocumentID
+			entry.item.OnFailure(entry.item, itemResponse, err)
+		}
+	}
+}
+
+func itemError(response elasticsearch.BulkItemResponse) error {
+	if response.Error == nil {
+		return fmt.Errorf("bulk item failed - status : (%d)", response.Status)
+	}
+
+	return fmt.Errorf("bulk item failed - status : (%d), type : (%s), reason : (%s)", response.Status, response.Error.Type, response.Error.Reason)
+}
+
+// encode returns the action and source lines of an item.
+func encode(item elasticsearch.BulkItem) ([]byte, error) {
+	action := item.Action
+	if action == "" {
+		action = "index"
+	}
+
+	switch action {
+	case "index", "create":
+	case "update", "delete":
+		if item.DocumentID == "" {
+			return nil, fmt.Errorf("%s requires a document ID", action)
+		}
+	default:
+		return nil, fmt.Errorf("invalid bulk action %q", action)
+	}
+
+	if item.Index == "" {
+		return nil, errors.New("index is required")
+	}
+
+	meta, err := json.Marshal(map[string]any{action: struct {
+		Index string `json:"_index"`
+		ID    string `json:"_id,omitempty"`
+	}{Index: item.Index, ID: item.DocumentID}})
+	if err != nil {
+		return nil, err
+	}
+
+	data := bytes.Buffer{}
+	data.Write(meta)
+	data.WriteByte('\n')
+
+	if action != "delete" {
+		if err := json.Compact(&data, []byte(item.Body)); err != nil {
+			return nil, fmt.Errorf("invalid body: %w", err)
+		}
+		data.WriteByte('\n')
+	}
+
+	return data.Bytes(), nil
+}
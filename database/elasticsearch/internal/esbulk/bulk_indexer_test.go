@@ -0,0 +1,258 @@
+package esbulk_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/elasticsearch"
+	"github.com/common-library/go/database/elasticsearch/internal/esbulk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBulk answers _bulk requests, returning the status of each item from statusOf.
+type fakeBulk struct {
+	mutex    sync.Mutex
+	requests [][]string
+	statusOf func(attempt int, id string) int
+	attempts map[string]int
+}
+
+func (f *fakeBulk) do(ctx context.Context, body []byte) (int, []byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	f.requests = append(f.requests, lines)
+
+	items := []map[string]any{}
+	for i := 0; i < len(lines); i++ {
+		meta := map[string]map[string]string{}
+		if err := json.Unmarshal([]byte(lines[i]), &meta); err != nil {
+			return 0, nil, err
+		}
+
+		for action, value := range meta {
+			if action != "delete" {
+				i++
+			}
+
+			f.attempts[value["_id"]]++
+			status := http.StatusCreated
+			if f.statusOf != nil {
+				status = f.statusOf(f.attempts[value["_id"]], value["_id"])
+			}
+
+			item := map[string]any{"_index": value["_index"], "_id": value["_id"], "status": status}
+			if status >= 300 {
+				item["error"] = map[string]string{"type": "test_exception", "reason": "rejected"}
+			} else {
+				item["result"] = "created"
+			}
+			items = append(items, map[string]any{action: item})
+		}
+	}
+
+	data, err := json.Marshal(map[string]any{"errors": false, "items": items})
+	return http.StatusOK, data, err
+}
+
+func newFakeBulk(statusOf func(attempt int, id string) int) *fakeBulk {
+	return &fakeBulk{statusOf: statusOf, attempts: map[string]int{}}
+}
+
+func TestBulkIndexer_FlushCount(t *testing.T) {
+	fake := newFakeBulk(nil)
+	indexer := esbulk.New(elasticsearch.BulkIndexerConfig{FlushCount: 2, FlushInterval: time.Hour, Workers: 1}, fake.do)
+
+	succeeded := atomic.Int32{}
+	for i := range 5 {
+		err := indexer.Add(context.Background(), elasticsearch.BulkItem{
+			Index:      "products",
+			DocumentID: fmt.Sprint(i),
+			Body:       `{ "name" : "product" }`,
+			OnSuccess: func(item elasticsearch.BulkItem, response elasticsearch.BulkItemResponse) {
+				assert.Equal(t, item.DocumentID, response.DocumentID)
+				assert.Equal(t, "created", response.Result)
+				succeeded.Add(1)
+			},
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, indexer.Close(context.Background()))
+
+	assert.Equal(t, int32(5), succeeded.Load())
+	require.Len(t, fake.requests, 3)
+	assert.Equal(t, []string{`{"index":{"_index":"products","_id":"0"}}`, `{"name":"product"}`,
+		`{"index":{"_index":"products","_id":"1"}}`, `{"name":"product"}`}, fake.requests[0])
+	assert.Equal(t, elasticsearch.BulkIndexerStats{Added: 5, Flushed: 5, Succeeded: 5, Requests: 3}, indexer.Stats())
+
+	assert.ErrorContains(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Index: "products", Body: `{}`}), "closed")
+	assert.NoError(t, indexer.Close(context.Background()))
+}
+
+func TestBulkIndexer_FlushBytesAndInterval(t *testing.T) {
+	fake := newFakeBulk(nil)
+	indexer := esbulk.New(elasticsearch.BulkIndexerConfig{FlushBytes: 100, FlushInterval: 50 * time.Millisecond}, fake.do)
+	defer indexer.Close(context.Background())
+
+	require.NoError(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Index: "logs", DocumentID: "1", Body: fmt.Sprintf(`{"message":"%0100d"}`, 0)}))
+	assert.Eventually(t, func() bool { return indexer.Stats().Requests == 1 }, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Index: "logs", DocumentID: "2", Body: `{}`}))
+	assert.Equal(t, uint64(1), indexer.Stats().Requests)
+	assert.Eventually(t, func() bool { return indexer.Stats().Succeeded == 2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestBulkIndexer_Retry(t *testing.T) {
+	fake := newFakeBulk(func(attempt int, id string) int {
+		switch {
+		case id == "throttled" && attempt <= 2:
+			return http.StatusTooManyRequests
+		case id == "overloaded":
+			return http.StatusTooManyRequests
+		case id == "invalid":
+			return http.StatusBadRequest
+		}
+		return http.StatusOK
+	})
+	indexer := esbulk.New(elasticsearch.BulkIndexerConfig{MaxRetries: 2, RetryBackoff: time.Millisecond, FlushInterval: time.Hour}, fake.do)
+
+	failures := sync.Map{}
+	for _, id := range []string{"throttled", "overloaded", "invalid", "ok"} {
+		require.NoError(t, indexer.Add(context.Background(), elasticsearch.BulkItem{
+			Action:     "update",
+			Index:      "products",
+			DocumentID: id,
+			Body:       `{"doc":{"stock":1}}`,
+			OnFailure: func(item elasticsearch.BulkItem, response elasticsearch.BulkItemResponse, err error) {
+				failures.Store(item.DocumentID, response.Status)
+				assert.Error(t, err)
+			},
+		}))
+	}
+	require.NoError(t, indexer.Flush(context.Background()))
+
+	assert.Equal(t, elasticsearch.BulkIndexerStats{Added: 4, Flushed: 8, Succeeded: 2, Failed: 2, Retried: 4, Requests: 3}, indexer.Stats())
+	status, ok := failures.Load("overloaded")
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	status, ok = failures.Load("invalid")
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	require.NoError(t, indexer.Close(context.Background()))
+}
+
+func TestBulkIndexer_RequestError(t *testing.T) {
+	calls := atomic.Int32{}
+	do := func(ctx context.Context, body []byte) (int, []byte, error) {
+		if calls.Add(1) == 1 {
+			return http.StatusTooManyRequests, nil, nil
+		}
+		return 0, nil, errors.New("connection refused")
+	}
+
+	errs := make(chan error, 1)
+	failed := atomic.Int32{}
+	indexer := esbulk.New(elasticsearch.BulkIndexerConfig{RetryBackoff: time.Millisecond, OnError: func(err error) { errs <- err }}, do)
+
+	require.NoError(t, indexer.Add(context.Background(), elasticsearch.BulkItem{
+		Action:     "delete",
+		Index:      "products",
+		DocumentID: "1",
+		OnFailure: func(item elasticsearch.BulkItem, response elasticsearch.BulkItemResponse, err error) {
+			assert.Equal(t, "1", response.DocumentID)
+			failed.Add(1)
+		},
+	}))
+	require.NoError(t, indexer.Close(context.Background()))
+
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, int32(1), failed.Load())
+	assert.ErrorContains(t, <-errs, "connection refused")
+}
+
+func TestBulkIndexer_CloseCanceled(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	fake := newFakeBulk(nil)
+	indexer := esbulk.New(elasticsearch.BulkIndexerConfig{FlushInterval: time.Hour, Workers: 4}, fake.do)
+
+	require.NoError(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Index: "products", DocumentID: "1", Body: `{}`}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, indexer.Close(ctx), context.Canceled)
+
+	// Close returns after the workers and the flusher have stopped.
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+
+	assert.NoError(t, indexer.Close(context.Background()))
+	assert.ErrorContains(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Index: "products", Body: `{}`}), "closed")
+}
+
+func TestBulkIndexer_CloseDeadline(t *testing.T) {
+	// The requests hang until the indexer cancels them.
+	hang := func(ctx context.Context, body []byte) (int, []byte, error) {
+		<-ctx.Done()
+		return 0, nil, ctx.Err()
+	}
+	indexer := esbulk.New(elasticsearch.BulkIndexerConfig{FlushCount: 1, FlushInterval: time.Hour, Workers: 1}, hang)
+
+	adders := sync.WaitGroup{}
+	for i := range 5 {
+		adders.Go(func() {
+			indexer.Add(context.Background(), elasticsearch.BulkItem{Index: "products", DocumentID: fmt.Sprint(i), Body: `{}`})
+		})
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, indexer.Close(ctx), context.DeadlineExceeded)
+
+	adders.Wait()
+	stats := indexer.Stats()
+	assert.Equal(t, stats.Added, stats.Failed)
+}
+
+func TestBulkIndexer_CloseDeadlineDuringRetry(t *testing.T) {
+	fake := newFakeBulk(func(attempt int, id string) int { return http.StatusTooManyRequests })
+	indexer := esbulk.New(elasticsearch.BulkIndexerConfig{FlushInterval: time.Hour, Workers: 1, RetryBackoff: time.Hour}, fake.do)
+
+	require.NoError(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Index: "products", DocumentID: "1", Body: `{}`}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	assert.ErrorIs(t, indexer.Close(ctx), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, uint64(1), indexer.Stats().Failed)
+}
+
+func TestBulkIndexer_InvalidItem(t *testing.T) {
+	indexer := esbulk.New(elasticsearch.BulkIndexerConfig{}, newFakeBulk(nil).do)
+	defer indexer.Close(context.Background())
+
+	assert.ErrorContains(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Action: "upsert", Index: "products", Body: `{}`}), "invalid bulk action")
+	assert.ErrorContains(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Action: "delete", Index: "products"}), "requires a document ID")
+	assert.ErrorContains(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Body: `{}`}), "index is required")
+	assert.ErrorContains(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Index: "products", Body: `{`}), "invalid body")
+	assert.Equal(t, uint64(0), indexer.Stats().Added)
+}
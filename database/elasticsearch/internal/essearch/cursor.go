@@ -0,0 +1,294 @@
+// Package essearch implements the elasticsearch.SearchCursor shared by the v7, v8 and v9 clients.
+package essearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/common-library/go/database/elasticsearch"
+)
+
+const (
+	defaultPageSize  = 1000
+	defaultKeepAlive = time.Minute
+)
+
+// PointInTimeRequests send the requests of a point in time cursor. They return the response
+// body, or an error for failed requests.
+type PointInTimeRequests struct {
+	// Open opens a point in time on index
+	Open func(ctx context.Context, index, keepAlive string) ([]byte, error)
+
+	// Search runs a search body carrying the point in time
+	Search func(ctx context.Context, body []byte) ([]byte, error)
+
+	// Close closes the point in time of body ({"id": ...})
+	Close func(ctx context.Context, body []byte) error
+}
+
+// ScrollRequests send the requests of a scroll cursor. They return the response body, or an
+// error for failed requests.
+type ScrollRequests struct {
+	// Search runs the initial search on index, opening a scroll context kept for keepAlive
+	Search func(ctx context.Context, index string, body []byte, keepAlive time.Duration) ([]byte, error)
+
+	// Scroll fetches the next page with body ({"scroll": ..., "scroll_id": ...})
+	Scroll func(ctx context.Context, body []byte) ([]byte, error)
+
+	// Clear clears the scroll context of body ({"scroll_id": [...]})
+	Clear func(ctx context.Context, body []byte) error
+}
+
+type response struct {
+	PitID    string `json:"pit_id"`
+	ScrollID string `json:"_scroll_id"`
+	Hits     struct {
+		Total json.RawMessage                            `json:"total"`
+		Hits  []elasticsearch.SearchHit[json.RawMessage] `json:"hits"`
+	} `json:"hits"`
+	Aggregations elasticsearch.Aggregations `json:"aggregations"`
+}
+
+type cursor struct {
+	config elasticsearch.SearchIteratorConfig
+	body   map[string]json.RawMessage
+	first  bool
+	done   bool
+
+	// next fetches a page; release frees the search context
+	next    func(ctx context.Context) (response, error)
+	release func(ctx context.Context) error
+}
+
+// NewPointInTime opens a point in time on index and returns a cursor paging through the hits of
+// body with search_after.
+func NewPointInTime(ctx context.Context, index, body string, config elasticsearch.SearchIteratorConfig, requests PointInTimeRequests) (elasticsearch.SearchCursor, error) {
+	c, err := newCursor(body, config)
+	if err != nil {
+		return nil, err
+	}
+
+	keepAlive := fmt.Sprintf("%dms", c.config.KeepAlive.Milliseconds())
+
+	data, err := requests.Open(ctx, index, keepAlive)
+	if err != nil {
+		return nil, err
+	}
+	opened := struct {
+		ID string `json:"id"`
+	}{}
+	if err := json.Unmarshal(data, &opened); err != nil {
+		return nil, err
+	} else if opened.ID == "" {
+		return nil, errors.New("open point in time response has no id")
+	}
+
+	pitID := opened.ID
+	var searchAfter []json.RawMessage
+
+	if _, ok := c.body["sort"]; !ok {
+		c.body["sort"] = json.RawMessage(`["_shard_doc"]`)
+	}
+
+	c.next = func(ctx context.Context) (response, error) {
+		page := c.page()
+		page["pit"] = marshal(map[string]string{"id": pitID, "keep_alive": keepAlive})
+		if searchAfter != nil {
+			page["search_after"] = marshal(searchAfter)
+		}
+
+		result, err := c.request(ctx, page, requests.Search)
+		if err != nil {
+			return result, err
+		}
+
+		if result.PitID != "" {
+			pitID = result.PitID
+		}
+		if hits := result.Hits.Hits; len(hits) != 0 {
+			searchAfter = hits[len(hits)-1].Sort
+		}
+
+		return result, nil
+	}
+
+	c.release = func(ctx context.Context) error {
+		return requests.Close(ctx, marshal(map[string]string{"id": pitID}))
+	}
+
+	return c, nil
+}
+
+// NewScroll returns a cursor paging through the hits of body with the scroll API. The initial
+// search runs on the first call of Next.
+func NewScroll(index, body string, config elasticsearch.SearchIteratorConfig, requests ScrollRequests) (elasticsearch.SearchCursor, error) {
+	c, err := newCursor(body, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := c.body["sort"]; !ok {
+		c.body["sort"] = json.RawMessage(`["_doc"]`)
+	}
+
+	scrollID := ""
+
+	c.next = func(ctx context.Context) (response, error) {
+		var result response
+		var err error
+
+		if scrollID == "" {
+			result, err = c.request(ctx, c.page(), func(ctx context.Context, body []byte) ([]byte, error) {
+				return requests.Search(ctx, index, body, c.config.KeepAlive)
+			})
+		} else {
+			body := marshal(map[string]string{"scroll": fmt.Sprintf("%dms", c.config.KeepAlive.Milliseconds()), "scroll_id": scrollID})
+			var data []byte
+			if data, err = requests.Scroll(ctx, body); err == nil {
+				err = json.Unmarshal(data, &result)
+			}
+		}
+		if err != nil {
+			return result, err
+		}
+
+		if result.ScrollID != "" {
+			scrollID = result.ScrollID
+		}
+
+		return result, nil
+	}
+
+	c.release = func(ctx context.Context) error {
+		if scrollID == "" {
+			return nil
+		}
+
+		return requests.Clear(ctx, marshal(map[string][]string{"scroll_id": {scrollID}}))
+	}
+
+	return c, nil
+}
+
+func newCursor(body string, config elasticsearch.SearchIteratorConfig) (*cursor, error) {
+	if config.PageSize <= 0 {
+		config.PageSize = defaultPageSize
+	}
+	if config.KeepAlive <= 0 {
+		config.KeepAlive = defaultKeepAlive
+	}
+
+	fields := map[string]json.RawMessage{}
+	if strings.TrimSpace(body) != "" {
+		if err := json.Unmarshal([]byte(body), &fields); err != nil {
+			return nil, fmt.Errorf("invalid search body: %w", err)
+		}
+	}
+
+	for _, name := range []string{"size", "from", "search_after", "pit", "scroll"} {
+		delete(fields, name)
+	}
+	fields["size"] = marshal(config.PageSize)
+
+	return &cursor{config: config, body: fields, first: true}, nil
+}
+
+// page returns the body of the next request. Only the first request counts the total hits and
+// computes the aggregations.
+func (c *cursor) page() map[string]json.RawMessage {
+	page := make(map[string]json.RawMessage, len(c.body)+2)
+	for name, value := range c.body {
+		page[name] = value
+	}
+
+	if c.first {
+		if _, ok := page["track_total_hits"]; !ok {
+			page["track_total_hits"] = json.RawMessage("true")
+		}
+	} else {
+		delete(page, "aggs")
+		delete(page, "aggregations")
+		page["track_total_hits"] = json.RawMessage("false")
+	}
+
+	return page
+}
+
+func (c *cursor) request(ctx context.Context, page map[string]json.RawMessage, send func(ctx context.Context, body []byte) ([]byte, error)) (response, error) {
+	result := response{}
+
+	data, err := send(ctx, marshal(page))
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
+
+func (c *cursor) Next(ctx context.Context) (elasticsearch.SearchPage, error) {
+	if c.done {
+		return elasticsearch.SearchPage{}, nil
+	}
+
+	result, err := c.next(ctx)
+	if err != nil {
+		return elasticsearch.SearchPage{}, err
+	}
+
+	page := elasticsearch.SearchPage{Hits: result.Hits.Hits}
+	if c.first {
+		c.first = false
+		page.Aggregations = result.Aggregations
+		if page.Total, err = total(result.Hits.Total); err != nil {
+			return elasticsearch.SearchPage{}, err
+		}
+	}
+
+	if len(page.Hits) < c.config.PageSize {
+		if err := c.Close(ctx); err != nil {
+			return page, err
+		}
+	}
+
+	return page, nil
+}
+
+func (c *cursor) Close(ctx context.Context) error {
+	if c.done {
+		return nil
+	}
+	c.done = true
+
+	return c.release(ctx)
+}
+
+// total reads hits.total, which is a number or {"value": ..., "relation": ...}.
+func total(data json.RawMessage) (int64, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return 0, nil
+	}
+
+	if data[0] != '{' {
+		var value int64
+		err := json.Unmarshal(data, &value)
+		return value, err
+	}
+
+	value := struct {
+		Value int64 `json:"value"`
+	}{}
+	err := json.Unmarshal(data, &value)
+	return value.Value, err
+}
+
+func marshal(value any) []byte {
+	data, _ := json.Marshal(value)
+	return data
+}
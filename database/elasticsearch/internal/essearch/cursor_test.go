@@ -0,0 +1,209 @@
+package essearch_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/elasticsearch"
+	"github.com/common-library/go/database/elasticsearch/internal/essearch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type document struct {
+	Name string `json:"name"`
+}
+
+// hits returns the hits from..to-1 of a search response.
+func hits(from, to int) []map[string]any {
+	result := []map[string]any{}
+	for i := from; i < to; i++ {
+		result = append(result, map[string]any{
+			"_index":  "products",
+			"_id":     fmt.Sprint(i),
+			"_score":  nil,
+			"_source": map[string]string{"name": fmt.Sprintf("product %d", i)},
+			"sort":    []any{int64(1) << 60, i},
+		})
+	}
+	return result
+}
+
+func TestPointInTime(t *testing.T) {
+	requests := []map[string]any{}
+	closed := []string{}
+
+	cursor, err := essearch.NewPointInTime(context.Background(), "products", `{"query": {"match_all": {}}, "size": 5, "aggs": {"names": {"terms": {"field": "name"}}}}`,
+		elasticsearch.SearchIteratorConfig{PageSize: 2, KeepAlive: 30 * time.Second},
+		essearch.PointInTimeRequests{
+			Open: func(ctx context.Context, index, keepAlive string) ([]byte, error) {
+				assert.Equal(t, "products", index)
+				assert.Equal(t, "30000ms", keepAlive)
+				return []byte(`{"id": "pit-0"}`), nil
+			},
+			Search: func(ctx context.Context, body []byte) ([]byte, error) {
+				request := map[string]any{}
+				decoder := json.NewDecoder(bytes.NewReader(body))
+				decoder.UseNumber()
+				require.NoError(t, decoder.Decode(&request))
+				requests = append(requests, request)
+
+				page := len(requests) - 1
+				return json.Marshal(map[string]any{
+					"pit_id":       fmt.Sprintf("pit-%d", len(requests)),
+					"hits":         map[string]any{"total": map[string]any{"value": 3, "relation": "eq"}, "hits": hits(page*2, min(page*2+2, 3))},
+					"aggregations": map[string]any{"names": map[string]any{"buckets": []any{}}},
+				})
+			},
+			Close: func(ctx context.Context, body []byte) error {
+				closed = append(closed, string(body))
+				return nil
+			},
+		})
+	require.NoError(t, err)
+
+	page, err := cursor.Next(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, page.Hits, 2)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Contains(t, page.Aggregations, "names")
+
+	page, err = cursor.Next(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, page.Hits, 1)
+	assert.Zero(t, page.Total)
+	assert.Nil(t, page.Aggregations)
+	assert.Equal(t, []string{`{"id":"pit-2"}`}, closed)
+
+	page, err = cursor.Next(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, page.Hits)
+	require.NoError(t, cursor.Close(context.Background()))
+	assert.Len(t, closed, 1)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, json.Number("2"), requests[0]["size"])
+	assert.Equal(t, true, requests[0]["track_total_hits"])
+	assert.Equal(t, []any{"_shard_doc"}, requests[0]["sort"])
+	assert.Equal(t, map[string]any{"id": "pit-0", "keep_alive": "30000ms"}, requests[0]["pit"])
+	assert.NotContains(t, requests[0], "search_after")
+	assert.Contains(t, requests[0], "aggs")
+
+	assert.Equal(t, false, requests[1]["track_total_hits"])
+	assert.Equal(t, map[string]any{"id": "pit-1", "keep_alive": "30000ms"}, requests[1]["pit"])
+	assert.NotContains(t, requests[1], "aggs")
+
+	assert.Equal(t, []any{json.Number("1152921504606846976"), json.Number("1")}, requests[1]["search_after"])
+}
+
+func TestPointInTime_Error(t *testing.T) {
+	_, err := essearch.NewPointInTime(context.Background(), "products", `{`, elasticsearch.SearchIteratorConfig{}, essearch.PointInTimeRequests{})
+	assert.ErrorContains(t, err, "invalid search body")
+
+	_, err = essearch.NewPointInTime(context.Background(), "missing", "", elasticsearch.SearchIteratorConfig{}, essearch.PointInTimeRequests{
+		Open: func(ctx context.Context, index, keepAlive string) ([]byte, error) {
+			return nil, errors.New("index_not_found_exception")
+		},
+	})
+	assert.ErrorContains(t, err, "index_not_found_exception")
+}
+
+func TestScroll(t *testing.T) {
+	searches, scrolls := 0, []string{}
+	cleared := []string{}
+
+	cursor, err := essearch.NewScroll("products", `{"query": {"term": {"name": "product"}}, "sort": [{"name": "asc"}]}`,
+		elasticsearch.SearchIteratorConfig{PageSize: 2},
+		essearch.ScrollRequests{
+			Search: func(ctx context.Context, index string, body []byte, keepAlive time.Duration) ([]byte, error) {
+				searches++
+				assert.Equal(t, time.Minute, keepAlive)
+				assert.JSONEq(t, `{"query": {"term": {"name": "product"}}, "sort": [{"name": "asc"}], "size": 2, "track_total_hits": true}`, string(body))
+				return json.Marshal(map[string]any{"_scroll_id": "scroll-1", "hits": map[string]any{"total": 4, "hits": hits(0, 2)}})
+			},
+			Scroll: func(ctx context.Context, body []byte) ([]byte, error) {
+				scrolls = append(scrolls, string(body))
+				return json.Marshal(map[string]any{"_scroll_id": "scroll-2", "hits": map[string]any{"total": 4, "hits": hits(len(scrolls)*2, min(len(scrolls)*2+2, 4))}})
+			},
+			Clear: func(ctx context.Context, body []byte) error {
+				cleared = append(cleared, string(body))
+				return nil
+			},
+		})
+	require.NoError(t, err)
+
+	count := 0
+	for {
+		page, err := cursor.Next(context.Background())
+		require.NoError(t, err)
+		if len(page.Hits) == 0 {
+			break
+		}
+		count += len(page.Hits)
+	}
+
+	assert.Equal(t, 4, count)
+	assert.Equal(t, 1, searches)
+	assert.Equal(t, []string{`{"scroll":"60000ms","scroll_id":"scroll-1"}`, `{"scroll":"60000ms","scroll_id":"scroll-2"}`}, scrolls)
+	assert.Equal(t, []string{`{"scroll_id":["scroll-2"]}`}, cleared)
+}
+
+// cursorClient is a client whose searches return the hits of a fixed list of pages.
+type cursorClient struct {
+	elasticsearch.ClientInterface
+
+	pages []string
+}
+
+func (c *cursorClient) OpenSearchCursor(ctx context.Context, index, body string, config elasticsearch.SearchIteratorConfig) (elasticsearch.SearchCursor, error) {
+	return essearch.NewScroll(index, body, config, essearch.ScrollRequests{
+		Search: func(ctx context.Context, index string, body []byte, keepAlive time.Duration) ([]byte, error) {
+			return []byte(c.pages[0]), nil
+		},
+		Scroll: func(ctx context.Context, body []byte) ([]byte, error) {
+			c.pages = c.pages[1:]
+			return []byte(c.pages[0]), nil
+		},
+		Clear: func(ctx context.Context, body []byte) error { return nil },
+	})
+}
+
+func TestSearchIterator(t *testing.T) {
+	client := &cursorClient{pages: []string{
+		`{"_scroll_id": "1", "hits": {"total": {"value": 3}, "hits": [{"_id": "a", "_source": {"name": "A"}}, {"_id": "b", "_source": {"name": "B"}}]},
+		  "aggregations": {"count": {"value": 3}}}`,
+		`{"_scroll_id": "1", "hits": {"hits": [{"_id": "c", "_source": {"name": "C"}}]}}`,
+	}}
+
+	iterator, err := elasticsearch.NewSearchIterator[document](context.Background(), client, "products", "", elasticsearch.SearchIteratorConfig{PageSize: 2})
+	require.NoError(t, err)
+	defer iterator.Close(context.Background())
+
+	assert.Equal(t, int64(3), iterator.Total())
+	count, err := iterator.Aggregations().Metric("count")
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, *count.Value)
+
+	names := []string{}
+	for hit, err := range iterator.All(context.Background()) {
+		require.NoError(t, err)
+		names = append(names, hit.ID+"="+hit.Source.Name)
+	}
+	assert.Equal(t, []string{"a=A", "b=B", "c=C"}, names)
+
+	for _, err := range iterator.All(context.Background()) {
+		assert.ErrorContains(t, err, "only be ranged over once")
+	}
+
+	client.pages = []string{`{"_scroll_id": "1", "hits": {"hits": [{"_id": "a", "_source": {"name": 1}}]}}`}
+	invalid, err := elasticsearch.NewSearchIterator[document](context.Background(), client, "products", "", elasticsearch.SearchIteratorConfig{})
+	require.NoError(t, err)
+	for _, err := range invalid.All(context.Background()) {
+		assert.Error(t, err)
+	}
+}
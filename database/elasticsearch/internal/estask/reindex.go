@@ -0,0 +1,80 @@
+// Package estask implements the task polling shared by the v7, v8 and v9 clients.
+package estask
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/common-library/go/database/elasticsearch"
+)
+
+const defaultPollInterval = time.Second
+
+type taskError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// Reindex starts a _reindex task with start, polls it with get until it completes and returns
+// its result.
+//
+// start sends the _reindex request with wait_for_completion=false and get sends a tasks get
+// request; both return the response body, or an error for failed requests.
+func Reindex(ctx context.Context, body []byte, pollInterval time.Duration,
+	start func(ctx context.Context, body []byte) ([]byte, error),
+	get func(ctx context.Context, taskID string) ([]byte, error)) (elasticsearch.ReindexResult, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	data, err := start(ctx, body)
+	if err != nil {
+		return elasticsearch.ReindexResult{}, err
+	}
+
+	started := struct {
+		Task string `json:"task"`
+	}{}
+	if err := json.Unmarshal(data, &started); err != nil {
+		return elasticsearch.ReindexResult{}, err
+	} else if started.Task == "" {
+		return elasticsearch.ReindexResult{}, errors.New("reindex response has no task")
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		data, err := get(ctx, started.Task)
+		if err != nil {
+			return elasticsearch.ReindexResult{}, err
+		}
+
+		task := struct {
+			Completed bool                        `json:"completed"`
+			Response  elasticsearch.ReindexResult `json:"response"`
+			Error     *taskError                  `json:"error"`
+		}{}
+		if err := json.Unmarshal(data, &task); err != nil {
+			return elasticsearch.ReindexResult{}, err
+		}
+
+		if task.Error != nil {
+			return task.Response, fmt.Errorf("reindex task %s failed - type : (%s), reason : (%s)", started.Task, task.Error.Type, task.Error.Reason)
+		} else if task.Completed {
+			if len(task.Response.Failures) != 0 {
+				return task.Response, fmt.Errorf("reindex task %s completed with %d failures: %s", started.Task, len(task.Response.Failures), task.Response.Failures[0])
+			}
+			return task.Response, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return elasticsearch.ReindexResult{}, fmt.Errorf("reindex task %s is still running: %w", started.Task, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package estask_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/elasticsearch/internal/estask"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func start(ctx context.Context, body []byte) ([]byte, error) {
+	return []byte(`{"task": "node:1"}`), nil
+}
+
+func TestReindex(t *testing.T) {
+	polls := 0
+	result, err := estask.Reindex(context.Background(), []byte(`{}`), time.Millisecond, start,
+		func(ctx context.Context, taskID string) ([]byte, error) {
+			assert.Equal(t, "node:1", taskID)
+			polls++
+			if polls < 3 {
+				return []byte(`{"completed": false, "task": {"status": {"total": 10, "created": 4}}}`), nil
+			}
+			return []byte(`{"completed": true, "response": {"took": 12, "total": 10, "created": 8, "updated": 2, "batches": 1, "failures": []}}`), nil
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, int64(12), result.Took)
+	assert.Equal(t, int64(10), result.Total)
+	assert.Equal(t, int64(8), result.Created)
+	assert.Equal(t, int64(2), result.Updated)
+}
+
+func TestReindex_Failures(t *testing.T) {
+	result, err := estask.Reindex(context.Background(), []byte(`{}`), 0, start,
+		func(ctx context.Context, taskID string) ([]byte, error) {
+			return []byte(`{"completed": true, "response": {"total": 2, "created": 1, "failures": [{"id": "2", "cause": {"type": "mapper_parsing_exception"}}]}}`), nil
+		})
+	assert.ErrorContains(t, err, "completed with 1 failures")
+	assert.Equal(t, int64(1), result.Created)
+
+	_, err = estask.Reindex(context.Background(), []byte(`{}`), 0, start,
+		func(ctx context.Context, taskID string) ([]byte, error) {
+			return []byte(`{"completed": true, "error": {"type": "index_not_found_exception", "reason": "no such index [old]"}}`), nil
+		})
+	assert.ErrorContains(t, err, "no such index [old]")
+
+	_, err = estask.Reindex(context.Background(), []byte(`{}`), 0,
+		func(ctx context.Context, body []byte) ([]byte, error) {
+			return nil, errors.New("action_request_validation_exception")
+		}, nil)
+	assert.ErrorContains(t, err, "action_request_validation_exception")
+
+	_, err = estask.Reindex(context.Background(), []byte(`{}`), 0,
+		func(ctx context.Context, body []byte) ([]byte, error) {
+			return []byte(`{}`), nil
+		}, nil)
+	assert.ErrorContains(t, err, "no task")
+}
+
+func TestReindex_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := estask.Reindex(ctx, []byte(`{}`), time.Millisecond, start,
+		func(ctx context.Context, taskID string) ([]byte, error) {
+			return []byte(`{"completed": false}`), nil
+		})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "node:1 is still running")
+}
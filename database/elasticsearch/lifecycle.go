@@ -0,0 +1,189 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AliasAction is an action of an atomic alias update.
+type AliasAction struct {
+	// Type is "add", "remove" or "remove_index"
+	Type string
+
+	// Index is the index the action applies to
+	Index string
+
+	// Alias is the alias to add or remove; unused for "remove_index"
+	Alias string
+
+	// IsWriteIndex marks the index as the write index of the alias (optional, "add" only)
+	IsWriteIndex *bool
+
+	// Filter is a JSON query limiting the documents visible through the alias (optional, "add" only)
+	Filter string
+}
+
+// MarshalJSON encodes the action in the format of the _aliases API.
+func (a AliasAction) MarshalJSON() ([]byte, error) {
+	switch a.Type {
+	case "add", "remove", "remove_index":
+	default:
+		return nil, fmt.Errorf("invalid alias action %q", a.Type)
+	}
+
+	action := struct {
+		Index        string          `json:"index"`
+		Alias        string          `json:"alias,omitempty"`
+		IsWriteIndex *bool           `json:"is_write_index,omitempty"`
+		Filter       json.RawMessage `json:"filter,omitempty"`
+	}{Index: a.Index, Alias: a.Alias, IsWriteIndex: a.IsWriteIndex}
+
+	if a.Filter != "" {
+		if !json.Valid([]byte(a.Filter)) {
+			return nil, errors.New("alias filter is not valid JSON")
+		}
+		action.Filter = json.RawMessage(a.Filter)
+	}
+
+	return json.Marshal(map[string]any{a.Type: action})
+}
+
+// RolloverResult is the result of a rollover.
+type RolloverResult struct {
+	// OldIndex is the index the alias or data stream pointed to
+	OldIndex string `json:"old_index"`
+
+	// NewIndex is the index created by the rollover
+	NewIndex string `json:"new_index"`
+
+	// RolledOver reports whether the rollover happened
+	RolledOver bool `json:"rolled_over"`
+
+	// DryRun reports whether the rollover was only simulated
+	DryRun bool `json:"dry_run"`
+
+	// Conditions maps each condition of the request to whether it was met
+	Conditions map[string]bool `json:"conditions"`
+}
+
+// ReindexResult is the result of a completed _reindex task.
+type ReindexResult struct {
+	// Took is the duration of the reindex in milliseconds
+	Took int64 `json:"took"`
+
+	// TimedOut reports whether a request of the reindex timed out
+	TimedOut bool `json:"timed_out"`
+
+	Total            int64 `json:"total"`
+	Created          int64 `json:"created"`
+	Updated          int64 `json:"updated"`
+	Deleted          int64 `json:"deleted"`
+	Batches          int64 `json:"batches"`
+	VersionConflicts int64 `json:"version_conflicts"`
+	Noops            int64 `json:"noops"`
+
+	// Failures are the raw failures of documents or searches
+	Failures []json.RawMessage `json:"failures"`
+}
+
+// SwapAlias points alias to index and away from all other indices in one atomic update.
+//
+// Parameters:
+//   - client: Initialized client of any version
+//   - alias: The alias to move
+//   - index: The index the alias points to afterwards
+//
+// Returns:
+//   - []string: The indices the alias pointed to before, without index
+//   - error: Error if the aliases cannot be read or updated, nil on success
+//
+// Readers of the alias see either the previous indices or index, never both or none.
+//
+// Example:
+//
+//	previous, err := elasticsearch.SwapAlias(client, "products", "products-v2")
+//	if err == nil {
+//		client.IndicesDelete(previous)
+//	}
+func SwapAlias(client ClientInterface, alias, index string) ([]string, error) {
+	indices, err := client.IndicesGetAlias(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := []string{}
+	actions := []AliasAction{{Type: "add", Index: index, Alias: alias}}
+	for _, current := range indices {
+		if current != index {
+			previous = append(previous, current)
+			actions = append(actions, AliasAction{Type: "remove", Index: current, Alias: alias})
+		}
+	}
+
+	if err := client.IndicesUpdateAliases(actions); err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+// ReindexAlias copies the indices behind alias into a new index and swaps the alias to it.
+//
+// Parameters:
+//   - ctx: Context of the reindex task polling
+//   - client: Initialized client of any version
+//   - alias: Alias read and written by the application
+//   - index: Name of the new index
+//   - indexBody: Settings and mappings of the new index
+//   - pollInterval: Interval of the task polling (default 1s)
+//
+// Returns:
+//   - ReindexResult: Result of the reindex task
+//   - []string: The indices the alias pointed to before; they are left in place
+//   - error: Error if a step fails, nil on success
+//
+// This is the zero-downtime reindex: the alias keeps serving the old indices while the new
+// index is filled, then moves atomically. Documents written through the alias during the
+// reindex go to the old indices and are copied only if written before their batch was read;
+// pause writes or replay them for a lossless switch.
+//
+// Example:
+//
+//	result, previous, err := elasticsearch.ReindexAlias(ctx, client, "products", "products-v2",
+//		`{"mappings": {"properties": {"name": {"type": "keyword"}}}}`, time.Second)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	log.Printf("copied %d documents", result.Created)
+//	client.IndicesDelete(previous)
+func ReindexAlias(ctx context.Context, client ClientInterface, alias, index, indexBody string, pollInterval time.Duration) (ReindexResult, []string, error) {
+	sources, err := client.IndicesGetAlias(alias)
+	if err != nil {
+		return ReindexResult{}, nil, err
+	} else if len(sources) == 0 {
+		return ReindexResult{}, nil, fmt.Errorf("alias %q does not exist", alias)
+	}
+
+	if err := client.IndicesCreate(index, indexBody); err != nil {
+		return ReindexResult{}, nil, err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"source": map[string]any{"index": sources},
+		"dest":   map[string]any{"index": index},
+	})
+	if err != nil {
+		return ReindexResult{}, nil, err
+	}
+
+	result, err := client.Reindex(ctx, string(body), pollInterval)
+	if err != nil {
+		return result, nil, err
+	}
+
+	previous, err := SwapAlias(client, alias, index)
+	return result, previous, err
+}
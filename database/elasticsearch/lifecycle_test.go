@@ -0,0 +1,119 @@
+package elasticsearch_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/elasticsearch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aliasClient keeps aliases in memory and records the other calls of ReindexAlias.
+type aliasClient struct {
+	elasticsearch.ClientInterface
+
+	aliases map[string][]string
+	created []string
+	reindex []string
+}
+
+func (c *aliasClient) IndicesGetAlias(alias string) ([]string, error) {
+	return append([]string{}, c.aliases[alias]...), nil
+}
+
+func (c *aliasClient) IndicesUpdateAliases(actions []elasticsearch.AliasAction) error {
+	if _, err := json.Marshal(actions); err != nil {
+		return err
+	}
+
+	for _, action := range actions {
+		switch action.Type {
+		case "add":
+			if !slices.Contains(c.aliases[action.Alias], action.Index) {
+				c.aliases[action.Alias] = append(c.aliases[action.Alias], action.Index)
+			}
+		case "remove":
+			indices := []string{}
+			for _, index := range c.aliases[action.Alias] {
+				if index != action.Index {
+					indices = append(indices, index)
+				}
+			}
+			c.aliases[action.Alias] = indices
+		}
+	}
+
+	return nil
+}
+
+func (c *aliasClient) IndicesCreate(index, body string) error {
+	c.created = append(c.created, index)
+	return nil
+}
+
+func (c *aliasClient) Reindex(ctx context.Context, body string, pollInterval time.Duration) (elasticsearch.ReindexResult, error) {
+	c.reindex = append(c.reindex, body)
+	if len(c.created) > 1 {
+		return elasticsearch.ReindexResult{}, errors.New("reindex failed")
+	}
+	return elasticsearch.ReindexResult{Total: 3, Created: 3}, nil
+}
+
+func TestAliasAction_MarshalJSON(t *testing.T) {
+	writeIndex := true
+	data, err := json.Marshal([]elasticsearch.AliasAction{
+		{Type: "add", Index: "logs-000001", Alias: "logs", IsWriteIndex: &writeIndex, Filter: `{"term": {"level": "error"}}`},
+		{Type: "remove", Index: "logs-old", Alias: "logs"},
+		{Type: "remove_index", Index: "logs-tmp"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"add": {"index": "logs-000001", "alias": "logs", "is_write_index": true, "filter": {"term": {"level": "error"}}}},
+		{"remove": {"index": "logs-old", "alias": "logs"}},
+		{"remove_index": {"index": "logs-tmp"}}
+	]`, string(data))
+
+	_, err = json.Marshal(elasticsearch.AliasAction{Type: "rename", Index: "logs", Alias: "logs"})
+	assert.ErrorContains(t, err, "invalid alias action")
+
+	_, err = json.Marshal(elasticsearch.AliasAction{Type: "add", Index: "logs", Alias: "logs", Filter: `{`})
+	assert.ErrorContains(t, err, "not valid JSON")
+}
+
+func TestSwapAlias(t *testing.T) {
+	client := &aliasClient{aliases: map[string][]string{"products": {"products-v1", "products-v2"}}}
+
+	previous, err := elasticsearch.SwapAlias(client, "products", "products-v2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"products-v1"}, previous)
+	assert.Equal(t, []string{"products-v2"}, client.aliases["products"])
+
+	previous, err = elasticsearch.SwapAlias(client, "orders", "orders-v1")
+	require.NoError(t, err)
+	assert.Empty(t, previous)
+	assert.Equal(t, []string{"orders-v1"}, client.aliases["orders"])
+}
+
+func TestReindexAlias(t *testing.T) {
+	client := &aliasClient{aliases: map[string][]string{"products": {"products-v1"}}}
+
+	result, previous, err := elasticsearch.ReindexAlias(context.Background(), client, "products", "products-v2", `{}`, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.Created)
+	assert.Equal(t, []string{"products-v1"}, previous)
+	assert.Equal(t, []string{"products-v2"}, client.created)
+	assert.JSONEq(t, `{"source": {"index": ["products-v1"]}, "dest": {"index": "products-v2"}}`, client.reindex[0])
+	assert.Equal(t, []string{"products-v2"}, client.aliases["products"])
+
+	_, _, err = elasticsearch.ReindexAlias(context.Background(), client, "products", "products-v3", `{}`, time.Second)
+	assert.ErrorContains(t, err, "reindex failed")
+	assert.Equal(t, []string{"products-v2"}, client.aliases["products"])
+
+	_, _, err = elasticsearch.ReindexAlias(context.Background(), client, "missing", "missing-v2", `{}`, time.Second)
+	assert.ErrorContains(t, err, "does not exist")
+}
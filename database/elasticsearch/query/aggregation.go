@@ -0,0 +1,342 @@
+package query
+
+import "maps"
+
+// Aggregation is an aggregation of a search.
+type Aggregation interface {
+	// Source returns the JSON object of the aggregation
+	Source() map[string]any
+}
+
+// aggregationSource returns {kind: body, "aggs": {...}}, leaving out aggs when there are none.
+func aggregationSource(kind string, body map[string]any, subAggregations map[string]Aggregation) map[string]any {
+	source := map[string]any{kind: body}
+
+	if len(subAggregations) != 0 {
+		aggs := map[string]any{}
+		for name, aggregation := range subAggregations {
+			aggs[name] = aggregation.Source()
+		}
+		source["aggs"] = aggs
+	}
+
+	return source
+}
+
+// RawAggregation is an aggregation given as its JSON object.
+type RawAggregation map[string]any
+
+// NewRawAggregation returns an aggregation with the given JSON object, for aggregations without a builder.
+//
+// Example:
+//
+//	query.NewRawAggregation(map[string]any{"percentiles": map[string]any{"field": "load_time"}})
+func NewRawAggregation(source map[string]any) RawAggregation {
+	return RawAggregation(source)
+}
+
+func (a RawAggregation) Source() map[string]any {
+	return a
+}
+
+// MetricAggregation computes a value from a field.
+type MetricAggregation struct {
+	kind    string
+	options map[string]any
+}
+
+func newMetricAggregation(kind, field string) *MetricAggregation {
+	return &MetricAggregation{kind: kind, options: map[string]any{"field": field}}
+}
+
+// NewAvgAggregation returns an avg aggregation.
+func NewAvgAggregation(field string) *MetricAggregation {
+	return newMetricAggregation("avg", field)
+}
+
+// NewSumAggregation returns a sum aggregation.
+func NewSumAggregation(field string) *MetricAggregation {
+	return newMetricAggregation("sum", field)
+}
+
+// NewMinAggregation returns a min aggregation.
+func NewMinAggregation(field string) *MetricAggregation {
+	return newMetricAggregation("min", field)
+}
+
+// NewMaxAggregation returns a max aggregation.
+func NewMaxAggregation(field string) *MetricAggregation {
+	return newMetricAggregation("max", field)
+}
+
+// NewCardinalityAggregation returns a cardinality aggregation counting the distinct values approximately.
+func NewCardinalityAggregation(field string) *MetricAggregation {
+	return newMetricAggregation("cardinality", field)
+}
+
+// NewValueCountAggregation returns a value_count aggregation.
+func NewValueCountAggregation(field string) *MetricAggregation {
+	return newMetricAggregation("value_count", field)
+}
+
+// NewStatsAggregation returns a stats aggregation computing count, min, max, avg and sum.
+func NewStatsAggregation(field string) *MetricAggregation {
+	return newMetricAggregation("stats", field)
+}
+
+// Missing sets the value used for documents without the field.
+func (a *MetricAggregation) Missing(value any) *MetricAggregation {
+	a.options["missing"] = value
+	return a
+}
+
+func (a *MetricAggregation) Source() map[string]any {
+	return aggregationSource(a.kind, maps.Clone(a.options), nil)
+}
+
+// TermsAggregation groups documents by the values of a field.
+type TermsAggregation struct {
+	options         map[string]any
+	order           []any
+	subAggregations map[string]Aggregation
+}
+
+// NewTermsAggregation returns a terms aggregation with the top buckets by document count.
+//
+// Example:
+//
+//	query.NewTermsAggregation("category").Size(20).
+//	    SubAggregation("avg_price", query.NewAvgAggregation("price"))
+func NewTermsAggregation(field string) *TermsAggregation {
+	return &TermsAggregation{options: map[string]any{"field": field}, subAggregations: map[string]Aggregation{}}
+}
+
+// Size sets the number of buckets returned (default 10).
+func (a *TermsAggregation) Size(size int) *TermsAggregation {
+	a.options["size"] = size
+	return a
+}
+
+// MinDocCount sets the minimum document count of returned buckets.
+func (a *TermsAggregation) MinDocCount(minDocCount int64) *TermsAggregation {
+	a.options["min_doc_count"] = minDocCount
+	return a
+}
+
+// Missing puts documents without the field in a bucket with this key.
+func (a *TermsAggregation) Missing(value any) *TermsAggregation {
+	a.options["missing"] = value
+	return a
+}
+
+// Order adds a sort of the buckets by key ("_key", "_count" or a sub-aggregation name) and direction ("asc" or "desc").
+func (a *TermsAggregation) Order(key, direction string) *TermsAggregation {
+	a.order = append(a.order, map[string]any{key: direction})
+	return a
+}
+
+// SubAggregation adds an aggregation computed for every bucket.
+func (a *TermsAggregation) SubAggregation(name string, aggregation Aggregation) *TermsAggregation {
+	a.subAggregations[name] = aggregation
+	return a
+}
+
+func (a *TermsAggregation) Source() map[string]any {
+	body := maps.Clone(a.options)
+	if len(a.order) != 0 {
+		body["order"] = a.order
+	}
+
+	return aggregationSource("terms", body, a.subAggregations)
+}
+
+// HistogramAggregation groups numeric values into fixed-width buckets.
+type HistogramAggregation struct {
+	options         map[string]any
+	subAggregations map[string]Aggregation
+}
+
+// NewHistogramAggregation returns a histogram aggregation.
+//
+// Example:
+//
+//	query.NewHistogramAggregation("price", 50).MinDocCount(1)
+func NewHistogramAggregation(field string, interval float64) *HistogramAggregation {
+	return &HistogramAggregation{options: map[string]any{"field": field, "interval": interval}, subAggregations: map[string]Aggregation{}}
+}
+
+// MinDocCount sets the minimum document count of returned buckets (default 0).
+func (a *HistogramAggregation) MinDocCount(minDocCount int64) *HistogramAggregation {
+	a.options["min_doc_count"] = minDocCount
+	return a
+}
+
+// SubAggregation adds an aggregation computed for every bucket.
+func (a *HistogramAggregation) SubAggregation(name string, aggregation Aggregation) *HistogramAggregation {
+	a.subAggregations[name] = aggregation
+	return a
+}
+
+func (a *HistogramAggregation) Source() map[string]any {
+	return aggregationSource("histogram", maps.Clone(a.options), a.subAggregations)
+}
+
+// DateHistogramAggregation groups dates into buckets of calendar or fixed intervals.
+type DateHistogramAggregation struct {
+	options         map[string]any
+	subAggregations map[string]Aggregation
+}
+
+// NewDateHistogramAggregation returns a date_histogram aggregation; set an interval with
+// CalendarInterval or FixedInterval.
+//
+// Example:
+//
+//	query.NewDateHistogramAggregation("created_at").CalendarInterval("1d").TimeZone("Asia/Seoul")
+func NewDateHistogramAggregation(field string) *DateHistogramAggregation {
+	return &DateHistogramAggregation{options: map[string]any{"field": field}, subAggregations: map[string]Aggregation{}}
+}
+
+// CalendarInterval sets a calendar-aware interval (e.g., "1d", "1w", "1M", "1y").
+func (a *DateHistogramAggregation) CalendarInterval(interval string) *DateHistogramAggregation {
+	delete(a.options, "fixed_interval")
+	a.options["calendar_interval"] = interval
+	return a
+}
+
+// FixedInterval sets a fixed interval (e.g., "30m", "12h", "90d").
+func (a *DateHistogramAggregation) FixedInterval(interval string) *DateHistogramAggregation {
+	delete(a.options, "calendar_interval")
+	a.options["fixed_interval"] = interval
+	return a
+}
+
+// Format sets the format of the bucket keys (e.g., "yyyy-MM-dd").
+func (a *DateHistogramAggregation) Format(format string) *DateHistogramAggregation {
+	a.options["format"] = format
+	return a
+}
+
+// TimeZone sets the time zone of the buckets.
+func (a *DateHistogramAggregation) TimeZone(timeZone string) *DateHistogramAggregation {
+	a.options["time_zone"] = timeZone
+	return a
+}
+
+// MinDocCount sets the minimum document count of returned buckets (default 0).
+func (a *DateHistogramAggregation) MinDocCount(minDocCount int64) *DateHistogramAggregation {
+	a.options["min_doc_count"] = minDocCount
+	return a
+}
+
+// SubAggregation adds an aggregation computed for every bucket.
+func (a *DateHistogramAggregation) SubAggregation(name string, aggregation Aggregation) *DateHistogramAggregation {
+	a.subAggregations[name] = aggregation
+	return a
+}
+
+func (a *DateHistogramAggregation) Source() map[string]any {
+	return aggregationSource("date_histogram", maps.Clone(a.options), a.subAggregations)
+}
+
+// RangeAggregation groups numeric values into custom ranges.
+type RangeAggregation struct {
+	options         map[string]any
+	ranges          []any
+	subAggregations map[string]Aggregation
+}
+
+// NewRangeAggregation returns a range aggregation; add ranges with Range.
+//
+// Example:
+//
+//	query.NewRangeAggregation("price").Range("cheap", nil, 100).Range("expensive", 100, nil)
+func NewRangeAggregation(field string) *RangeAggregation {
+	return &RangeAggregation{options: map[string]any{"field": field}, subAggregations: map[string]Aggregation{}}
+}
+
+// Range adds a range from from (inclusive) to to (exclusive); nil leaves a side open and an
+// empty key lets the cluster name the bucket.
+func (a *RangeAggregation) Range(key string, from, to any) *RangeAggregation {
+	bucket := map[string]any{}
+	if key != "" {
+		bucket["key"] = key
+	}
+	if from != nil {
+		bucket["from"] = from
+	}
+	if to != nil {
+		bucket["to"] = to
+	}
+
+	a.ranges = append(a.ranges, bucket)
+	return a
+}
+
+// SubAggregation adds an aggregation computed for every bucket.
+func (a *RangeAggregation) SubAggregation(name string, aggregation Aggregation) *RangeAggregation {
+	a.subAggregations[name] = aggregation
+	return a
+}
+
+func (a *RangeAggregation) Source() map[string]any {
+	body := maps.Clone(a.options)
+	body["ranges"] = a.ranges
+	if a.ranges == nil {
+		body["ranges"] = []any{}
+	}
+
+	return aggregationSource("range", body, a.subAggregations)
+}
+
+// FilterAggregation is a single bucket of the documents matching a query.
+type FilterAggregation struct {
+	query           Query
+	subAggregations map[string]Aggregation
+}
+
+// NewFilterAggregation returns a filter aggregation.
+//
+// Example:
+//
+//	query.NewFilterAggregation(query.Term("in_stock", true)).
+//	    SubAggregation("avg_price", query.NewAvgAggregation("price"))
+func NewFilterAggregation(query Query) *FilterAggregation {
+	return &FilterAggregation{query: query, subAggregations: map[string]Aggregation{}}
+}
+
+// SubAggregation adds an aggregation computed on the bucket.
+func (a *FilterAggregation) SubAggregation(name string, aggregation Aggregation) *FilterAggregation {
+	a.subAggregations[name] = aggregation
+	return a
+}
+
+func (a *FilterAggregation) Source() map[string]any {
+	return aggregationSource("filter", a.query.Source(), a.subAggregations)
+}
+
+// NestedAggregation is a single bucket of the nested objects at a path.
+type NestedAggregation struct {
+	path            string
+	subAggregations map[string]Aggregation
+}
+
+// NewNestedAggregation returns a nested aggregation; its sub-aggregations run on the nested objects.
+//
+// Example:
+//
+//	query.NewNestedAggregation("comments").
+//	    SubAggregation("avg_stars", query.NewAvgAggregation("comments.stars"))
+func NewNestedAggregation(path string) *NestedAggregation {
+	return &NestedAggregation{path: path, subAggregations: map[string]Aggregation{}}
+}
+
+// SubAggregation adds an aggregation computed on the nested objects.
+func (a *NestedAggregation) SubAggregation(name string, aggregation Aggregation) *NestedAggregation {
+	a.subAggregations[name] = aggregation
+	return a
+}
+
+func (a *NestedAggregation) Source() map[string]any {
+	return aggregationSource("nested", map[string]any{"path": a.path}, a.subAggregations)
+}
@@ -0,0 +1,432 @@
+// Package query provides a version-neutral builder for Elasticsearch search bodies.
+//
+// The builders produce the JSON accepted by Elasticsearch 7, 8 and 9, so the same body can be
+// passed to Search, DeleteByQuery or NewSearchIterator of any client version. Building a body
+// needs no cluster, which makes queries easy to assert in unit tests.
+//
+// Features:
+//   - bool, term, terms, range, match, multi_match, nested, exists and match_all queries
+//   - Metric and bucket aggregations with sub-aggregations
+//   - Sort, highlighting, paging and source filtering
+//   - Raw queries and aggregations for anything not covered
+//
+// Example:
+//
+//	body, err := query.NewSearch().
+//	    Query(query.Bool().
+//	        Must(query.Match("name", "laptop")).
+//	        Filter(query.Term("category", "electronics"), query.Range("price").Lte(1000))).
+//	    Aggregation("brands", query.NewTermsAggregation("brand").Size(10)).
+//	    Sort("price", "asc").
+//	    Body()
+//
+//	result, err := client.Search("products", body)
+package query
+
+import "maps"
+
+// Query is a query of the query DSL.
+type Query interface {
+	// Source returns the JSON object of the query
+	Source() map[string]any
+}
+
+// fieldQuery returns {kind: {field: value}}, or {kind: {field: {valueKey: value, options...}}} when there are options.
+func fieldQuery(kind, field, valueKey string, value any, options map[string]any) map[string]any {
+	if len(options) == 0 {
+		return map[string]any{kind: map[string]any{field: value}}
+	}
+
+	body := maps.Clone(options)
+	body[valueKey] = value
+
+	return map[string]any{kind: map[string]any{field: body}}
+}
+
+func sources(queries []Query) []any {
+	result := make([]any, 0, len(queries))
+	for _, query := range queries {
+		result = append(result, query.Source())
+	}
+	return result
+}
+
+// RawQuery is a query given as its JSON object.
+type RawQuery map[string]any
+
+// Raw returns a query with the given JSON object, for queries without a builder.
+//
+// Example:
+//
+//	query.Raw(map[string]any{"prefix": map[string]any{"name": "lap"}})
+func Raw(source map[string]any) RawQuery {
+	return RawQuery(source)
+}
+
+func (q RawQuery) Source() map[string]any {
+	return q
+}
+
+// MatchAllQuery matches every document.
+type MatchAllQuery struct{}
+
+// MatchAll returns a match_all query.
+func MatchAll() MatchAllQuery {
+	return MatchAllQuery{}
+}
+
+func (q MatchAllQuery) Source() map[string]any {
+	return map[string]any{"match_all": map[string]any{}}
+}
+
+// BoolQuery combines queries with must, filter, should and must_not clauses.
+type BoolQuery struct {
+	must, filter, should, mustNot []Query
+	options                       map[string]any
+}
+
+// Bool returns an empty bool query, which matches every document.
+//
+// Example:
+//
+//	query.Bool().
+//	    Must(query.Match("title", "elasticsearch")).
+//	    Filter(query.Term("status", "published")).
+//	    MustNot(query.Exists("deleted_at"))
+func Bool() *BoolQuery {
+	return &BoolQuery{options: map[string]any{}}
+}
+
+// Must adds queries that must match and contribute to the score.
+func (q *BoolQuery) Must(queries ...Query) *BoolQuery {
+	q.must = append(q.must, queries...)
+	return q
+}
+
+// Filter adds queries that must match without scoring; they are cached by the cluster.
+func (q *BoolQuery) Filter(queries ...Query) *BoolQuery {
+	q.filter = append(q.filter, queries...)
+	return q
+}
+
+// Should adds queries that should match; see MinimumShouldMatch.
+func (q *BoolQuery) Should(queries ...Query) *BoolQuery {
+	q.should = append(q.should, queries...)
+	return q
+}
+
+// MustNot adds queries that must not match.
+func (q *BoolQuery) MustNot(queries ...Query) *BoolQuery {
+	q.mustNot = append(q.mustNot, queries...)
+	return q
+}
+
+// MinimumShouldMatch sets how many should clauses must match (e.g., 1 or "75%").
+func (q *BoolQuery) MinimumShouldMatch(value any) *BoolQuery {
+	q.options["minimum_should_match"] = value
+	return q
+}
+
+// Boost sets the relevance boost of the query.
+func (q *BoolQuery) Boost(boost float64) *BoolQuery {
+	q.options["boost"] = boost
+	return q
+}
+
+func (q *BoolQuery) Source() map[string]any {
+	body := maps.Clone(q.options)
+	for name, clauses := range map[string][]Query{"must": q.must, "filter": q.filter, "should": q.should, "must_not": q.mustNot} {
+		if len(clauses) != 0 {
+			body[name] = sources(clauses)
+		}
+	}
+
+	return map[string]any{"bool": body}
+}
+
+// TermQuery matches documents whose field contains the exact value.
+type TermQuery struct {
+	field   string
+	value   any
+	options map[string]any
+}
+
+// Term returns a term query. Use it on keyword, numeric, date and boolean fields; text fields
+// are analyzed and rarely contain the exact value.
+//
+// Example:
+//
+//	query.Term("status", "published")
+func Term(field string, value any) *TermQuery {
+	return &TermQuery{field: field, value: value, options: map[string]any{}}
+}
+
+// Boost sets the relevance boost of the query.
+func (q *TermQuery) Boost(boost float64) *TermQuery {
+	q.options["boost"] = boost
+	return q
+}
+
+// CaseInsensitive makes the match ignore the case of ASCII characters.
+func (q *TermQuery) CaseInsensitive(caseInsensitive bool) *TermQuery {
+	q.options["case_insensitive"] = caseInsensitive
+	return q
+}
+
+func (q *TermQuery) Source() map[string]any {
+	return fieldQuery("term", q.field, "value", q.value, q.options)
+}
+
+// TermsQuery matches documents whose field contains any of the values.
+type TermsQuery struct {
+	field   string
+	values  []any
+	options map[string]any
+}
+
+// Terms returns a terms query.
+//
+// Example:
+//
+//	query.Terms("tags", "go", "elasticsearch")
+func Terms(field string, values ...any) *TermsQuery {
+	return &TermsQuery{field: field, values: values, options: map[string]any{}}
+}
+
+// Boost sets the relevance boost of the query.
+func (q *TermsQuery) Boost(boost float64) *TermsQuery {
+	q.options["boost"] = boost
+	return q
+}
+
+func (q *TermsQuery) Source() map[string]any {
+	values := q.values
+	if values == nil {
+		values = []any{}
+	}
+
+	body := maps.Clone(q.options)
+	body[q.field] = values
+
+	return map[string]any{"terms": body}
+}
+
+// RangeQuery matches documents whose field is within bounds.
+type RangeQuery struct {
+	field   string
+	options map[string]any
+}
+
+// Range returns a range query without bounds.
+//
+// Example:
+//
+//	query.Range("created_at").Gte("now-7d/d").Lt("now/d")
+//	query.Range("price").Gt(10).Lte(100)
+func Range(field string) *RangeQuery {
+	return &RangeQuery{field: field, options: map[string]any{}}
+}
+
+// Gt sets the exclusive lower bound.
+func (q *RangeQuery) Gt(value any) *RangeQuery {
+	q.options["gt"] = value
+	return q
+}
+
+// Gte sets the inclusive lower bound.
+func (q *RangeQuery) Gte(value any) *RangeQuery {
+	q.options["gte"] = value
+	return q
+}
+
+// Lt sets the exclusive upper bound.
+func (q *RangeQuery) Lt(value any) *RangeQuery {
+	q.options["lt"] = value
+	return q
+}
+
+// Lte sets the inclusive upper bound.
+func (q *RangeQuery) Lte(value any) *RangeQuery {
+	q.options["lte"] = value
+	return q
+}
+
+// Format sets the date format of the bounds (e.g., "yyyy-MM-dd").
+func (q *RangeQuery) Format(format string) *RangeQuery {
+	q.options["format"] = format
+	return q
+}
+
+// TimeZone sets the time zone of date bounds (e.g., "+09:00" or "Asia/Seoul").
+func (q *RangeQuery) TimeZone(timeZone string) *RangeQuery {
+	q.options["time_zone"] = timeZone
+	return q
+}
+
+// Boost sets the relevance boost of the query.
+func (q *RangeQuery) Boost(boost float64) *RangeQuery {
+	q.options["boost"] = boost
+	return q
+}
+
+func (q *RangeQuery) Source() map[string]any {
+	return map[string]any{"range": map[string]any{q.field: maps.Clone(q.options)}}
+}
+
+// MatchQuery is a full-text query on one field.
+type MatchQuery struct {
+	field   string
+	text    any
+	options map[string]any
+}
+
+// Match returns a match query, which analyzes text and matches documents with any of its terms.
+//
+// Example:
+//
+//	query.Match("title", "quick brown fox").Operator("and")
+func Match(field string, text any) *MatchQuery {
+	return &MatchQuery{field: field, text: text, options: map[string]any{}}
+}
+
+// Operator sets whether any ("or", default) or all ("and") terms must match.
+func (q *MatchQuery) Operator(operator string) *MatchQuery {
+	q.options["operator"] = operator
+	return q
+}
+
+// Fuzziness sets the allowed edit distance (e.g., "AUTO" or "1").
+func (q *MatchQuery) Fuzziness(fuzziness string) *MatchQuery {
+	q.options["fuzziness"] = fuzziness
+	return q
+}
+
+// MinimumShouldMatch sets how many terms must match (e.g., 2 or "75%").
+func (q *MatchQuery) MinimumShouldMatch(value any) *MatchQuery {
+	q.options["minimum_should_match"] = value
+	return q
+}
+
+// Analyzer sets the analyzer of text.
+func (q *MatchQuery) Analyzer(analyzer string) *MatchQuery {
+	q.options["analyzer"] = analyzer
+	return q
+}
+
+// Boost sets the relevance boost of the query.
+func (q *MatchQuery) Boost(boost float64) *MatchQuery {
+	q.options["boost"] = boost
+	return q
+}
+
+func (q *MatchQuery) Source() map[string]any {
+	return fieldQuery("match", q.field, "query", q.text, q.options)
+}
+
+// MultiMatchQuery is a full-text query on several fields.
+type MultiMatchQuery struct {
+	options map[string]any
+}
+
+// MultiMatch returns a multi_match query. Fields may carry a boost (e.g., "title^3").
+//
+// Example:
+//
+//	query.MultiMatch("wireless headphones", "name^3", "description").Type("best_fields")
+func MultiMatch(text any, fields ...string) *MultiMatchQuery {
+	if fields == nil {
+		fields = []string{}
+	}
+
+	return &MultiMatchQuery{options: map[string]any{"query": text, "fields": fields}}
+}
+
+// Type sets how the fields are combined: "best_fields" (default), "most_fields", "cross_fields",
+// "phrase", "phrase_prefix" or "bool_prefix".
+func (q *MultiMatchQuery) Type(multiMatchType string) *MultiMatchQuery {
+	q.options["type"] = multiMatchType
+	return q
+}
+
+// Operator sets whether any ("or", default) or all ("and") terms must match.
+func (q *MultiMatchQuery) Operator(operator string) *MultiMatchQuery {
+	q.options["operator"] = operator
+	return q
+}
+
+// Fuzziness sets the allowed edit distance (e.g., "AUTO").
+func (q *MultiMatchQuery) Fuzziness(fuzziness string) *MultiMatchQuery {
+	q.options["fuzziness"] = fuzziness
+	return q
+}
+
+// TieBreaker sets the weight of the other fields for "best_fields" (0 to 1).
+func (q *MultiMatchQuery) TieBreaker(tieBreaker float64) *MultiMatchQuery {
+	q.options["tie_breaker"] = tieBreaker
+	return q
+}
+
+// Boost sets the relevance boost of the query.
+func (q *MultiMatchQuery) Boost(boost float64) *MultiMatchQuery {
+	q.options["boost"] = boost
+	return q
+}
+
+func (q *MultiMatchQuery) Source() map[string]any {
+	return map[string]any{"multi_match": maps.Clone(q.options)}
+}
+
+// NestedQuery runs a query on nested objects.
+type NestedQuery struct {
+	query   Query
+	options map[string]any
+}
+
+// Nested returns a nested query matching documents with a nested object at path matching query.
+// Fields in query are referred to by their full path.
+//
+// Example:
+//
+//	query.Nested("comments", query.Bool().
+//	    Must(query.Match("comments.text", "great")).
+//	    Filter(query.Range("comments.stars").Gte(4)))
+func Nested(path string, query Query) *NestedQuery {
+	return &NestedQuery{query: query, options: map[string]any{"path": path}}
+}
+
+// ScoreMode sets how the scores of matching objects combine: "avg" (default), "max", "min", "sum" or "none".
+func (q *NestedQuery) ScoreMode(scoreMode string) *NestedQuery {
+	q.options["score_mode"] = scoreMode
+	return q
+}
+
+// IgnoreUnmapped makes the query match nothing instead of failing when path is not mapped.
+func (q *NestedQuery) IgnoreUnmapped(ignoreUnmapped bool) *NestedQuery {
+	q.options["ignore_unmapped"] = ignoreUnmapped
+	return q
+}
+
+func (q *NestedQuery) Source() map[string]any {
+	body := maps.Clone(q.options)
+	body["query"] = q.query.Source()
+
+	return map[string]any{"nested": body}
+}
+
+// ExistsQuery matches documents with an indexed value for a field.
+type ExistsQuery struct {
+	field string
+}
+
+// Exists returns an exists query.
+//
+// Example:
+//
+//	query.Bool().MustNot(query.Exists("deleted_at"))
+func Exists(field string) ExistsQuery {
+	return ExistsQuery{field: field}
+}
+
+func (q ExistsQuery) Source() map[string]any {
+	return map[string]any{"exists": map[string]any{"field": q.field}}
+}
@@ -0,0 +1,141 @@
+package query_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/common-library/go/database/elasticsearch/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func toJSON(t *testing.T, source map[string]any) string {
+	t.Helper()
+
+	data, err := json.Marshal(source)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestQueries(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		query    query.Query
+		expected string
+	}{
+		{query.MatchAll(), `{"match_all": {}}`},
+		{query.Term("status", "published"), `{"term": {"status": "published"}}`},
+		{query.Term("status", "Published").CaseInsensitive(true).Boost(2), `{"term": {"status": {"value": "Published", "case_insensitive": true, "boost": 2}}}`},
+		{query.Terms("tags", "go", "elasticsearch"), `{"terms": {"tags": ["go", "elasticsearch"]}}`},
+		{query.Terms("tags"), `{"terms": {"tags": []}}`},
+		{query.Range("price").Gt(10).Lte(100), `{"range": {"price": {"gt": 10, "lte": 100}}}`},
+		{query.Range("created_at").Gte("2024-01-01").Lt("2025-01-01").Format("yyyy-MM-dd").TimeZone("+09:00"),
+			`{"range": {"created_at": {"gte": "2024-01-01", "lt": "2025-01-01", "format": "yyyy-MM-dd", "time_zone": "+09:00"}}}`},
+		{query.Match("title", "quick fox"), `{"match": {"title": "quick fox"}}`},
+		{query.Match("title", "quick fox").Operator("and").Fuzziness("AUTO"), `{"match": {"title": {"query": "quick fox", "operator": "and", "fuzziness": "AUTO"}}}`},
+		{query.MultiMatch("headphones", "name^3", "description").Type("best_fields").TieBreaker(0.3),
+			`{"multi_match": {"query": "headphones", "fields": ["name^3", "description"], "type": "best_fields", "tie_breaker": 0.3}}`},
+		{query.Exists("deleted_at"), `{"exists": {"field": "deleted_at"}}`},
+		{query.Nested("comments", query.Range("comments.stars").Gte(4)).ScoreMode("max"),
+			`{"nested": {"path": "comments", "query": {"range": {"comments.stars": {"gte": 4}}}, "score_mode": "max"}}`},
+		{query.Raw(map[string]any{"prefix": map[string]any{"name": "lap"}}), `{"prefix": {"name": "lap"}}`},
+		{query.Bool(), `{"bool": {}}`},
+		{query.Bool().
+			Must(query.Match("title", "elasticsearch")).
+			Filter(query.Term("status", "published"), query.Range("year").Gte(2020)).
+			Should(query.Term("tags", "go")).
+			MustNot(query.Exists("deleted_at")).
+			MinimumShouldMatch(1),
+			`{"bool": {
+				"must": [{"match": {"title": "elasticsearch"}}],
+				"filter": [{"term": {"status": "published"}}, {"range": {"year": {"gte": 2020}}}],
+				"should": [{"term": {"tags": "go"}}],
+				"must_not": [{"exists": {"field": "deleted_at"}}],
+				"minimum_should_match": 1
+			}}`},
+	} {
+		assert.JSONEq(t, test.expected, toJSON(t, test.query.Source()))
+	}
+}
+
+func TestAggregations(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		aggregation query.Aggregation
+		expected    string
+	}{
+		{query.NewAvgAggregation("price"), `{"avg": {"field": "price"}}`},
+		{query.NewStatsAggregation("price").Missing(0), `{"stats": {"field": "price", "missing": 0}}`},
+		{query.NewCardinalityAggregation("user_id"), `{"cardinality": {"field": "user_id"}}`},
+		{query.NewTermsAggregation("category").Size(5).MinDocCount(2).Order("_count", "desc").Order("_key", "asc").
+			SubAggregation("avg_price", query.NewAvgAggregation("price")),
+			`{"terms": {"field": "category", "size": 5, "min_doc_count": 2, "order": [{"_count": "desc"}, {"_key": "asc"}]},
+			  "aggs": {"avg_price": {"avg": {"field": "price"}}}}`},
+		{query.NewHistogramAggregation("price", 50).MinDocCount(1), `{"histogram": {"field": "price", "interval": 50, "min_doc_count": 1}}`},
+		{query.NewDateHistogramAggregation("created_at").FixedInterval("12h").CalendarInterval("1d").Format("yyyy-MM-dd"),
+			`{"date_histogram": {"field": "created_at", "calendar_interval": "1d", "format": "yyyy-MM-dd"}}`},
+		{query.NewRangeAggregation("price").Range("cheap", nil, 100).Range("", 100, nil),
+			`{"range": {"field": "price", "ranges": [{"key": "cheap", "to": 100}, {"from": 100}]}}`},
+		{query.NewFilterAggregation(query.Term("in_stock", true)).SubAggregation("max_price", query.NewMaxAggregation("price")),
+			`{"filter": {"term": {"in_stock": true}}, "aggs": {"max_price": {"max": {"field": "price"}}}}`},
+		{query.NewNestedAggregation("comments").SubAggregation("stars", query.NewTermsAggregation("comments.stars")),
+			`{"nested": {"path": "comments"}, "aggs": {"stars": {"terms": {"field": "comments.stars"}}}}`},
+		{query.NewRawAggregation(map[string]any{"percentiles": map[string]any{"field": "load_time"}}), `{"percentiles": {"field": "load_time"}}`},
+	} {
+		assert.JSONEq(t, test.expected, toJSON(t, test.aggregation.Source()))
+	}
+}
+
+func TestSearch_Body(t *testing.T) {
+	t.Parallel()
+
+	body, err := query.NewSearch().Body()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, body)
+
+	body, err = query.NewSearch().
+		Query(query.Bool().Must(query.Match("name", "laptop")).Filter(query.Range("price").Lte(1000))).
+		Aggregation("brands", query.NewTermsAggregation("brand").Size(10)).
+		Aggregation("avg_price", query.NewAvgAggregation("price")).
+		Sort("price", "asc").
+		Sort("_score", "desc").
+		Highlight(query.NewHighlight("name").Field("description").PreTags("<mark>").PostTags("</mark>").FragmentSize(150)).
+		Size(20).
+		From(40).
+		SourceIncludes("name", "price").
+		TrackTotalHits(true).
+		Body()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"query": {"bool": {"must": [{"match": {"name": "laptop"}}], "filter": [{"range": {"price": {"lte": 1000}}}]}},
+		"aggs": {"brands": {"terms": {"field": "brand", "size": 10}}, "avg_price": {"avg": {"field": "price"}}},
+		"sort": [{"price": {"order": "asc"}}, {"_score": {"order": "desc"}}],
+		"highlight": {"fields": {"name": {}, "description": {}}, "pre_tags": ["<mark>"], "post_tags": ["</mark>"], "fragment_size": 150},
+		"size": 20,
+		"from": 40,
+		"_source": {"includes": ["name", "price"]},
+		"track_total_hits": true
+	}`, body)
+
+	_, err = query.NewSearch().Query(query.Range("price").Gt(math.Inf(1))).Body()
+	assert.Error(t, err)
+}
+
+func TestSearch_Reuse(t *testing.T) {
+	t.Parallel()
+
+	match := query.Match("title", "go")
+	search := query.NewSearch().Query(match)
+
+	source := search.Source()
+	source["size"] = 1
+	match.Operator("and")
+
+	body, err := search.Body()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"query": {"match": {"title": {"query": "go", "operator": "and"}}}}`, body)
+}
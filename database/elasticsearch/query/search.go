@@ -0,0 +1,181 @@
+package query
+
+import (
+	"encoding/json"
+	"maps"
+)
+
+// Search is a search body with a query, aggregations, sort, highlighting and paging.
+type Search struct {
+	query          Query
+	aggregations   map[string]Aggregation
+	sort           []any
+	highlight      *Highlight
+	options        map[string]any
+	sourceIncludes []string
+}
+
+// NewSearch returns an empty search body, which matches every document.
+//
+// Example:
+//
+//	body, err := query.NewSearch().
+//	    Query(query.MultiMatch("wireless headphones", "name^3", "description")).
+//	    Highlight(query.NewHighlight("name", "description")).
+//	    Size(20).
+//	    Body()
+func NewSearch() *Search {
+	return &Search{aggregations: map[string]Aggregation{}, options: map[string]any{}}
+}
+
+// Query sets the query.
+func (s *Search) Query(query Query) *Search {
+	s.query = query
+	return s
+}
+
+// Aggregation adds a named aggregation; its result is read with the same name.
+func (s *Search) Aggregation(name string, aggregation Aggregation) *Search {
+	s.aggregations[name] = aggregation
+	return s
+}
+
+// Sort adds a sort by field ("_score" for relevance, "_doc" for index order) and order ("asc" or
+// "desc"). Later sorts break ties of earlier ones.
+func (s *Search) Sort(field, order string) *Search {
+	s.sort = append(s.sort, map[string]any{field: map[string]any{"order": order}})
+	return s
+}
+
+// Highlight sets the highlighting of matches.
+func (s *Search) Highlight(highlight *Highlight) *Search {
+	s.highlight = highlight
+	return s
+}
+
+// Size sets the number of hits returned (default 10).
+func (s *Search) Size(size int) *Search {
+	s.options["size"] = size
+	return s
+}
+
+// From sets the number of hits skipped. For deep paging use NewSearchIterator instead.
+func (s *Search) From(from int) *Search {
+	s.options["from"] = from
+	return s
+}
+
+// SourceIncludes limits the returned _source to the fields (wildcards allowed).
+func (s *Search) SourceIncludes(fields ...string) *Search {
+	s.sourceIncludes = append(s.sourceIncludes, fields...)
+	return s
+}
+
+// TrackTotalHits sets whether the total number of hits is counted exactly beyond 10,000.
+func (s *Search) TrackTotalHits(trackTotalHits bool) *Search {
+	s.options["track_total_hits"] = trackTotalHits
+	return s
+}
+
+// Source returns the JSON object of the search body.
+func (s *Search) Source() map[string]any {
+	body := maps.Clone(s.options)
+
+	if s.query != nil {
+		body["query"] = s.query.Source()
+	}
+
+	if len(s.aggregations) != 0 {
+		aggs := map[string]any{}
+		for name, aggregation := range s.aggregations {
+			aggs[name] = aggregation.Source()
+		}
+		body["aggs"] = aggs
+	}
+
+	if len(s.sort) != 0 {
+		body["sort"] = s.sort
+	}
+
+	if s.highlight != nil {
+		body["highlight"] = s.highlight.Source()
+	}
+
+	if len(s.sourceIncludes) != 0 {
+		body["_source"] = map[string]any{"includes": s.sourceIncludes}
+	}
+
+	return body
+}
+
+// Body returns the search body as JSON for Search, DeleteByQuery or NewSearchIterator.
+//
+// Returns error if a value of the body cannot be encoded as JSON.
+func (s *Search) Body() (string, error) {
+	data, err := json.Marshal(s.Source())
+	return string(data), err
+}
+
+// Highlight configures the highlighting of matches in the hits.
+type Highlight struct {
+	fields  []string
+	options map[string]any
+}
+
+// NewHighlight returns a highlight of the fields (wildcards allowed).
+//
+// Example:
+//
+//	query.NewHighlight("title", "content").PreTags("<mark>").PostTags("</mark>").FragmentSize(150)
+func NewHighlight(fields ...string) *Highlight {
+	return &Highlight{fields: fields, options: map[string]any{}}
+}
+
+// Field adds a field.
+func (h *Highlight) Field(field string) *Highlight {
+	h.fields = append(h.fields, field)
+	return h
+}
+
+// PreTags sets the tags inserted before highlighted text (default "<em>").
+func (h *Highlight) PreTags(tags ...string) *Highlight {
+	h.options["pre_tags"] = tags
+	return h
+}
+
+// PostTags sets the tags inserted after highlighted text (default "</em>").
+func (h *Highlight) PostTags(tags ...string) *Highlight {
+	h.options["post_tags"] = tags
+	return h
+}
+
+// FragmentSize sets the size of the highlighted fragments in characters (default 100).
+func (h *Highlight) FragmentSize(size int) *Highlight {
+	h.options["fragment_size"] = size
+	return h
+}
+
+// NumberOfFragments sets the maximum number of fragments per field (default 5, 0 for the whole field).
+func (h *Highlight) NumberOfFragments(number int) *Highlight {
+	h.options["number_of_fragments"] = number
+	return h
+}
+
+// RequireFieldMatch sets whether only fields matching the query are highlighted (default true).
+func (h *Highlight) RequireFieldMatch(requireFieldMatch bool) *Highlight {
+	h.options["require_field_match"] = requireFieldMatch
+	return h
+}
+
+// Source returns the JSON object of the highlight.
+func (h *Highlight) Source() map[string]any {
+	body := maps.Clone(h.options)
+
+	fields := map[string]any{}
+	for _, field := range h.fields {
+		fields[field] = map[string]any{}
+	}
+	body["fields"] = fields
+
+	return body
+}
@@ -0,0 +1,180 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"time"
+)
+
+// SearchIteratorConfig configures the paging of a search iterator. Zero values select the defaults.
+type SearchIteratorConfig struct {
+	// PageSize is the number of hits fetched per request (default 1000)
+	PageSize int
+
+	// KeepAlive is how long the point in time or scroll context is kept between two pages (default 1m)
+	KeepAlive time.Duration
+}
+
+// SearchHit is a search hit whose source decodes into T.
+type SearchHit[T any] struct {
+	// Index is the index holding the document
+	Index string `json:"_index"`
+
+	// ID is the document identifier
+	ID string `json:"_id"`
+
+	// Score is the relevance score, nil when the hits are sorted by other fields
+	Score *float64 `json:"_score"`
+
+	// Sort holds the sort values of the hit as raw JSON, keeping the precision of long values
+	Sort []json.RawMessage `json:"sort,omitempty"`
+
+	// Highlight holds the highlighted fragments per field when the search requests highlighting
+	Highlight map[string][]string `json:"highlight,omitempty"`
+
+	// Source is the decoded document
+	Source T `json:"_source"`
+}
+
+// SearchPage is one page of the hits of a SearchCursor.
+type SearchPage struct {
+	// Hits are the hits of the page with their raw sources
+	Hits []SearchHit[json.RawMessage]
+
+	// Total is the number of matching documents, set on the first page
+	Total int64
+
+	// Aggregations are the aggregation results, set on the first page
+	Aggregations Aggregations
+}
+
+// SearchCursor pages through all hits of a search.
+//
+// Version 8 and 9 clients use a point in time with search_after, version 7 clients use the scroll
+// API. The search context is released when the last page has been read or when Close is called.
+type SearchCursor interface {
+	// Next returns the next page. A page without hits marks the end of the results.
+	Next(ctx context.Context) (SearchPage, error)
+
+	// Close releases the point in time or scroll context. It can be called more than once.
+	Close(ctx context.Context) error
+}
+
+// SearchIterator iterates over all hits of a search, decoding their sources into T.
+type SearchIterator[T any] struct {
+	cursor       SearchCursor
+	first        SearchPage
+	total        int64
+	aggregations Aggregations
+	started      bool
+}
+
+// NewSearchIterator runs a search and returns an iterator over all of its hits.
+//
+// Parameters:
+//   - ctx: Context of the first request
+//   - client: Initialized client of any version
+//   - index: Index, alias or comma-separated list of indices to search
+//   - body: JSON search body with query, sort and aggregations, or empty for all documents;
+//     "size", "from", "search_after" and "pit" are managed by the iterator
+//   - config: Page size and keep-alive of the search context
+//
+// Returns:
+//   - *SearchIterator[T]: Iterator positioned before the first hit, with Total and Aggregations available
+//   - error: Error if the client is not initialized or the first request fails, nil on success
+//
+// Without a sort in body the hits come in index order, which is the cheapest way to export an
+// index. The iterator keeps a search context open on the cluster until all hits have been read;
+// call Close when stopping early.
+//
+// Example:
+//
+//	type Product struct {
+//		Name  string  `json:"name"`
+//		Price float64 `json:"price"`
+//	}
+//
+//	products, err := elasticsearch.NewSearchIterator[Product](ctx, client, "products",
+//		`{"query": {"term": {"category": "books"}}, "aggs": {"avg_price": {"avg": {"field": "price"}}}}`,
+//		elasticsearch.SearchIteratorConfig{PageSize: 500})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer products.Close(ctx)
+//
+//	average, _ := products.Aggregations().Metric("avg_price")
+//	for hit, err := range products.All(ctx) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(hit.ID, hit.Source.Name)
+//	}
+func NewSearchIterator[T any](ctx context.Context, client ClientInterface, index, body string, config SearchIteratorConfig) (*SearchIterator[T], error) {
+	cursor, err := client.OpenSearchCursor(ctx, index, body, config)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := cursor.Next(ctx)
+	if err != nil {
+		cursor.Close(context.WithoutCancel(ctx))
+		return nil, err
+	}
+
+	return &SearchIterator[T]{cursor: cursor, first: page, total: page.Total, aggregations: page.Aggregations}, nil
+}
+
+// Total returns the number of documents matching the search.
+func (i *SearchIterator[T]) Total() int64 {
+	return i.total
+}
+
+// Aggregations returns the aggregation results of the search.
+func (i *SearchIterator[T]) Aggregations() Aggregations {
+	return i.aggregations
+}
+
+// All returns a sequence of all hits, fetching further pages as it goes.
+//
+// The sequence stops after the first error. It can be ranged over only once.
+func (i *SearchIterator[T]) All(ctx context.Context) iter.Seq2[SearchHit[T], error] {
+	return func(yield func(SearchHit[T], error) bool) {
+		if i.started {
+			yield(SearchHit[T]{}, errors.New("search iterator can only be ranged over once"))
+			return
+		}
+		i.started = true
+
+		page := i.first
+		i.first = SearchPage{}
+
+		for len(page.Hits) != 0 {
+			for _, raw := range page.Hits {
+				hit := SearchHit[T]{Index: raw.Index, ID: raw.ID, Score: raw.Score, Sort: raw.Sort, Highlight: raw.Highlight}
+				if len(raw.Source) != 0 {
+					if err := json.Unmarshal(raw.Source, &hit.Source); err != nil {
+						yield(SearchHit[T]{}, err)
+						return
+					}
+				}
+
+				if !yield(hit, nil) {
+					return
+				}
+			}
+
+			var err error
+			if page, err = i.cursor.Next(ctx); err != nil {
+				yield(SearchHit[T]{}, err)
+				return
+			}
+		}
+	}
+}
+
+// Close releases the search context on the cluster.
+func (i *SearchIterator[T]) Close(ctx context.Context) error {
+	return i.cursor.Close(ctx)
+}
@@ -1,17 +1,22 @@
 // Package testutil provides test utilities for Elasticsearch client testing.
 //
 // This package simplifies testing by providing helper functions to create
-// Elasticsearch test containers and initialize clients across different versions.
+// Elasticsearch test containers and initialize clients across different versions,
+// and an in-process fake client for unit tests without Docker.
 //
 // Features:
 //   - Multi-version client creation (v7, v8, v9)
 //   - Testcontainers integration
 //   - Simplified client initialization for tests
+//   - In-memory FakeClient implementing ClientInterface with a subset of the query DSL
 //
 // Example:
 //
 //	client := testutil.GetTestClient(t, "v8", []string{"http://localhost:9200"})
 //	client.Index("testindex", "1", `{"test":"data"}`)
+//
+//	fake := testutil.NewFakeClient()
+//	fake.Index("testindex", "1", `{"test":"data"}`)
 package testutil
 
 import (
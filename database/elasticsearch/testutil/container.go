@@ -26,6 +26,7 @@ func RunWithElasticsearch(m *testing.M, image string, elasticsearchURL *string)
 			"xpack.security.enabled":                            "false",
 			"xpack.ml.enabled":                                  "false",
 			"xpack.watcher.enabled":                             "false",
+			"path.repo":                                         "/tmp/snapshots",
 		},
 		WaitingFor: wait.ForHTTP("/_cluster/health?wait_for_status=yellow&timeout=1s").
 			WithPort(nat.Port("9200/tcp")).
@@ -0,0 +1,1010 @@
+package testutil
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/common-library/go/database/elasticsearch"
+	"github.com/common-library/go/database/elasticsearch/internal/esbulk"
+)
+
+var rolloverSuffix = regexp.MustCompile(`^(.*-)(\d+)$`)
+
+var _ elasticsearch.ClientInterface = (*FakeClient)(nil)
+
+type fakeIndex struct {
+	documents map[string]json.RawMessage
+	order     []string
+	aliases   map[string]*bool
+}
+
+func newFakeIndex() *fakeIndex {
+	return &fakeIndex{documents: map[string]json.RawMessage{}, aliases: map[string]*bool{}}
+}
+
+func (i *fakeIndex) put(documentID string, source json.RawMessage) bool {
+	_, exists := i.documents[documentID]
+	if !exists {
+		i.order = append(i.order, documentID)
+	}
+	i.documents[documentID] = source
+
+	return !exists
+}
+
+func (i *fakeIndex) remove(documentID string) bool {
+	if _, exists := i.documents[documentID]; !exists {
+		return false
+	}
+
+	delete(i.documents, documentID)
+	i.order = slices.DeleteFunc(i.order, func(id string) bool { return id == documentID })
+
+	return true
+}
+
+func (i *fakeIndex) clone() *fakeIndex {
+	return &fakeIndex{documents: maps.Clone(i.documents), order: slices.Clone(i.order), aliases: maps.Clone(i.aliases)}
+}
+
+// FakeClient is an in-process fake of elasticsearch.ClientInterface for unit tests without Docker.
+//
+// Documents are kept in memory per index and every write is immediately visible, like the
+// refresh=true writes of the real clients. Search, DeleteByQuery, Reindex and OpenSearchCursor
+// evaluate a subset of the query DSL:
+//   - match_all, match_none, ids, term, terms, range, exists, match and nested queries
+//   - bool queries with must, filter, should, must_not and minimum_should_match
+//   - from, size and sort by fields, _score or _doc
+//
+// Term queries compare exact values and match queries compare lowercased words, so analyzers
+// and mappings are not applied. Every hit scores 1. Aggregations and unsupported queries return
+// an error instead of a wrong result. Errors of missing indices and documents have the format of
+// the real clients.
+//
+// Templates, ILM policies and snapshot repositories are stored but not applied; they can be read
+// back with the Exists and Get methods. Snapshots copy the documents of their indices.
+//
+// Example:
+//
+//	client := testutil.NewFakeClient()
+//	client.Index("products", "1", `{"name": "laptop", "price": 1200}`)
+//
+//	result, err := client.Search("products", `{"query": {"range": {"price": {"gte": 1000}}}}`)
+type FakeClient struct {
+	mutex sync.Mutex
+
+	indices            map[string]*fakeIndex
+	templates          map[string]json.RawMessage
+	indexTemplates     map[string]json.RawMessage
+	componentTemplates map[string]json.RawMessage
+	lifecycles         map[string]json.RawMessage
+	repositories       map[string]map[string]map[string]*fakeIndex
+
+	sequence int64
+}
+
+// NewFakeClient returns an empty FakeClient. Initialize is not required.
+//
+// Example:
+//
+//	var client elasticsearch.ClientInterface = testutil.NewFakeClient()
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		indices:            map[string]*fakeIndex{},
+		templates:          map[string]json.RawMessage{},
+		indexTemplates:     map[string]json.RawMessage{},
+		componentTemplates: map[string]json.RawMessage{},
+		lifecycles:         map[string]json.RawMessage{},
+		repositories:       map[string]map[string]map[string]*fakeIndex{}}
+}
+
+// Initialize accepts any configuration and keeps the stored data.
+func (c *FakeClient) Initialize(addresses []string, timeout time.Duration, cloudID, apiKey, username, password, certificateFingerprint string, caCert []byte) error {
+	return nil
+}
+
+// Exists checks if a document exists in the index or the index behind an alias.
+func (c *FakeClient) Exists(index, documentID string) (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	names, err := c.resolve([]string{index}, true)
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range names {
+		if _, ok := c.indices[name].documents[documentID]; ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Index stores a document, creating the index if needed. An empty documentID generates one.
+func (c *FakeClient) Index(index, documentID, body string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	source, err := compact(body)
+	if err != nil {
+		return err
+	}
+
+	name, err := c.writeIndex(index)
+	if err != nil {
+		return err
+	}
+
+	if documentID == "" {
+		documentID = c.generateID()
+	}
+	c.indices[name].put(documentID, source)
+
+	return nil
+}
+
+// Delete deletes a document.
+func (c *FakeClient) Delete(index, documentID string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	names, err := c.resolve([]string{index}, false)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if c.indices[name].remove(documentID) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("response error - status : (%s)", status(http.StatusNotFound))
+}
+
+// DeleteByQuery deletes the documents matching the query of body.
+func (c *FakeClient) DeleteByQuery(indices []string, body string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	request, err := parseSearchRequest(body)
+	if err != nil {
+		return err
+	}
+
+	hits, err := c.search(indices, request)
+	if err != nil {
+		return err
+	}
+
+	for _, hit := range hits {
+		c.indices[hit.index].remove(hit.id)
+	}
+
+	return nil
+}
+
+// IndicesExists checks if all indices exist.
+func (c *FakeClient) IndicesExists(indices []string) (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, err := c.resolve(indices, false)
+	return err == nil, nil
+}
+
+// IndicesCreate creates an index, applying the aliases of body. Mappings and settings are ignored.
+func (c *FakeClient) IndicesCreate(index, body string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.indices[index]; ok {
+		return responseError(http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("index [%s] already exists", index))
+	}
+
+	request := struct {
+		Aliases map[string]struct {
+			IsWriteIndex *bool `json:"is_write_index"`
+		} `json:"aliases"`
+	}{}
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &request); err != nil {
+			return parseError(err)
+		}
+	}
+
+	created := newFakeIndex()
+	for alias, options := range request.Aliases {
+		created.aliases[alias] = options.IsWriteIndex
+	}
+	c.indices[index] = created
+
+	return nil
+}
+
+// IndicesDelete deletes indices.
+func (c *FakeClient) IndicesDelete(indices []string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	names, err := c.resolve(indices, false)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		delete(c.indices, name)
+	}
+
+	return nil
+}
+
+// IndicesExistsTemplate checks if all legacy templates exist.
+func (c *FakeClient) IndicesExistsTemplate(name []string) (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, template := range name {
+		if _, ok := c.templates[template]; !ok {
+			return false, nil
+		}
+	}
+
+	return len(name) != 0, nil
+}
+
+// IndicesPutTemplate stores a legacy template.
+func (c *FakeClient) IndicesPutTemplate(name, body string) error {
+	return c.putDefinition(c.templates, name, body)
+}
+
+// IndicesDeleteTemplate deletes a legacy template.
+func (c *FakeClient) IndicesDeleteTemplate(name string) error {
+	return c.deleteDefinition(c.templates, name, "index_template_missing_exception", "index_template [%s] missing")
+}
+
+// IndicesExistsIndexTemplate checks if a composable index template exists.
+func (c *FakeClient) IndicesExistsIndexTemplate(name string) (bool, error) {
+	return c.existsDefinition(c.indexTemplates, name), nil
+}
+
+// IndicesPutIndexTemplate stores a composable index template.
+func (c *FakeClient) IndicesPutIndexTemplate(name, body string) error {
+	return c.putDefinition(c.indexTemplates, name, body)
+}
+
+// IndicesDeleteIndexTemplate deletes a composable index template.
+func (c *FakeClient) IndicesDeleteIndexTemplate(name string) error {
+	return c.deleteDefinition(c.indexTemplates, name, "resource_not_found_exception", "index_template matching [%s] not found")
+}
+
+// ClusterExistsComponentTemplate checks if a component template exists.
+func (c *FakeClient) ClusterExistsComponentTemplate(name string) (bool, error) {
+	return c.existsDefinition(c.componentTemplates, name), nil
+}
+
+// ClusterPutComponentTemplate stores a component template.
+func (c *FakeClient) ClusterPutComponentTemplate(name, body string) error {
+	return c.putDefinition(c.componentTemplates, name, body)
+}
+
+// ClusterDeleteComponentTemplate deletes a component template.
+func (c *FakeClient) ClusterDeleteComponentTemplate(name string) error {
+	return c.deleteDefinition(c.componentTemplates, name, "resource_not_found_exception", "component template matching [%s] not found")
+}
+
+// IndicesUpdateAliases applies alias actions atomically.
+func (c *FakeClient) IndicesUpdateAliases(actions []elasticsearch.AliasAction) error {
+	if _, err := json.Marshal(actions); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, action := range actions {
+		if _, ok := c.indices[action.Index]; !ok {
+			return indexNotFound(action.Index)
+		} else if action.Type == "remove" {
+			if _, ok := c.indices[action.Index].aliases[action.Alias]; !ok {
+				return responseError(http.StatusNotFound, "aliases_not_found_exception", fmt.Sprintf("aliases [%s] missing", action.Alias))
+			}
+		}
+	}
+
+	for _, action := range actions {
+		switch action.Type {
+		case "add":
+			c.indices[action.Index].aliases[action.Alias] = action.IsWriteIndex
+		case "remove":
+			delete(c.indices[action.Index].aliases, action.Alias)
+		case "remove_index":
+			delete(c.indices, action.Index)
+		}
+	}
+
+	return nil
+}
+
+// IndicesGetAlias returns the sorted indices of an alias, or an empty slice if it does not exist.
+func (c *FakeClient) IndicesGetAlias(alias string) ([]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.aliasIndices(alias), nil
+}
+
+// IndicesRollover moves the write index of an alias to a new index.
+//
+// Only the max_docs condition is evaluated; other conditions are reported as not met. Without
+// conditions the alias always rolls over.
+func (c *FakeClient) IndicesRollover(alias, newIndex, body string) (elasticsearch.RolloverResult, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	request := struct {
+		Conditions map[string]json.RawMessage `json:"conditions"`
+	}{}
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &request); err != nil {
+			return elasticsearch.RolloverResult{}, parseError(err)
+		}
+	}
+
+	oldIndex, err := c.writeIndex(alias)
+	if err != nil {
+		return elasticsearch.RolloverResult{}, err
+	} else if _, ok := c.indices[alias]; ok {
+		return elasticsearch.RolloverResult{}, responseError(http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("rollover target [%s] does not point to an alias", alias))
+	}
+
+	if newIndex == "" {
+		match := rolloverSuffix.FindStringSubmatch(oldIndex)
+		if match == nil {
+			return elasticsearch.RolloverResult{}, responseError(http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("index name [%s] does not match pattern '^.*-\\d+$'", oldIndex))
+		}
+		number, _ := strconv.Atoi(match[2])
+		newIndex = fmt.Sprintf("%s%0*d", match[1], len(match[2]), number+1)
+	}
+
+	result := elasticsearch.RolloverResult{OldIndex: oldIndex, NewIndex: newIndex, Conditions: map[string]bool{}, RolledOver: true}
+	for name, value := range request.Conditions {
+		condition := fmt.Sprintf("[%s: %s]", name, strings.Trim(string(value), `"`))
+		maxDocs := 0
+		result.Conditions[condition] = name == "max_docs" && json.Unmarshal(value, &maxDocs) == nil && len(c.indices[oldIndex].documents) >= maxDocs
+	}
+	if len(request.Conditions) != 0 {
+		result.RolledOver = slices.Contains(slices.Collect(maps.Values(result.Conditions)), true)
+	}
+
+	if !result.RolledOver {
+		return result, nil
+	} else if _, ok := c.indices[newIndex]; ok {
+		return elasticsearch.RolloverResult{}, responseError(http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("index [%s] already exists", newIndex))
+	}
+
+	writeIndex, notWriteIndex := true, false
+	created := newFakeIndex()
+	created.aliases[alias] = &writeIndex
+	c.indices[newIndex] = created
+	c.indices[oldIndex].aliases[alias] = &notWriteIndex
+
+	return result, nil
+}
+
+// IndicesForcemerge checks that the indices exist.
+func (c *FakeClient) IndicesForcemerge(indices []string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, err := c.resolve(indices, false)
+	return err
+}
+
+// Reindex copies the documents of source.index matching source.query to dest.index.
+func (c *FakeClient) Reindex(ctx context.Context, body string, pollInterval time.Duration) (elasticsearch.ReindexResult, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	request := struct {
+		Source struct {
+			Index json.RawMessage `json:"index"`
+			Query json.RawMessage `json:"query"`
+		} `json:"source"`
+		Dest struct {
+			Index string `json:"index"`
+		} `json:"dest"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		return elasticsearch.ReindexResult{}, parseError(err)
+	}
+
+	indices := []string{}
+	if err := json.Unmarshal(request.Source.Index, &indices); err != nil {
+		index := ""
+		if err := json.Unmarshal(request.Source.Index, &index); err != nil {
+			return elasticsearch.ReindexResult{}, responseError(http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: use _all if you really want to copy from all existing indexes;")
+		}
+		indices = strings.Split(index, ",")
+	}
+
+	hits, err := c.search(indices, searchRequest{Query: request.Source.Query})
+	if err != nil {
+		return elasticsearch.ReindexResult{}, err
+	}
+
+	dest, err := c.writeIndex(request.Dest.Index)
+	if err != nil {
+		return elasticsearch.ReindexResult{}, err
+	}
+
+	result := elasticsearch.ReindexResult{Total: int64(len(hits)), Batches: 1}
+	for _, hit := range hits {
+		if c.indices[dest].put(hit.id, hit.source) {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+// ILMPutLifecycle stores the policy of body.
+func (c *FakeClient) ILMPutLifecycle(policy, body string) error {
+	request := struct {
+		Policy json.RawMessage `json:"policy"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		return parseError(err)
+	} else if request.Policy == nil {
+		return responseError(http.StatusBadRequest, "x_content_parse_exception", "required [policy] field is missing")
+	}
+
+	return c.putDefinition(c.lifecycles, policy, string(request.Policy))
+}
+
+// ILMGetLifecycle returns the stored policy as JSON.
+func (c *FakeClient) ILMGetLifecycle(policy string) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if body, ok := c.lifecycles[policy]; ok {
+		return string(body), nil
+	}
+
+	return "", responseError(http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("Lifecycle policy not found: %s", policy))
+}
+
+// ILMDeleteLifecycle deletes a policy.
+func (c *FakeClient) ILMDeleteLifecycle(policy string) error {
+	return c.deleteDefinition(c.lifecycles, policy, "resource_not_found_exception", "Lifecycle policy not found: %s")
+}
+
+// SnapshotCreateRepository registers a snapshot repository; its settings are ignored.
+func (c *FakeClient) SnapshotCreateRepository(repository, body string) error {
+	if _, err := compact(body); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.repositories[repository]; !ok {
+		c.repositories[repository] = map[string]map[string]*fakeIndex{}
+	}
+
+	return nil
+}
+
+// SnapshotDeleteRepository unregisters a snapshot repository together with its snapshots.
+func (c *FakeClient) SnapshotDeleteRepository(repository string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.repositories[repository]; !ok {
+		return repositoryMissing(repository)
+	}
+	delete(c.repositories, repository)
+
+	return nil
+}
+
+// SnapshotCreate copies the indices of body (all indices by default) into a snapshot.
+func (c *FakeClient) SnapshotCreate(repository, snapshot, body string, waitForCompletion bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	request := struct {
+		Indices json.RawMessage `json:"indices"`
+	}{}
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &request); err != nil {
+			return parseError(err)
+		}
+	}
+
+	snapshots, ok := c.repositories[repository]
+	if !ok {
+		return repositoryMissing(repository)
+	} else if _, ok := snapshots[snapshot]; ok {
+		return responseError(http.StatusBadRequest, "invalid_snapshot_name_exception", fmt.Sprintf("[%s:%s] Invalid snapshot name [%s], snapshot with the same name already exists", repository, snapshot, snapshot))
+	}
+
+	names, err := c.resolve(indexNames(request.Indices, []string{"_all"}), true)
+	if err != nil {
+		return err
+	}
+
+	indices := map[string]*fakeIndex{}
+	for _, name := range names {
+		indices[name] = c.indices[name].clone()
+	}
+	snapshots[snapshot] = indices
+
+	return nil
+}
+
+// SnapshotRestore restores the indices of body (all indices of the snapshot by default),
+// renamed with rename_pattern and rename_replacement. Open indices with the same names cannot be restored.
+func (c *FakeClient) SnapshotRestore(repository, snapshot, body string, waitForCompletion bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	request := struct {
+		Indices           json.RawMessage `json:"indices"`
+		RenamePattern     string          `json:"rename_pattern"`
+		RenameReplacement string          `json:"rename_replacement"`
+	}{}
+	if body != "" {
+		if err := json.Unmarshal([]byte(body), &request); err != nil {
+			return parseError(err)
+		}
+	}
+
+	indices, err := c.snapshot(repository, snapshot)
+	if err != nil {
+		return err
+	}
+
+	var rename *regexp.Regexp
+	if request.RenamePattern != "" {
+		if rename, err = regexp.Compile(request.RenamePattern); err != nil {
+			return responseError(http.StatusBadRequest, "illegal_argument_exception", err.Error())
+		}
+	}
+
+	restores := map[string]*fakeIndex{}
+	for name, index := range indices {
+		if !matchAny(indexNames(request.Indices, []string{"*"}), name) {
+			continue
+		}
+
+		target := name
+		if rename != nil {
+			target = rename.ReplaceAllString(name, request.RenameReplacement)
+		}
+		if _, ok := c.indices[target]; ok {
+			return responseError(http.StatusInternalServerError, "snapshot_restore_exception", fmt.Sprintf("[%s:%s] cannot restore index [%s] because an open index with same name already exists in the cluster", repository, snapshot, target))
+		}
+		restores[target] = index.clone()
+	}
+
+	maps.Copy(c.indices, restores)
+
+	return nil
+}
+
+// SnapshotDelete deletes a snapshot.
+func (c *FakeClient) SnapshotDelete(repository, snapshot string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, err := c.snapshot(repository, snapshot); err != nil {
+		return err
+	}
+	delete(c.repositories[repository], snapshot)
+
+	return nil
+}
+
+// Search returns the response of a search as JSON in the format of the real clients.
+func (c *FakeClient) Search(index, body string) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	request, err := parseSearchRequest(body)
+	if err != nil {
+		return "", err
+	}
+
+	hits, err := c.search(strings.Split(index, ","), request)
+	if err != nil {
+		return "", err
+	}
+
+	total := len(hits)
+	size := 10
+	if request.Size != nil {
+		size = *request.Size
+	}
+	hits = hits[min(request.From, len(hits)):min(request.From+size, len(hits))]
+
+	var maxScore *float64
+	responseHits := make([]elasticsearch.SearchHit[json.RawMessage], 0, len(hits))
+	for _, hit := range hits {
+		responseHit := hit.searchHit()
+		if responseHit.Score != nil {
+			maxScore = responseHit.Score
+		}
+		responseHits = append(responseHits, responseHit)
+	}
+
+	response := map[string]any{
+		"took":      0,
+		"timed_out": false,
+		"hits": map[string]any{
+			"total":     map[string]any{"value": total, "relation": "eq"},
+			"max_score": maxScore,
+			"hits":      responseHits}}
+
+	data, err := json.Marshal(response)
+	return string(data), err
+}
+
+// BulkIndexer returns a BulkIndexer writing to the fake with the batching and callbacks of the real clients.
+func (c *FakeClient) BulkIndexer(config elasticsearch.BulkIndexerConfig) (elasticsearch.BulkIndexer, error) {
+	return esbulk.New(config, func(ctx context.Context, body []byte) (int, []byte, error) {
+		data, err := c.bulk(body)
+		if err != nil {
+			return http.StatusBadRequest, []byte(err.Error()), nil
+		}
+		return http.StatusOK, data, nil
+	}), nil
+}
+
+// OpenSearchCursor opens a cursor over a snapshot of the hits of a search, like a point in time.
+func (c *FakeClient) OpenSearchCursor(ctx context.Context, index, body string, config elasticsearch.SearchIteratorConfig) (elasticsearch.SearchCursor, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	request, err := parseSearchRequest(body)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := c.search(strings.Split(index, ","), request)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.PageSize <= 0 {
+		config.PageSize = 1000
+	}
+
+	return &fakeCursor{hits: hits, pageSize: config.PageSize}, nil
+}
+
+func (c *FakeClient) bulk(body []byte) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	items := []map[string]elasticsearch.BulkItemResponse{}
+	failed := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(body)+1)
+	for scanner.Scan() {
+		meta := map[string]struct {
+			Index string `json:"_index"`
+			ID    string `json:"_id"`
+		}{}
+		if err := json.Unmarshal(scanner.Bytes(), &meta); err != nil || len(meta) != 1 {
+			return nil, fmt.Errorf("malformed action/metadata line [%s]", scanner.Text())
+		}
+
+		for action, target := range meta {
+			source := []byte{}
+			if action != "delete" {
+				if !scanner.Scan() {
+					return nil, fmt.Errorf("missing source of action [%s]", action)
+				}
+				source = slices.Clone(scanner.Bytes())
+			}
+
+			response := c.bulkItem(action, target.Index, target.ID, source)
+			failed = failed || response.Error != nil
+			items = append(items, map[string]elasticsearch.BulkItemResponse{action: response})
+		}
+	}
+
+	return json.Marshal(map[string]any{"took": 0, "errors": failed, "items": items})
+}
+
+func (c *FakeClient) bulkItem(action, index, documentID string, source []byte) elasticsearch.BulkItemResponse {
+	response := elasticsearch.BulkItemResponse{Index: index, DocumentID: documentID}
+	failure := func(status int, errorType, reason string) elasticsearch.BulkItemResponse {
+		response.Status, response.Error = status, &elasticsearch.BulkItemError{Type: errorType, Reason: reason}
+		return response
+	}
+
+	name, err := c.writeIndex(index)
+	if err != nil {
+		return failure(http.StatusBadRequest, "illegal_argument_exception", err.Error())
+	}
+	response.Index = name
+	target := c.indices[name]
+
+	if response.DocumentID == "" {
+		response.DocumentID = c.generateID()
+	}
+	_, exists := target.documents[response.DocumentID]
+
+	switch action {
+	case "index", "create":
+		if action == "create" && exists {
+			return failure(http.StatusConflict, "version_conflict_engine_exception", fmt.Sprintf("[%s]: version conflict, document already exists", response.DocumentID))
+		}
+		target.put(response.DocumentID, source)
+		response.Status, response.Result = http.StatusCreated, "created"
+		if exists {
+			response.Status, response.Result = http.StatusOK, "updated"
+		}
+	case "update":
+		request := struct {
+			Doc         map[string]json.RawMessage `json:"doc"`
+			DocAsUpsert bool                       `json:"doc_as_upsert"`
+		}{}
+		if err := json.Unmarshal(source, &request); err != nil || request.Doc == nil {
+			return failure(http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: script or doc is missing;")
+		} else if !exists && !request.DocAsUpsert {
+			return failure(http.StatusNotFound, "document_missing_exception", fmt.Sprintf("[%s]: document missing", response.DocumentID))
+		}
+
+		document := map[string]json.RawMessage{}
+		if exists {
+			json.Unmarshal(target.documents[response.DocumentID], &document)
+		}
+		maps.Copy(document, request.Doc)
+		data, _ := json.Marshal(document)
+		target.put(response.DocumentID, data)
+		response.Status, response.Result = http.StatusOK, "updated"
+		if !exists {
+			response.Status, response.Result = http.StatusCreated, "created"
+		}
+	case "delete":
+		response.Status, response.Result = http.StatusNotFound, "not_found"
+		if target.remove(response.DocumentID) {
+			response.Status, response.Result = http.StatusOK, "deleted"
+		}
+	}
+
+	return response
+}
+
+// resolve returns the sorted indices of names, which may be indices, aliases, wildcards or _all.
+func (c *FakeClient) resolve(names []string, allowMissing bool) ([]string, error) {
+	result := map[string]bool{}
+	for _, name := range names {
+		switch {
+		case name == "_all" || name == "*":
+			for index := range c.indices {
+				result[index] = true
+			}
+		case strings.ContainsAny(name, "*?"):
+			for index, current := range c.indices {
+				if matchAny([]string{name}, index) || slices.ContainsFunc(slices.Collect(maps.Keys(current.aliases)), func(alias string) bool { return matchAny([]string{name}, alias) }) {
+					result[index] = true
+				}
+			}
+		case c.indices[name] != nil:
+			result[name] = true
+		case len(c.aliasIndices(name)) != 0:
+			for _, index := range c.aliasIndices(name) {
+				result[index] = true
+			}
+		case !allowMissing:
+			return nil, indexNotFound(name)
+		}
+	}
+
+	return slices.Sorted(maps.Keys(result)), nil
+}
+
+func (c *FakeClient) aliasIndices(alias string) []string {
+	indices := []string{}
+	for name, index := range c.indices {
+		if _, ok := index.aliases[alias]; ok {
+			indices = append(indices, name)
+		}
+	}
+	slices.Sort(indices)
+
+	return indices
+}
+
+// writeIndex returns the index written through name, creating the index if name is not an alias.
+func (c *FakeClient) writeIndex(name string) (string, error) {
+	if _, ok := c.indices[name]; ok {
+		return name, nil
+	}
+
+	indices := c.aliasIndices(name)
+	if len(indices) == 0 {
+		if name == "" || strings.ContainsAny(name, `*?,"<>|\ /`) {
+			return "", responseError(http.StatusBadRequest, "invalid_index_name_exception", fmt.Sprintf("Invalid index name [%s]", name))
+		}
+		c.indices[name] = newFakeIndex()
+		return name, nil
+	}
+
+	for _, index := range indices {
+		if isWriteIndex := c.indices[index].aliases[name]; isWriteIndex != nil && *isWriteIndex {
+			return index, nil
+		}
+	}
+	if len(indices) == 1 {
+		if isWriteIndex := c.indices[indices[0]].aliases[name]; isWriteIndex == nil {
+			return indices[0], nil
+		}
+	}
+
+	return "", responseError(http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("no write index is defined for alias [%s]", name))
+}
+
+func (c *FakeClient) snapshot(repository, snapshot string) (map[string]*fakeIndex, error) {
+	if snapshots, ok := c.repositories[repository]; !ok {
+		return nil, repositoryMissing(repository)
+	} else if indices, ok := snapshots[snapshot]; !ok {
+		return nil, responseError(http.StatusNotFound, "snapshot_missing_exception", fmt.Sprintf("[%s:%s] is missing", repository, snapshot))
+	} else {
+		return indices, nil
+	}
+}
+
+func (c *FakeClient) generateID() string {
+	c.sequence++
+	return fmt.Sprintf("fake-%d", c.sequence)
+}
+
+func (c *FakeClient) existsDefinition(definitions map[string]json.RawMessage, name string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, ok := definitions[name]
+	return ok
+}
+
+func (c *FakeClient) putDefinition(definitions map[string]json.RawMessage, name, body string) error {
+	data, err := compact(body)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	definitions[name] = data
+
+	return nil
+}
+
+func (c *FakeClient) deleteDefinition(definitions map[string]json.RawMessage, name, errorType, reason string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := definitions[name]; !ok {
+		return responseError(http.StatusNotFound, errorType, fmt.Sprintf(reason, name))
+	}
+	delete(definitions, name)
+
+	return nil
+}
+
+type fakeCursor struct {
+	hits     []fakeHit
+	pageSize int
+	offset   int
+	closed   bool
+}
+
+func (c *fakeCursor) Next(ctx context.Context) (elasticsearch.SearchPage, error) {
+	if c.closed && c.offset < len(c.hits) {
+		return elasticsearch.SearchPage{}, errors.New("search cursor is closed")
+	}
+
+	page := elasticsearch.SearchPage{Hits: []elasticsearch.SearchHit[json.RawMessage]{}}
+	if c.offset == 0 {
+		page.Total = int64(len(c.hits))
+	}
+
+	end := min(c.offset+c.pageSize, len(c.hits))
+	for _, hit := range c.hits[c.offset:end] {
+		page.Hits = append(page.Hits, hit.searchHit())
+	}
+	c.offset = end
+
+	if len(page.Hits) < c.pageSize {
+		c.closed = true
+	}
+
+	return page, nil
+}
+
+func (c *fakeCursor) Close(ctx context.Context) error {
+	c.closed = true
+	return nil
+}
+
+func status(code int) string {
+	return fmt.Sprintf("%d %s", code, http.StatusText(code))
+}
+
+func responseError(code int, errorType, reason string) error {
+	return fmt.Errorf("response error - status : (%s), type : (%s), reason : (%s)", status(code), errorType, reason)
+}
+
+func parseError(err error) error {
+	return responseError(http.StatusBadRequest, "parse_exception", err.Error())
+}
+
+func indexNotFound(index string) error {
+	return responseError(http.StatusNotFound, "index_not_found_exception", fmt.Sprintf("no such index [%s]", index))
+}
+
+func repositoryMissing(repository string) error {
+	return responseError(http.StatusNotFound, "repository_missing_exception", fmt.Sprintf("[%s] missing", repository))
+}
+
+func compact(body string) (json.RawMessage, error) {
+	buffer := bytes.Buffer{}
+	if err := json.Compact(&buffer, []byte(body)); err != nil {
+		return nil, parseError(err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// indexNames returns the indices of a JSON string ("a,b") or array, or defaults if there are none.
+func indexNames(data json.RawMessage, defaults []string) []string {
+	names := []string{}
+	if err := json.Unmarshal(data, &names); err != nil {
+		name := ""
+		if json.Unmarshal(data, &name) == nil && name != "" {
+			names = strings.Split(name, ",")
+		}
+	}
+
+	if len(names) == 0 {
+		return defaults
+	}
+
+	return names
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if pattern == "_all" || pattern == name {
+			return true
+		} else if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
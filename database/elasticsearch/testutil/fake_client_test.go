@@ -0,0 +1,332 @@
+package testutil_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/elasticsearch"
+	"github.com/common-library/go/database/elasticsearch/query"
+	"github.com/common-library/go/database/elasticsearch/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type searchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []elasticsearch.SearchHit[map[string]any] `json:"hits"`
+	} `json:"hits"`
+}
+
+func search(t *testing.T, client elasticsearch.ClientInterface, index, body string) ([]string, int) {
+	t.Helper()
+
+	result, err := client.Search(index, body)
+	require.NoError(t, err)
+
+	response := searchResponse{}
+	require.NoError(t, json.Unmarshal([]byte(result), &response))
+
+	ids := []string{}
+	for _, hit := range response.Hits.Hits {
+		ids = append(ids, hit.ID)
+	}
+
+	return ids, response.Hits.Total.Value
+}
+
+func newProducts(t *testing.T) *testutil.FakeClient {
+	t.Helper()
+
+	client := testutil.NewFakeClient()
+	for i, body := range []string{
+		`{"name": "Wireless Headphones", "brand": "acme", "price": 120, "tags": ["audio", "wireless"], "reviews": [{"stars": 5}]}`,
+		`{"name": "Wired Headphones", "brand": "acme", "price": 40, "tags": ["audio"], "reviews": [{"stars": 3}]}`,
+		`{"name": "Wireless Speaker", "brand": "globex", "price": 200, "tags": ["audio", "wireless"], "reviews": [{"stars": 2}, {"stars": 4}]}`,
+		`{"name": "Wireless Mouse", "brand": "globex", "price": 30, "released": "2024-05-01"}`,
+	} {
+		require.NoError(t, client.Index("products", fmt.Sprintf("%d", i+1), body))
+	}
+
+	return client
+}
+
+func TestFakeClient_Documents(t *testing.T) {
+	t.Parallel()
+
+	var client elasticsearch.ClientInterface = testutil.NewFakeClient()
+	require.NoError(t, client.Initialize([]string{"http://localhost:9200"}, time.Second, "", "", "", "", "", nil))
+
+	exists, err := client.IndicesExists([]string{"products"})
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, client.Index("products", "1", `{"name": "laptop"}`))
+	assert.Error(t, client.Index("products", "2", `{`))
+
+	exists, err = client.IndicesExists([]string{"products"})
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.Exists("products", "1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.Exists("missing", "1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, client.Index("products", "1", `{"name": "desktop"}`))
+	ids, total := search(t, client, "products", `{"query": {"term": {"name": "desktop"}}}`)
+	assert.Equal(t, []string{"1"}, ids)
+	assert.Equal(t, 1, total)
+
+	require.NoError(t, client.Delete("products", "1"))
+	assert.ErrorContains(t, client.Delete("products", "1"), "404 Not Found")
+	assert.ErrorContains(t, client.Delete("missing", "1"), "index_not_found_exception")
+
+	assert.ErrorContains(t, client.IndicesCreate("products", `{}`), "resource_already_exists_exception")
+	require.NoError(t, client.IndicesForcemerge([]string{"products"}))
+	require.NoError(t, client.IndicesDelete([]string{"products"}))
+	assert.ErrorContains(t, client.IndicesDelete([]string{"products"}), "no such index [products]")
+}
+
+func TestFakeClient_Search(t *testing.T) {
+	t.Parallel()
+
+	client := newProducts(t)
+
+	for _, test := range []struct {
+		body  string
+		ids   []string
+		total int
+	}{
+		{``, []string{"1", "2", "3", "4"}, 4},
+		{`{"query": {"match_all": {}}, "sort": ["_doc"]}`, []string{"1", "2", "3", "4"}, 4},
+		{`{"query": {"term": {"brand": {"value": "globex"}}}, "sort": [{"price": "asc"}]}`, []string{"4", "3"}, 2},
+		{`{"query": {"terms": {"tags": ["wireless"]}}, "sort": [{"price": {"order": "desc"}}]}`, []string{"3", "1"}, 2},
+		{`{"query": {"range": {"price": {"gte": 40, "lt": 200}}}, "sort": [{"price": "asc"}]}`, []string{"2", "1"}, 2},
+		{`{"query": {"range": {"released": {"gte": "2024-01-01"}}}}`, []string{"4"}, 1},
+		{`{"query": {"match": {"name": "wireless headphones"}}, "sort": [{"price": "asc"}]}`, []string{"4", "2", "1", "3"}, 4},
+		{`{"query": {"match": {"name": {"query": "wireless headphones", "operator": "and"}}}}`, []string{"1"}, 1},
+		{`{"query": {"exists": {"field": "released"}}}`, []string{"4"}, 1},
+		{`{"query": {"ids": {"values": ["2", "3"]}}, "sort": [{"price": "desc"}]}`, []string{"3", "2"}, 2},
+		{`{"query": {"bool": {"must": {"match": {"name": "wireless"}}, "filter": [{"term": {"brand": "acme"}}]}}}`, []string{"1"}, 1},
+		{`{"query": {"bool": {"must_not": [{"term": {"brand": "acme"}}]}}, "sort": [{"price": "asc"}]}`, []string{"4", "3"}, 2},
+		{`{"query": {"bool": {"should": [{"term": {"brand": "acme"}}, {"range": {"price": {"gt": 100}}}], "minimum_should_match": 2}}}`, []string{"1"}, 1},
+		{`{"query": {"nested": {"path": "reviews", "query": {"range": {"reviews.stars": {"gte": 4}}}}}, "sort": [{"price": "asc"}]}`, []string{"1", "3"}, 2},
+		{`{"query": {"match_all": {}}, "sort": [{"price": "asc"}], "from": 1, "size": 2}`, []string{"2", "1"}, 4},
+		{`{"sort": [{"released": "desc"}, {"price": "asc"}]}`, []string{"4", "2", "1", "3"}, 4},
+	} {
+		ids, total := search(t, client, "products", test.body)
+		assert.Equal(t, test.ids, ids, test.body)
+		assert.Equal(t, test.total, total, test.body)
+	}
+
+	body, err := query.NewSearch().
+		Query(query.Bool().
+			Must(query.MultiMatch("wireless", "name")).
+			Filter(query.Range("price").Gte(50), query.Nested("reviews", query.Range("reviews.stars").Gte(4)))).
+		Sort("price", "desc").
+		Body()
+	require.NoError(t, err)
+	_, err = client.Search("products", body)
+	assert.ErrorContains(t, err, "query [multi_match] is not supported")
+
+	body, err = query.NewSearch().
+		Query(query.Bool().
+			Must(query.Match("name", "wireless")).
+			Filter(query.Range("price").Gte(50), query.Nested("reviews", query.Range("reviews.stars").Gte(4)))).
+		Sort("price", "desc").
+		Body()
+	require.NoError(t, err)
+	ids, _ := search(t, client, "products", body)
+	assert.Equal(t, []string{"3", "1"}, ids)
+
+	_, err = client.Search("products", `{"aggs": {"brands": {"terms": {"field": "brand"}}}}`)
+	assert.ErrorContains(t, err, "aggregations are not supported")
+
+	_, err = client.Search("missing", `{}`)
+	assert.ErrorContains(t, err, "index_not_found_exception")
+
+	for _, body := range []string{`{"from": -1}`, `{"size": -1}`, `{"from": 9223372036854775807, "size": 10}`, `{"from": 1, "size": 9223372036854775807}`} {
+		_, err = client.Search("products", body)
+		assert.ErrorContains(t, err, "illegal_argument_exception", body)
+	}
+}
+
+func TestFakeClient_DeleteByQuery(t *testing.T) {
+	t.Parallel()
+
+	client := newProducts(t)
+
+	require.NoError(t, client.DeleteByQuery([]string{"products"}, `{"query": {"range": {"price": {"lt": 100}}}}`))
+
+	ids, _ := search(t, client, "products", `{"sort": [{"price": "asc"}]}`)
+	assert.Equal(t, []string{"1", "3"}, ids)
+
+	assert.Error(t, client.DeleteByQuery([]string{"products"}, `{"query": {"wildcard": {"name": "w*"}}}`))
+}
+
+func TestFakeClient_Templates(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewFakeClient()
+
+	require.NoError(t, client.IndicesPutTemplate("legacy", `{"index_patterns": ["legacy-*"]}`))
+	exists, err := client.IndicesExistsTemplate([]string{"legacy"})
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.NoError(t, client.IndicesDeleteTemplate("legacy"))
+	assert.Error(t, client.IndicesDeleteTemplate("legacy"))
+
+	require.NoError(t, client.ClusterPutComponentTemplate("settings", `{"template": {"settings": {"number_of_shards": 1}}}`))
+	require.NoError(t, client.IndicesPutIndexTemplate("logs", `{"index_patterns": ["logs-*"], "composed_of": ["settings"]}`))
+	exists, err = client.IndicesExistsIndexTemplate("logs")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = client.ClusterExistsComponentTemplate("settings")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.NoError(t, client.IndicesDeleteIndexTemplate("logs"))
+	require.NoError(t, client.ClusterDeleteComponentTemplate("settings"))
+	exists, err = client.IndicesExistsIndexTemplate("logs")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, client.ILMPutLifecycle("logs", `{"policy": {"phases": {"delete": {"min_age": "30d", "actions": {"delete": {}}}}}}`))
+	policy, err := client.ILMGetLifecycle("logs")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"phases": {"delete": {"min_age": "30d", "actions": {"delete": {}}}}}`, policy)
+	require.NoError(t, client.ILMDeleteLifecycle("logs"))
+	_, err = client.ILMGetLifecycle("logs")
+	assert.ErrorContains(t, err, "resource_not_found_exception")
+}
+
+func TestFakeClient_Aliases(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewFakeClient()
+
+	require.NoError(t, client.IndicesCreate("logs-000001", `{"aliases": {"logs": {"is_write_index": true}}}`))
+	require.NoError(t, client.Index("logs", "1", `{"message": "first"}`))
+
+	result, err := client.IndicesRollover("logs", "", `{"conditions": {"max_docs": 2}}`)
+	require.NoError(t, err)
+	assert.False(t, result.RolledOver)
+
+	result, err = client.IndicesRollover("logs", "", `{"conditions": {"max_docs": 1}}`)
+	require.NoError(t, err)
+	assert.True(t, result.RolledOver)
+	assert.Equal(t, "logs-000001", result.OldIndex)
+	assert.Equal(t, "logs-000002", result.NewIndex)
+	assert.Equal(t, map[string]bool{"[max_docs: 1]": true}, result.Conditions)
+
+	require.NoError(t, client.Index("logs", "2", `{"message": "second"}`))
+	exists, err := client.Exists("logs-000002", "2")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	indices, err := client.IndicesGetAlias("logs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logs-000001", "logs-000002"}, indices)
+	_, total := search(t, client, "logs", `{}`)
+	assert.Equal(t, 2, total)
+	_, total = search(t, client, "logs-*", `{}`)
+	assert.Equal(t, 2, total)
+
+	require.NoError(t, client.Index("products-v1", "1", `{"name": "laptop"}`))
+	_, err = elasticsearch.SwapAlias(client, "products", "products-v1")
+	require.NoError(t, err)
+
+	reindexed, previous, err := elasticsearch.ReindexAlias(context.Background(), client, "products", "products-v2", `{}`, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), reindexed.Created)
+	assert.Equal(t, []string{"products-v1"}, previous)
+
+	indices, err = client.IndicesGetAlias("products")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"products-v2"}, indices)
+
+	assert.ErrorContains(t, client.IndicesUpdateAliases([]elasticsearch.AliasAction{{Type: "add", Index: "missing", Alias: "products"}}), "no such index [missing]")
+}
+
+func TestFakeClient_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	client := newProducts(t)
+
+	assert.ErrorContains(t, client.SnapshotCreate("backups", "first", "", true), "repository_missing_exception")
+	require.NoError(t, client.SnapshotCreateRepository("backups", `{"type": "fs", "settings": {"location": "/tmp"}}`))
+	require.NoError(t, client.SnapshotCreate("backups", "first", `{"indices": "products"}`, true))
+
+	require.NoError(t, client.Delete("products", "1"))
+	assert.ErrorContains(t, client.SnapshotRestore("backups", "first", "", true), "already exists")
+
+	require.NoError(t, client.SnapshotRestore("backups", "first", `{"rename_pattern": "(.+)", "rename_replacement": "restored-$1"}`, true))
+	exists, err := client.Exists("restored-products", "1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, client.SnapshotDelete("backups", "first"))
+	assert.ErrorContains(t, client.SnapshotDelete("backups", "first"), "snapshot_missing_exception")
+	require.NoError(t, client.SnapshotDeleteRepository("backups"))
+}
+
+func TestFakeClient_BulkIndexer(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewFakeClient()
+
+	indexer, err := client.BulkIndexer(elasticsearch.BulkIndexerConfig{FlushCount: 10})
+	require.NoError(t, err)
+
+	failures := []string{}
+	for i := range 25 {
+		require.NoError(t, indexer.Add(context.Background(), elasticsearch.BulkItem{
+			Index:      "numbers",
+			DocumentID: fmt.Sprintf("%d", i),
+			Body:       fmt.Sprintf(`{"number": %d}`, i)}))
+	}
+	require.NoError(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Action: "create", Index: "numbers", DocumentID: "0", Body: `{"number": 0}`,
+		OnFailure: func(item elasticsearch.BulkItem, response elasticsearch.BulkItemResponse, err error) {
+			failures = append(failures, response.Error.Type)
+		}}))
+	require.NoError(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Action: "update", Index: "numbers", DocumentID: "1", Body: `{"doc": {"odd": true}}`}))
+	require.NoError(t, indexer.Add(context.Background(), elasticsearch.BulkItem{Action: "delete", Index: "numbers", DocumentID: "2"}))
+	require.NoError(t, indexer.Close(context.Background()))
+
+	stats := indexer.Stats()
+	assert.Equal(t, uint64(27), stats.Succeeded)
+	assert.Equal(t, uint64(1), stats.Failed)
+	assert.Equal(t, []string{"version_conflict_engine_exception"}, failures)
+
+	ids, total := search(t, client, "numbers", `{"query": {"term": {"odd": true}}}`)
+	assert.Equal(t, []string{"1"}, ids)
+	assert.Equal(t, 1, total)
+
+	type document struct {
+		Number int `json:"number"`
+	}
+
+	iterator, err := elasticsearch.NewSearchIterator[document](context.Background(), client, "numbers",
+		`{"query": {"range": {"number": {"gte": 5}}}, "sort": [{"number": "desc"}]}`, elasticsearch.SearchIteratorConfig{PageSize: 7})
+	require.NoError(t, err)
+	defer iterator.Close(context.Background())
+
+	assert.Equal(t, int64(20), iterator.Total())
+
+	numbers := []int{}
+	for hit, err := range iterator.All(context.Background()) {
+		require.NoError(t, err)
+		numbers = append(numbers, hit.Source.Number)
+	}
+	require.Len(t, numbers, 20)
+	assert.Equal(t, 24, numbers[0])
+	assert.Equal(t, 5, numbers[19])
+}
@@ -0,0 +1,611 @@
+package testutil
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/common-library/go/database/elasticsearch"
+)
+
+type searchRequest struct {
+	Query        json.RawMessage `json:"query"`
+	From         int             `json:"from"`
+	Size         *int            `json:"size"`
+	Sort         json.RawMessage `json:"sort"`
+	Aggs         json.RawMessage `json:"aggs"`
+	Aggregations json.RawMessage `json:"aggregations"`
+}
+
+func parseSearchRequest(body string) (searchRequest, error) {
+	request := searchRequest{}
+	if strings.TrimSpace(body) == "" {
+		return request, nil
+	}
+
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		return request, parseError(err)
+	} else if request.Aggs != nil || request.Aggregations != nil {
+		return request, unsupported("aggregations are not supported by FakeClient")
+	}
+
+	switch {
+	case request.From < 0:
+		return request, responseError(http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("[from] parameter cannot be negative, found [%d]", request.From))
+	case request.Size != nil && *request.Size < 0:
+		return request, responseError(http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("[size] parameter cannot be negative, found [%d]", *request.Size))
+	case request.From > maxResultWindow || request.Size != nil && *request.Size > maxResultWindow-request.From:
+		return request, responseError(http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Result window is too large, from + size must be less than or equal to: [%d]", maxResultWindow))
+	}
+
+	return request, nil
+}
+
+// maxResultWindow is the default index.max_result_window of Elasticsearch.
+const maxResultWindow = 10000
+
+type sortField struct {
+	field      string
+	descending bool
+}
+
+type fakeHit struct {
+	index      string
+	id         string
+	source     json.RawMessage
+	sortValues []any
+	scored     bool
+}
+
+func (h fakeHit) searchHit() elasticsearch.SearchHit[json.RawMessage] {
+	hit := elasticsearch.SearchHit[json.RawMessage]{Index: h.index, ID: h.id, Source: h.source}
+
+	if h.scored {
+		score := 1.0
+		hit.Score = &score
+	}
+
+	for _, value := range h.sortValues {
+		data, _ := json.Marshal(value)
+		hit.Sort = append(hit.Sort, data)
+	}
+
+	return hit
+}
+
+// search returns the sorted hits of the documents of indices matching the query of request.
+func (c *FakeClient) search(indices []string, request searchRequest) ([]fakeHit, error) {
+	names, err := c.resolve(indices, false)
+	if err != nil {
+		return nil, err
+	}
+
+	query := map[string]any{"match_all": map[string]any{}}
+	if len(request.Query) != 0 {
+		query = map[string]any{}
+		if err := json.Unmarshal(request.Query, &query); err != nil {
+			return nil, parseError(err)
+		}
+	}
+
+	sortFields, err := parseSort(request.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := []fakeHit{}
+	for _, name := range names {
+		index := c.indices[name]
+		for _, id := range index.order {
+			document := map[string]any{}
+			if err := json.Unmarshal(index.documents[id], &document); err != nil {
+				return nil, err
+			}
+
+			if matched, err := matches(query, id, document); err != nil {
+				return nil, err
+			} else if !matched {
+				continue
+			}
+
+			hit := fakeHit{index: name, id: id, source: index.documents[id], scored: len(sortFields) == 0}
+			for _, field := range sortFields {
+				switch field.field {
+				case "_score":
+					hit.scored = true
+					hit.sortValues = append(hit.sortValues, 1.0)
+				case "_doc":
+					hit.sortValues = append(hit.sortValues, len(hits))
+				default:
+					hit.sortValues = append(hit.sortValues, first(values(document, field.field)))
+				}
+			}
+			hits = append(hits, hit)
+		}
+	}
+
+	slices.SortStableFunc(hits, func(a, b fakeHit) int {
+		for i, field := range sortFields {
+			if result := compareSortValues(a.sortValues[i], b.sortValues[i], field.descending); result != 0 {
+				return result
+			}
+		}
+		return 0
+	})
+
+	return hits, nil
+}
+
+func parseSort(data json.RawMessage) ([]sortField, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	entries := []json.RawMessage{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		entries = []json.RawMessage{data}
+	}
+
+	fields := []sortField{}
+	for _, entry := range entries {
+		name := ""
+		if err := json.Unmarshal(entry, &name); err == nil {
+			fields = append(fields, sortField{field: name, descending: name == "_score"})
+			continue
+		}
+
+		object := map[string]json.RawMessage{}
+		if err := json.Unmarshal(entry, &object); err != nil || len(object) != 1 {
+			return nil, responseError(http.StatusBadRequest, "parsing_exception", fmt.Sprintf("malformed sort [%s]", entry))
+		}
+
+		for name, options := range object {
+			order := ""
+			if err := json.Unmarshal(options, &order); err != nil {
+				settings := struct {
+					Order string `json:"order"`
+				}{}
+				if err := json.Unmarshal(options, &settings); err != nil {
+					return nil, parseError(err)
+				}
+				order = settings.Order
+			}
+
+			fields = append(fields, sortField{field: name, descending: order == "desc" || (order == "" && name == "_score")})
+		}
+	}
+
+	return fields, nil
+}
+
+// compareSortValues orders missing values last in either direction.
+func compareSortValues(a, b any, descending bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	result, ok := compare(a, b)
+	if !ok {
+		result = strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+	if descending {
+		return -result
+	}
+
+	return result
+}
+
+// matches reports whether a document matches a query of the supported subset of the query DSL.
+func matches(query map[string]any, id string, document map[string]any) (bool, error) {
+	if len(query) != 1 {
+		return false, responseError(http.StatusBadRequest, "parsing_exception", "query malformed, must have exactly one query")
+	}
+
+	for kind, body := range query {
+		switch kind {
+		case "match_all":
+			return true, nil
+		case "match_none":
+			return false, nil
+		case "ids":
+			return slices.Contains(stringSlice(field(body, "values")), id), nil
+		case "exists":
+			name, _ := field(body, "field").(string)
+			return len(values(document, name)) != 0, nil
+		case "term":
+			name, value, err := fieldOptions(kind, body, "value")
+			if err != nil {
+				return false, err
+			}
+			return slices.ContainsFunc(values(document, name), func(current any) bool { return equal(current, value) }), nil
+		case "terms":
+			return matchesTerms(body, document)
+		case "range":
+			return matchesRange(body, document)
+		case "match":
+			return matchesText(body, document)
+		case "bool":
+			return matchesBool(body, id, document)
+		case "nested":
+			return matchesNested(body, id, document)
+		default:
+			return false, unsupported(fmt.Sprintf("query [%s] is not supported by FakeClient", kind))
+		}
+	}
+
+	return false, nil
+}
+
+func matchesTerms(body any, document map[string]any) (bool, error) {
+	object, ok := body.(map[string]any)
+	if !ok {
+		return false, malformed("terms")
+	}
+
+	for name, value := range object {
+		if name == "boost" {
+			continue
+		}
+
+		terms, ok := value.([]any)
+		if !ok {
+			return false, malformed("terms")
+		}
+
+		return slices.ContainsFunc(values(document, name), func(current any) bool {
+			return slices.ContainsFunc(terms, func(term any) bool { return equal(current, term) })
+		}), nil
+	}
+
+	return false, malformed("terms")
+}
+
+func matchesRange(body any, document map[string]any) (bool, error) {
+	object, ok := body.(map[string]any)
+	if !ok || len(object) != 1 {
+		return false, malformed("range")
+	}
+
+	for name, options := range object {
+		bounds, ok := options.(map[string]any)
+		if !ok {
+			return false, malformed("range")
+		}
+
+		return slices.ContainsFunc(values(document, name), func(current any) bool {
+			for operator, bound := range bounds {
+				result, ok := compare(current, bound)
+				switch operator {
+				case "gt":
+					ok = ok && result > 0
+				case "gte":
+					ok = ok && result >= 0
+				case "lt":
+					ok = ok && result < 0
+				case "lte":
+					ok = ok && result <= 0
+				default:
+					continue
+				}
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}), nil
+	}
+
+	return false, malformed("range")
+}
+
+func matchesText(body any, document map[string]any) (bool, error) {
+	name, text, err := fieldOptions("match", body, "query")
+	if err != nil {
+		return false, err
+	}
+
+	operator := "or"
+	if object, ok := body.(map[string]any)[name].(map[string]any); ok {
+		if value, ok := object["operator"].(string); ok {
+			operator = strings.ToLower(value)
+		}
+	}
+
+	words := tokenize(fmt.Sprint(text))
+	if len(words) == 0 {
+		return false, nil
+	}
+
+	return slices.ContainsFunc(values(document, name), func(current any) bool {
+		contained := tokenize(fmt.Sprint(current))
+		count := 0
+		for _, word := range words {
+			if slices.Contains(contained, word) {
+				count++
+			}
+		}
+		return count == len(words) || (operator == "or" && count > 0)
+	}), nil
+}
+
+func matchesBool(body any, id string, document map[string]any) (bool, error) {
+	object, ok := body.(map[string]any)
+	if !ok {
+		return false, malformed("bool")
+	}
+
+	clauses := func(name string) ([]map[string]any, error) {
+		result := []map[string]any{}
+		switch value := object[name].(type) {
+		case nil:
+		case map[string]any:
+			result = append(result, value)
+		case []any:
+			for _, clause := range value {
+				query, ok := clause.(map[string]any)
+				if !ok {
+					return nil, malformed("bool")
+				}
+				result = append(result, query)
+			}
+		default:
+			return nil, malformed("bool")
+		}
+		return result, nil
+	}
+
+	count := func(name string) (int, int, error) {
+		queries, err := clauses(name)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		matched := 0
+		for _, query := range queries {
+			if ok, err := matches(query, id, document); err != nil {
+				return 0, 0, err
+			} else if ok {
+				matched++
+			}
+		}
+		return matched, len(queries), nil
+	}
+
+	required := 0
+	for _, name := range []string{"must", "filter"} {
+		matched, total, err := count(name)
+		if err != nil || matched != total {
+			return false, err
+		}
+		required += total
+	}
+
+	if matched, _, err := count("must_not"); err != nil || matched != 0 {
+		return false, err
+	}
+
+	matched, total, err := count("should")
+	if err != nil {
+		return false, err
+	}
+
+	minimum := 0
+	if required == 0 && total != 0 {
+		minimum = 1
+	}
+	if value, ok := object["minimum_should_match"]; ok {
+		if minimum, err = minimumShouldMatch(value, total); err != nil {
+			return false, err
+		}
+	}
+
+	return matched >= minimum, nil
+}
+
+func matchesNested(body any, id string, document map[string]any) (bool, error) {
+	object, ok := body.(map[string]any)
+	if !ok {
+		return false, malformed("nested")
+	}
+
+	path, _ := object["path"].(string)
+	query, ok := object["query"].(map[string]any)
+	if path == "" || !ok {
+		return false, malformed("nested")
+	}
+
+	for _, value := range values(document, path) {
+		if nested, ok := value.(map[string]any); ok {
+			if matched, err := matches(query, id, wrap(path, nested)); err != nil || matched {
+				return matched, err
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// wrap returns a document with value at the dotted path, so nested queries can use full field names.
+func wrap(path string, value map[string]any) map[string]any {
+	names := strings.Split(path, ".")
+
+	document := value
+	for i := len(names) - 1; i >= 0; i-- {
+		document = map[string]any{names[i]: document}
+	}
+
+	return document
+}
+
+func minimumShouldMatch(value any, total int) (int, error) {
+	switch value := value.(type) {
+	case float64:
+		if value < 0 {
+			return max(total+int(value), 0), nil
+		}
+		return int(value), nil
+	case string:
+		if percentage, ok := strings.CutSuffix(value, "%"); ok {
+			number, err := strconv.Atoi(percentage)
+			if err != nil {
+				return 0, malformed("bool")
+			}
+			if number < 0 {
+				return total - total*(-number)/100, nil
+			}
+			return total * number / 100, nil
+		}
+
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, malformed("bool")
+		}
+		return minimumShouldMatch(float64(number), total)
+	default:
+		return 0, malformed("bool")
+	}
+}
+
+// fieldOptions returns the field and value of {field: value} or {field: {key: value, ...}}.
+func fieldOptions(kind string, body any, key string) (string, any, error) {
+	object, ok := body.(map[string]any)
+	if ok {
+		for name, value := range object {
+			if name == "boost" || name == "_name" {
+				continue
+			}
+
+			if options, ok := value.(map[string]any); ok {
+				value, ok = options[key]
+				if !ok {
+					return "", nil, malformed(kind)
+				}
+			}
+			return name, value, nil
+		}
+	}
+
+	return "", nil, malformed(kind)
+}
+
+// values returns the non-null values at a dotted path, flattening arrays.
+func values(document map[string]any, path string) []any {
+	current := []any{document}
+	for name := range strings.SplitSeq(path, ".") {
+		next := []any{}
+		for _, value := range current {
+			if object, ok := value.(map[string]any); ok {
+				next = append(next, flatten(object[name])...)
+			}
+		}
+		current = next
+	}
+
+	return current
+}
+
+func flatten(value any) []any {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		result := []any{}
+		for _, element := range value {
+			result = append(result, flatten(element)...)
+		}
+		return result
+	default:
+		return []any{value}
+	}
+}
+
+func first(values []any) any {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values[0]
+}
+
+func field(body any, name string) any {
+	if object, ok := body.(map[string]any); ok {
+		return object[name]
+	}
+
+	return nil
+}
+
+func stringSlice(value any) []string {
+	result := []string{}
+	if elements, ok := value.([]any); ok {
+		for _, element := range elements {
+			result = append(result, fmt.Sprint(element))
+		}
+	}
+
+	return result
+}
+
+// compare compares numbers, including numeric strings, or strings, which orders ISO 8601 dates.
+func compare(a, b any) (int, bool) {
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			return cmp.Compare(x, y), true
+		}
+	}
+
+	x, ok := a.(string)
+	if !ok {
+		return 0, false
+	}
+	y, ok := b.(string)
+	if !ok {
+		return 0, false
+	}
+
+	return strings.Compare(x, y), true
+}
+
+func number(value any) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case string:
+		number, err := strconv.ParseFloat(value, 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func equal(a, b any) bool {
+	if result, ok := compare(a, b); ok {
+		return result == 0
+	}
+
+	return a == b
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func malformed(kind string) error {
+	return responseError(http.StatusBadRequest, "parsing_exception", fmt.Sprintf("[%s] query malformed", kind))
+}
+
+func unsupported(reason string) error {
+	return responseError(http.StatusBadRequest, "illegal_argument_exception", reason)
+}
@@ -6,9 +6,12 @@
 // Features:
 //   - Full Elasticsearch 7.x API support
 //   - Document operations (index, exists, delete, delete by query)
+//   - Bulk indexing through BulkIndexer
 //   - Index management (create, delete, exists, force merge)
-//   - Template management (put, delete, exists)
+//   - Template management (put, delete, exists), composable index and component templates
+//   - Alias updates, rollover, reindex with task polling, ILM policies and snapshots
 //   - Search operations with JSON responses
+//   - Deep paging with scroll through OpenSearchCursor
 //   - Certificate fingerprint authentication
 //   - Cloud ID support
 //
@@ -22,11 +25,14 @@ package v7
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -35,7 +41,11 @@ import (
 	"github.com/elastic/go-elasticsearch/v7/estransport"
 	"github.com/thedevsaddam/gojsonq/v2"
 
+	common_elasticsearch "github.com/common-library/go/database/elasticsearch"
+	"github.com/common-library/go/database/elasticsearch/internal/esbulk"
 	"github.com/common-library/go/database/elasticsearch/internal/eslock"
+	"github.com/common-library/go/database/elasticsearch/internal/essearch"
+	"github.com/common-library/go/database/elasticsearch/internal/estask"
 )
 
 // Client is a struct that provides client related methods.
@@ -613,6 +623,837 @@ func (c *Client) Search(index, body string) (string, error) {
 	}
 }
 
+// BulkIndexer creates a BulkIndexer sending _bulk requests with this client.
+//
+// Parameters:
+//   - config: Flush thresholds, worker count, retry policy and refresh parameter; zero values select the defaults
+//
+// Returns the running indexer; Close it to send the remaining items and stop its workers.
+// Returns error if client is not initialized.
+//
+// Example:
+//
+//	indexer, err := client.BulkIndexer(elasticsearch.BulkIndexerConfig{FlushCount: 500, Workers: 4})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer indexer.Close(context.Background())
+//
+//	err = indexer.Add(ctx, elasticsearch.BulkItem{
+//		Index:      "products",
+//		DocumentID: "1",
+//		Body:       `{"name":"Laptop"}`,
+//		OnFailure: func(item elasticsearch.BulkItem, response elasticsearch.BulkItemResponse, err error) {
+//			log.Println(item.DocumentID, err)
+//		},
+//	})
+func (c *Client) BulkIndexer(config common_elasticsearch.BulkIndexerConfig) (common_elasticsearch.BulkIndexer, error) {
+	if c.client == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	client := c.client
+
+	return esbulk.New(config, func(ctx context.Context, body []byte) (int, []byte, error) {
+		request := esapi.BulkRequest{
+			Body:    bytes.NewReader(body),
+			Refresh: config.Refresh}
+
+		response, err := request.Do(ctx, client)
+		if err != nil {
+			return 0, nil, err
+		}
+		defer response.Body.Close()
+
+		data, err := io.ReadAll(response.Body)
+		return response.StatusCode, data, err
+	}), nil
+}
+
+// OpenSearchCursor opens a cursor paging through all hits of a search with the scroll API.
+//
+// Parameters:
+//   - ctx: Unused; the initial search runs on the first call of Next
+//   - index: Index, alias or comma-separated list of indices to search
+//   - body: JSON search body; "size", "from" and "search_after" are managed by the cursor
+//   - config: Page size and keep-alive of the scroll context
+//
+// Returns the cursor; the scroll context is cleared after the last page or by Close.
+// Returns error if client is not initialized or the body is invalid.
+//
+// Use elasticsearch.NewSearchIterator to iterate over decoded hits instead of raw pages.
+//
+// Example:
+//
+//	cursor, err := client.OpenSearchCursor(ctx, "products", `{"query": {"match_all": {}}}`,
+//		elasticsearch.SearchIteratorConfig{PageSize: 1000})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer cursor.Close(ctx)
+//
+//	for {
+//		page, err := cursor.Next(ctx)
+//		if err != nil {
+//			log.Fatal(err)
+//		} else if len(page.Hits) == 0 {
+//			break
+//		}
+//		export(page.Hits)
+//	}
+func (c *Client) OpenSearchCursor(ctx context.Context, index, body string, config common_elasticsearch.SearchIteratorConfig) (common_elasticsearch.SearchCursor, error) {
+	if c.client == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	return essearch.NewScroll(index, body, config, essearch.ScrollRequests{
+		Search: func(ctx context.Context, index string, body []byte, keepAlive time.Duration) ([]byte, error) {
+			return c.perform(ctx, esapi.SearchRequest{Index: []string{index}, Body: bytes.NewReader(body), Scroll: keepAlive})
+		},
+		Scroll: func(ctx context.Context, body []byte) ([]byte, error) {
+			return c.perform(ctx, esapi.ScrollRequest{Body: bytes.NewReader(body)})
+		},
+		Clear: func(ctx context.Context, body []byte) error {
+			return c.release(ctx, esapi.ClearScrollRequest{Body: bytes.NewReader(body)})
+		},
+	})
+}
+
+// IndicesUpdateAliases applies alias actions in one atomic request.
+//
+// Parameters:
+//   - actions: Add, remove and remove_index actions applied together
+//
+// Returns error if client is not initialized, an action is invalid or the update fails.
+//
+// Readers never see a state between the actions, which makes alias swaps safe.
+//
+// Example:
+//
+//	err := client.IndicesUpdateAliases([]elasticsearch.AliasAction{
+//		{Type: "remove", Index: "products-v1", Alias: "products"},
+//		{Type: "add", Index: "products-v2", Alias: "products"},
+//	})
+func (c *Client) IndicesUpdateAliases(actions []common_elasticsearch.AliasAction) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	body, err := json.Marshal(map[string]any{"actions": actions})
+	if err != nil {
+		return err
+	}
+
+	request := esapi.IndicesUpdateAliasesRequest{
+		Body:       bytes.NewReader(body),
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// IndicesGetAlias returns the indices an alias points to.
+//
+// Parameters:
+//   - alias: The alias name
+//
+// Returns the sorted index names, empty if the alias does not exist.
+// Returns error if client is not initialized or request fails.
+//
+// Example:
+//
+//	indices, err := client.IndicesGetAlias("products")
+func (c *Client) IndicesGetAlias(alias string) ([]string, error) {
+	if c.client == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	request := esapi.IndicesGetAliasRequest{
+		Name:       []string{alias},
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return nil, err
+	} else {
+		defer response.Body.Close()
+
+		if response.StatusCode == http.StatusNotFound {
+			return []string{}, nil
+		} else if response.IsError() {
+			return nil, c.responseErrorToError(response.Status(), response.Body)
+		}
+
+		result := map[string]json.RawMessage{}
+		if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+			return nil, err
+		}
+
+		return slices.Sorted(maps.Keys(result)), nil
+	}
+}
+
+// IndicesExistsIndexTemplate checks if a composable index template exists.
+//
+// Parameters:
+//   - name: The template name
+//
+// Returns true if the template exists, false otherwise.
+// Returns error if client is not initialized or request fails.
+//
+// Example:
+//
+//	exists, err := client.IndicesExistsIndexTemplate("logs")
+func (c *Client) IndicesExistsIndexTemplate(name string) (bool, error) {
+	if c.client == nil {
+		return false, errors.New("please call Initialize first")
+	}
+
+	request := esapi.IndicesExistsIndexTemplateRequest{
+		Name:       name,
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return false, err
+	} else {
+		defer response.Body.Close()
+
+		if response.StatusCode == http.StatusNotFound {
+			return false, nil
+		} else if response.IsError() {
+			return false, c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return true, nil
+}
+
+// IndicesPutIndexTemplate creates or updates a composable index template.
+//
+// Parameters:
+//   - name: The template name
+//   - body: JSON template with index patterns, composed_of component templates, priority and template
+//
+// Returns error if client is not initialized or operation fails.
+//
+// Composable templates take precedence over legacy templates put with IndicesPutTemplate.
+//
+// Example:
+//
+//	err := client.IndicesPutIndexTemplate("logs", `{
+//		"index_patterns": ["logs-*"],
+//		"composed_of": ["logs-mappings"],
+//		"priority": 100,
+//		"template": {"settings": {"number_of_shards": 1}}
+//	}`)
+func (c *Client) IndicesPutIndexTemplate(name, body string) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.IndicesPutIndexTemplateRequest{
+		Name:       name,
+		Body:       strings.NewReader(body),
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// IndicesDeleteIndexTemplate deletes a composable index template.
+//
+// Parameters:
+//   - name: The template name
+//
+// Returns error if client is not initialized or deletion fails.
+//
+// Example:
+//
+//	err := client.IndicesDeleteIndexTemplate("logs")
+func (c *Client) IndicesDeleteIndexTemplate(name string) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.IndicesDeleteIndexTemplateRequest{
+		Name:       name,
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// ClusterExistsComponentTemplate checks if a component template exists.
+//
+// Parameters:
+//   - name: The component template name
+//
+// Returns true if the component template exists, false otherwise.
+// Returns error if client is not initialized or request fails.
+//
+// Example:
+//
+//	exists, err := client.ClusterExistsComponentTemplate("logs-mappings")
+func (c *Client) ClusterExistsComponentTemplate(name string) (bool, error) {
+	if c.client == nil {
+		return false, errors.New("please call Initialize first")
+	}
+
+	request := esapi.ClusterExistsComponentTemplateRequest{
+		Name:       name,
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return false, err
+	} else {
+		defer response.Body.Close()
+
+		if response.StatusCode == http.StatusNotFound {
+			return false, nil
+		} else if response.IsError() {
+			return false, c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return true, nil
+}
+
+// ClusterPutComponentTemplate creates or updates a component template.
+//
+// Parameters:
+//   - name: The component template name
+//   - body: JSON with a template of settings, mappings and aliases
+//
+// Returns error if client is not initialized or operation fails.
+//
+// Component templates are building blocks that composable index templates list in composed_of.
+//
+// Example:
+//
+//	err := client.ClusterPutComponentTemplate("logs-mappings", `{
+//		"template": {
+//			"mappings": {"properties": {"@timestamp": {"type": "date"}}}
+//		}
+//	}`)
+func (c *Client) ClusterPutComponentTemplate(name, body string) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.ClusterPutComponentTemplateRequest{
+		Name:       name,
+		Body:       strings.NewReader(body),
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// ClusterDeleteComponentTemplate deletes a component template.
+//
+// Parameters:
+//   - name: The component template name
+//
+// Returns error if client is not initialized or deletion fails.
+//
+// A component template used by an index template cannot be deleted.
+//
+// Example:
+//
+//	err := client.ClusterDeleteComponentTemplate("logs-mappings")
+func (c *Client) ClusterDeleteComponentTemplate(name string) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.ClusterDeleteComponentTemplateRequest{
+		Name:       name,
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// IndicesRollover creates a new index for an alias or data stream when conditions are met.
+//
+// Parameters:
+//   - alias: The alias with a write index, or the data stream
+//   - newIndex: Name of the new index, or empty to increment the number of the current one
+//   - body: JSON with conditions (e.g., max_age, max_docs), settings and mappings, or empty to roll over unconditionally
+//
+// Returns the old and new index and whether the rollover happened.
+// Returns error if client is not initialized or the rollover fails.
+//
+// Example:
+//
+//	result, err := client.IndicesRollover("logs", "", `{
+//		"conditions": {"max_age": "7d", "max_docs": 1000000}
+//	}`)
+//	if result.RolledOver {
+//		log.Println("new write index", result.NewIndex)
+//	}
+func (c *Client) IndicesRollover(alias, newIndex, body string) (common_elasticsearch.RolloverResult, error) {
+	if c.client == nil {
+		return common_elasticsearch.RolloverResult{}, errors.New("please call Initialize first")
+	}
+
+	request := esapi.IndicesRolloverRequest{
+		Alias:      alias,
+		NewIndex:   newIndex,
+		Human:      true,
+		ErrorTrace: true}
+	if body != "" {
+		request.Body = strings.NewReader(body)
+	}
+
+	result := common_elasticsearch.RolloverResult{}
+	if data, err := c.perform(context.Background(), request); err != nil {
+		return result, err
+	} else if err := json.Unmarshal(data, &result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Reindex copies documents with the _reindex API and waits for the task to complete.
+//
+// Parameters:
+//   - ctx: Context of the polling; the task keeps running on the cluster if ctx is done
+//   - body: JSON with source and dest (e.g., {"source": {"index": "old"}, "dest": {"index": "new"}})
+//   - pollInterval: Interval of the task polling (default 1s)
+//
+// Returns the counters of the completed task.
+// Returns error if client is not initialized, the task fails or reports document failures.
+//
+// The reindex runs as a background task, so copying large indices is not limited by the
+// response timeout of the client. The destination is refreshed when the task completes.
+//
+// Example:
+//
+//	result, err := client.Reindex(ctx, `{
+//		"source": {"index": "products-v1"},
+//		"dest": {"index": "products-v2"}
+//	}`, 5*time.Second)
+func (c *Client) Reindex(ctx context.Context, body string, pollInterval time.Duration) (common_elasticsearch.ReindexResult, error) {
+	if c.client == nil {
+		return common_elasticsearch.ReindexResult{}, errors.New("please call Initialize first")
+	}
+
+	waitForCompletion := false
+	refresh := true
+
+	return estask.Reindex(ctx, []byte(body), pollInterval,
+		func(ctx context.Context, body []byte) ([]byte, error) {
+			return c.perform(ctx, esapi.ReindexRequest{
+				Body:              bytes.NewReader(body),
+				WaitForCompletion: &waitForCompletion,
+				Refresh:           &refresh,
+				Human:             true,
+				ErrorTrace:        true})
+		},
+		func(ctx context.Context, taskID string) ([]byte, error) {
+			return c.perform(ctx, esapi.TasksGetRequest{
+				TaskID:     taskID,
+				Human:      true,
+				ErrorTrace: true})
+		})
+}
+
+// ILMPutLifecycle creates or updates an index lifecycle management policy.
+//
+// Parameters:
+//   - policy: The policy name
+//   - body: JSON with the policy phases
+//
+// Returns error if client is not initialized or operation fails.
+//
+// Example:
+//
+//	err := client.ILMPutLifecycle("logs", `{
+//		"policy": {
+//			"phases": {
+//				"hot": {"actions": {"rollover": {"max_age": "7d"}}},
+//				"delete": {"min_age": "30d", "actions": {"delete": {}}}
+//			}
+//		}
+//	}`)
+func (c *Client) ILMPutLifecycle(policy, body string) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.ILMPutLifecycleRequest{
+		Policy:     policy,
+		Body:       strings.NewReader(body),
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// ILMGetLifecycle returns an index lifecycle management policy.
+//
+// Parameters:
+//   - policy: The policy name
+//
+// Returns the policy as JSON in the form accepted by ILMPutLifecycle ({"policy": {...}}).
+// Returns error if client is not initialized or the policy does not exist.
+//
+// Example:
+//
+//	policy, err := client.ILMGetLifecycle("logs")
+func (c *Client) ILMGetLifecycle(policy string) (string, error) {
+	if c.client == nil {
+		return "", errors.New("please call Initialize first")
+	}
+
+	data, err := c.perform(context.Background(), esapi.ILMGetLifecycleRequest{
+		Policy:     policy,
+		Human:      true,
+		ErrorTrace: true})
+	if err != nil {
+		return "", err
+	}
+
+	result := map[string]struct {
+		Policy json.RawMessage `json:"policy"`
+	}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", err
+	} else if _, ok := result[policy]; !ok {
+		return "", fmt.Errorf("lifecycle policy %q not found", policy)
+	}
+
+	body, err := json.Marshal(result[policy])
+	return string(body), err
+}
+
+// ILMDeleteLifecycle deletes an index lifecycle management policy.
+//
+// Parameters:
+//   - policy: The policy name
+//
+// Returns error if client is not initialized or deletion fails.
+//
+// A policy used by indices cannot be deleted.
+//
+// Example:
+//
+//	err := client.ILMDeleteLifecycle("logs")
+func (c *Client) ILMDeleteLifecycle(policy string) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.ILMDeleteLifecycleRequest{
+		Policy:     policy,
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// SnapshotCreateRepository registers a snapshot repository.
+//
+// Parameters:
+//   - repository: The repository name
+//   - body: JSON with the repository type and settings
+//
+// Returns error if client is not initialized or operation fails.
+//
+// Filesystem repositories require their location in path.repo of every node.
+//
+// Example:
+//
+//	err := client.SnapshotCreateRepository("backups", `{
+//		"type": "fs",
+//		"settings": {"location": "/mnt/backups"}
+//	}`)
+func (c *Client) SnapshotCreateRepository(repository, body string) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.SnapshotCreateRepositoryRequest{
+		Repository: repository,
+		Body:       strings.NewReader(body),
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// SnapshotDeleteRepository unregisters a snapshot repository. The snapshots are kept in storage.
+//
+// Parameters:
+//   - repository: The repository name
+//
+// Returns error if client is not initialized or deletion fails.
+//
+// Example:
+//
+//	err := client.SnapshotDeleteRepository("backups")
+func (c *Client) SnapshotDeleteRepository(repository string) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.SnapshotDeleteRepositoryRequest{
+		Repository: []string{repository},
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// SnapshotCreate creates a snapshot.
+//
+// Parameters:
+//   - repository: The repository name
+//   - snapshot: The snapshot name
+//   - body: JSON with indices and options (e.g., {"indices": "products-*"}), or empty for all indices
+//   - waitForCompletion: Whether to return only after the snapshot has finished
+//
+// Returns error if client is not initialized or the snapshot fails.
+//
+// Waiting is bounded by the response timeout of the client; start large snapshots without waiting.
+//
+// Example:
+//
+//	err := client.SnapshotCreate("backups", "products-2025-01-01", `{"indices": "products"}`, true)
+func (c *Client) SnapshotCreate(repository, snapshot, body string, waitForCompletion bool) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.SnapshotCreateRequest{
+		Repository:        repository,
+		Snapshot:          snapshot,
+		WaitForCompletion: &waitForCompletion,
+		Human:             true,
+		ErrorTrace:        true}
+	if body != "" {
+		request.Body = strings.NewReader(body)
+	}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// SnapshotRestore restores a snapshot.
+//
+// Parameters:
+//   - repository: The repository name
+//   - snapshot: The snapshot name
+//   - body: JSON with indices and options (e.g., rename_pattern and rename_replacement), or empty for all indices
+//   - waitForCompletion: Whether to return only after the restore has finished
+//
+// Returns error if client is not initialized or the restore fails.
+//
+// Restored indices must not exist or must be closed; rename them to restore next to the originals.
+//
+// Example:
+//
+//	err := client.SnapshotRestore("backups", "products-2025-01-01", `{
+//		"indices": "products",
+//		"rename_pattern": "(.+)",
+//		"rename_replacement": "restored-$1"
+//	}`, true)
+func (c *Client) SnapshotRestore(repository, snapshot, body string, waitForCompletion bool) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.SnapshotRestoreRequest{
+		Repository:        repository,
+		Snapshot:          snapshot,
+		WaitForCompletion: &waitForCompletion,
+		Human:             true,
+		ErrorTrace:        true}
+	if body != "" {
+		request.Body = strings.NewReader(body)
+	}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// SnapshotDelete deletes a snapshot.
+//
+// Parameters:
+//   - repository: The repository name
+//   - snapshot: The snapshot name
+//
+// Returns error if client is not initialized or deletion fails.
+//
+// Example:
+//
+//	err := client.SnapshotDelete("backups", "products-2025-01-01")
+func (c *Client) SnapshotDelete(repository, snapshot string) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	request := esapi.SnapshotDeleteRequest{
+		Repository: repository,
+		Snapshot:   snapshot,
+		Human:      true,
+		ErrorTrace: true}
+
+	if response, err := request.Do(context.Background(), c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
+// perform sends a request and returns the body of a successful response.
+func (c *Client) perform(ctx context.Context, request esapi.Request) ([]byte, error) {
+	if response, err := request.Do(ctx, c.client); err != nil {
+		return nil, err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() {
+			return nil, c.responseErrorToError(response.Status(), response.Body)
+		}
+
+		return io.ReadAll(response.Body)
+	}
+}
+
+// release sends a request freeing a search context; a context that no longer exists is not an error.
+func (c *Client) release(ctx context.Context, request esapi.Request) error {
+	if response, err := request.Do(ctx, c.client); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.IsError() && response.StatusCode != http.StatusNotFound {
+			return c.responseErrorToError(response.Status(), response.Body)
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) responseErrorToError(status string, reader io.Reader) error {
 	buffer := new(bytes.Buffer)
 	buffer.ReadFrom(reader)
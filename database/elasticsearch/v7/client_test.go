@@ -1,13 +1,17 @@
 package v7_test
 
 import (
+	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/common-library/go/database/elasticsearch"
+	"github.com/common-library/go/database/elasticsearch/query"
 	"github.com/common-library/go/database/elasticsearch/testutil"
 	v7 "github.com/common-library/go/database/elasticsearch/v7"
 	imgtestutil "github.com/common-library/go/testutil"
@@ -324,3 +328,390 @@ func TestClient_IndicesForcemerge(t *testing.T) {
 	err = client.IndicesDelete([]string{indexName})
 	assert.NoError(t, err)
 }
+
+func TestClient_BulkIndexer(t *testing.T) {
+	client := testutil.GetTestClient(t, "v7", []string{elasticsearchURL})
+
+	indexName := "test-index-bulk"
+
+	indexer, err := client.BulkIndexer(elasticsearch.BulkIndexerConfig{FlushCount: 10, Workers: 2, Refresh: "wait_for"})
+	require.NoError(t, err)
+
+	succeeded := atomic.Int32{}
+	failed := atomic.Int32{}
+	for i := range 25 {
+		err := indexer.Add(context.Background(), elasticsearch.BulkItem{
+			Index:      indexName,
+			DocumentID: fmt.Sprintf("doc%d", i),
+			Body:       fmt.Sprintf(`{"title": "Document %d"}`, i),
+			OnSuccess: func(item elasticsearch.BulkItem, response elasticsearch.BulkItemResponse) {
+				succeeded.Add(1)
+			},
+			OnFailure: func(item elasticsearch.BulkItem, response elasticsearch.BulkItemResponse, err error) {
+				failed.Add(1)
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	err = indexer.Add(context.Background(), elasticsearch.BulkItem{
+		Action:     "update",
+		Index:      indexName,
+		DocumentID: "missing",
+		Body:       `{"doc": {"title": "Missing"}}`,
+		OnFailure: func(item elasticsearch.BulkItem, response elasticsearch.BulkItemResponse, err error) {
+			assert.Equal(t, 404, response.Status)
+			failed.Add(1)
+		},
+	})
+	require.NoError(t, err)
+
+	err = indexer.Close(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(25), succeeded.Load())
+	assert.Equal(t, int32(1), failed.Load())
+
+	stats := indexer.Stats()
+	assert.Equal(t, uint64(26), stats.Added)
+	assert.Equal(t, uint64(3), stats.Requests)
+
+	exists, err := client.Exists(indexName, "doc24")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	err = client.IndicesDelete([]string{indexName})
+	assert.NoError(t, err)
+}
+
+func TestClient_SearchIterator(t *testing.T) {
+	client := testutil.GetTestClient(t, "v7", []string{elasticsearchURL})
+
+	indexName := "test-index-search-iterator"
+
+	err := client.IndicesCreate(indexName, `{"mappings": {"properties": {"group": {"type": "keyword"}, "number": {"type": "integer"}}}}`)
+	require.NoError(t, err)
+
+	indexer, err := client.BulkIndexer(elasticsearch.BulkIndexerConfig{Refresh: "wait_for"})
+	require.NoError(t, err)
+	for i := range 25 {
+		err := indexer.Add(context.Background(), elasticsearch.BulkItem{
+			Index:      indexName,
+			DocumentID: fmt.Sprintf("doc%d", i),
+			Body:       fmt.Sprintf(`{"group": "group%d", "number": %d}`, i%2, i),
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, indexer.Close(context.Background()))
+
+	type document struct {
+		Group  string `json:"group"`
+		Number int    `json:"number"`
+	}
+
+	iterator, err := elasticsearch.NewSearchIterator[document](context.Background(), client, indexName,
+		`{"sort": [{"number": "asc"}], "aggs": {"groups": {"terms": {"field": "group"}}, "total": {"sum": {"field": "number"}}}}`,
+		elasticsearch.SearchIteratorConfig{PageSize: 10})
+	require.NoError(t, err)
+	defer iterator.Close(context.Background())
+
+	assert.Equal(t, int64(25), iterator.Total())
+
+	groups, err := iterator.Aggregations().Buckets("groups")
+	require.NoError(t, err)
+	require.Len(t, groups.Buckets, 2)
+	assert.Equal(t, "group0", groups.Buckets[0].Key)
+	assert.Equal(t, int64(13), groups.Buckets[0].DocCount)
+
+	total, err := iterator.Aggregations().Metric("total")
+	require.NoError(t, err)
+	assert.Equal(t, 300.0, *total.Value)
+
+	numbers := []int{}
+	for hit, err := range iterator.All(context.Background()) {
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("doc%d", hit.Source.Number), hit.ID)
+		numbers = append(numbers, hit.Source.Number)
+	}
+	require.Len(t, numbers, 25)
+	for i, number := range numbers {
+		assert.Equal(t, i, number)
+	}
+
+	cursor, err := client.OpenSearchCursor(context.Background(), indexName, "", elasticsearch.SearchIteratorConfig{PageSize: 10})
+	require.NoError(t, err)
+	page, err := cursor.Next(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, page.Hits, 10)
+	assert.NoError(t, cursor.Close(context.Background()))
+	assert.NoError(t, cursor.Close(context.Background()))
+
+	err = client.IndicesDelete([]string{indexName})
+	assert.NoError(t, err)
+}
+
+func TestClient_ReindexAlias(t *testing.T) {
+	client := testutil.GetTestClient(t, "v7", []string{elasticsearchURL})
+
+	alias := "test-alias"
+	oldIndex, newIndex := alias+"-v1", alias+"-v2"
+
+	err := client.IndicesCreate(oldIndex, `{"settings": {"number_of_shards": 1, "number_of_replicas": 0}}`)
+	require.NoError(t, err)
+
+	err = client.IndicesUpdateAliases([]elasticsearch.AliasAction{{Type: "add", Index: oldIndex, Alias: alias}})
+	require.NoError(t, err)
+
+	indices, err := client.IndicesGetAlias(alias)
+	require.NoError(t, err)
+	assert.Equal(t, []string{oldIndex}, indices)
+
+	for i := range 3 {
+		err := client.Index(alias, fmt.Sprintf("doc%d", i), `{"title": "Document"}`)
+		require.NoError(t, err)
+	}
+
+	result, previous, err := elasticsearch.ReindexAlias(context.Background(), client, alias, newIndex,
+		`{"settings": {"number_of_shards": 1, "number_of_replicas": 0}, "mappings": {"properties": {"title": {"type": "keyword"}}}}`,
+		100*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	assert.Equal(t, int64(3), result.Created)
+	assert.Equal(t, []string{oldIndex}, previous)
+
+	indices, err = client.IndicesGetAlias(alias)
+	require.NoError(t, err)
+	assert.Equal(t, []string{newIndex}, indices)
+
+	exists, err := client.Exists(alias, "doc2")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	indices, err = client.IndicesGetAlias(alias + "-missing")
+	assert.NoError(t, err)
+	assert.Empty(t, indices)
+
+	err = client.IndicesDelete([]string{oldIndex, newIndex})
+	assert.NoError(t, err)
+}
+
+func TestClient_IndexTemplate(t *testing.T) {
+	client := testutil.GetTestClient(t, "v7", []string{elasticsearchURL})
+
+	name := "test-index-template"
+
+	err := client.ClusterPutComponentTemplate(name+"-mappings", `{
+		"template": {"mappings": {"properties": {"level": {"type": "keyword"}}}}
+	}`)
+	require.NoError(t, err)
+
+	exists, err := client.ClusterExistsComponentTemplate(name + "-mappings")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	err = client.IndicesPutIndexTemplate(name, fmt.Sprintf(`{
+		"index_patterns": ["%s-*"],
+		"composed_of": ["%s-mappings"],
+		"priority": 500,
+		"template": {"settings": {"number_of_shards": 1, "number_of_replicas": 0}}
+	}`, name, name))
+	require.NoError(t, err)
+
+	exists, err = client.IndicesExistsIndexTemplate(name)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	err = client.Index(name+"-1", "doc1", `{"level": "error"}`)
+	require.NoError(t, err)
+
+	result, err := client.Search(name+"-1", `{"query": {"term": {"level": "error"}}}`)
+	require.NoError(t, err)
+	assert.Contains(t, result, "doc1")
+
+	err = client.IndicesDelete([]string{name + "-1"})
+	assert.NoError(t, err)
+
+	err = client.IndicesDeleteIndexTemplate(name)
+	assert.NoError(t, err)
+	err = client.ClusterDeleteComponentTemplate(name + "-mappings")
+	assert.NoError(t, err)
+
+	exists, err = client.IndicesExistsIndexTemplate(name)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = client.ClusterExistsComponentTemplate(name + "-mappings")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestClient_IndicesRollover(t *testing.T) {
+	client := testutil.GetTestClient(t, "v7", []string{elasticsearchURL})
+
+	alias := "test-rollover"
+
+	err := client.IndicesCreate(alias+"-000001", fmt.Sprintf(`{
+		"settings": {"number_of_shards": 1, "number_of_replicas": 0},
+		"aliases": {"%s": {"is_write_index": true}}
+	}`, alias))
+	require.NoError(t, err)
+
+	result, err := client.IndicesRollover(alias, "", `{"conditions": {"max_docs": 1}}`)
+	require.NoError(t, err)
+	assert.False(t, result.RolledOver)
+
+	err = client.Index(alias, "doc1", `{"title": "Document"}`)
+	require.NoError(t, err)
+
+	result, err = client.IndicesRollover(alias, "", `{"conditions": {"max_docs": 1}}`)
+	require.NoError(t, err)
+	assert.True(t, result.RolledOver)
+	assert.Equal(t, alias+"-000001", result.OldIndex)
+	assert.Equal(t, alias+"-000002", result.NewIndex)
+
+	err = client.IndicesDelete([]string{alias + "-000001", alias + "-000002"})
+	assert.NoError(t, err)
+}
+
+func TestClient_ILMLifecycle(t *testing.T) {
+	client := testutil.GetTestClient(t, "v7", []string{elasticsearchURL})
+
+	policy := "test-policy"
+
+	err := client.ILMPutLifecycle(policy, `{
+		"policy": {
+			"phases": {
+				"hot": {"actions": {"rollover": {"max_docs": 1000}}},
+				"delete": {"min_age": "30d", "actions": {"delete": {}}}
+			}
+		}
+	}`)
+	require.NoError(t, err)
+
+	body, err := client.ILMGetLifecycle(policy)
+	require.NoError(t, err)
+	assert.Contains(t, body, `"phases"`)
+	assert.Contains(t, body, `"max_docs":1000`)
+
+	err = client.ILMPutLifecycle(policy, body)
+	assert.NoError(t, err)
+
+	err = client.ILMDeleteLifecycle(policy)
+	assert.NoError(t, err)
+
+	_, err = client.ILMGetLifecycle(policy)
+	assert.Error(t, err)
+}
+
+func TestClient_Snapshot(t *testing.T) {
+	client := testutil.GetTestClient(t, "v7", []string{elasticsearchURL})
+
+	index := "test-snapshot"
+	repository := index + "-repository"
+
+	err := client.IndicesCreate(index, `{"settings": {"number_of_shards": 1, "number_of_replicas": 0}}`)
+	require.NoError(t, err)
+	err = client.Index(index, "doc1", `{"title": "Document"}`)
+	require.NoError(t, err)
+
+	err = client.SnapshotCreateRepository(repository, fmt.Sprintf(`{"type": "fs", "settings": {"location": "/tmp/snapshots/%s"}}`, repository))
+	require.NoError(t, err)
+
+	err = client.SnapshotCreate(repository, "snapshot-1", fmt.Sprintf(`{"indices": "%s"}`, index), true)
+	require.NoError(t, err)
+
+	err = client.SnapshotRestore(repository, "snapshot-1", fmt.Sprintf(`{
+		"indices": "%s",
+		"rename_pattern": "(.+)",
+		"rename_replacement": "restored-$1"
+	}`, index), true)
+	require.NoError(t, err)
+
+	exists, err := client.Exists("restored-"+index, "doc1")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	err = client.SnapshotDelete(repository, "snapshot-1")
+	assert.NoError(t, err)
+	err = client.SnapshotDeleteRepository(repository)
+	assert.NoError(t, err)
+
+	err = client.IndicesDelete([]string{index, "restored-" + index})
+	assert.NoError(t, err)
+}
+
+func TestClient_QueryBuilder(t *testing.T) {
+	client := testutil.GetTestClient(t, "v7", []string{elasticsearchURL})
+
+	indexName := "test-index-query-builder"
+
+	err := client.IndicesCreate(indexName, `{"mappings": {"properties": {
+		"name": {"type": "text"},
+		"brand": {"type": "keyword"},
+		"price": {"type": "integer"},
+		"reviews": {"type": "nested", "properties": {"stars": {"type": "integer"}}}
+	}}}`)
+	require.NoError(t, err)
+
+	indexer, err := client.BulkIndexer(elasticsearch.BulkIndexerConfig{Refresh: "wait_for"})
+	require.NoError(t, err)
+	for i, body := range []string{
+		`{"name": "wireless headphones", "brand": "acme", "price": 120, "reviews": [{"stars": 5}]}`,
+		`{"name": "wired headphones", "brand": "acme", "price": 40, "reviews": [{"stars": 3}]}`,
+		`{"name": "wireless speaker", "brand": "globex", "price": 200, "reviews": [{"stars": 2}, {"stars": 4}]}`,
+		`{"name": "wireless mouse", "brand": "globex", "price": 30}`,
+	} {
+		err := indexer.Add(context.Background(), elasticsearch.BulkItem{Index: indexName, DocumentID: fmt.Sprintf("doc%d", i), Body: body})
+		require.NoError(t, err)
+	}
+	require.NoError(t, indexer.Close(context.Background()))
+
+	body, err := query.NewSearch().
+		Query(query.Bool().
+			Must(query.MultiMatch("wireless", "name")).
+			Filter(query.Range("price").Gte(50), query.Nested("reviews", query.Range("reviews.stars").Gte(4))).
+			MustNot(query.Terms("brand", "initech"))).
+		Aggregation("brands", query.NewTermsAggregation("brand").SubAggregation("avg_price", query.NewAvgAggregation("price"))).
+		Sort("price", "asc").
+		Highlight(query.NewHighlight("name")).
+		Body()
+	require.NoError(t, err)
+
+	type product struct {
+		Name  string `json:"name"`
+		Price int    `json:"price"`
+	}
+
+	iterator, err := elasticsearch.NewSearchIterator[product](context.Background(), client, indexName, body, elasticsearch.SearchIteratorConfig{})
+	require.NoError(t, err)
+	defer iterator.Close(context.Background())
+
+	assert.Equal(t, int64(2), iterator.Total())
+
+	brands, err := iterator.Aggregations().Buckets("brands")
+	require.NoError(t, err)
+	require.Len(t, brands.Buckets, 2)
+	assert.Equal(t, "acme", brands.Buckets[0].Key)
+
+	ids := []string{}
+	for hit, err := range iterator.All(context.Background()) {
+		require.NoError(t, err)
+		assert.Contains(t, hit.Highlight["name"][0], "<em>wireless</em>")
+		ids = append(ids, hit.ID)
+	}
+	assert.Equal(t, []string{"doc0", "doc2"}, ids)
+
+	body, err = query.NewSearch().Query(query.Term("brand", "acme")).Body()
+	require.NoError(t, err)
+	err = client.DeleteByQuery([]string{indexName}, body)
+	require.NoError(t, err)
+
+	body, err = query.NewSearch().Query(query.MatchAll()).Body()
+	require.NoError(t, err)
+	remaining, err := elasticsearch.NewSearchIterator[product](context.Background(), client, indexName, body, elasticsearch.SearchIteratorConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), remaining.Total())
+	assert.NoError(t, remaining.Close(context.Background()))
+
+	err = client.IndicesDelete([]string{indexName})
+	assert.NoError(t, err)
+}
@@ -6,9 +6,15 @@
 // Features:
 //   - Automatic connection and reconnection handling
 //   - CRUD operations with type-safe results
-//   - Aggregation pipeline support
+//   - Generic Collection[T] handles with typed finds, iterators, upserts and find-and-update
+//   - Projection, sort, skip and limit options
+//   - Multi-document transactions
+//   - Typed aggregation pipeline builder
+//   - Change streams with persisted resume tokens
 //   - Bulk write operations
-//   - Index management
+//   - Index management with TTL, unique and partial indexes
+//   - Index synchronization from struct tags
+//   - JSON-schema validators
 //   - Context-based timeout control
 //
 // Example:
@@ -24,6 +30,7 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -33,6 +40,8 @@ import (
 
 // Client is a struct that provides client related methods.
 type Client struct {
+	mutex sync.RWMutex
+
 	address string
 	timeout time.Duration
 
@@ -49,7 +58,7 @@ type Client struct {
 //
 // Returns error if connection or ping fails.
 //
-// The method is called automatically by all database operations.
+// The caller must hold c.mutex for writing.
 func (c *Client) connect() error {
 	if c.client != nil && c.client.Ping(c.ctx, readpref.Primary()) == nil {
 		return nil
@@ -77,6 +86,7 @@ func (c *Client) connect() error {
 //
 // This method is called by Finalize and during reconnection attempts.
 // It safely handles nil clients and cleans up context cancellation functions.
+// The caller must hold c.mutex for writing.
 func (c *Client) disConnect() error {
 	if c.client == nil {
 		return nil
@@ -93,6 +103,42 @@ func (c *Client) disConnect() error {
 	return err
 }
 
+// connection returns the driver client and the context for operations, reconnecting first
+// if the connection is lost.
+//
+// Operations use the returned values rather than the fields of c, so they can run concurrently
+// with each other and with a reconnection. Concurrent callers that find the connection lost
+// share one reconnection.
+func (c *Client) connection() (*mongo.Client, context.Context, error) {
+	c.mutex.RLock()
+	client, ctx := c.client, c.ctx
+	c.mutex.RUnlock()
+
+	if client == nil {
+		return nil, nil, errors.New("please call Initialize first")
+	}
+
+	if client.Ping(ctx, readpref.Primary()) == nil {
+		return client, ctx, nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	switch {
+	case c.client == nil:
+		return nil, nil, errors.New("please call Initialize first")
+	case c.client != client:
+		return c.client, c.ctx, nil
+	}
+
+	if err := c.connect(); err != nil {
+		return nil, nil, err
+	}
+
+	return c.client, c.ctx, nil
+}
+
 // Initialize initializes the MongoDB client with connection settings.
 //
 // Parameters:
@@ -110,6 +156,9 @@ func (c *Client) disConnect() error {
 //	err := client.Initialize("localhost:27017", 10*time.Second)
 //	defer client.Finalize()
 func (c *Client) Initialize(address string, timeout time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	c.address = address
 	c.timeout = timeout
 
@@ -129,6 +178,9 @@ func (c *Client) Initialize(address string, timeout time.Duration) error {
 //	client.Initialize("localhost:27017", 10*time.Second)
 //	defer client.Finalize()
 func (c *Client) Finalize() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	return c.disConnect()
 }
 
@@ -153,19 +205,16 @@ func (c *Client) Finalize() error {
 //	}
 //	user, ok := result.(User)
 func (c *Client) FindOne(databaseName, collectionName string, filter any, dataForm any) (any, error) {
-	if c.client == nil {
-		return nil, errors.New("please call Initialize first")
-	}
-
-	if err := c.connect(); err != nil {
+	client, ctx, err := c.connection()
+	if err != nil {
 		return nil, err
 	}
 
-	collection := c.client.Database(databaseName).Collection(collectionName)
+	collection := client.Database(databaseName).Collection(collectionName)
 
 	document := reflect.New(reflect.TypeOf(dataForm))
 
-	if err := collection.FindOne(c.ctx, filter).Decode(document.Interface()); err != nil {
+	if err := collection.FindOne(ctx, filter).Decode(document.Interface()); err != nil {
 		return nil, err
 	}
 
@@ -194,17 +243,14 @@ func (c *Client) FindOne(databaseName, collectionName string, filter any, dataFo
 //	}
 //	users, ok := results.([]User)
 func (c *Client) Find(databaseName, collectionName string, filter, dataForm any) (any, error) {
-	if c.client == nil {
-		return nil, errors.New("please call Initialize first")
-	}
-
-	if err := c.connect(); err != nil {
+	client, ctx, err := c.connection()
+	if err != nil {
 		return nil, err
 	}
 
-	collection := c.client.Database(databaseName).Collection(collectionName)
+	collection := client.Database(databaseName).Collection(collectionName)
 
-	cursor, err := collection.Find(c.ctx, filter)
+	cursor, err := collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -214,11 +260,11 @@ func (c *Client) Find(databaseName, collectionName string, filter, dataForm any)
 	results := reflect.New(tempSlice.Type())
 	results.Elem().Set(tempSlice)
 
-	if err := cursor.All(c.ctx, results.Interface()); err != nil {
+	if err := cursor.All(ctx, results.Interface()); err != nil {
 		return nil, err
 	}
 
-	if err := cursor.Close(c.ctx); err != nil {
+	if err := cursor.Close(ctx); err != nil {
 		return nil, err
 	}
 
@@ -243,16 +289,13 @@ func (c *Client) Find(databaseName, collectionName string, filter, dataForm any)
 //	// Or with bson.M
 //	err = client.InsertOne("mydb", "users", bson.M{"_id": 1, "name": "Alice", "age": 30})
 func (c *Client) InsertOne(databaseName, collectionName string, document any) error {
-	if c.client == nil {
-		return errors.New("please call Initialize first")
-	}
-
-	if err := c.connect(); err != nil {
+	client, ctx, err := c.connection()
+	if err != nil {
 		return err
 	}
 
-	collection := c.client.Database(databaseName).Collection(collectionName)
-	if _, err := collection.InsertOne(c.ctx, document); err != nil {
+	collection := client.Database(databaseName).Collection(collectionName)
+	if _, err := collection.InsertOne(ctx, document); err != nil {
 		return err
 	}
 
@@ -279,16 +322,13 @@ func (c *Client) InsertOne(databaseName, collectionName string, document any) er
 //	}
 //	err := client.InsertMany("mydb", "users", docs)
 func (c *Client) InsertMany(databaseName, collectionName string, documents []any) error {
-	if c.client == nil {
-		return errors.New("please call Initialize first")
-	}
-
-	if err := c.connect(); err != nil {
+	client, ctx, err := c.connection()
+	if err != nil {
 		return err
 	}
 
-	collection := c.client.Database(databaseName).Collection(collectionName)
-	if _, err := collection.InsertMany(c.ctx, documents); err != nil {
+	collection := client.Database(databaseName).Collection(collectionName)
+	if _, err := collection.InsertMany(ctx, documents); err != nil {
 		return err
 	}
 
@@ -316,16 +356,13 @@ func (c *Client) InsertMany(databaseName, collectionName string, documents []any
 //		bson.D{{"$set", bson.D{{"age", 31}}}},
 //	)
 func (c *Client) UpdateOne(databaseName, collectionName string, filter, update any) error {
-	if c.client == nil {
-		return errors.New("please call Initialize first")
-	}
-
-	if err := c.connect(); err != nil {
+	client, ctx, err := c.connection()
+	if err != nil {
 		return err
 	}
 
-	collection := c.client.Database(databaseName).Collection(collectionName)
-	if _, err := collection.UpdateOne(c.ctx, filter, update); err != nil {
+	collection := client.Database(databaseName).Collection(collectionName)
+	if _, err := collection.UpdateOne(ctx, filter, update); err != nil {
 		return err
 	}
 
@@ -353,16 +390,13 @@ func (c *Client) UpdateOne(databaseName, collectionName string, filter, update a
 //		bson.D{{"$inc", bson.D{{"age", 1}}}},
 //	)
 func (c *Client) UpdateMany(databaseName, collectionName string, filter, update any) error {
-	if c.client == nil {
-		return errors.New("please call Initialize first")
-	}
-
-	if err := c.connect(); err != nil {
+	client, ctx, err := c.connection()
+	if err != nil {
 		return err
 	}
 
-	collection := c.client.Database(databaseName).Collection(collectionName)
-	if _, err := collection.UpdateMany(c.ctx, filter, update); err != nil {
+	collection := client.Database(databaseName).Collection(collectionName)
+	if _, err := collection.UpdateMany(ctx, filter, update); err != nil {
 		return err
 	}
 
@@ -384,16 +418,13 @@ func (c *Client) UpdateMany(databaseName, collectionName string, filter, update
 //
 //	err := client.DeleteOne("mydb", "users", bson.M{"name": "Alice"})
 func (c *Client) DeleteOne(databaseName, collectionName string, filter any) error {
-	if c.client == nil {
-		return errors.New("please call Initialize first")
-	}
-
-	if err := c.connect(); err != nil {
+	client, ctx, err := c.connection()
+	if err != nil {
 		return err
 	}
 
-	collection := c.client.Database(databaseName).Collection(collectionName)
-	if _, err := collection.DeleteOne(c.ctx, filter); err != nil {
+	collection := client.Database(databaseName).Collection(collectionName)
+	if _, err := collection.DeleteOne(ctx, filter); err != nil {
 		return err
 	}
 
@@ -419,16 +450,13 @@ func (c *Client) DeleteOne(databaseName, collectionName string, filter any) erro
 //	// Delete all documents
 //	err = client.DeleteMany("mydb", "users", bson.M{})
 func (c *Client) DeleteMany(databaseName, collectionName string, filter any) error {
-	if c.client == nil {
-		return errors.New("please call Initialize first")
-	}
-
-	if err := c.connect(); err != nil {
+	client, ctx, err := c.connection()
+	if err != nil {
 		return err
 	}
 
-	collection := c.client.Database(databaseName).Collection(collectionName)
-	if _, err := collection.DeleteMany(c.ctx, filter); err != nil {
+	collection := client.Database(databaseName).Collection(collectionName)
+	if _, err := collection.DeleteMany(ctx, filter); err != nil {
 		return err
 	}
 
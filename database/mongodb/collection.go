@@ -0,0 +1,358 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection is a typed handle to a collection whose documents decode into T.
+//
+// A Collection only holds the client and the database and collection names, so it is cheap to
+// create and safe for concurrent use. Operations take a context, which bounds the operation and,
+// inside WithTransaction, carries the session.
+type Collection[T any] struct {
+	client         *Client
+	databaseName   string
+	collectionName string
+}
+
+// NewCollection creates a typed handle to a collection.
+//
+// Parameters:
+//   - client: Initialized MongoDB client
+//   - databaseName: Name of the database
+//   - collectionName: Name of the collection
+//
+// Returns:
+//   - *Collection[T]: Handle decoding documents into T
+//
+// Example:
+//
+//	type User struct {
+//	    ID   primitive.ObjectID `bson:"_id,omitempty"`
+//	    Name string             `bson:"name"`
+//	    Age  int                `bson:"age"`
+//	}
+//
+//	users := mongodb.NewCollection[User](&client, "mydb", "users")
+//	user, err := users.FindOne(ctx, bson.M{"name": "Alice"})
+func NewCollection[T any](client *Client, databaseName, collectionName string) *Collection[T] {
+	return &Collection[T]{client: client, databaseName: databaseName, collectionName: collectionName}
+}
+
+// FindOption configures FindOne, Find and FindOneAndUpdate.
+type FindOption func(*findSettings)
+
+type findSettings struct {
+	projection any
+	sort       any
+	skip       *int64
+	limit      *int64
+}
+
+// WithProjection limits the returned fields (e.g., bson.M{"name": 1, "age": 1}).
+//
+// Fields left out of the projection keep their zero value in T.
+func WithProjection(projection any) FindOption {
+	return func(o *findSettings) { o.projection = projection }
+}
+
+// WithSort orders the matched documents (e.g., bson.D{{"age", -1}, {"name", 1}}).
+//
+// Use bson.D rather than bson.M for more than one key, since map order is random.
+func WithSort(sort any) FindOption {
+	return func(o *findSettings) { o.sort = sort }
+}
+
+// WithSkip skips the first skip matched documents.
+func WithSkip(skip int64) FindOption {
+	return func(o *findSettings) { o.skip = &skip }
+}
+
+// WithLimit returns at most limit documents. It only applies to Find.
+func WithLimit(limit int64) FindOption {
+	return func(o *findSettings) { o.limit = &limit }
+}
+
+func newFindSettings(findOptions []FindOption) findSettings {
+	o := findSettings{}
+	for _, findOption := range findOptions {
+		findOption(&o)
+	}
+
+	return o
+}
+
+// collection returns the driver collection, connecting first if needed.
+func (c *Collection[T]) collection() (*mongo.Collection, error) {
+	if c.client == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	client, _, err := c.client.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Database(c.databaseName).Collection(c.collectionName), nil
+}
+
+// FindOne finds a single document matching the filter.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - filter: BSON filter document (e.g., bson.M{"name": "Alice"})
+//   - findOptions: Optional WithProjection, WithSort and WithSkip
+//
+// Returns:
+//   - T: The first matching document
+//   - error: mongo.ErrNoDocuments if nothing matches, or an error if the client is not initialized or the query fails
+//
+// Example:
+//
+//	// Oldest user
+//	user, err := users.FindOne(ctx, bson.M{}, mongodb.WithSort(bson.D{{"age", -1}}))
+//	if errors.Is(err, mongo.ErrNoDocuments) {
+//	    // not found
+//	}
+func (c *Collection[T]) FindOne(ctx context.Context, filter any, findOptions ...FindOption) (T, error) {
+	var result T
+
+	collection, err := c.collection()
+	if err != nil {
+		return result, err
+	}
+
+	o := newFindSettings(findOptions)
+	findOneOptions := options.FindOne()
+	if o.projection != nil {
+		findOneOptions.SetProjection(o.projection)
+	}
+	if o.sort != nil {
+		findOneOptions.SetSort(o.sort)
+	}
+	if o.skip != nil {
+		findOneOptions.SetSkip(*o.skip)
+	}
+
+	err = collection.FindOne(ctx, filter, findOneOptions).Decode(&result)
+
+	return result, err
+}
+
+// Find returns an iterator over the documents matching the filter.
+//
+// Parameters:
+//   - ctx: Context for the query and the iteration
+//   - filter: BSON filter document (e.g., bson.M{"age": bson.M{"$gte": 18}})
+//   - findOptions: Optional WithProjection, WithSort, WithSkip and WithLimit
+//
+// Returns:
+//   - iter.Seq2[T, error]: Iterator yielding each document, or a zero T and the error that ended the iteration
+//
+// The query runs when iteration starts and documents are decoded one at a time as the cursor
+// fetches batches, so large results are not held in memory. The cursor is closed when the loop
+// ends, including on break. Iterating again runs the query again.
+//
+// Example:
+//
+//	for user, err := range users.Find(ctx, bson.M{"age": bson.M{"$gte": 18}},
+//	    mongodb.WithSort(bson.D{{"name", 1}}), mongodb.WithSkip(20), mongodb.WithLimit(10)) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(user.Name)
+//	}
+func (c *Collection[T]) Find(ctx context.Context, filter any, findOptions ...FindOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		collection, err := c.collection()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		o := newFindSettings(findOptions)
+		driverOptions := options.Find()
+		if o.projection != nil {
+			driverOptions.SetProjection(o.projection)
+		}
+		if o.sort != nil {
+			driverOptions.SetSort(o.sort)
+		}
+		if o.skip != nil {
+			driverOptions.SetSkip(*o.skip)
+		}
+		if o.limit != nil {
+			driverOptions.SetLimit(*o.limit)
+		}
+
+		cursor, err := collection.Find(ctx, filter, driverOptions)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer cursor.Close(context.WithoutCancel(ctx))
+
+		for cursor.Next(ctx) {
+			var result T
+			if err := cursor.Decode(&result); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			if !yield(result, nil) {
+				return
+			}
+		}
+
+		if err := cursor.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// InsertOne inserts a single document.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - document: Document to insert
+//
+// Returns:
+//   - any: The _id of the inserted document, generated by the driver when the document has none
+//   - error: Error if the client is not initialized or the insertion fails
+//
+// Example:
+//
+//	id, err := users.InsertOne(ctx, User{Name: "Alice", Age: 30})
+func (c *Collection[T]) InsertOne(ctx context.Context, document T) (any, error) {
+	collection, err := c.collection()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := collection.InsertOne(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.InsertedID, nil
+}
+
+// Upsert replaces the document matching the filter, or inserts it if nothing matches.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - filter: BSON filter matching at most one document (e.g., bson.M{"_id": id})
+//   - document: Replacement document
+//
+// Returns:
+//   - bool: true if the document was inserted, false if an existing document was replaced
+//   - error: Error if the client is not initialized or the operation fails
+//
+// The whole document is replaced; use FindOneAndUpdate with update operators to change single
+// fields. When inserting, equality conditions of the filter are copied into the new document.
+//
+// Example:
+//
+//	inserted, err := users.Upsert(ctx, bson.M{"email": user.Email}, user)
+func (c *Collection[T]) Upsert(ctx context.Context, filter any, document T) (bool, error) {
+	collection, err := c.collection()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := collection.ReplaceOne(ctx, filter, document, options.Replace().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+
+	return result.UpsertedCount > 0, nil
+}
+
+// FindOneAndUpdate updates a single document and returns it after the update.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - filter: BSON filter document (e.g., bson.M{"_id": id})
+//   - update: Update operations (e.g., bson.M{"$inc": bson.M{"balance": -100}})
+//   - findOptions: Optional WithProjection and WithSort, which picks the document when several match
+//
+// Returns:
+//   - T: The document as it is after the update
+//   - error: mongo.ErrNoDocuments if nothing matches, or an error if the client is not initialized or the update fails
+//
+// Finding and updating is atomic, which makes it suitable for counters and work queues.
+//
+// Example:
+//
+//	// Claim the oldest pending job
+//	job, err := jobs.FindOneAndUpdate(ctx,
+//	    bson.M{"status": "pending"},
+//	    bson.M{"$set": bson.M{"status": "running"}},
+//	    mongodb.WithSort(bson.D{{"created_at", 1}}))
+func (c *Collection[T]) FindOneAndUpdate(ctx context.Context, filter, update any, findOptions ...FindOption) (T, error) {
+	var result T
+
+	collection, err := c.collection()
+	if err != nil {
+		return result, err
+	}
+
+	o := newFindSettings(findOptions)
+	updateOptions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if o.projection != nil {
+		updateOptions.SetProjection(o.projection)
+	}
+	if o.sort != nil {
+		updateOptions.SetSort(o.sort)
+	}
+
+	err = collection.FindOneAndUpdate(ctx, filter, update, updateOptions).Decode(&result)
+
+	return result, err
+}
+
+// WithTransaction runs fn in a multi-document transaction.
+//
+// Parameters:
+//   - ctx: Context for the session and the transaction
+//   - fn: Function running the operations of the transaction. It must pass the given context to them
+//
+// Returns:
+//   - error: Error returned by fn, or an error if the client is not initialized or the commit fails
+//
+// The transaction is committed when fn returns nil and aborted when it returns an error.
+// Operations on Collection handles and the driver that receive the context of fn are part of the
+// transaction. On transient errors and unknown commit results the driver retries fn and the
+// commit for up to 120 seconds, so fn must be safe to run more than once. Transactions require a
+// replica set or a sharded cluster.
+//
+// Example:
+//
+//	err := client.WithTransaction(ctx, func(ctx context.Context) error {
+//	    if _, err := accounts.FindOneAndUpdate(ctx, bson.M{"_id": from}, bson.M{"$inc": bson.M{"balance": -100}}); err != nil {
+//	        return err
+//	    }
+//	    _, err := accounts.FindOneAndUpdate(ctx, bson.M{"_id": to}, bson.M{"$inc": bson.M{"balance": 100}})
+//	    return err
+//	})
+func (c *Client) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	client, _, err := c.connection()
+	if err != nil {
+		return err
+	}
+
+	return client.UseSession(ctx, func(sessionContext mongo.SessionContext) error {
+		_, err := sessionContext.WithTransaction(sessionContext, func(sessionContext mongo.SessionContext) (any, error) {
+			return nil, fn(sessionContext)
+		})
+
+		return err
+	})
+}
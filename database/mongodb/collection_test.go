@@ -0,0 +1,263 @@
+package mongodb_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/mongodb"
+	"github.com/common-library/go/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newCollection(t *testing.T, collectionName string) (*mongodb.Client, *mongodb.Collection[TestStruct]) {
+	t.Helper()
+
+	client := &mongodb.Client{}
+	require.NoError(t, client.Initialize(mongoAddress, 30))
+	t.Cleanup(func() { client.Finalize() })
+
+	require.NoError(t, client.DeleteMany("testdb", collectionName, bson.M{}))
+	t.Cleanup(func() { client.DeleteMany("testdb", collectionName, bson.M{}) })
+
+	return client, mongodb.NewCollection[TestStruct](client, "testdb", collectionName)
+}
+
+func TestCollection_InsertOneAndFindOne(t *testing.T) {
+	ctx := context.Background()
+	_, users := newCollection(t, "collection_find_one")
+
+	id, err := users.InsertOne(ctx, TestStruct{ID: "u1", Name: "Alice", Age: 30, Email: "alice@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "u1", id)
+
+	user, err := users.FindOne(ctx, bson.M{"_id": "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, TestStruct{ID: "u1", Name: "Alice", Age: 30, Email: "alice@example.com"}, user)
+
+	user, err = users.FindOne(ctx, bson.M{"_id": "u1"}, mongodb.WithProjection(bson.M{"name": 1}))
+	require.NoError(t, err)
+	assert.Equal(t, TestStruct{ID: "u1", Name: "Alice"}, user)
+
+	_, err = users.FindOne(ctx, bson.M{"_id": "missing"})
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+
+	_, err = users.InsertOne(ctx, TestStruct{ID: "u1"})
+	assert.True(t, mongo.IsDuplicateKeyError(err))
+}
+
+func TestCollection_Find(t *testing.T) {
+	ctx := context.Background()
+	_, users := newCollection(t, "collection_find")
+
+	for i := range 10 {
+		_, err := users.InsertOne(ctx, TestStruct{ID: fmt.Sprintf("u%d", i), Name: fmt.Sprintf("user%d", i), Age: 20 + i})
+		require.NoError(t, err)
+	}
+
+	ages := []int{}
+	for user, err := range users.Find(ctx, bson.M{"age": bson.M{"$gte": 22}},
+		mongodb.WithSort(bson.D{{Key: "age", Value: -1}}), mongodb.WithSkip(1), mongodb.WithLimit(3)) {
+		require.NoError(t, err)
+		ages = append(ages, user.Age)
+	}
+	assert.Equal(t, []int{28, 27, 26}, ages)
+
+	count := 0
+	for user, err := range users.Find(ctx, bson.M{}, mongodb.WithProjection(bson.M{"age": 1})) {
+		require.NoError(t, err)
+		assert.Empty(t, user.Name)
+		count++
+	}
+	assert.Equal(t, 10, count)
+
+	count = 0
+	for _, err := range users.Find(ctx, bson.M{}) {
+		require.NoError(t, err)
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, count)
+
+	for _, err := range users.Find(ctx, bson.M{"$invalid": 1}) {
+		assert.Error(t, err)
+	}
+}
+
+func TestCollection_Upsert(t *testing.T) {
+	ctx := context.Background()
+	_, users := newCollection(t, "collection_upsert")
+
+	inserted, err := users.Upsert(ctx, bson.M{"_id": "u1"}, TestStruct{ID: "u1", Name: "Alice", Age: 30})
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	inserted, err = users.Upsert(ctx, bson.M{"_id": "u1"}, TestStruct{ID: "u1", Name: "Alice", Age: 31})
+	require.NoError(t, err)
+	assert.False(t, inserted)
+
+	user, err := users.FindOne(ctx, bson.M{"_id": "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, 31, user.Age)
+}
+
+func TestCollection_FindOneAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	_, users := newCollection(t, "collection_find_one_and_update")
+
+	for i := range 3 {
+		_, err := users.InsertOne(ctx, TestStruct{ID: fmt.Sprintf("u%d", i), Name: "pending", Age: i})
+		require.NoError(t, err)
+	}
+
+	user, err := users.FindOneAndUpdate(ctx, bson.M{"name": "pending"}, bson.M{"$set": bson.M{"name": "running"}},
+		mongodb.WithSort(bson.D{{Key: "age", Value: -1}}))
+	require.NoError(t, err)
+	assert.Equal(t, TestStruct{ID: "u2", Name: "running", Age: 2}, user)
+
+	user, err = users.FindOneAndUpdate(ctx, bson.M{"_id": "u0"}, bson.M{"$inc": bson.M{"age": 10}},
+		mongodb.WithProjection(bson.M{"age": 1}))
+	require.NoError(t, err)
+	assert.Equal(t, TestStruct{ID: "u0", Age: 10}, user)
+
+	_, err = users.FindOneAndUpdate(ctx, bson.M{"_id": "missing"}, bson.M{"$set": bson.M{"age": 1}})
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+}
+
+func TestCollection_ConcurrentReconnect(t *testing.T) {
+	ctx := context.Background()
+	client, users := newCollection(t, "collection_concurrent")
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 20 {
+				id := fmt.Sprintf("u%d-%d", i, j)
+				users.InsertOne(ctx, TestStruct{ID: id, Name: "Alice"})
+				users.FindOne(ctx, bson.M{"_id": id})
+				client.Find("testdb", "collection_concurrent", bson.M{"_id": id}, TestStruct{})
+			}
+		})
+	}
+
+	// Reconnect while operations run; they may fail in between but must not race.
+	for range 5 {
+		require.NoError(t, client.Finalize())
+		require.NoError(t, client.Initialize(mongoAddress, 30))
+	}
+	wg.Wait()
+
+	_, err := users.FindOne(ctx, bson.M{})
+	assert.NoError(t, err)
+}
+
+func TestCollection_NotInitialized(t *testing.T) {
+	ctx := context.Background()
+	users := mongodb.NewCollection[TestStruct](&mongodb.Client{}, "testdb", "users")
+
+	_, err := users.FindOne(ctx, bson.M{})
+	assert.ErrorContains(t, err, "please call Initialize first")
+
+	for _, err := range users.Find(ctx, bson.M{}) {
+		assert.ErrorContains(t, err, "please call Initialize first")
+	}
+
+	_, err = users.InsertOne(ctx, TestStruct{})
+	assert.ErrorContains(t, err, "please call Initialize first")
+
+	_, err = users.Upsert(ctx, bson.M{}, TestStruct{})
+	assert.ErrorContains(t, err, "please call Initialize first")
+
+	_, err = users.FindOneAndUpdate(ctx, bson.M{}, bson.M{})
+	assert.ErrorContains(t, err, "please call Initialize first")
+
+	err = (&mongodb.Client{}).WithTransaction(ctx, func(ctx context.Context) error { return nil })
+	assert.ErrorContains(t, err, "please call Initialize first")
+}
+
+// newReplicaSetClient starts a single node replica set, which transactions and change streams need.
+func newReplicaSetClient(t *testing.T) *mongodb.Client {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        testutil.MongoImage,
+			ExposedPorts: []string{"27017/tcp"},
+			Cmd:          []string{"--replSet", "rs0", "--bind_ip_all"},
+			WaitingFor:   wait.ForLog("Waiting for connections").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { container.Terminate(ctx) })
+
+	code, _, err := container.Exec(ctx, []string{"mongosh", "--quiet", "--eval",
+		`rs.initiate({_id: "rs0", members: [{_id: 0, host: "localhost:27017"}]})`})
+	require.NoError(t, err)
+	require.Equal(t, 0, code)
+
+	endpoint, err := container.Endpoint(ctx, "")
+	require.NoError(t, err)
+
+	client := &mongodb.Client{}
+	require.NoError(t, client.Initialize(endpoint+"/?directConnection=true", 30))
+	t.Cleanup(func() { client.Finalize() })
+
+	// Writes fail until the node has been elected primary
+	require.Eventually(t, func() bool {
+		return client.InsertOne("testdb", "ready", bson.M{}) == nil
+	}, 30*time.Second, 500*time.Millisecond)
+
+	return client
+}
+
+func TestClient_WithTransaction(t *testing.T) {
+	ctx := context.Background()
+	client := newReplicaSetClient(t)
+
+	accounts := mongodb.NewCollection[TestStruct](client, "testdb", "accounts")
+
+	_, err := accounts.Upsert(ctx, bson.M{"_id": "a"}, TestStruct{ID: "a", Age: 100})
+	require.NoError(t, err)
+	_, err = accounts.Upsert(ctx, bson.M{"_id": "b"}, TestStruct{ID: "b", Age: 0})
+	require.NoError(t, err)
+
+	transfer := func(amount int, fail bool) error {
+		return client.WithTransaction(ctx, func(ctx context.Context) error {
+			if _, err := accounts.FindOneAndUpdate(ctx, bson.M{"_id": "a"}, bson.M{"$inc": bson.M{"age": -amount}}); err != nil {
+				return err
+			}
+			if _, err := accounts.FindOneAndUpdate(ctx, bson.M{"_id": "b"}, bson.M{"$inc": bson.M{"age": amount}}); err != nil {
+				return err
+			}
+			if fail {
+				return errors.New("transfer failed")
+			}
+			return nil
+		})
+	}
+
+	require.NoError(t, transfer(30, false))
+
+	assert.ErrorContains(t, transfer(50, true), "transfer failed")
+
+	a, err := accounts.FindOne(ctx, bson.M{"_id": "a"})
+	require.NoError(t, err)
+	assert.Equal(t, 70, a.Age)
+
+	b, err := accounts.FindOne(ctx, bson.M{"_id": "b"})
+	require.NoError(t, err)
+	assert.Equal(t, 30, b.Age)
+}
@@ -0,0 +1,640 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index describes an index of a collection.
+type Index struct {
+	// Name of the index; empty means the server default built from the keys (e.g., "email_1")
+	Name string
+
+	// Keys are the indexed fields in order with 1 (ascending), -1 (descending) or a type such as "text"
+	Keys bson.D
+
+	// Unique rejects documents with a duplicate key
+	Unique bool
+
+	// Sparse skips documents missing the indexed fields
+	Sparse bool
+
+	// ExpireAfter makes a TTL index removing documents this long after the time in the indexed date field
+	ExpireAfter time.Duration
+
+	// PartialFilter indexes only the documents matching this filter (e.g., bson.M{"deleted": false})
+	PartialFilter any
+}
+
+// IndexDefiner is implemented by document types declaring indexes that struct tags cannot express,
+// such as partial indexes or indexes on nested fields. Sync includes them with the tag indexes.
+type IndexDefiner interface {
+	Indexes() []Index
+}
+
+// SyncResult lists the index changes made by Sync.
+type SyncResult struct {
+	// Created are the names of the created indexes, including changed ones that were recreated
+	Created []string
+
+	// Dropped are the names of the dropped indexes, including changed ones that were recreated
+	Dropped []string
+}
+
+type indexSpecification struct {
+	Name                    string `bson:"name"`
+	Keys                    bson.D `bson:"key"`
+	Unique                  bool   `bson:"unique"`
+	Sparse                  bool   `bson:"sparse"`
+	ExpireAfterSeconds      *int64 `bson:"expireAfterSeconds"`
+	PartialFilterExpression bson.M `bson:"partialFilterExpression"`
+	Weights                 bson.D `bson:"weights"`
+}
+
+// name returns the name of the index, generating the server default if it has none.
+func (i Index) name() string {
+	if i.Name != "" {
+		return i.Name
+	}
+
+	parts := []string{}
+	for _, key := range i.Keys {
+		parts = append(parts, fmt.Sprintf("%s_%v", key.Key, key.Value))
+	}
+
+	return strings.Join(parts, "_")
+}
+
+func (i Index) model() mongo.IndexModel {
+	indexOptions := options.Index().SetName(i.name())
+	if i.Unique {
+		indexOptions.SetUnique(true)
+	}
+	if i.Sparse {
+		indexOptions.SetSparse(true)
+	}
+	if i.ExpireAfter > 0 {
+		indexOptions.SetExpireAfterSeconds(int32(i.ExpireAfter / time.Second))
+	}
+	if i.PartialFilter != nil {
+		indexOptions.SetPartialFilterExpression(i.PartialFilter)
+	}
+
+	return mongo.IndexModel{Keys: i.Keys, Options: indexOptions}
+}
+
+// equal reports whether two indexes have the same name, keys and options.
+func (i Index) equal(other Index) bool {
+	if i.name() != other.name() || len(i.Keys) != len(other.Keys) ||
+		i.Unique != other.Unique || i.Sparse != other.Sparse ||
+		i.ExpireAfter/time.Second != other.ExpireAfter/time.Second {
+		return false
+	}
+
+	if keysSignature(i.Keys) != keysSignature(other.Keys) {
+		return false
+	}
+
+	return sameDocument(i.PartialFilter, other.PartialFilter)
+}
+
+// keysSignature returns a comparable form of index keys, in which the fields of a text index are unordered.
+func keysSignature(keys bson.D) string {
+	fields := []string{}
+	textFields := []string{}
+	for _, key := range keys {
+		if key.Value == "text" {
+			textFields = append(textFields, key.Key)
+		} else {
+			fields = append(fields, fmt.Sprintf("%s:%v", key.Key, key.Value))
+		}
+	}
+	slices.Sort(textFields)
+
+	return strings.Join(fields, ",") + "|" + strings.Join(textFields, ",")
+}
+
+// sameDocument compares two documents ignoring field order and number types.
+func sameDocument(a, b any) bool {
+	normalize := func(document any) any {
+		if document == nil {
+			return nil
+		}
+
+		data, err := bson.MarshalExtJSON(document, false, false)
+		if err != nil {
+			return document
+		}
+
+		var result any
+		if err := json.Unmarshal(data, &result); err != nil {
+			return document
+		}
+
+		return result
+	}
+
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// CreateIndexes creates indexes on the collection.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - indexes: Indexes to create
+//
+// Returns:
+//   - []string: Names of the indexes
+//   - error: Error if the client is not initialized or an index cannot be created
+//
+// Creating an index that already exists with the same keys and options does nothing. Creating one
+// whose name or keys match an existing index with different options fails; use Sync or DropIndex
+// to change indexes.
+//
+// Example:
+//
+//	names, err := users.CreateIndexes(ctx,
+//	    mongodb.Index{Keys: bson.D{{"email", 1}}, Unique: true},
+//	    mongodb.Index{Keys: bson.D{{"created_at", 1}}, ExpireAfter: 30 * 24 * time.Hour},
+//	    mongodb.Index{Name: "active_tenant", Keys: bson.D{{"tenant_id", 1}, {"name", 1}},
+//	        PartialFilter: bson.M{"deleted": false}},
+//	)
+func (c *Collection[T]) CreateIndexes(ctx context.Context, indexes ...Index) ([]string, error) {
+	collection, err := c.collection()
+	if err != nil {
+		return nil, err
+	} else if len(indexes) == 0 {
+		return []string{}, nil
+	}
+
+	models := make([]mongo.IndexModel, len(indexes))
+	for i, index := range indexes {
+		models[i] = index.model()
+	}
+
+	return collection.Indexes().CreateMany(ctx, models)
+}
+
+// ListIndexes returns the indexes of the collection, including the _id index.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//
+// Returns:
+//   - []Index: Indexes with their names set
+//   - error: Error if the client is not initialized or the indexes cannot be listed
+//
+// A collection that does not exist has no indexes.
+//
+// Example:
+//
+//	indexes, err := users.ListIndexes(ctx)
+//	for _, index := range indexes {
+//	    fmt.Println(index.Name, index.Keys, index.Unique)
+//	}
+func (c *Collection[T]) ListIndexes(ctx context.Context) ([]Index, error) {
+	collection, err := c.collection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		if commandError := (mongo.CommandError{}); errors.As(err, &commandError) && commandError.Name == "NamespaceNotFound" {
+			return []Index{}, nil
+		}
+		return nil, err
+	}
+
+	specifications := []indexSpecification{}
+	if err := cursor.All(ctx, &specifications); err != nil {
+		return nil, err
+	}
+
+	indexes := make([]Index, len(specifications))
+	for i, specification := range specifications {
+		// Text indexes are stored with _fts and _ftsx keys and the text fields in weights
+		keys := bson.D{}
+		for _, key := range specification.Keys {
+			switch key.Key {
+			case "_fts":
+				for _, weight := range specification.Weights {
+					keys = append(keys, bson.E{Key: weight.Key, Value: "text"})
+				}
+			case "_ftsx":
+			default:
+				keys = append(keys, key)
+			}
+		}
+
+		indexes[i] = Index{
+			Name:   specification.Name,
+			Keys:   keys,
+			Unique: specification.Unique,
+			Sparse: specification.Sparse,
+		}
+		if specification.ExpireAfterSeconds != nil {
+			indexes[i].ExpireAfter = time.Duration(*specification.ExpireAfterSeconds) * time.Second
+		}
+		if specification.PartialFilterExpression != nil {
+			indexes[i].PartialFilter = specification.PartialFilterExpression
+		}
+	}
+
+	return indexes, nil
+}
+
+// DropIndex drops an index by name.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - name: Name of the index (e.g., "email_1")
+//
+// Returns:
+//   - error: Error if the client is not initialized or the index does not exist
+func (c *Collection[T]) DropIndex(ctx context.Context, name string) error {
+	collection, err := c.collection()
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.Indexes().DropOne(ctx, name)
+	return err
+}
+
+// SetJSONSchema sets the JSON-schema validator of the collection, creating the collection if needed.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - schema: The $jsonSchema document, or nil to remove the validator
+//   - validationLevel: "strict" (all inserts and updates), "moderate" (only documents that are already valid), "off", or "" for the server default "strict"
+//   - validationAction: "error" (reject invalid documents), "warn" (log them), or "" for the server default "error"
+//
+// Returns:
+//   - error: Error if the client is not initialized or the schema is rejected
+//
+// Existing documents are not checked when the validator is set.
+//
+// Example:
+//
+//	err := users.SetJSONSchema(ctx, bson.M{
+//	    "bsonType": "object",
+//	    "required": bson.A{"name", "email"},
+//	    "properties": bson.M{
+//	        "name":  bson.M{"bsonType": "string"},
+//	        "email": bson.M{"bsonType": "string", "pattern": "^.+@.+$"},
+//	        "age":   bson.M{"bsonType": "int", "minimum": 0},
+//	    },
+//	}, "strict", "error")
+func (c *Collection[T]) SetJSONSchema(ctx context.Context, schema any, validationLevel, validationAction string) error {
+	collection, err := c.collection()
+	if err != nil {
+		return err
+	}
+
+	validator := bson.M{}
+	if schema != nil {
+		validator = bson.M{"$jsonSchema": schema}
+	}
+
+	command := bson.D{{Key: "collMod", Value: c.collectionName}, {Key: "validator", Value: validator}}
+	if validationLevel != "" {
+		command = append(command, bson.E{Key: "validationLevel", Value: validationLevel})
+	}
+	if validationAction != "" {
+		command = append(command, bson.E{Key: "validationAction", Value: validationAction})
+	}
+
+	err = collection.Database().RunCommand(ctx, command).Err()
+	if commandError := (mongo.CommandError{}); errors.As(err, &commandError) && commandError.Name == "NamespaceNotFound" {
+		createOptions := options.CreateCollection().SetValidator(validator)
+		if validationLevel != "" {
+			createOptions.SetValidationLevel(validationLevel)
+		}
+		if validationAction != "" {
+			createOptions.SetValidationAction(validationAction)
+		}
+
+		return collection.Database().CreateCollection(ctx, c.collectionName, createOptions)
+	}
+
+	return err
+}
+
+// Sync reconciles the indexes of the collection with the indexes declared on T.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//
+// Returns:
+//   - SyncResult: Names of the created and dropped indexes
+//   - error: Error if the client is not initialized, the declarations are invalid or an index cannot be changed
+//
+// The declared indexes are those of StructIndexes. Missing indexes are created, indexes whose keys
+// or options differ are dropped and recreated, and indexes that are not declared are dropped,
+// except for the _id index. Running Sync again without changes to T does nothing. Recreating
+// large indexes takes time, so run Sync on deployment rather than on every start of many instances.
+//
+// Example:
+//
+//	type User struct {
+//	    ID        primitive.ObjectID `bson:"_id,omitempty"`
+//	    Email     string             `bson:"email" index:",unique"`
+//	    TenantID  string             `bson:"tenant_id" index:"tenant_name"`
+//	    Name      string             `bson:"name" index:"tenant_name"`
+//	    CreatedAt time.Time          `bson:"created_at" index:",ttl=720h"`
+//	}
+//
+//	result, err := mongodb.NewCollection[User](&client, "mydb", "users").Sync(ctx)
+func (c *Collection[T]) Sync(ctx context.Context) (SyncResult, error) {
+	result := SyncResult{Created: []string{}, Dropped: []string{}}
+
+	desired, err := StructIndexes[T]()
+	if err != nil {
+		return result, err
+	}
+
+	existing, err := c.ListIndexes(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	existingByName := map[string]Index{}
+	for _, index := range existing {
+		existingByName[index.Name] = index
+	}
+
+	desiredNames := map[string]bool{"_id_": true}
+	create := []Index{}
+	for _, index := range desired {
+		desiredNames[index.name()] = true
+
+		if current, ok := existingByName[index.name()]; !ok {
+			create = append(create, index)
+		} else if !index.equal(current) {
+			if err := c.DropIndex(ctx, current.Name); err != nil {
+				return result, err
+			}
+			result.Dropped = append(result.Dropped, current.Name)
+			create = append(create, index)
+		}
+	}
+
+	for _, index := range existing {
+		if !desiredNames[index.Name] {
+			if err := c.DropIndex(ctx, index.Name); err != nil {
+				return result, err
+			}
+			result.Dropped = append(result.Dropped, index.Name)
+		}
+	}
+
+	names, err := c.CreateIndexes(ctx, create...)
+	if err != nil {
+		return result, err
+	}
+	result.Created = append(result.Created, names...)
+
+	return result, nil
+}
+
+// StructIndexes returns the indexes declared on the document type T.
+//
+// Returns:
+//   - []Index: Declared indexes in field order, followed by those of the Indexes method
+//   - error: Error if a tag is invalid or two declarations use the same index name differently
+//
+// Indexes are declared with index tags on the fields of T. A tag holds one or more declarations
+// separated by ";", each made of an index name followed by comma separated options:
+//   - unique: the index is unique
+//   - sparse: the index is sparse
+//   - desc: the field is indexed in descending order
+//   - text: the field is part of a text index
+//   - ttl=<duration>: documents expire this long after the date in the field (e.g., ttl=24h)
+//
+// Declarations with an empty name are single field indexes named by the server default (e.g.,
+// "email_1"). Fields sharing a name form one compound index with the fields in struct order and
+// the options of all declarations. Field names are taken from the bson tags, and fields of
+// structs embedded with ",inline" are included. If T or *T implements IndexDefiner, its indexes
+// are added.
+//
+// Example:
+//
+//	type Order struct {
+//	    CustomerID string    `bson:"customer_id" index:"customer_created"`
+//	    CreatedAt  time.Time `bson:"created_at" index:"customer_created,desc;,ttl=2160h"`
+//	    Number     string    `bson:"number" index:",unique"`
+//	}
+//
+//	// customer_created: {customer_id: 1, created_at: -1}
+//	// created_at_1: TTL of 90 days
+//	// number_1: unique
+//	indexes, err := mongodb.StructIndexes[Order]()
+func StructIndexes[T any]() ([]Index, error) {
+	indexes := []Index{}
+	named := map[string]int{}
+
+	var collect func(structType reflect.Type, prefix string) error
+	collect = func(structType reflect.Type, prefix string) error {
+		for i := range structType.NumField() {
+			field := structType.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			fieldName, inline := bsonFieldName(field)
+			if fieldName == "-" {
+				continue
+			}
+
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if inline && fieldType.Kind() == reflect.Struct {
+				if err := collect(fieldType, prefix); err != nil {
+					return err
+				}
+				continue
+			}
+
+			tag, ok := field.Tag.Lookup("index")
+			if !ok {
+				continue
+			}
+
+			for declaration := range strings.SplitSeq(tag, ";") {
+				index, err := parseIndexTag(prefix+fieldName, declaration)
+				if err != nil {
+					return fmt.Errorf("field %s: %w", field.Name, err)
+				}
+
+				if index.Name == "" {
+					indexes = append(indexes, index)
+				} else if position, ok := named[index.Name]; !ok {
+					named[index.Name] = len(indexes)
+					indexes = append(indexes, index)
+				} else {
+					merged := &indexes[position]
+					if index.ExpireAfter > 0 && merged.ExpireAfter > 0 && index.ExpireAfter != merged.ExpireAfter {
+						return fmt.Errorf("field %s: index %s has different ttl values", field.Name, index.Name)
+					}
+
+					merged.Keys = append(merged.Keys, index.Keys...)
+					merged.Unique = merged.Unique || index.Unique
+					merged.Sparse = merged.Sparse || index.Sparse
+					merged.ExpireAfter = max(merged.ExpireAfter, index.ExpireAfter)
+				}
+			}
+		}
+
+		return nil
+	}
+
+	documentType := reflect.TypeFor[T]()
+	if documentType.Kind() == reflect.Pointer {
+		documentType = documentType.Elem()
+	}
+	if documentType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", documentType)
+	}
+
+	if err := collect(documentType, ""); err != nil {
+		return nil, err
+	}
+
+	var document T
+	if definer, ok := any(document).(IndexDefiner); ok {
+		indexes = append(indexes, definer.Indexes()...)
+	} else if definer, ok := any(&document).(IndexDefiner); ok {
+		indexes = append(indexes, definer.Indexes()...)
+	}
+
+	seen := map[string]bool{}
+	for _, index := range indexes {
+		if seen[index.name()] {
+			return nil, fmt.Errorf("index %s is declared more than once", index.name())
+		}
+		seen[index.name()] = true
+	}
+
+	return indexes, nil
+}
+
+// bsonFieldName returns the BSON name of a struct field as the driver encodes it and whether it is inlined.
+func bsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("bson")
+	name, options, _ := strings.Cut(tag, ",")
+
+	inline := false
+	for option := range strings.SplitSeq(options, ",") {
+		inline = inline || option == "inline"
+	}
+
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	return name, inline
+}
+
+// parseIndexTag parses one index declaration of a field.
+func parseIndexTag(fieldName, declaration string) (Index, error) {
+	items := strings.Split(declaration, ",")
+
+	index := Index{Name: strings.TrimSpace(items[0])}
+	var value any = 1
+
+	for _, item := range items[1:] {
+		option, argument, _ := strings.Cut(strings.TrimSpace(item), "=")
+
+		switch option {
+		case "unique":
+			index.Unique = true
+		case "sparse":
+			index.Sparse = true
+		case "desc":
+			value = -1
+		case "text":
+			value = "text"
+		case "ttl":
+			expireAfter, err := time.ParseDuration(argument)
+			if err != nil || expireAfter < time.Second {
+				return Index{}, fmt.Errorf("invalid ttl %q", argument)
+			}
+			index.ExpireAfter = expireAfter
+		case "":
+		default:
+			return Index{}, fmt.Errorf("unknown index option %q", option)
+		}
+	}
+
+	index.Keys = bson.D{{Key: fieldName, Value: value}}
+
+	return index, nil
+}
+
+// CreateIndex creates an ascending index on a single field.
+//
+// Parameters:
+//   - databaseName: Name of the database
+//   - collectionName: Name of the collection
+//   - field: Field to index
+//   - unique: Whether the index rejects duplicate values
+//
+// Returns error if client is not initialized, connection fails, or index creation fails.
+//
+// Example:
+//
+//	err := client.CreateIndex("mydb", "users", "email", true)
+func (c *Client) CreateIndex(databaseName, collectionName, field string, unique bool) error {
+	return c.CreateIndexes(databaseName, collectionName, []string{field}, unique)
+}
+
+// CreateIndexes creates an ascending compound index on multiple fields.
+//
+// Parameters:
+//   - databaseName: Name of the database
+//   - collectionName: Name of the collection
+//   - fields: Fields to index, in order
+//   - unique: Whether the index rejects duplicate combinations of values
+//
+// Returns error if client is not initialized, connection fails, or index creation fails.
+//
+// Use Collection.CreateIndexes for descending, TTL, sparse or partial indexes.
+//
+// Example:
+//
+//	err := client.CreateIndexes("mydb", "orders", []string{"user_id", "created_at"}, false)
+func (c *Client) CreateIndexes(databaseName, collectionName string, fields []string, unique bool) error {
+	if len(fields) == 0 {
+		return errors.New("at least one field is required")
+	}
+
+	client, ctx, err := c.connection()
+	if err != nil {
+		return err
+	}
+
+	index := Index{Keys: bson.D{}, Unique: unique}
+	for _, field := range fields {
+		index.Keys = append(index.Keys, bson.E{Key: field, Value: 1})
+	}
+
+	collection := client.Database(databaseName).Collection(collectionName)
+	if _, err := collection.Indexes().CreateOne(ctx, index.model()); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package mongodb_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/mongodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IndexedBase struct {
+	TenantID string `bson:"tenant_id" index:"tenant_name"`
+}
+
+type indexedUser struct {
+	IndexedBase `bson:",inline"`
+
+	ID        string    `bson:"_id,omitempty"`
+	Name      string    `bson:"name" index:"tenant_name,desc;name_text,text"`
+	Email     string    `bson:"email" index:",unique,sparse"`
+	Bio       string    `bson:"bio" index:"name_text,text"`
+	CreatedAt time.Time `bson:"created_at" index:",ttl=24h"`
+	Ignored   string    `bson:"-" index:",unique"`
+	Nickname  string    `index:""`
+}
+
+func (indexedUser) Indexes() []mongodb.Index {
+	return []mongodb.Index{
+		{Name: "active_email", Keys: bson.D{{Key: "email", Value: 1}, {Key: "created_at", Value: -1}}, PartialFilter: bson.M{"active": true}},
+	}
+}
+
+func TestStructIndexes(t *testing.T) {
+	indexes, err := mongodb.StructIndexes[indexedUser]()
+	require.NoError(t, err)
+
+	assert.Equal(t, []mongodb.Index{
+		{Name: "tenant_name", Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "name", Value: -1}}},
+		{Name: "name_text", Keys: bson.D{{Key: "name", Value: "text"}, {Key: "bio", Value: "text"}}},
+		{Keys: bson.D{{Key: "email", Value: 1}}, Unique: true, Sparse: true},
+		{Keys: bson.D{{Key: "created_at", Value: 1}}, ExpireAfter: 24 * time.Hour},
+		{Keys: bson.D{{Key: "nickname", Value: 1}}},
+		{Name: "active_email", Keys: bson.D{{Key: "email", Value: 1}, {Key: "created_at", Value: -1}}, PartialFilter: bson.M{"active": true}},
+	}, indexes)
+
+	type invalidOption struct {
+		Name string `bson:"name" index:",unknown"`
+	}
+	_, err = mongodb.StructIndexes[invalidOption]()
+	assert.ErrorContains(t, err, "unknown index option")
+
+	type invalidTTL struct {
+		CreatedAt time.Time `bson:"created_at" index:",ttl=soon"`
+	}
+	_, err = mongodb.StructIndexes[invalidTTL]()
+	assert.ErrorContains(t, err, "invalid ttl")
+
+	type duplicate struct {
+		Name  string `bson:"name" index:""`
+		Other string `bson:"other" index:"name_1"`
+	}
+	_, err = mongodb.StructIndexes[duplicate]()
+	assert.ErrorContains(t, err, "declared more than once")
+
+	_, err = mongodb.StructIndexes[string]()
+	assert.Error(t, err)
+}
+
+func TestCollection_CreateAndListIndexes(t *testing.T) {
+	ctx := context.Background()
+	client, users := newCollection(t, "index_users")
+
+	// TestStruct declares no indexes, so syncing drops the indexes created here
+	defer users.Sync(ctx)
+
+	names, err := users.CreateIndexes(ctx,
+		mongodb.Index{Keys: bson.D{{Key: "email", Value: 1}}, Unique: true},
+		mongodb.Index{Keys: bson.D{{Key: "age", Value: 1}}, ExpireAfter: time.Hour},
+		mongodb.Index{Name: "adult_name", Keys: bson.D{{Key: "name", Value: -1}}, PartialFilter: bson.M{"age": bson.M{"$gte": 18}}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"email_1", "age_1", "adult_name"}, names)
+
+	indexes, err := users.ListIndexes(ctx)
+	require.NoError(t, err)
+	require.Len(t, indexes, 4)
+
+	byName := map[string]mongodb.Index{}
+	for _, index := range indexes {
+		byName[index.Name] = index
+	}
+	assert.Contains(t, byName, "_id_")
+	assert.True(t, byName["email_1"].Unique)
+	assert.Equal(t, time.Hour, byName["age_1"].ExpireAfter)
+	assert.NotNil(t, byName["adult_name"].PartialFilter)
+
+	_, err = users.InsertOne(ctx, TestStruct{ID: "u1", Email: "same@example.com"})
+	require.NoError(t, err)
+	_, err = users.InsertOne(ctx, TestStruct{ID: "u2", Email: "same@example.com"})
+	assert.True(t, mongo.IsDuplicateKeyError(err))
+
+	require.NoError(t, users.DropIndex(ctx, "email_1"))
+	assert.Error(t, users.DropIndex(ctx, "email_1"))
+
+	require.NoError(t, client.CreateIndex("testdb", "index_users", "email", false))
+	require.NoError(t, client.CreateIndexes("testdb", "index_users", []string{"name", "age"}, true))
+	assert.Error(t, client.CreateIndexes("testdb", "index_users", []string{}, true))
+
+	indexes, err = users.ListIndexes(ctx)
+	require.NoError(t, err)
+	names = []string{}
+	for _, index := range indexes {
+		names = append(names, index.Name)
+	}
+	assert.ElementsMatch(t, []string{"_id_", "age_1", "adult_name", "email_1", "name_1_age_1"}, names)
+
+	missing := mongodb.NewCollection[TestStruct](client, "testdb", "index_missing")
+	indexes, err = missing.ListIndexes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, indexes)
+}
+
+func TestCollection_Sync(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newCollection(t, "index_sync")
+	users := mongodb.NewCollection[indexedUser](client, "testdb", "index_sync")
+
+	_, err := users.CreateIndexes(ctx,
+		mongodb.Index{Keys: bson.D{{Key: "legacy", Value: 1}}},
+		mongodb.Index{Keys: bson.D{{Key: "created_at", Value: 1}}, ExpireAfter: time.Hour},
+	)
+	require.NoError(t, err)
+
+	result, err := users.Sync(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"legacy_1", "created_at_1"}, result.Dropped)
+	assert.ElementsMatch(t, []string{"tenant_name", "name_text", "email_1", "created_at_1", "nickname_1", "active_email"}, result.Created)
+
+	indexes, err := users.ListIndexes(ctx)
+	require.NoError(t, err)
+	assert.Len(t, indexes, 7)
+	for _, index := range indexes {
+		if index.Name == "created_at_1" {
+			assert.Equal(t, 24*time.Hour, index.ExpireAfter)
+		}
+	}
+
+	result, err = users.Sync(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Created)
+	assert.Empty(t, result.Dropped)
+
+	_, err = mongodb.NewCollection[TestStruct](&mongodb.Client{}, "testdb", "index_sync").Sync(ctx)
+	assert.ErrorContains(t, err, "please call Initialize first")
+}
+
+func TestCollection_SetJSONSchema(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newCollection(t, "schema_users")
+	users := mongodb.NewCollection[bson.M](client, "testdb", "schema_users")
+
+	schema := bson.M{
+		"bsonType": "object",
+		"required": bson.A{"name"},
+		"properties": bson.M{
+			"name": bson.M{"bsonType": "string"},
+			"age":  bson.M{"bsonType": "int", "minimum": 0},
+		},
+	}
+	require.NoError(t, users.SetJSONSchema(ctx, schema, "strict", "error"))
+	defer users.SetJSONSchema(ctx, nil, "", "")
+
+	_, err := users.InsertOne(ctx, bson.M{"name": "Alice", "age": 30})
+	assert.NoError(t, err)
+	_, err = users.InsertOne(ctx, bson.M{"age": 30})
+	assert.Error(t, err)
+	_, err = users.InsertOne(ctx, bson.M{"name": "Bob", "age": -1})
+	assert.Error(t, err)
+
+	require.NoError(t, users.SetJSONSchema(ctx, schema, "", "warn"))
+	_, err = users.InsertOne(ctx, bson.M{"age": 30})
+	assert.NoError(t, err)
+
+	require.NoError(t, users.SetJSONSchema(ctx, nil, "", "error"))
+	_, err = users.InsertOne(ctx, bson.M{"age": -1})
+	assert.NoError(t, err)
+
+	created := mongodb.NewCollection[bson.M](client, "testdb", "schema_created_"+strconv.FormatInt(time.Now().UnixNano(), 10))
+	require.NoError(t, created.SetJSONSchema(ctx, schema, "", ""))
+	_, err = created.InsertOne(ctx, bson.M{"age": 1})
+	assert.Error(t, err)
+}
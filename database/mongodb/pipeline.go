@@ -0,0 +1,305 @@
+package mongodb
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Pipeline builds an aggregation pipeline stage by stage.
+//
+// Each method appends one stage and returns the pipeline, so calls can be chained. Stages run in
+// the order they were added. The zero value is an empty pipeline ready to use.
+//
+// Example:
+//
+//	pipeline := mongodb.NewPipeline().
+//	    Match(bson.M{"status": "active"}).
+//	    Group("$department", bson.M{"count": bson.M{"$sum": 1}, "avgAge": bson.M{"$avg": "$age"}}).
+//	    Sort(bson.D{{"count", -1}}).
+//	    Limit(10)
+type Pipeline struct {
+	stages mongo.Pipeline
+}
+
+// NewPipeline creates an empty aggregation pipeline.
+//
+// Returns:
+//   - *Pipeline: Pipeline without stages
+func NewPipeline() *Pipeline {
+	return &Pipeline{stages: mongo.Pipeline{}}
+}
+
+// Stage appends a stage that has no dedicated method.
+//
+// Parameters:
+//   - name: Stage operator including the dollar sign (e.g., "$bucket")
+//   - value: Stage specification
+//
+// Example:
+//
+//	pipeline.Stage("$bucket", bson.M{"groupBy": "$age", "boundaries": bson.A{0, 18, 65, 120}})
+func (p *Pipeline) Stage(name string, value any) *Pipeline {
+	p.stages = append(p.stages, bson.D{{Key: name, Value: value}})
+	return p
+}
+
+// Match appends a $match stage keeping the documents that match filter.
+//
+// Put $match stages first so they can use indexes.
+func (p *Pipeline) Match(filter any) *Pipeline {
+	return p.Stage("$match", filter)
+}
+
+// Group appends a $group stage grouping documents by id.
+//
+// Parameters:
+//   - id: Group key expression (e.g., "$department", bson.M{"year": bson.M{"$year": "$created_at"}}), or nil for one group
+//   - accumulators: Output fields and their accumulators (e.g., bson.M{"total": bson.M{"$sum": "$amount"}})
+//
+// The group key is written to the _id field of the results.
+func (p *Pipeline) Group(id any, accumulators bson.M) *Pipeline {
+	group := bson.D{{Key: "_id", Value: id}}
+	for _, key := range slices.Sorted(maps.Keys(accumulators)) {
+		group = append(group, bson.E{Key: key, Value: accumulators[key]})
+	}
+
+	return p.Stage("$group", group)
+}
+
+// Lookup appends a $lookup stage joining the documents of another collection by equality.
+//
+// Parameters:
+//   - from: Collection to join in the same database
+//   - localField: Field of the input documents
+//   - foreignField: Field of the documents in from
+//   - as: Array field receiving the matching documents
+func (p *Pipeline) Lookup(from, localField, foreignField, as string) *Pipeline {
+	return p.Stage("$lookup", bson.D{
+		{Key: "from", Value: from},
+		{Key: "localField", Value: localField},
+		{Key: "foreignField", Value: foreignField},
+		{Key: "as", Value: as},
+	})
+}
+
+// LookupPipeline appends a $lookup stage joining the results of a pipeline run on another collection.
+//
+// Parameters:
+//   - from: Collection to join in the same database
+//   - let: Variables from the input document available as $$name in pipeline, or nil
+//   - pipeline: Pipeline run on from for each input document
+//   - as: Array field receiving the results
+//
+// Example:
+//
+//	pipeline.LookupPipeline("orders", bson.M{"userID": "$_id"},
+//	    mongodb.NewPipeline().
+//	        Match(bson.M{"$expr": bson.M{"$eq": bson.A{"$user_id", "$$userID"}}}).
+//	        Sort(bson.D{{"created_at", -1}}).
+//	        Limit(5),
+//	    "recentOrders")
+func (p *Pipeline) LookupPipeline(from string, let bson.M, pipeline *Pipeline, as string) *Pipeline {
+	lookup := bson.D{{Key: "from", Value: from}}
+	if let != nil {
+		lookup = append(lookup, bson.E{Key: "let", Value: let})
+	}
+	lookup = append(lookup, bson.E{Key: "pipeline", Value: pipeline.Stages()}, bson.E{Key: "as", Value: as})
+
+	return p.Stage("$lookup", lookup)
+}
+
+// Project appends a $project stage including, excluding or computing fields.
+func (p *Pipeline) Project(projection any) *Pipeline {
+	return p.Stage("$project", projection)
+}
+
+// AddFields appends an $addFields stage adding or replacing fields.
+func (p *Pipeline) AddFields(fields any) *Pipeline {
+	return p.Stage("$addFields", fields)
+}
+
+// Unset appends an $unset stage removing fields.
+func (p *Pipeline) Unset(fields ...string) *Pipeline {
+	return p.Stage("$unset", fields)
+}
+
+// Unwind appends an $unwind stage producing one document per element of an array field.
+//
+// Parameters:
+//   - path: Array field path prefixed with a dollar sign (e.g., "$tags")
+//   - preserveNullAndEmptyArrays: Whether documents with a missing, null or empty array are kept
+func (p *Pipeline) Unwind(path string, preserveNullAndEmptyArrays bool) *Pipeline {
+	return p.Stage("$unwind", bson.D{
+		{Key: "path", Value: path},
+		{Key: "preserveNullAndEmptyArrays", Value: preserveNullAndEmptyArrays},
+	})
+}
+
+// Sort appends a $sort stage (e.g., bson.D{{"count", -1}, {"_id", 1}}).
+//
+// Use bson.D rather than bson.M for more than one key, since map order is random.
+func (p *Pipeline) Sort(sort any) *Pipeline {
+	return p.Stage("$sort", sort)
+}
+
+// SortByCount appends a $sortByCount stage counting the documents per value of expression.
+func (p *Pipeline) SortByCount(expression any) *Pipeline {
+	return p.Stage("$sortByCount", expression)
+}
+
+// Skip appends a $skip stage.
+func (p *Pipeline) Skip(skip int64) *Pipeline {
+	return p.Stage("$skip", skip)
+}
+
+// Limit appends a $limit stage.
+func (p *Pipeline) Limit(limit int64) *Pipeline {
+	return p.Stage("$limit", limit)
+}
+
+// Sample appends a $sample stage picking size random documents.
+func (p *Pipeline) Sample(size int64) *Pipeline {
+	return p.Stage("$sample", bson.D{{Key: "size", Value: size}})
+}
+
+// Count appends a $count stage writing the number of documents to field.
+func (p *Pipeline) Count(field string) *Pipeline {
+	return p.Stage("$count", field)
+}
+
+// ReplaceRoot appends a $replaceRoot stage replacing each document with newRoot (e.g., "$profile").
+func (p *Pipeline) ReplaceRoot(newRoot any) *Pipeline {
+	return p.Stage("$replaceRoot", bson.D{{Key: "newRoot", Value: newRoot}})
+}
+
+// Facet appends a $facet stage running several pipelines on the same input documents.
+//
+// Parameters:
+//   - facets: Output field names and the pipelines producing them
+//
+// The result is a single document with one array field per facet.
+//
+// Example:
+//
+//	pipeline.Facet(map[string]*mongodb.Pipeline{
+//	    "items": mongodb.NewPipeline().Skip(20).Limit(10),
+//	    "total": mongodb.NewPipeline().Count("count"),
+//	})
+func (p *Pipeline) Facet(facets map[string]*Pipeline) *Pipeline {
+	facet := bson.D{}
+	for _, key := range slices.Sorted(maps.Keys(facets)) {
+		facet = append(facet, bson.E{Key: key, Value: facets[key].Stages()})
+	}
+
+	return p.Stage("$facet", facet)
+}
+
+// Stages returns the stages of the pipeline.
+//
+// Returns:
+//   - mongo.Pipeline: Copy of the stages, usable with the driver directly
+func (p *Pipeline) Stages() mongo.Pipeline {
+	if p == nil {
+		return mongo.Pipeline{}
+	}
+
+	return append(mongo.Pipeline{}, p.stages...)
+}
+
+// Aggregate runs an aggregation pipeline on a collection and decodes the results into R.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - collection: Collection the pipeline runs on
+//   - pipeline: Aggregation pipeline
+//
+// Returns:
+//   - []R: Results in pipeline order, empty if there are none
+//   - error: Error if the client is not initialized, the pipeline fails or a result cannot be decoded
+//
+// The result type usually differs from the document type of the collection, so it is given
+// explicitly while the collection type is inferred. All results are held in memory; end the
+// pipeline with $limit or use the driver for very large results.
+//
+// Example:
+//
+//	type DepartmentStats struct {
+//	    Department string  `bson:"_id"`
+//	    Count      int     `bson:"count"`
+//	    AvgAge     float64 `bson:"avgAge"`
+//	}
+//
+//	stats, err := mongodb.Aggregate[DepartmentStats](ctx, users,
+//	    mongodb.NewPipeline().
+//	        Group("$department", bson.M{"count": bson.M{"$sum": 1}, "avgAge": bson.M{"$avg": "$age"}}).
+//	        Sort(bson.D{{"count", -1}}))
+func Aggregate[R, T any](ctx context.Context, collection *Collection[T], pipeline *Pipeline) ([]R, error) {
+	driverCollection, err := collection.collection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := driverCollection.Aggregate(ctx, pipeline.Stages())
+	if err != nil {
+		return nil, err
+	}
+
+	results := []R{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Aggregate runs an aggregation pipeline and returns the results as the specified type.
+//
+// Parameters:
+//   - databaseName: Name of the database
+//   - collectionName: Name of the collection
+//   - pipeline: *Pipeline, mongo.Pipeline or a slice of stage documents (e.g., []bson.M)
+//   - dataForm: Template of the result type (e.g., AggResult{})
+//
+// Returns a slice of the template type as any (e.g., []AggResult), or an error if the client is
+// not initialized or the pipeline fails.
+//
+// Example:
+//
+//	pipeline := mongodb.NewPipeline().
+//	    Match(bson.M{"age": bson.M{"$gte": 25}}).
+//	    Group("$department", bson.M{"count": bson.M{"$sum": 1}})
+//
+//	results, err := client.Aggregate("mydb", "users", pipeline, AggResult{})
+//	aggResults := results.([]AggResult)
+func (c *Client) Aggregate(databaseName, collectionName string, pipeline any, dataForm any) (any, error) {
+	client, ctx, err := c.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	if p, ok := pipeline.(*Pipeline); ok {
+		pipeline = p.Stages()
+	}
+
+	collection := client.Database(databaseName).Collection(collectionName)
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	dataType := reflect.TypeOf(dataForm)
+	results := reflect.New(reflect.SliceOf(dataType))
+	results.Elem().Set(reflect.MakeSlice(reflect.SliceOf(dataType), 0, 0))
+
+	if err := cursor.All(ctx, results.Interface()); err != nil {
+		return nil, err
+	}
+
+	return results.Elem().Interface(), nil
+}
@@ -0,0 +1,188 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/common-library/go/database/mongodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestPipeline_Stages(t *testing.T) {
+	pipeline := mongodb.NewPipeline().
+		Match(bson.M{"age": bson.M{"$gte": 18}}).
+		Group("$department", bson.M{"count": bson.M{"$sum": 1}, "avg": bson.M{"$avg": "$age"}}).
+		Sort(bson.D{{Key: "count", Value: -1}}).
+		Limit(5)
+
+	assert.Equal(t, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"age": bson.M{"$gte": 18}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$department"},
+			{Key: "avg", Value: bson.M{"$avg": "$age"}},
+			{Key: "count", Value: bson.M{"$sum": 1}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}}}},
+		{{Key: "$limit", Value: int64(5)}},
+	}, pipeline.Stages())
+
+	stages := pipeline.Stages()
+	stages[0] = bson.D{}
+	assert.NotEqual(t, stages, pipeline.Stages())
+
+	var empty *mongodb.Pipeline
+	assert.Empty(t, empty.Stages())
+	assert.Empty(t, (&mongodb.Pipeline{}).Stages())
+}
+
+type departmentStats struct {
+	Department string  `bson:"_id"`
+	Count      int     `bson:"count"`
+	AvgAge     float64 `bson:"avgAge"`
+}
+
+func insertPipelineTestData(t *testing.T) (*mongodb.Client, *mongodb.Collection[TestStruct]) {
+	t.Helper()
+
+	ctx := context.Background()
+	client, users := newCollection(t, "pipeline_users")
+
+	for _, user := range []TestStruct{
+		{ID: "u1", Name: "Alice", Age: 30, Email: "engineering"},
+		{ID: "u2", Name: "Bob", Age: 40, Email: "engineering"},
+		{ID: "u3", Name: "Carol", Age: 25, Email: "sales"},
+		{ID: "u4", Name: "Dave", Age: 17, Email: "sales"},
+	} {
+		_, err := users.InsertOne(ctx, user)
+		require.NoError(t, err)
+	}
+
+	return client, users
+}
+
+func TestAggregate(t *testing.T) {
+	ctx := context.Background()
+	_, users := insertPipelineTestData(t)
+
+	stats, err := mongodb.Aggregate[departmentStats](ctx, users,
+		mongodb.NewPipeline().
+			Match(bson.M{"age": bson.M{"$gte": 18}}).
+			Group("$email", bson.M{"count": bson.M{"$sum": 1}, "avgAge": bson.M{"$avg": "$age"}}).
+			Sort(bson.D{{Key: "count", Value: -1}}))
+	require.NoError(t, err)
+	assert.Equal(t, []departmentStats{
+		{Department: "engineering", Count: 2, AvgAge: 35},
+		{Department: "sales", Count: 1, AvgAge: 25},
+	}, stats)
+
+	names, err := mongodb.Aggregate[bson.M](ctx, users,
+		mongodb.NewPipeline().
+			Sort(bson.D{{Key: "age", Value: 1}}).
+			Skip(1).
+			Limit(2).
+			Project(bson.M{"_id": 0, "name": 1}))
+	require.NoError(t, err)
+	assert.Equal(t, []bson.M{{"name": "Carol"}, {"name": "Alice"}}, names)
+
+	empty, err := mongodb.Aggregate[bson.M](ctx, users, mongodb.NewPipeline().Match(bson.M{"age": 100}))
+	require.NoError(t, err)
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+
+	_, err = mongodb.Aggregate[bson.M](ctx, users, mongodb.NewPipeline().Stage("$invalid", bson.M{}))
+	assert.Error(t, err)
+}
+
+func TestAggregate_LookupAndFacet(t *testing.T) {
+	ctx := context.Background()
+	client, users := insertPipelineTestData(t)
+
+	require.NoError(t, client.DeleteMany("testdb", "pipeline_orders", bson.M{}))
+	defer client.DeleteMany("testdb", "pipeline_orders", bson.M{})
+	require.NoError(t, client.InsertMany("testdb", "pipeline_orders", []any{
+		bson.M{"_id": "o1", "user_id": "u1", "amount": 10},
+		bson.M{"_id": "o2", "user_id": "u1", "amount": 20},
+		bson.M{"_id": "o3", "user_id": "u3", "amount": 5},
+	}))
+
+	type userOrders struct {
+		Name   string   `bson:"name"`
+		Orders []bson.M `bson:"orders"`
+		Total  int      `bson:"total"`
+	}
+
+	results, err := mongodb.Aggregate[userOrders](ctx, users,
+		mongodb.NewPipeline().
+			Lookup("pipeline_orders", "_id", "user_id", "orders").
+			AddFields(bson.M{"total": bson.M{"$sum": "$orders.amount"}}).
+			Sort(bson.D{{Key: "total", Value: -1}, {Key: "name", Value: 1}}))
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, "Alice", results[0].Name)
+	assert.Len(t, results[0].Orders, 2)
+	assert.Equal(t, 30, results[0].Total)
+	assert.Equal(t, "Carol", results[1].Name)
+	assert.Equal(t, 5, results[1].Total)
+
+	type largestOrder struct {
+		Name  string `bson:"name"`
+		Order []struct {
+			Amount int `bson:"amount"`
+		} `bson:"order"`
+	}
+
+	largest, err := mongodb.Aggregate[largestOrder](ctx, users,
+		mongodb.NewPipeline().
+			Match(bson.M{"_id": "u1"}).
+			LookupPipeline("pipeline_orders", bson.M{"userID": "$_id"},
+				mongodb.NewPipeline().
+					Match(bson.M{"$expr": bson.M{"$eq": bson.A{"$user_id", "$$userID"}}}).
+					Sort(bson.D{{Key: "amount", Value: -1}}).
+					Limit(1),
+				"order"))
+	require.NoError(t, err)
+	require.Len(t, largest, 1)
+	require.Len(t, largest[0].Order, 1)
+	assert.Equal(t, 20, largest[0].Order[0].Amount)
+
+	type page struct {
+		Items []TestStruct `bson:"items"`
+		Total []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+	}
+
+	pages, err := mongodb.Aggregate[page](ctx, users,
+		mongodb.NewPipeline().
+			Sort(bson.D{{Key: "name", Value: 1}}).
+			Facet(map[string]*mongodb.Pipeline{
+				"items": mongodb.NewPipeline().Skip(1).Limit(2),
+				"total": mongodb.NewPipeline().Count("count"),
+			}))
+	require.NoError(t, err)
+	require.Len(t, pages, 1)
+	require.Len(t, pages[0].Items, 2)
+	assert.Equal(t, "Bob", pages[0].Items[0].Name)
+	assert.Equal(t, "Carol", pages[0].Items[1].Name)
+	require.Len(t, pages[0].Total, 1)
+	assert.Equal(t, 4, pages[0].Total[0].Count)
+}
+
+func TestClient_Aggregate(t *testing.T) {
+	client, _ := insertPipelineTestData(t)
+
+	results, err := client.Aggregate("testdb", "pipeline_users",
+		mongodb.NewPipeline().Match(bson.M{"email": "sales"}).SortByCount("$email"), bson.M{})
+	require.NoError(t, err)
+	assert.Equal(t, []bson.M{{"_id": "sales", "count": int32(2)}}, results)
+
+	results, err = client.Aggregate("testdb", "pipeline_users", []bson.M{{"$count": "count"}}, bson.M{})
+	require.NoError(t, err)
+	assert.Equal(t, []bson.M{{"count": int32(4)}}, results)
+
+	_, err = (&mongodb.Client{}).Aggregate("testdb", "pipeline_users", []bson.M{}, bson.M{})
+	assert.ErrorContains(t, err, "please call Initialize first")
+}
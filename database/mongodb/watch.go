@@ -0,0 +1,250 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	watchMinReconnectDelay = 100 * time.Millisecond
+	watchMaxReconnectDelay = 30 * time.Second
+)
+
+// ChangeEvent is a change stream event of a collection whose documents decode into T.
+type ChangeEvent[T any] struct {
+	// ResumeToken identifies the event; a stream started after it continues with the next event
+	ResumeToken bson.Raw `bson:"_id"`
+
+	// OperationType is the kind of change (e.g., "insert", "update", "replace", "delete", "invalidate")
+	OperationType string `bson:"operationType"`
+
+	// ClusterTime is the time of the change in the oplog
+	ClusterTime primitive.Timestamp `bson:"clusterTime"`
+
+	// DocumentKey holds the _id (and shard key) of the changed document
+	DocumentKey bson.M `bson:"documentKey"`
+
+	// FullDocument is the document after the change, nil for deletes or if it was deleted since
+	FullDocument *T `bson:"fullDocument"`
+
+	// UpdateDescription lists the changed fields of "update" events
+	UpdateDescription *UpdateDescription `bson:"updateDescription"`
+}
+
+// UpdateDescription describes the fields changed by an update.
+type UpdateDescription struct {
+	// UpdatedFields maps the dotted paths of set fields to their new values
+	UpdatedFields bson.M `bson:"updatedFields"`
+
+	// RemovedFields lists the dotted paths of removed fields
+	RemovedFields []string `bson:"removedFields"`
+}
+
+// ResumeTokenStore persists the position of a change stream consumer.
+//
+// Implementations must be safe to call from the goroutine of Watch.
+type ResumeTokenStore interface {
+	// Load returns the last saved token, or nil if there is none
+	Load(ctx context.Context) (bson.Raw, error)
+
+	// Save replaces the saved token
+	Save(ctx context.Context, token bson.Raw) error
+}
+
+// CollectionResumeTokenStore is a ResumeTokenStore keeping tokens in a MongoDB collection.
+//
+// Each consumer is one document identified by its name, so many consumers can share a collection.
+type CollectionResumeTokenStore struct {
+	tokens *Collection[resumeTokenDocument]
+	name   string
+}
+
+type resumeTokenDocument struct {
+	Name      string    `bson:"_id"`
+	Token     bson.Raw  `bson:"token"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// NewCollectionResumeTokenStore creates a resume token store backed by a collection.
+//
+// Parameters:
+//   - client: Initialized MongoDB client
+//   - databaseName: Name of the database
+//   - collectionName: Name of the collection holding the tokens (e.g., "resume_tokens")
+//   - name: Unique name of the consumer
+//
+// Returns:
+//   - *CollectionResumeTokenStore: Store reading and writing the document of name
+//
+// Example:
+//
+//	store := mongodb.NewCollectionResumeTokenStore(&client, "mydb", "resume_tokens", "order-indexer")
+func NewCollectionResumeTokenStore(client *Client, databaseName, collectionName, name string) *CollectionResumeTokenStore {
+	return &CollectionResumeTokenStore{
+		tokens: NewCollection[resumeTokenDocument](client, databaseName, collectionName),
+		name:   name,
+	}
+}
+
+// Load returns the saved token of the consumer, or nil if none was saved.
+func (s *CollectionResumeTokenStore) Load(ctx context.Context) (bson.Raw, error) {
+	document, err := s.tokens.FindOne(ctx, bson.M{"_id": s.name})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return document.Token, nil
+}
+
+// Save stores the token of the consumer.
+func (s *CollectionResumeTokenStore) Save(ctx context.Context, token bson.Raw) error {
+	_, err := s.tokens.Upsert(ctx, bson.M{"_id": s.name}, resumeTokenDocument{Name: s.name, Token: token, UpdatedAt: time.Now()})
+	return err
+}
+
+// Watch opens a change stream on the collection and delivers its events on the returned channel.
+//
+// Parameters:
+//   - ctx: Context that ends the change stream; the returned channel is closed when it is done
+//   - pipeline: Pipeline filtering or reshaping the events (e.g., a $match on operationType), or nil for all events
+//   - store: Store for the resume token, or nil to start with the changes after Watch
+//   - errorHandler: Function called with stream and store errors (can be nil)
+//
+// Returns:
+//   - <-chan ChangeEvent[T]: Events in the order of the changes
+//   - error: Error if the client is not initialized or the token cannot be loaded or the stream cannot be opened, nil on success
+//
+// Events of updates carry the current version of the document in FullDocument. When the stream
+// fails, Watch reports the error, waits with exponential backoff and opens a new stream resuming
+// after the last delivered event, so no events are missed or repeated.
+//
+// With a store, the stream starts after the saved token, so a consumer continues where it
+// stopped after a restart. The token of an event is saved when the reader receives the next
+// event, that is once the reader is done with it. Delivery is therefore at least once: after a
+// restart the last event is delivered again if its processing did not finish. Resuming fails
+// once the token has dropped out of the oplog; clear the stored token to start over. Change
+// streams require a replica set or a sharded cluster.
+//
+// Example:
+//
+//	store := mongodb.NewCollectionResumeTokenStore(&client, "mydb", "resume_tokens", "order-indexer")
+//
+//	events, err := orders.Watch(ctx,
+//	    mongodb.NewPipeline().Match(bson.M{"operationType": bson.M{"$in": bson.A{"insert", "update"}}}),
+//	    store, func(err error) { log.Println(err) })
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for event := range events {
+//	    index(event.FullDocument)
+//	}
+func (c *Collection[T]) Watch(ctx context.Context, pipeline *Pipeline, store ResumeTokenStore, errorHandler func(err error)) (<-chan ChangeEvent[T], error) {
+	if _, err := c.collection(); err != nil {
+		return nil, err
+	}
+
+	reportError := func(err error) {
+		if errorHandler != nil {
+			errorHandler(err)
+		}
+	}
+
+	var resumeToken bson.Raw
+	if store != nil {
+		token, err := store.Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resumeToken = token
+	}
+
+	open := func() (*mongo.ChangeStream, error) {
+		collection, err := c.collection()
+		if err != nil {
+			return nil, err
+		}
+
+		changeStreamOptions := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+		if resumeToken != nil {
+			changeStreamOptions.SetStartAfter(resumeToken)
+		}
+
+		return collection.Watch(ctx, pipeline.Stages(), changeStreamOptions)
+	}
+
+	stream, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan ChangeEvent[T])
+
+	go func() {
+		defer close(events)
+
+		// pending is the token of the last delivered event, saved once the reader takes the next one
+		var pending bson.Raw
+
+		for {
+			for stream.Next(ctx) {
+				event := ChangeEvent[T]{}
+				if err := stream.Decode(&event); err != nil {
+					reportError(err)
+					continue
+				}
+
+				delivered := false
+				select {
+				case events <- event:
+					delivered = true
+				case <-ctx.Done():
+				}
+				if !delivered {
+					break
+				}
+
+				if store != nil && pending != nil {
+					if err := store.Save(context.WithoutCancel(ctx), pending); err != nil {
+						reportError(err)
+					}
+				}
+				pending = event.ResumeToken
+			}
+
+			err := stream.Err()
+			if token := stream.ResumeToken(); token != nil {
+				resumeToken = token
+			}
+			stream.Close(context.WithoutCancel(ctx))
+
+			if ctx.Err() != nil {
+				return
+			} else if err != nil {
+				reportError(err)
+			}
+
+			for delay := watchMinReconnectDelay; ; delay = min(delay*2, watchMaxReconnectDelay) {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+
+				if stream, err = open(); err == nil {
+					break
+				}
+				reportError(err)
+			}
+		}
+	}()
+
+	return events, nil
+}
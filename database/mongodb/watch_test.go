@@ -0,0 +1,137 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/mongodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func receiveEvent[T any](t *testing.T, events <-chan mongodb.ChangeEvent[T]) mongodb.ChangeEvent[T] {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "events channel closed")
+		return event
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "no change event received")
+		return mongodb.ChangeEvent[T]{}
+	}
+}
+
+func TestCollection_Watch(t *testing.T) {
+	client := newReplicaSetClient(t)
+	users := mongodb.NewCollection[TestStruct](client, "testdb", "watch_users")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := users.Watch(ctx, nil, nil, func(err error) { t.Log(err) })
+	require.NoError(t, err)
+
+	_, err = users.InsertOne(ctx, TestStruct{ID: "u1", Name: "Alice", Age: 30})
+	require.NoError(t, err)
+
+	event := receiveEvent(t, events)
+	assert.Equal(t, "insert", event.OperationType)
+	assert.Equal(t, bson.M{"_id": "u1"}, event.DocumentKey)
+	require.NotNil(t, event.FullDocument)
+	assert.Equal(t, "Alice", event.FullDocument.Name)
+	assert.NotEmpty(t, event.ResumeToken)
+
+	_, err = users.FindOneAndUpdate(ctx, bson.M{"_id": "u1"}, bson.M{"$set": bson.M{"age": 31}, "$unset": bson.M{"email": ""}})
+	require.NoError(t, err)
+
+	event = receiveEvent(t, events)
+	assert.Equal(t, "update", event.OperationType)
+	require.NotNil(t, event.FullDocument)
+	assert.Equal(t, 31, event.FullDocument.Age)
+	require.NotNil(t, event.UpdateDescription)
+	assert.EqualValues(t, 31, event.UpdateDescription.UpdatedFields["age"])
+	assert.Equal(t, []string{"email"}, event.UpdateDescription.RemovedFields)
+
+	require.NoError(t, client.DeleteOne("testdb", "watch_users", bson.M{"_id": "u1"}))
+
+	event = receiveEvent(t, events)
+	assert.Equal(t, "delete", event.OperationType)
+	assert.Nil(t, event.FullDocument)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestCollection_WatchPipeline(t *testing.T) {
+	client := newReplicaSetClient(t)
+	users := mongodb.NewCollection[TestStruct](client, "testdb", "watch_pipeline")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := users.Watch(ctx, mongodb.NewPipeline().Match(bson.M{"operationType": "insert", "fullDocument.age": bson.M{"$gte": 18}}), nil, nil)
+	require.NoError(t, err)
+
+	_, err = users.InsertOne(ctx, TestStruct{ID: "child", Age: 10})
+	require.NoError(t, err)
+	_, err = users.InsertOne(ctx, TestStruct{ID: "adult", Age: 40})
+	require.NoError(t, err)
+
+	event := receiveEvent(t, events)
+	assert.Equal(t, "adult", event.FullDocument.ID)
+}
+
+func TestCollection_WatchResume(t *testing.T) {
+	client := newReplicaSetClient(t)
+	users := mongodb.NewCollection[TestStruct](client, "testdb", "watch_resume")
+	store := mongodb.NewCollectionResumeTokenStore(client, "testdb", "resume_tokens", "watch_resume")
+
+	token, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, token)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := users.Watch(ctx, nil, store, nil)
+	require.NoError(t, err)
+
+	for _, id := range []string{"u1", "u2", "u3"} {
+		_, err := users.InsertOne(context.Background(), TestStruct{ID: id})
+		require.NoError(t, err)
+	}
+
+	// u1 is done once u2 is received; u2 is received but not finished when the consumer stops
+	assert.Equal(t, "u1", receiveEvent(t, events).FullDocument.ID)
+	assert.Equal(t, "u2", receiveEvent(t, events).FullDocument.ID)
+
+	cancel()
+	for range events {
+	}
+
+	token, err = store.Load(context.Background())
+	require.NoError(t, err)
+	assert.NotNil(t, token)
+
+	_, err = users.InsertOne(context.Background(), TestStruct{ID: "u4"})
+	require.NoError(t, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err = users.Watch(ctx, nil, store, nil)
+	require.NoError(t, err)
+
+	for _, id := range []string{"u2", "u3", "u4"} {
+		assert.Equal(t, id, receiveEvent(t, events).FullDocument.ID)
+	}
+}
+
+func TestCollection_WatchNotInitialized(t *testing.T) {
+	users := mongodb.NewCollection[TestStruct](&mongodb.Client{}, "testdb", "users")
+
+	_, err := users.Watch(context.Background(), nil, nil, nil)
+	assert.ErrorContains(t, err, "please call Initialize first")
+}
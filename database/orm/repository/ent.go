@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+type entTxKey struct {
+	client any
+}
+
+// EntTx is a transaction of a generated ent client, such as *ent.Tx.
+type EntTx[C any] interface {
+	Client() C
+	Commit() error
+	Rollback() error
+}
+
+// EntQuery is a Query translated to ent. Convert Where and Orders to the predicate and order
+// types of the generated package, e.g., predicate.User(query.Where) and user.OrderOption(order).
+type EntQuery struct {
+	Where  func(*entsql.Selector)
+	Orders []func(*entsql.Selector)
+	Offset int
+	Limit  int
+}
+
+// EntConfig wires an Ent repository to the generated client C of one entity.
+//
+// Each function receives the client to use, which is the transactional client inside WithTx.
+type EntConfig[T any, ID comparable, C any] struct {
+	// Client is the generated client, e.g., *ent.Client.
+	Client C
+
+	// Begin starts a transaction, e.g., by calling client.Tx(ctx).
+	Begin func(ctx context.Context, client C) (EntTx[C], error)
+
+	// Create saves entity and sets its generated fields.
+	Create func(ctx context.Context, client C, entity *T) error
+
+	// Get returns the entity with id.
+	Get func(ctx context.Context, client C, id ID) (T, error)
+
+	// Update saves all fields of entity.
+	Update func(ctx context.Context, client C, entity *T) error
+
+	// Delete removes the entity with id.
+	Delete func(ctx context.Context, client C, id ID) error
+
+	// Find returns the entities matching query.
+	Find func(ctx context.Context, client C, query EntQuery) ([]T, error)
+
+	// Count returns the number of entities matching where.
+	Count func(ctx context.Context, client C, where func(*entsql.Selector)) (int, error)
+
+	// ValidColumn reports whether a filter or sort field is a column, e.g., user.ValidColumn.
+	ValidColumn func(column string) bool
+
+	// IsNotFound reports whether an error means no entity was found, e.g., ent.IsNotFound.
+	IsNotFound func(err error) bool
+}
+
+// Ent is a Repository over a generated ent client.
+//
+// Ent generates a distinct API per schema, so the operations are supplied by EntConfig, while
+// Ent translates filters, sorts and paging and handles transactions and not-found errors.
+type Ent[T any, ID comparable, C any] struct {
+	config EntConfig[T, ID, C]
+}
+
+// NewEnt creates an Ent repository from the operations of one generated entity.
+//
+// Parameters:
+//   - config: Generated client and the operations of the entity
+//
+// Returns:
+//   - *Ent[T, ID, C]: Repository
+//   - error: Error if an operation is missing
+//
+// Example:
+//
+//	users, err := repository.NewEnt(repository.EntConfig[User, int, *ent.Client]{
+//	    Client: client,
+//	    Begin: func(ctx context.Context, client *ent.Client) (repository.EntTx[*ent.Client], error) {
+//	        return client.Tx(ctx)
+//	    },
+//	    Create: func(ctx context.Context, client *ent.Client, u *User) error {
+//	        created, err := client.User.Create().SetName(u.Name).Save(ctx)
+//	        if err == nil {
+//	            u.ID = created.ID
+//	        }
+//	        return err
+//	    },
+//	    Get: func(ctx context.Context, client *ent.Client, id int) (User, error) {
+//	        u, err := client.User.Get(ctx, id)
+//	        if err != nil {
+//	            return User{}, err
+//	        }
+//	        return User{ID: u.ID, Name: u.Name}, nil
+//	    },
+//	    Find: func(ctx context.Context, client *ent.Client, query repository.EntQuery) ([]User, error) {
+//	        q := client.User.Query().Where(predicate.User(query.Where)).Offset(query.Offset)
+//	        for _, order := range query.Orders {
+//	            q.Order(user.OrderOption(order))
+//	        }
+//	        if query.Limit > 0 {
+//	            q.Limit(query.Limit)
+//	        }
+//	        ...
+//	    },
+//	    ...
+//	    ValidColumn: user.ValidColumn,
+//	    IsNotFound:  ent.IsNotFound,
+//	})
+func NewEnt[T any, ID comparable, C any](config EntConfig[T, ID, C]) (*Ent[T, ID, C], error) {
+	switch {
+	case config.Begin == nil, config.Create == nil, config.Get == nil, config.Update == nil,
+		config.Delete == nil, config.Find == nil, config.Count == nil:
+		return nil, errors.New("Begin, Create, Get, Update, Delete, Find and Count are required")
+	case config.ValidColumn == nil, config.IsNotFound == nil:
+		return nil, errors.New("ValidColumn and IsNotFound are required")
+	}
+
+	return &Ent[T, ID, C]{config: config}, nil
+}
+
+// Client returns the transactional client of ctx, or the client outside WithTx, for queries
+// the repository does not cover.
+func (r *Ent[T, ID, C]) Client(ctx context.Context) C {
+	if tx, ok := ctx.Value(entTxKey{r.config.Client}).(EntTx[C]); ok {
+		return tx.Client()
+	}
+
+	return r.config.Client
+}
+
+// Create saves entity and sets its generated fields.
+func (r *Ent[T, ID, C]) Create(ctx context.Context, entity *T) error {
+	return r.config.Create(ctx, r.Client(ctx), entity)
+}
+
+// Get returns the entity with id, or ErrNotFound.
+func (r *Ent[T, ID, C]) Get(ctx context.Context, id ID) (T, error) {
+	entity, err := r.config.Get(ctx, r.Client(ctx), id)
+
+	return entity, r.notFound(err)
+}
+
+// Update saves all fields of entity, or returns ErrNotFound.
+func (r *Ent[T, ID, C]) Update(ctx context.Context, entity *T) error {
+	return r.notFound(r.config.Update(ctx, r.Client(ctx), entity))
+}
+
+// Delete removes the entity with id, or returns ErrNotFound.
+func (r *Ent[T, ID, C]) Delete(ctx context.Context, id ID) error {
+	return r.notFound(r.config.Delete(ctx, r.Client(ctx), id))
+}
+
+// Find returns the entities matching query.
+func (r *Ent[T, ID, C]) Find(ctx context.Context, query Query) ([]T, error) {
+	where, err := r.where(query.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	entQuery := EntQuery{Where: where, Offset: query.Offset, Limit: query.Limit}
+	for _, sort := range query.Sorts {
+		if !r.config.ValidColumn(sort.Field) {
+			return nil, fmt.Errorf("unknown field %q", sort.Field)
+		}
+
+		option := entsql.OrderAsc()
+		if sort.Descending {
+			option = entsql.OrderDesc()
+		}
+		entQuery.Orders = append(entQuery.Orders, entsql.OrderByField(sort.Field, option).ToFunc())
+	}
+
+	entities, err := r.config.Find(ctx, r.Client(ctx), entQuery)
+	if err != nil {
+		return nil, err
+	}
+	if entities == nil {
+		entities = []T{}
+	}
+
+	return entities, nil
+}
+
+// Count returns the number of entities matching all filters.
+func (r *Ent[T, ID, C]) Count(ctx context.Context, filters ...Filter) (int64, error) {
+	where, err := r.where(filters)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := r.config.Count(ctx, r.Client(ctx), where)
+
+	return int64(count), err
+}
+
+// FindPage returns one page of the entities matching query, with the total count.
+func (r *Ent[T, ID, C]) FindPage(ctx context.Context, query Query, request PageRequest) (Page[T], error) {
+	return findPage(ctx, r, query, request)
+}
+
+// WithTx runs fn in a transaction. Repositories of the same client join the transaction when
+// called with the context passed to fn, and a nested WithTx runs in the outer transaction.
+func (r *Ent[T, ID, C]) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(entTxKey{r.config.Client}).(EntTx[C]); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.config.Begin(ctx, r.config.Client)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			tx.Rollback()
+			panic(recovered)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, entTxKey{r.config.Client}, tx)); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	return tx.Commit()
+}
+
+func (r *Ent[T, ID, C]) notFound(err error) error {
+	if err != nil && r.config.IsNotFound(err) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+func (r *Ent[T, ID, C]) where(filters []Filter) (func(*entsql.Selector), error) {
+	predicates := []func(*entsql.Selector){}
+	for _, filter := range filters {
+		if !r.config.ValidColumn(filter.Field) {
+			return nil, fmt.Errorf("unknown field %q", filter.Field)
+		}
+
+		switch filter.Operator {
+		case OperatorEqual:
+			predicates = append(predicates, entsql.FieldEQ(filter.Field, filter.Value))
+		case OperatorNotEqual:
+			predicates = append(predicates, entsql.FieldNEQ(filter.Field, filter.Value))
+		case OperatorGreater:
+			predicates = append(predicates, entsql.FieldGT(filter.Field, filter.Value))
+		case OperatorGreaterOrEqual:
+			predicates = append(predicates, entsql.FieldGTE(filter.Field, filter.Value))
+		case OperatorLess:
+			predicates = append(predicates, entsql.FieldLT(filter.Field, filter.Value))
+		case OperatorLessOrEqual:
+			predicates = append(predicates, entsql.FieldLTE(filter.Field, filter.Value))
+		case OperatorIn:
+			values, ok := filter.Value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("value of IN filter on %q must be []any", filter.Field)
+			}
+			predicates = append(predicates, entsql.FieldIn(filter.Field, values...))
+		case OperatorContains:
+			substring, ok := filter.Value.(string)
+			if !ok {
+				return nil, fmt.Errorf("value of CONTAINS filter on %q must be a string", filter.Field)
+			}
+			predicates = append(predicates, entsql.FieldContains(filter.Field, substring))
+		case OperatorIsNull:
+			predicates = append(predicates, entsql.FieldIsNull(filter.Field))
+		case OperatorIsNotNull:
+			predicates = append(predicates, entsql.FieldNotNull(filter.Field))
+		default:
+			return nil, fmt.Errorf("unsupported operator %q", filter.Operator)
+		}
+	}
+
+	return entsql.AndPredicates(predicates...), nil
+}
@@ -0,0 +1,248 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/common-library/go/database/orm/ent"
+	"github.com/common-library/go/database/orm/ent/predicate"
+	_ "github.com/common-library/go/database/orm/ent/runtime"
+	"github.com/common-library/go/database/orm/ent/table01forent"
+	"github.com/common-library/go/database/orm/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Record struct {
+	ID      int
+	Name    string
+	Count   int
+	Enabled bool
+}
+
+var _ repository.Repository[Record, int] = (*repository.Ent[Record, int, *ent.Client])(nil)
+
+func toRecord(entity *ent.Table01ForEnt) Record {
+	return Record{ID: entity.ID, Name: entity.Field01, Count: entity.Field02, Enabled: entity.Field03}
+}
+
+func newRecords(t *testing.T) (*repository.Ent[Record, int, *ent.Client], *ent.Client) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "ent.db")+"?_pragma=foreign_keys(1)")
+	require.NoError(t, err)
+
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	t.Cleanup(func() { client.Close() })
+	require.NoError(t, client.Schema.Create(context.Background()))
+
+	records, err := repository.NewEnt(repository.EntConfig[Record, int, *ent.Client]{
+		Client: client,
+		Begin: func(ctx context.Context, client *ent.Client) (repository.EntTx[*ent.Client], error) {
+			return client.Tx(ctx)
+		},
+		Create: func(ctx context.Context, client *ent.Client, record *Record) error {
+			created, err := client.Table01ForEnt.Create().
+				SetField01(record.Name).
+				SetField02(record.Count).
+				SetField03(record.Enabled).
+				Save(ctx)
+			if err != nil {
+				return err
+			}
+
+			*record = toRecord(created)
+			return nil
+		},
+		Get: func(ctx context.Context, client *ent.Client, id int) (Record, error) {
+			entity, err := client.Table01ForEnt.Get(ctx, id)
+			if err != nil {
+				return Record{}, err
+			}
+
+			return toRecord(entity), nil
+		},
+		Update: func(ctx context.Context, client *ent.Client, record *Record) error {
+			return client.Table01ForEnt.UpdateOneID(record.ID).
+				SetField01(record.Name).
+				SetField02(record.Count).
+				SetField03(record.Enabled).
+				Exec(ctx)
+		},
+		Delete: func(ctx context.Context, client *ent.Client, id int) error {
+			return client.Table01ForEnt.DeleteOneID(id).Exec(ctx)
+		},
+		Find: func(ctx context.Context, client *ent.Client, query repository.EntQuery) ([]Record, error) {
+			q := client.Table01ForEnt.Query().Where(predicate.Table01ForEnt(query.Where)).Offset(query.Offset)
+			for _, order := range query.Orders {
+				q.Order(table01forent.OrderOption(order))
+			}
+			if query.Limit > 0 {
+				q.Limit(query.Limit)
+			}
+
+			entities, err := q.All(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			records := []Record{}
+			for _, entity := range entities {
+				records = append(records, toRecord(entity))
+			}
+			return records, nil
+		},
+		Count: func(ctx context.Context, client *ent.Client, where func(*entsql.Selector)) (int, error) {
+			return client.Table01ForEnt.Query().Where(predicate.Table01ForEnt(where)).Count(ctx)
+		},
+		ValidColumn: table01forent.ValidColumn,
+		IsNotFound:  ent.IsNotFound,
+	})
+	require.NoError(t, err)
+
+	return records, client
+}
+
+func TestNewEnt(t *testing.T) {
+	_, err := repository.NewEnt(repository.EntConfig[Record, int, *ent.Client]{})
+	assert.Error(t, err)
+}
+
+func TestEnt_CRUD(t *testing.T) {
+	ctx := context.Background()
+	records, _ := newRecords(t)
+
+	first := Record{Name: "first", Count: 1}
+	require.NoError(t, records.Create(ctx, &first))
+	assert.Equal(t, 1, first.ID)
+
+	second := Record{Name: "second", Count: 2, Enabled: true}
+	require.NoError(t, records.Create(ctx, &second))
+
+	assert.Error(t, records.Create(ctx, &Record{Name: "first"}))
+
+	record, err := records.Get(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, second, record)
+
+	_, err = records.Get(ctx, 100)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	record.Count = 20
+	require.NoError(t, records.Update(ctx, &record))
+	updated, err := records.Get(ctx, record.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 20, updated.Count)
+
+	assert.ErrorIs(t, records.Update(ctx, &Record{ID: 100, Name: "missing"}), repository.ErrNotFound)
+
+	require.NoError(t, records.Delete(ctx, first.ID))
+	assert.ErrorIs(t, records.Delete(ctx, first.ID), repository.ErrNotFound)
+
+	count, err := records.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestEnt_Find(t *testing.T) {
+	ctx := context.Background()
+	records, _ := newRecords(t)
+
+	for i, name := range []string{"alpha", "beta", "gamma", "delta", "epsilon"} {
+		require.NoError(t, records.Create(ctx, &Record{Name: name, Count: i, Enabled: i%2 == 0}))
+	}
+
+	names := func(query repository.Query) []string {
+		t.Helper()
+
+		result, err := records.Find(ctx, query)
+		require.NoError(t, err)
+
+		names := []string{}
+		for _, record := range result {
+			names = append(names, record.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"alpha", "beta", "delta", "epsilon", "gamma"}, names(repository.Query{Sorts: []repository.Sort{repository.Asc("field01")}}))
+	assert.Equal(t, []string{"epsilon", "gamma"}, names(repository.Query{
+		Filters: []repository.Filter{repository.Eq("field03", true)},
+		Sorts:   []repository.Sort{repository.Desc("field02")},
+		Limit:   2,
+	}))
+	assert.Equal(t, []string{"beta", "gamma"}, names(repository.Query{Filters: []repository.Filter{repository.Gt("field02", 0), repository.Lte("field02", 2)}}))
+	assert.Equal(t, []string{"delta", "epsilon"}, names(repository.Query{Filters: []repository.Filter{repository.Gte("field02", 3)}}))
+	assert.Equal(t, []string{"alpha"}, names(repository.Query{Filters: []repository.Filter{repository.Lt("field02", 1)}}))
+	assert.Equal(t, []string{"alpha", "gamma"}, names(repository.Query{Filters: []repository.Filter{repository.In("field01", "alpha", "gamma", "missing")}}))
+	assert.Len(t, names(repository.Query{Filters: []repository.Filter{repository.NotEq("field01", "alpha")}}), 4)
+	assert.Equal(t, []string{"alpha", "delta", "epsilon"}, names(repository.Query{Filters: []repository.Filter{repository.Contains("field01", "l")}, Sorts: []repository.Sort{repository.Asc("id")}}))
+	assert.Len(t, names(repository.Query{Filters: []repository.Filter{repository.IsNull("field06")}}), 5)
+	assert.Empty(t, names(repository.Query{Filters: []repository.Filter{repository.IsNotNull("field06")}}))
+	assert.Equal(t, []string{"gamma", "delta", "epsilon"}, names(repository.Query{Sorts: []repository.Sort{repository.Asc("id")}, Offset: 2}))
+
+	_, err := records.Find(ctx, repository.Query{Filters: []repository.Filter{repository.Eq("name", "alpha")}})
+	assert.ErrorContains(t, err, "unknown field")
+
+	_, err = records.Find(ctx, repository.Query{Sorts: []repository.Sort{repository.Asc("name")}})
+	assert.ErrorContains(t, err, "unknown field")
+
+	_, err = records.Count(ctx, repository.Filter{Field: "field01", Operator: "LIKE"})
+	assert.ErrorContains(t, err, "unsupported operator")
+
+	page, err := records.FindPage(ctx, repository.Query{Sorts: []repository.Sort{repository.Asc("id")}}, repository.PageRequest{Number: 2, Size: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), page.Total)
+	assert.Equal(t, 3, page.TotalPages())
+	assert.True(t, page.HasNext())
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "gamma", page.Items[0].Name)
+}
+
+func TestEnt_WithTx(t *testing.T) {
+	ctx := context.Background()
+	records, client := newRecords(t)
+
+	err := records.WithTx(ctx, func(ctx context.Context) error {
+		if err := records.Create(ctx, &Record{Name: "committed"}); err != nil {
+			return err
+		}
+
+		return records.WithTx(ctx, func(ctx context.Context) error {
+			return records.Create(ctx, &Record{Name: "nested"})
+		})
+	})
+	require.NoError(t, err)
+
+	rollback := errors.New("rollback")
+	err = records.WithTx(ctx, func(ctx context.Context) error {
+		if err := records.Create(ctx, &Record{Name: "rolled back"}); err != nil {
+			return err
+		}
+
+		assert.NotSame(t, client, records.Client(ctx))
+		count, err := records.Client(ctx).Table01ForEnt.Query().Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		return rollback
+	})
+	assert.ErrorIs(t, err, rollback)
+
+	assert.Panics(t, func() {
+		records.WithTx(ctx, func(ctx context.Context) error {
+			records.Create(ctx, &Record{Name: "panicked"})
+			panic("panic")
+		})
+	})
+
+	assert.Same(t, client, records.Client(ctx))
+	count, err := records.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
@@ -0,0 +1,215 @@
+// Package repository provides a generic repository layer over the ORM integrations.
+//
+// Domain code depends on the Repository interface, and an adapter binds it to a persistence
+// technology, so that the technology can be switched without rewriting the domain layer.
+//
+// Features:
+//   - Generic Repository[T, ID] interface for CRUD, filtering, sorting and paging
+//   - Technology-neutral filters, sorts and page requests
+//   - Transactions carried in the context and shared by repositories of the same database
+//   - sqlx adapter mapping structs to tables through `db` tags
+//   - ent adapter wired to the generated client of any schema
+//
+// Example:
+//
+//	type UserService struct {
+//	    users repository.Repository[User, int64]
+//	}
+//
+//	func (s *UserService) Activate(ctx context.Context, id int64) error {
+//	    return s.users.WithTx(ctx, func(ctx context.Context) error {
+//	        user, err := s.users.Get(ctx, id)
+//	        if err != nil {
+//	            return err
+//	        }
+//	        user.Active = true
+//	        return s.users.Update(ctx, &user)
+//	    })
+//	}
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when no entity has the requested ID.
+var ErrNotFound = errors.New("not found")
+
+// Repository is the persistence interface of entities of type T identified by ID.
+type Repository[T any, ID comparable] interface {
+	// Create inserts entity. Generated fields such as the ID are set on entity.
+	Create(ctx context.Context, entity *T) error
+
+	// Get returns the entity with id, or ErrNotFound.
+	Get(ctx context.Context, id ID) (T, error)
+
+	// Update saves all fields of entity, identified by its ID, or returns ErrNotFound.
+	Update(ctx context.Context, entity *T) error
+
+	// Delete removes the entity with id, or returns ErrNotFound.
+	Delete(ctx context.Context, id ID) error
+
+	// Find returns the entities matching query.
+	Find(ctx context.Context, query Query) ([]T, error)
+
+	// Count returns the number of entities matching all filters.
+	Count(ctx context.Context, filters ...Filter) (int64, error)
+
+	// FindPage returns one page of the entities matching query, with the total count.
+	FindPage(ctx context.Context, query Query, request PageRequest) (Page[T], error)
+
+	// WithTx runs fn in a transaction. Calls with the context passed to fn join the
+	// transaction, which is committed if fn returns nil and rolled back otherwise.
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Operator is the comparison of a filter.
+type Operator string
+
+const (
+	OperatorEqual          Operator = "="
+	OperatorNotEqual       Operator = "<>"
+	OperatorGreater        Operator = ">"
+	OperatorGreaterOrEqual Operator = ">="
+	OperatorLess           Operator = "<"
+	OperatorLessOrEqual    Operator = "<="
+	OperatorIn             Operator = "IN"
+	OperatorContains       Operator = "CONTAINS"
+	OperatorIsNull         Operator = "IS NULL"
+	OperatorIsNotNull      Operator = "IS NOT NULL"
+)
+
+// Filter is a condition on one field. Field is the column name, e.g., the `db` tag for sqlx.
+type Filter struct {
+	Field    string
+	Operator Operator
+	Value    any
+}
+
+// Eq returns a filter matching field equal to value.
+func Eq(field string, value any) Filter {
+	return Filter{Field: field, Operator: OperatorEqual, Value: value}
+}
+
+// NotEq returns a filter matching field not equal to value.
+func NotEq(field string, value any) Filter {
+	return Filter{Field: field, Operator: OperatorNotEqual, Value: value}
+}
+
+// Gt returns a filter matching field greater than value.
+func Gt(field string, value any) Filter {
+	return Filter{Field: field, Operator: OperatorGreater, Value: value}
+}
+
+// Gte returns a filter matching field greater than or equal to value.
+func Gte(field string, value any) Filter {
+	return Filter{Field: field, Operator: OperatorGreaterOrEqual, Value: value}
+}
+
+// Lt returns a filter matching field less than value.
+func Lt(field string, value any) Filter {
+	return Filter{Field: field, Operator: OperatorLess, Value: value}
+}
+
+// Lte returns a filter matching field less than or equal to value.
+func Lte(field string, value any) Filter {
+	return Filter{Field: field, Operator: OperatorLessOrEqual, Value: value}
+}
+
+// In returns a filter matching field equal to one of values. No values match nothing.
+func In(field string, values ...any) Filter {
+	return Filter{Field: field, Operator: OperatorIn, Value: values}
+}
+
+// Contains returns a filter matching field containing substring. Case sensitivity depends on
+// the database and its collation.
+func Contains(field string, substring string) Filter {
+	return Filter{Field: field, Operator: OperatorContains, Value: substring}
+}
+
+// IsNull returns a filter matching field being NULL.
+func IsNull(field string) Filter {
+	return Filter{Field: field, Operator: OperatorIsNull}
+}
+
+// IsNotNull returns a filter matching field not being NULL.
+func IsNotNull(field string) Filter {
+	return Filter{Field: field, Operator: OperatorIsNotNull}
+}
+
+// Sort is an ordering by one field.
+type Sort struct {
+	Field      string
+	Descending bool
+}
+
+// Asc returns an ascending ordering by field.
+func Asc(field string) Sort {
+	return Sort{Field: field}
+}
+
+// Desc returns a descending ordering by field.
+func Desc(field string) Sort {
+	return Sort{Field: field, Descending: true}
+}
+
+// Query selects entities matching all Filters, ordered by Sorts. Offset skips entities and a
+// positive Limit caps their number.
+type Query struct {
+	Filters []Filter
+	Sorts   []Sort
+	Offset  int
+	Limit   int
+}
+
+// PageRequest is a page of Size entities, numbered from 1.
+type PageRequest struct {
+	Number int
+	Size   int
+}
+
+// Page is one page of entities with the total number of matching entities.
+type Page[T any] struct {
+	Items  []T
+	Number int
+	Size   int
+	Total  int64
+}
+
+// TotalPages returns the number of pages of the matching entities.
+func (p Page[T]) TotalPages() int {
+	if p.Size <= 0 {
+		return 0
+	}
+
+	return int((p.Total + int64(p.Size) - 1) / int64(p.Size))
+}
+
+// HasNext reports whether a page follows this one.
+func (p Page[T]) HasNext() bool {
+	return p.Number < p.TotalPages()
+}
+
+func findPage[T any, ID comparable](ctx context.Context, repository Repository[T, ID], query Query, request PageRequest) (Page[T], error) {
+	if request.Number < 1 || request.Size < 1 {
+		return Page[T]{}, errors.New("page number and size must be positive")
+	}
+
+	total, err := repository.Count(ctx, query.Filters...)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	query.Offset = (request.Number - 1) * request.Size
+	query.Limit = request.Size
+
+	items := []T{}
+	if int64(query.Offset) < total {
+		if items, err = repository.Find(ctx, query); err != nil {
+			return Page[T]{}, err
+		}
+	}
+
+	return Page[T]{Items: items, Number: request.Number, Size: request.Size, Total: total}, nil
+}
@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type sqlxTxKey struct {
+	db *sqlx.DB
+}
+
+// SQLXConfig is the table mapping of an SQLX repository.
+type SQLXConfig struct {
+	// Table is the table name.
+	Table string
+
+	// IDColumn is the primary key column. Defaults to "id".
+	IDColumn string
+
+	// GeneratedID omits the ID column from inserts and sets the ID generated by the database
+	// (AUTO_INCREMENT, SERIAL or INTEGER PRIMARY KEY) on the created entity.
+	GeneratedID bool
+}
+
+// SQLX is a Repository over sqlx.
+//
+// Columns are the fields of T, named by their `db` tag or, without a tag, by the mapper of the
+// database (lower-cased field names by default). Embedded structs are flattened. Queries use
+// LIMIT and OFFSET and therefore target MySQL, PostgreSQL and SQLite.
+type SQLX[T any, ID comparable] struct {
+	db          *sqlx.DB
+	table       string
+	idColumn    string
+	idIndex     []int
+	generatedID bool
+	columns     []string
+	columnSet   map[string]bool
+}
+
+// NewSQLX creates an SQLX repository of entities of type T stored in one table.
+//
+// Parameters:
+//   - db: Database connected with sqlx
+//   - config: Table name, ID column and ID generation
+//
+// Returns:
+//   - *SQLX[T, ID]: Repository
+//   - error: Error if T is not a struct or has no field for the ID column
+//
+// Example:
+//
+//	type User struct {
+//	    ID     int64  `db:"id"`
+//	    Name   string `db:"name"`
+//	    Active bool   `db:"active"`
+//	}
+//
+//	users, err := repository.NewSQLX[User, int64](db, repository.SQLXConfig{Table: "users", GeneratedID: true})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	user := User{Name: "Alice"}
+//	err = users.Create(ctx, &user)
+func NewSQLX[T any, ID comparable](db *sqlx.DB, config SQLXConfig) (*SQLX[T, ID], error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, errors.New("entities must be structs, got " + t.String())
+	}
+	if config.Table == "" {
+		return nil, errors.New("table is required")
+	}
+	if config.IDColumn == "" {
+		config.IDColumn = "id"
+	}
+
+	r := &SQLX[T, ID]{
+		db:          db,
+		table:       config.Table,
+		idColumn:    config.IDColumn,
+		generatedID: config.GeneratedID,
+		columnSet:   map[string]bool{},
+	}
+
+	for _, field := range db.Mapper.TypeMap(t).Index {
+		if field.Embedded || field.Name == "" || field.Name == "-" || strings.Contains(field.Path, ".") {
+			continue
+		}
+
+		r.columns = append(r.columns, field.Name)
+		r.columnSet[field.Name] = true
+		if field.Name == config.IDColumn {
+			r.idIndex = field.Index
+		}
+	}
+
+	if r.idIndex == nil {
+		return nil, fmt.Errorf("%s has no field for column %q", t, config.IDColumn)
+	}
+
+	return r, nil
+}
+
+// Executor returns the transaction of ctx, or the database outside WithTx, for queries the
+// repository does not cover.
+//
+// Example:
+//
+//	err := users.WithTx(ctx, func(ctx context.Context) error {
+//	    _, err := users.Executor(ctx).ExecContext(ctx, "UPDATE users SET active = false WHERE last_login < ?", cutoff)
+//	    return err
+//	})
+func (r *SQLX[T, ID]) Executor(ctx context.Context) sqlx.ExtContext {
+	if tx, ok := ctx.Value(sqlxTxKey{r.db}).(*sqlx.Tx); ok {
+		return tx
+	}
+
+	return r.db
+}
+
+// Create inserts entity. With GeneratedID, the generated ID is set on entity.
+func (r *SQLX[T, ID]) Create(ctx context.Context, entity *T) error {
+	columns := r.columns
+	if r.generatedID {
+		columns = []string{}
+		for _, column := range r.columns {
+			if column != r.idColumn {
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	executor := r.Executor(ctx)
+	query, args, err := executor.BindNamed(
+		"INSERT INTO "+r.table+" ("+strings.Join(columns, ", ")+") VALUES (:"+strings.Join(columns, ", :")+")", entity)
+	if err != nil {
+		return err
+	}
+
+	if !r.generatedID {
+		_, err := executor.ExecContext(ctx, query, args...)
+		return err
+	}
+
+	id := reflect.ValueOf(entity).Elem().FieldByIndex(r.idIndex)
+
+	if sqlx.BindType(executor.DriverName()) == sqlx.DOLLAR {
+		return executor.QueryRowxContext(ctx, query+" RETURNING "+r.idColumn, args...).Scan(id.Addr().Interface())
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	lastInsertID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(lastInsertID)
+	if !value.CanConvert(id.Type()) {
+		return fmt.Errorf("cannot set generated ID on field of type %s", id.Type())
+	}
+	id.Set(value.Convert(id.Type()))
+
+	return nil
+}
+
+// Get returns the entity with id, or ErrNotFound.
+func (r *SQLX[T, ID]) Get(ctx context.Context, id ID) (T, error) {
+	var entity T
+
+	executor := r.Executor(ctx)
+	err := sqlx.GetContext(ctx, executor, &entity,
+		executor.Rebind("SELECT "+strings.Join(r.columns, ", ")+" FROM "+r.table+" WHERE "+r.idColumn+" = ?"), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity, ErrNotFound
+	}
+
+	return entity, err
+}
+
+// Update saves all columns of entity, identified by its ID, or returns ErrNotFound.
+func (r *SQLX[T, ID]) Update(ctx context.Context, entity *T) error {
+	assignments := []string{}
+	for _, column := range r.columns {
+		if column != r.idColumn {
+			assignments = append(assignments, column+" = :"+column)
+		}
+	}
+
+	executor := r.Executor(ctx)
+	query, args, err := executor.BindNamed(
+		"UPDATE "+r.table+" SET "+strings.Join(assignments, ", ")+" WHERE "+r.idColumn+" = :"+r.idColumn, entity)
+	if err != nil {
+		return err
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected > 0 {
+		return nil
+	}
+
+	// MySQL reports no affected rows when the values are unchanged.
+	id := reflect.ValueOf(entity).Elem().FieldByIndex(r.idIndex).Interface()
+	if count, err := r.count(ctx, []Filter{Eq(r.idColumn, id)}); err != nil {
+		return err
+	} else if count == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the entity with id, or returns ErrNotFound.
+func (r *SQLX[T, ID]) Delete(ctx context.Context, id ID) error {
+	executor := r.Executor(ctx)
+	result, err := executor.ExecContext(ctx, executor.Rebind("DELETE FROM "+r.table+" WHERE "+r.idColumn+" = ?"), id)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Find returns the entities matching query.
+func (r *SQLX[T, ID]) Find(ctx context.Context, query Query) ([]T, error) {
+	where, args, err := r.where(query.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := "SELECT " + strings.Join(r.columns, ", ") + " FROM " + r.table + where
+
+	if len(query.Sorts) > 0 {
+		orders := []string{}
+		for _, sort := range query.Sorts {
+			if !r.columnSet[sort.Field] {
+				return nil, fmt.Errorf("unknown field %q", sort.Field)
+			}
+
+			if sort.Descending {
+				orders = append(orders, sort.Field+" DESC")
+			} else {
+				orders = append(orders, sort.Field+" ASC")
+			}
+		}
+		statement += " ORDER BY " + strings.Join(orders, ", ")
+	}
+
+	switch {
+	case query.Limit > 0:
+		statement += fmt.Sprintf(" LIMIT %d", query.Limit)
+	case query.Offset > 0:
+		statement += fmt.Sprintf(" LIMIT %d", math.MaxInt64)
+	}
+	if query.Offset > 0 {
+		statement += fmt.Sprintf(" OFFSET %d", query.Offset)
+	}
+
+	executor := r.Executor(ctx)
+	entities := []T{}
+	if err := sqlx.SelectContext(ctx, executor, &entities, executor.Rebind(statement), args...); err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
+
+// Count returns the number of entities matching all filters.
+func (r *SQLX[T, ID]) Count(ctx context.Context, filters ...Filter) (int64, error) {
+	return r.count(ctx, filters)
+}
+
+// FindPage returns one page of the entities matching query, with the total count.
+func (r *SQLX[T, ID]) FindPage(ctx context.Context, query Query, request PageRequest) (Page[T], error) {
+	return findPage(ctx, r, query, request)
+}
+
+// WithTx runs fn in a transaction. Repositories of the same database join the transaction
+// when called with the context passed to fn, and a nested WithTx runs in the outer transaction.
+func (r *SQLX[T, ID]) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(sqlxTxKey{r.db}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			tx.Rollback()
+			panic(recovered)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, sqlxTxKey{r.db}, tx)); err != nil {
+		return errors.Join(err, ignoreTxDone(tx.Rollback()))
+	}
+
+	return tx.Commit()
+}
+
+func (r *SQLX[T, ID]) count(ctx context.Context, filters []Filter) (int64, error) {
+	where, args, err := r.where(filters)
+	if err != nil {
+		return 0, err
+	}
+
+	executor := r.Executor(ctx)
+	count := int64(0)
+	err = sqlx.GetContext(ctx, executor, &count, executor.Rebind("SELECT COUNT(*) FROM "+r.table+where), args...)
+
+	return count, err
+}
+
+func (r *SQLX[T, ID]) where(filters []Filter) (string, []any, error) {
+	if len(filters) == 0 {
+		return "", nil, nil
+	}
+
+	conditions := []string{}
+	args := []any{}
+	for _, filter := range filters {
+		if !r.columnSet[filter.Field] {
+			return "", nil, fmt.Errorf("unknown field %q", filter.Field)
+		}
+
+		switch filter.Operator {
+		case OperatorEqual, OperatorNotEqual, OperatorGreater, OperatorGreaterOrEqual, OperatorLess, OperatorLessOrEqual:
+			conditions = append(conditions, filter.Field+" "+string(filter.Operator)+" ?")
+			args = append(args, filter.Value)
+		case OperatorIn:
+			values, ok := filter.Value.([]any)
+			if !ok {
+				return "", nil, fmt.Errorf("value of IN filter on %q must be []any", filter.Field)
+			}
+
+			if len(values) == 0 {
+				conditions = append(conditions, "1 = 0")
+			} else {
+				conditions = append(conditions, filter.Field+" IN (?"+strings.Repeat(", ?", len(values)-1)+")")
+				args = append(args, values...)
+			}
+		case OperatorContains:
+			substring, ok := filter.Value.(string)
+			if !ok {
+				return "", nil, fmt.Errorf("value of CONTAINS filter on %q must be a string", filter.Field)
+			}
+
+			conditions = append(conditions, filter.Field+" LIKE ? ESCAPE '!'")
+			args = append(args, "%"+likeEscaper.Replace(substring)+"%")
+		case OperatorIsNull, OperatorIsNotNull:
+			conditions = append(conditions, filter.Field+" "+string(filter.Operator))
+		default:
+			return "", nil, fmt.Errorf("unsupported operator %q", filter.Operator)
+		}
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+func ignoreTxDone(err error) error {
+	if errors.Is(err, sql.ErrTxDone) {
+		return nil
+	}
+
+	return err
+}
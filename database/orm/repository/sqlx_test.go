@@ -0,0 +1,285 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/common-library/go/database/orm/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+type Audit struct {
+	CreatedBy string `db:"created_by"`
+}
+
+type Item struct {
+	ID      int64          `db:"id"`
+	Name    string         `db:"name"`
+	Count   int            `db:"count"`
+	Enabled bool           `db:"enabled"`
+	Note    sql.NullString `db:"note"`
+	Audit
+
+	cache string
+}
+
+type Tag struct {
+	Name  string `db:"name"`
+	Label string `db:"label"`
+}
+
+var _ repository.Repository[Item, int64] = (*repository.SQLX[Item, int64])(nil)
+
+func newSQLXDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	db, err := sqlx.Open("sqlite", filepath.Join(t.TempDir(), "repository.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	db.MustExec(`CREATE TABLE items (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		count INTEGER NOT NULL,
+		enabled BOOLEAN NOT NULL,
+		note TEXT,
+		created_by TEXT NOT NULL
+	)`)
+	db.MustExec(`CREATE TABLE tags (name TEXT PRIMARY KEY, label TEXT NOT NULL)`)
+
+	return db
+}
+
+func newItems(t *testing.T, db *sqlx.DB) *repository.SQLX[Item, int64] {
+	t.Helper()
+
+	items, err := repository.NewSQLX[Item, int64](db, repository.SQLXConfig{Table: "items", GeneratedID: true})
+	require.NoError(t, err)
+
+	return items
+}
+
+func TestNewSQLX(t *testing.T) {
+	db := newSQLXDB(t)
+
+	_, err := repository.NewSQLX[int, int64](db, repository.SQLXConfig{Table: "items"})
+	assert.Error(t, err)
+
+	_, err = repository.NewSQLX[Item, int64](db, repository.SQLXConfig{})
+	assert.Error(t, err)
+
+	_, err = repository.NewSQLX[Tag, string](db, repository.SQLXConfig{Table: "tags"})
+	assert.ErrorContains(t, err, `no field for column "id"`)
+
+	_, err = repository.NewSQLX[Tag, string](db, repository.SQLXConfig{Table: "tags", IDColumn: "name"})
+	assert.NoError(t, err)
+}
+
+func TestSQLX_CRUD(t *testing.T) {
+	ctx := context.Background()
+	items := newItems(t, newSQLXDB(t))
+
+	first := Item{Name: "first", Count: 1, Audit: Audit{CreatedBy: "tester"}}
+	require.NoError(t, items.Create(ctx, &first))
+	assert.Equal(t, int64(1), first.ID)
+
+	second := Item{Name: "second", Count: 2, Enabled: true, Note: sql.NullString{String: "note", Valid: true}, Audit: Audit{CreatedBy: "tester"}}
+	require.NoError(t, items.Create(ctx, &second))
+	assert.Equal(t, int64(2), second.ID)
+
+	assert.Error(t, items.Create(ctx, &Item{Name: "first"}))
+
+	item, err := items.Get(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, second, item)
+
+	_, err = items.Get(ctx, 100)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	item.Count = 20
+	item.Note = sql.NullString{}
+	require.NoError(t, items.Update(ctx, &item))
+	require.NoError(t, items.Update(ctx, &item))
+	updated, err := items.Get(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 20, updated.Count)
+	assert.False(t, updated.Note.Valid)
+
+	assert.ErrorIs(t, items.Update(ctx, &Item{ID: 100, Name: "missing"}), repository.ErrNotFound)
+
+	require.NoError(t, items.Delete(ctx, first.ID))
+	assert.ErrorIs(t, items.Delete(ctx, first.ID), repository.ErrNotFound)
+
+	count, err := items.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestSQLX_AssignedID(t *testing.T) {
+	ctx := context.Background()
+
+	tags, err := repository.NewSQLX[Tag, string](newSQLXDB(t), repository.SQLXConfig{Table: "tags", IDColumn: "name"})
+	require.NoError(t, err)
+
+	require.NoError(t, tags.Create(ctx, &Tag{Name: "go", Label: "Go"}))
+
+	tag, err := tags.Get(ctx, "go")
+	require.NoError(t, err)
+	assert.Equal(t, Tag{Name: "go", Label: "Go"}, tag)
+
+	tag.Label = "Golang"
+	require.NoError(t, tags.Update(ctx, &tag))
+	tag, err = tags.Get(ctx, "go")
+	require.NoError(t, err)
+	assert.Equal(t, "Golang", tag.Label)
+}
+
+func TestSQLX_Find(t *testing.T) {
+	ctx := context.Background()
+	items := newItems(t, newSQLXDB(t))
+
+	for i, name := range []string{"alpha", "beta", "gamma", "delta", "100%_done"} {
+		item := Item{Name: name, Count: i, Enabled: i%2 == 0, Audit: Audit{CreatedBy: "tester"}}
+		if i == 1 {
+			item.Note = sql.NullString{String: "note", Valid: true}
+		}
+		require.NoError(t, items.Create(ctx, &item))
+	}
+
+	names := func(query repository.Query) []string {
+		t.Helper()
+
+		result, err := items.Find(ctx, query)
+		require.NoError(t, err)
+
+		names := []string{}
+		for _, item := range result {
+			names = append(names, item.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"alpha", "beta", "gamma", "delta", "100%_done"}, names(repository.Query{}))
+	assert.Equal(t, []string{"100%_done", "alpha", "beta", "delta", "gamma"}, names(repository.Query{Sorts: []repository.Sort{repository.Asc("name")}}))
+	assert.Equal(t, []string{"100%_done", "gamma", "alpha"}, names(repository.Query{
+		Filters: []repository.Filter{repository.Eq("enabled", true)},
+		Sorts:   []repository.Sort{repository.Desc("count"), repository.Asc("name")},
+		Limit:   3,
+	}))
+	assert.Equal(t, []string{"delta", "100%_done"}, names(repository.Query{Filters: []repository.Filter{repository.Gte("count", 3)}}))
+	assert.Equal(t, []string{"alpha"}, names(repository.Query{Filters: []repository.Filter{repository.Lt("count", 1)}}))
+	assert.Equal(t, []string{"beta", "gamma"}, names(repository.Query{Filters: []repository.Filter{repository.Gt("count", 0), repository.Lte("count", 2)}}))
+	assert.Equal(t, []string{"alpha", "gamma"}, names(repository.Query{Filters: []repository.Filter{repository.In("name", "alpha", "gamma", "missing")}}))
+	assert.Equal(t, []string{}, names(repository.Query{Filters: []repository.Filter{repository.In("name")}}))
+	assert.Equal(t, []string{"beta"}, names(repository.Query{Filters: []repository.Filter{repository.IsNotNull("note")}}))
+	assert.Len(t, names(repository.Query{Filters: []repository.Filter{repository.IsNull("note"), repository.NotEq("name", "alpha")}}), 3)
+	assert.Equal(t, []string{"100%_done"}, names(repository.Query{Filters: []repository.Filter{repository.Contains("name", "%_")}}))
+	assert.Equal(t, []string{"alpha", "beta", "gamma"}, names(repository.Query{Filters: []repository.Filter{repository.Contains("name", "a")}, Sorts: []repository.Sort{repository.Asc("count")}, Limit: 3}))
+	assert.Equal(t, []string{"gamma", "delta", "100%_done"}, names(repository.Query{Sorts: []repository.Sort{repository.Asc("id")}, Offset: 2}))
+
+	_, err := items.Find(ctx, repository.Query{Filters: []repository.Filter{repository.Eq("name; DROP TABLE items", 1)}})
+	assert.ErrorContains(t, err, "unknown field")
+
+	_, err = items.Find(ctx, repository.Query{Sorts: []repository.Sort{repository.Asc("cache")}})
+	assert.ErrorContains(t, err, "unknown field")
+
+	_, err = items.Find(ctx, repository.Query{Filters: []repository.Filter{{Field: "name", Operator: "LIKE", Value: "a"}}})
+	assert.ErrorContains(t, err, "unsupported operator")
+
+	_, err = items.Count(ctx, repository.Filter{Field: "name", Operator: repository.OperatorIn, Value: "a"})
+	assert.Error(t, err)
+}
+
+func TestSQLX_FindPage(t *testing.T) {
+	ctx := context.Background()
+	items := newItems(t, newSQLXDB(t))
+
+	for i := range 7 {
+		item := Item{Name: string(rune('a' + i)), Count: i, Audit: Audit{CreatedBy: "tester"}}
+		require.NoError(t, items.Create(ctx, &item))
+	}
+
+	query := repository.Query{Filters: []repository.Filter{repository.Gt("count", 0)}, Sorts: []repository.Sort{repository.Asc("count")}}
+
+	page, err := items.FindPage(ctx, query, repository.PageRequest{Number: 2, Size: 4})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), page.Total)
+	assert.Equal(t, 2, page.TotalPages())
+	assert.False(t, page.HasNext())
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "f", page.Items[0].Name)
+
+	page, err = items.FindPage(ctx, query, repository.PageRequest{Number: 1, Size: 4})
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 4)
+	assert.True(t, page.HasNext())
+
+	page, err = items.FindPage(ctx, query, repository.PageRequest{Number: 3, Size: 4})
+	require.NoError(t, err)
+	assert.Empty(t, page.Items)
+
+	_, err = items.FindPage(ctx, query, repository.PageRequest{Number: 0, Size: 4})
+	assert.Error(t, err)
+}
+
+func TestSQLX_WithTx(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLXDB(t)
+	items := newItems(t, db)
+	tags, err := repository.NewSQLX[Tag, string](db, repository.SQLXConfig{Table: "tags", IDColumn: "name"})
+	require.NoError(t, err)
+
+	err = items.WithTx(ctx, func(ctx context.Context) error {
+		if err := items.Create(ctx, &Item{Name: "committed", Audit: Audit{CreatedBy: "tester"}}); err != nil {
+			return err
+		}
+
+		return tags.WithTx(ctx, func(ctx context.Context) error {
+			return tags.Create(ctx, &Tag{Name: "committed", Label: "Committed"})
+		})
+	})
+	require.NoError(t, err)
+
+	rollback := errors.New("rollback")
+	err = items.WithTx(ctx, func(ctx context.Context) error {
+		if err := items.Create(ctx, &Item{Name: "rolled back", Audit: Audit{CreatedBy: "tester"}}); err != nil {
+			return err
+		}
+		if err := tags.Create(ctx, &Tag{Name: "rolled back", Label: "Rolled back"}); err != nil {
+			return err
+		}
+
+		count, err := items.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		_, err = items.Executor(ctx).ExecContext(ctx, "UPDATE items SET count = 10")
+		require.NoError(t, err)
+
+		return rollback
+	})
+	assert.ErrorIs(t, err, rollback)
+
+	assert.Panics(t, func() {
+		items.WithTx(ctx, func(ctx context.Context) error {
+			items.Create(ctx, &Item{Name: "panicked", Audit: Audit{CreatedBy: "tester"}})
+			panic("panic")
+		})
+	})
+
+	result, err := items.Find(ctx, repository.Query{})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "committed", result[0].Name)
+	assert.Equal(t, 0, result[0].Count)
+
+	count, err := tags.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
@@ -6,6 +6,10 @@
 //   - Simple client creation with various authentication methods
 //   - Instant vector queries with Query()
 //   - Range vector queries with QueryRange()
+//   - Typed results with QuerySamples() and QueryRangeSeries()
+//   - Series, label names, label values and metric metadata
+//   - Alert, rule and scrape target listing
+//   - PromQL selector builder with safely escaped label matchers
 //   - Configurable timeout for all operations
 //   - Support for basic auth and bearer token authentication
 //
@@ -162,3 +162,29 @@ func TestClientWithInvalidQueries(t *testing.T) {
 	_, _, err = c.QueryRange("invalid_query{", r, 10*time.Second)
 	assert.Error(t, err)
 }
+
+func TestClientTypedQueries(t *testing.T) {
+	c, err := client.NewClient("http://" + prometheusEndpoint)
+	require.NoError(t, err)
+
+	samples, _, err := c.QuerySamples("vector(1)", time.Now(), 10*time.Second)
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Equal(t, 1.0, samples[0].Value)
+
+	now := time.Now()
+	_, _, err = c.QueryRangeSeries("up", client.Range{Start: now.Add(-5 * time.Minute), End: now, Step: time.Minute}, 10*time.Second)
+	assert.NoError(t, err)
+
+	_, _, err = c.LabelValues("job", nil, now.Add(-time.Hour), now, 10*time.Second)
+	assert.NoError(t, err)
+
+	_, err = c.Targets(10 * time.Second)
+	assert.NoError(t, err)
+
+	_, err = c.Rules(10 * time.Second)
+	assert.NoError(t, err)
+
+	_, err = c.Alerts(10 * time.Second)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+var (
+	labelNamePattern  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+)
+
+type matcher struct {
+	label    string
+	operator string
+	value    string
+}
+
+// Selector builds a PromQL series selector with safely quoted label matchers.
+type Selector struct {
+	metric   string
+	matchers []matcher
+	window   time.Duration
+	offset   time.Duration
+}
+
+// NewSelector returns a selector of a metric, or of all metrics if metric is empty.
+//
+// Label values are quoted and escaped, so values from user input cannot change the query.
+// Metric names that are not valid identifiers are matched with __name__.
+//
+// Parameters:
+//   - metric: Metric name (e.g., "http_requests_total")
+//
+// Returns:
+//   - *Selector: Selector to add matchers to
+//
+// Example:
+//
+//	selector, err := client.NewSelector("http_requests_total").
+//	    Equal("job", "api").
+//	    NotEqual("status", "200").
+//	    Regexp("path", "/api/"+client.QuoteRegexp(userInput)+".*").
+//	    Range(5 * time.Minute).
+//	    Build()
+//	// http_requests_total{job="api",status!="200",path=~"/api/....*"}[5m]
+//
+//	samples, _, err := c.QuerySamples("sum(rate("+selector+"))", time.Now(), 10*time.Second)
+func NewSelector(metric string) *Selector {
+	return &Selector{metric: metric}
+}
+
+// Equal adds a label="value" matcher.
+func (s *Selector) Equal(label, value string) *Selector {
+	return s.match(label, "=", value)
+}
+
+// NotEqual adds a label!="value" matcher.
+func (s *Selector) NotEqual(label, value string) *Selector {
+	return s.match(label, "!=", value)
+}
+
+// Regexp adds a label=~"pattern" matcher; the pattern is fully anchored by Prometheus.
+func (s *Selector) Regexp(label, pattern string) *Selector {
+	return s.match(label, "=~", pattern)
+}
+
+// NotRegexp adds a label!~"pattern" matcher.
+func (s *Selector) NotRegexp(label, pattern string) *Selector {
+	return s.match(label, "!~", pattern)
+}
+
+// Range makes the selector a range vector selector over window (e.g., [5m]).
+func (s *Selector) Range(window time.Duration) *Selector {
+	s.window = window
+	return s
+}
+
+// Offset shifts the evaluation time of the selector into the past.
+func (s *Selector) Offset(offset time.Duration) *Selector {
+	s.offset = offset
+	return s
+}
+
+// Build returns the selector as PromQL.
+//
+// Returns:
+//   - string: PromQL selector
+//   - error: Error if a label name is invalid, a regular expression does not compile, or the
+//     selector has neither a metric name nor a matcher
+func (s *Selector) Build() (string, error) {
+	matchers := []string{}
+	metric := s.metric
+
+	if metric != "" && !metricNamePattern.MatchString(metric) {
+		matchers = append(matchers, model.MetricNameLabel+"="+strconv.Quote(metric))
+		metric = ""
+	}
+
+	for _, matcher := range s.matchers {
+		if !labelNamePattern.MatchString(matcher.label) {
+			return "", fmt.Errorf("invalid label name %q", matcher.label)
+		}
+
+		if matcher.operator == "=~" || matcher.operator == "!~" {
+			if _, err := regexp.Compile("^(?s:" + matcher.value + ")$"); err != nil {
+				return "", fmt.Errorf("invalid regular expression of label %q: %w", matcher.label, err)
+			}
+		}
+
+		matchers = append(matchers, matcher.label+matcher.operator+strconv.Quote(matcher.value))
+	}
+
+	if metric == "" && len(matchers) == 0 {
+		return "", errors.New("selector requires a metric name or a label matcher")
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(metric)
+	if len(matchers) != 0 || metric == "" {
+		builder.WriteString("{" + strings.Join(matchers, ",") + "}")
+	}
+	if s.window > 0 {
+		builder.WriteString("[" + model.Duration(s.window).String() + "]")
+	}
+	if s.offset > 0 {
+		builder.WriteString(" offset " + model.Duration(s.offset).String())
+	}
+
+	return builder.String(), nil
+}
+
+func (s *Selector) match(label, operator, value string) *Selector {
+	s.matchers = append(s.matchers, matcher{label: label, operator: operator, value: value})
+	return s
+}
+
+// QuoteRegexp escapes the regular expression metacharacters of value, so it matches literally
+// inside a Regexp or NotRegexp pattern.
+//
+// Example:
+//
+//	client.NewSelector("http_requests_total").Regexp("path", client.QuoteRegexp("/api/v1.0")+"/.*")
+func QuoteRegexp(value string) string {
+	return regexp.QuoteMeta(value)
+}
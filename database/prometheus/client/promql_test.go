@@ -0,0 +1,41 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/prometheus/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorBuild(t *testing.T) {
+	for _, test := range []struct {
+		selector *client.Selector
+		expected string
+	}{
+		{client.NewSelector("up"), `up`},
+		{client.NewSelector("up").Equal("job", "api"), `up{job="api"}`},
+		{client.NewSelector("http_requests_total").Equal("job", "api").NotEqual("status", "200").Regexp("path", "/api/.*").NotRegexp("method", "GET|HEAD"),
+			`http_requests_total{job="api",status!="200",path=~"/api/.*",method!~"GET|HEAD"}`},
+		{client.NewSelector("up").Equal("job", `a"} or vector(1) or up{job="`), `up{job="a\"} or vector(1) or up{job=\""}`},
+		{client.NewSelector("up").Equal("path", `C:\temp`+"\n"), `up{path="C:\\temp\n"}`},
+		{client.NewSelector("").Equal("job", "api"), `{job="api"}`},
+		{client.NewSelector("http.requests-total"), `{__name__="http.requests-total"}`},
+		{client.NewSelector("http_requests_total").Range(5 * time.Minute).Offset(time.Hour), `http_requests_total[5m] offset 1h`},
+		{client.NewSelector("up").Regexp("path", client.QuoteRegexp("/v1.0/(x)")+".*"), `up{path=~"/v1\\.0/\\(x\\).*"}`},
+	} {
+		selector, err := test.selector.Build()
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, selector)
+	}
+
+	_, err := client.NewSelector("up").Equal("job-name", "api").Build()
+	assert.ErrorContains(t, err, `invalid label name "job-name"`)
+
+	_, err = client.NewSelector("up").Regexp("path", "(").Build()
+	assert.ErrorContains(t, err, "invalid regular expression")
+
+	_, err = client.NewSelector("").Build()
+	assert.Error(t, err)
+}
@@ -0,0 +1,471 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+type Metadata = v1.Metadata
+
+// Sample is a value of an instant query with the labels of its series.
+type Sample struct {
+	Labels    map[string]string
+	Value     float64
+	Timestamp time.Time
+}
+
+// Point is a value of a series at a timestamp.
+type Point struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// Series is a series of a range query with its labels and points.
+type Series struct {
+	Labels map[string]string
+	Points []Point
+}
+
+// Alert is an active alert.
+type Alert struct {
+	Labels      map[string]string
+	Annotations map[string]string
+
+	// State is "pending" or "firing"
+	State    string
+	ActiveAt time.Time
+	Value    string
+}
+
+// Rule is an alerting or recording rule.
+type Rule struct {
+	// Type is "alerting" or "recording"
+	Type  string
+	Name  string
+	Query string
+
+	Labels      map[string]string
+	Annotations map[string]string
+
+	// Duration is the for clause of an alerting rule
+	Duration time.Duration
+
+	// State is "inactive", "pending" or "firing" for alerting rules
+	State  string
+	Alerts []Alert
+
+	// Health is "ok", "err" or "unknown"
+	Health         string
+	LastError      string
+	LastEvaluation time.Time
+	EvaluationTime time.Duration
+}
+
+// RuleGroup is a group of rules evaluated together.
+type RuleGroup struct {
+	Name     string
+	File     string
+	Interval time.Duration
+	Rules    []Rule
+}
+
+// Target is an active scrape target.
+type Target struct {
+	ScrapePool       string
+	ScrapeURL        string
+	Labels           map[string]string
+	DiscoveredLabels map[string]string
+
+	// Health is "up", "down" or "unknown"
+	Health             string
+	LastError          string
+	LastScrape         time.Time
+	LastScrapeDuration time.Duration
+}
+
+// QuerySamples executes an instant PromQL query and returns its samples.
+//
+// A vector result returns one sample per series and a scalar result returns one sample without
+// labels. Native histogram samples are left out; use Query to read them.
+//
+// Parameters:
+//   - query: PromQL query string (e.g., "up", "sum by (job) (rate(http_requests_total[5m]))")
+//   - when: Timestamp for query evaluation
+//   - timeout: Maximum duration to wait for query completion
+//
+// Returns:
+//   - []Sample: Samples of the result
+//   - v1.Warnings: Warnings returned by Prometheus
+//   - error: Error if query fails, times out or returns a matrix or string
+//
+// Example:
+//
+//	samples, _, err := c.QuerySamples(`up{job="api"}`, time.Now(), 10*time.Second)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, sample := range samples {
+//	    fmt.Println(sample.Labels["instance"], sample.Value)
+//	}
+func (c *client) QuerySamples(query string, when time.Time, timeout time.Duration) ([]Sample, v1.Warnings, error) {
+	value, warnings, err := c.Query(query, when, timeout)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	samples := []Sample{}
+	switch value := value.(type) {
+	case model.Vector:
+		for _, sample := range value {
+			if sample.Histogram == nil {
+				samples = append(samples, Sample{Labels: labels(model.LabelSet(sample.Metric)), Value: float64(sample.Value), Timestamp: sample.Timestamp.Time()})
+			}
+		}
+	case *model.Scalar:
+		samples = append(samples, Sample{Labels: map[string]string{}, Value: float64(value.Value), Timestamp: value.Timestamp.Time()})
+	default:
+		return nil, warnings, fmt.Errorf("query returned %s, expected vector or scalar", value.Type())
+	}
+
+	return samples, warnings, nil
+}
+
+// QueryRangeSeries executes a PromQL query over a time range and returns its series.
+//
+// Native histogram points are left out; use QueryRange to read them.
+//
+// Parameters:
+//   - query: PromQL query string (e.g., "rate(http_requests_total[5m])")
+//   - r: Time range with start, end, and step interval
+//   - timeout: Maximum duration to wait for query completion
+//
+// Returns:
+//   - []Series: Series of the result
+//   - v1.Warnings: Warnings returned by Prometheus
+//   - error: Error if query fails, times out or does not return a matrix
+//
+// Example:
+//
+//	now := time.Now()
+//	series, _, err := c.QueryRangeSeries(
+//	    "rate(process_cpu_seconds_total[5m])",
+//	    client.Range{Start: now.Add(-time.Hour), End: now, Step: time.Minute},
+//	    10*time.Second,
+//	)
+//
+//	for _, s := range series {
+//	    for _, point := range s.Points {
+//	        fmt.Println(s.Labels["job"], point.Timestamp, point.Value)
+//	    }
+//	}
+func (c *client) QueryRangeSeries(query string, r v1.Range, timeout time.Duration) ([]Series, v1.Warnings, error) {
+	value, warnings, err := c.QueryRange(query, r, timeout)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	matrix, ok := value.(model.Matrix)
+	if !ok {
+		return nil, warnings, fmt.Errorf("query returned %s, expected matrix", value.Type())
+	}
+
+	series := make([]Series, 0, len(matrix))
+	for _, stream := range matrix {
+		points := make([]Point, 0, len(stream.Values))
+		for _, pair := range stream.Values {
+			points = append(points, Point{Timestamp: pair.Timestamp.Time(), Value: float64(pair.Value)})
+		}
+		series = append(series, Series{Labels: labels(model.LabelSet(stream.Metric)), Points: points})
+	}
+
+	return series, warnings, nil
+}
+
+// Series returns the label sets of the series matching any of the selectors within a time range.
+//
+// Parameters:
+//   - matches: Series selectors (e.g., `up`, `http_requests_total{job="api"}`)
+//   - start: Start of the time range
+//   - end: End of the time range
+//   - timeout: Maximum duration to wait for completion
+//
+// Returns:
+//   - []map[string]string: Labels of each series, including __name__
+//   - v1.Warnings: Warnings returned by Prometheus
+//   - error: Error if the request fails or times out
+//
+// Example:
+//
+//	series, _, err := c.Series([]string{`up{job="api"}`}, time.Now().Add(-time.Hour), time.Now(), 10*time.Second)
+func (c *client) Series(matches []string, start, end time.Time, timeout time.Duration) ([]map[string]string, v1.Warnings, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	labelSets, warnings, err := v1.NewAPI(c.prometheusClient).Series(ctx, matches, start, end)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	series := make([]map[string]string, 0, len(labelSets))
+	for _, labelSet := range labelSets {
+		series = append(series, labels(labelSet))
+	}
+
+	return series, warnings, nil
+}
+
+// LabelNames returns the label names of the series matching the selectors within a time range.
+//
+// Parameters:
+//   - matches: Series selectors, or nil for all series
+//   - start: Start of the time range
+//   - end: End of the time range
+//   - timeout: Maximum duration to wait for completion
+//
+// Returns:
+//   - []string: Sorted label names
+//   - v1.Warnings: Warnings returned by Prometheus
+//   - error: Error if the request fails or times out
+//
+// Example:
+//
+//	names, _, err := c.LabelNames(nil, time.Now().Add(-time.Hour), time.Now(), 10*time.Second)
+func (c *client) LabelNames(matches []string, start, end time.Time, timeout time.Duration) ([]string, v1.Warnings, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return v1.NewAPI(c.prometheusClient).LabelNames(ctx, matches, start, end)
+}
+
+// LabelValues returns the values of a label in the series matching the selectors within a time range.
+//
+// Parameters:
+//   - label: Label name (e.g., "job", or "__name__" for metric names)
+//   - matches: Series selectors, or nil for all series
+//   - start: Start of the time range
+//   - end: End of the time range
+//   - timeout: Maximum duration to wait for completion
+//
+// Returns:
+//   - []string: Sorted label values
+//   - v1.Warnings: Warnings returned by Prometheus
+//   - error: Error if the request fails or times out
+//
+// Example:
+//
+//	jobs, _, err := c.LabelValues("job", nil, time.Now().Add(-time.Hour), time.Now(), 10*time.Second)
+func (c *client) LabelValues(label string, matches []string, start, end time.Time, timeout time.Duration) ([]string, v1.Warnings, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	labelValues, warnings, err := v1.NewAPI(c.prometheusClient).LabelValues(ctx, label, matches, start, end)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	values := make([]string, 0, len(labelValues))
+	for _, value := range labelValues {
+		values = append(values, string(value))
+	}
+
+	return values, warnings, nil
+}
+
+// Metadata returns the type, help and unit of metrics as reported by the scrape targets.
+//
+// Parameters:
+//   - metric: Metric name, or "" for all metrics
+//   - timeout: Maximum duration to wait for completion
+//
+// Returns:
+//   - map[string][]Metadata: Metadata per metric name, one entry per distinct metadata
+//   - error: Error if the request fails or times out
+//
+// Example:
+//
+//	metadata, err := c.Metadata("http_requests_total", 10*time.Second)
+//	fmt.Println(metadata["http_requests_total"][0].Type)
+func (c *client) Metadata(metric string, timeout time.Duration) (map[string][]Metadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return v1.NewAPI(c.prometheusClient).Metadata(ctx, metric, "")
+}
+
+// Alerts returns the pending and firing alerts.
+//
+// Parameters:
+//   - timeout: Maximum duration to wait for completion
+//
+// Returns:
+//   - []Alert: Active alerts
+//   - error: Error if the request fails or times out
+//
+// Example:
+//
+//	alerts, err := c.Alerts(10 * time.Second)
+//	for _, alert := range alerts {
+//	    fmt.Println(alert.Labels["alertname"], alert.State)
+//	}
+func (c *client) Alerts(timeout time.Duration) ([]Alert, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := v1.NewAPI(c.prometheusClient).Alerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]Alert, 0, len(result.Alerts))
+	for _, alert := range result.Alerts {
+		alerts = append(alerts, toAlert(alert))
+	}
+
+	return alerts, nil
+}
+
+// Rules returns the rule groups with their alerting and recording rules in evaluation order.
+//
+// Parameters:
+//   - timeout: Maximum duration to wait for completion
+//
+// Returns:
+//   - []RuleGroup: Rule groups
+//   - error: Error if the request fails or times out
+//
+// Example:
+//
+//	groups, err := c.Rules(10 * time.Second)
+//	for _, group := range groups {
+//	    for _, rule := range group.Rules {
+//	        if rule.Health != "ok" {
+//	            fmt.Println(group.Name, rule.Name, rule.LastError)
+//	        }
+//	    }
+//	}
+func (c *client) Rules(timeout time.Duration) ([]RuleGroup, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := v1.NewAPI(c.prometheusClient).Rules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]RuleGroup, 0, len(result.Groups))
+	for _, group := range result.Groups {
+		rules := make([]Rule, 0, len(group.Rules))
+		for _, rule := range group.Rules {
+			switch rule := rule.(type) {
+			case v1.AlertingRule:
+				alerts := make([]Alert, 0, len(rule.Alerts))
+				for _, alert := range rule.Alerts {
+					alerts = append(alerts, toAlert(*alert))
+				}
+
+				rules = append(rules, Rule{
+					Type:           string(v1.RuleTypeAlerting),
+					Name:           rule.Name,
+					Query:          rule.Query,
+					Labels:         labels(rule.Labels),
+					Annotations:    labels(rule.Annotations),
+					Duration:       seconds(rule.Duration),
+					State:          rule.State,
+					Alerts:         alerts,
+					Health:         string(rule.Health),
+					LastError:      rule.LastError,
+					LastEvaluation: rule.LastEvaluation,
+					EvaluationTime: seconds(rule.EvaluationTime)})
+			case v1.RecordingRule:
+				rules = append(rules, Rule{
+					Type:           string(v1.RuleTypeRecording),
+					Name:           rule.Name,
+					Query:          rule.Query,
+					Labels:         labels(rule.Labels),
+					Annotations:    map[string]string{},
+					Health:         string(rule.Health),
+					LastError:      rule.LastError,
+					LastEvaluation: rule.LastEvaluation,
+					EvaluationTime: seconds(rule.EvaluationTime)})
+			}
+		}
+
+		groups = append(groups, RuleGroup{Name: group.Name, File: group.File, Interval: seconds(group.Interval), Rules: rules})
+	}
+
+	return groups, nil
+}
+
+// Targets returns the active scrape targets with their health.
+//
+// Parameters:
+//   - timeout: Maximum duration to wait for completion
+//
+// Returns:
+//   - []Target: Active targets
+//   - error: Error if the request fails or times out
+//
+// Example:
+//
+//	targets, err := c.Targets(10 * time.Second)
+//	for _, target := range targets {
+//	    if target.Health != "up" {
+//	        fmt.Println(target.ScrapeURL, target.LastError)
+//	    }
+//	}
+func (c *client) Targets(timeout time.Duration) ([]Target, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := v1.NewAPI(c.prometheusClient).Targets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]Target, 0, len(result.Active))
+	for _, target := range result.Active {
+		discoveredLabels := map[string]string{}
+		for name, value := range target.DiscoveredLabels {
+			discoveredLabels[name] = value
+		}
+
+		targets = append(targets, Target{
+			ScrapePool:         target.ScrapePool,
+			ScrapeURL:          target.ScrapeURL,
+			Labels:             labels(target.Labels),
+			DiscoveredLabels:   discoveredLabels,
+			Health:             string(target.Health),
+			LastError:          target.LastError,
+			LastScrape:         target.LastScrape,
+			LastScrapeDuration: seconds(target.LastScrapeDuration)})
+	}
+
+	return targets, nil
+}
+
+func labels(labelSet model.LabelSet) map[string]string {
+	result := make(map[string]string, len(labelSet))
+	for name, value := range labelSet {
+		result[string(name)] = string(value)
+	}
+
+	return result
+}
+
+func toAlert(alert v1.Alert) Alert {
+	return Alert{
+		Labels:      labels(alert.Labels),
+		Annotations: labels(alert.Annotations),
+		State:       string(alert.State),
+		ActiveAt:    alert.ActiveAt,
+		Value:       alert.Value}
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
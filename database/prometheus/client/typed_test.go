@@ -0,0 +1,162 @@
+package client_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/prometheus/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAPIServer(t *testing.T, responses map[string]string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status": "success", "data": ` + data + `}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestClientQuerySamples(t *testing.T) {
+	address := newAPIServer(t, map[string]string{"/api/v1/query": `{"resultType": "vector", "result": [
+		{"metric": {"__name__": "up", "job": "api", "instance": "a:9090"}, "value": [1700000000, "1"]},
+		{"metric": {"__name__": "up", "job": "api", "instance": "b:9090"}, "value": [1700000000.5, "0"]}
+	]}`})
+
+	c, err := client.NewClient(address)
+	require.NoError(t, err)
+
+	samples, _, err := c.QuerySamples("up", time.Now(), 10*time.Second)
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, map[string]string{"__name__": "up", "job": "api", "instance": "a:9090"}, samples[0].Labels)
+	assert.Equal(t, 1.0, samples[0].Value)
+	assert.Equal(t, time.Unix(1700000000, 0), samples[0].Timestamp)
+	assert.Equal(t, 0.0, samples[1].Value)
+	assert.Equal(t, time.UnixMilli(1700000000500), samples[1].Timestamp)
+
+	address = newAPIServer(t, map[string]string{"/api/v1/query": `{"resultType": "scalar", "result": [1700000000, "42"]}`})
+	c, err = client.NewClient(address)
+	require.NoError(t, err)
+
+	samples, _, err = c.QuerySamples("42", time.Now(), 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []client.Sample{{Labels: map[string]string{}, Value: 42, Timestamp: time.Unix(1700000000, 0)}}, samples)
+
+	address = newAPIServer(t, map[string]string{"/api/v1/query": `{"resultType": "matrix", "result": []}`})
+	c, err = client.NewClient(address)
+	require.NoError(t, err)
+
+	_, _, err = c.QuerySamples("up[5m]", time.Now(), 10*time.Second)
+	assert.ErrorContains(t, err, "expected vector or scalar")
+}
+
+func TestClientQueryRangeSeries(t *testing.T) {
+	address := newAPIServer(t, map[string]string{"/api/v1/query_range": `{"resultType": "matrix", "result": [
+		{"metric": {"job": "api"}, "values": [[1700000000, "1.5"], [1700000060, "2.5"]]}
+	]}`})
+
+	c, err := client.NewClient(address)
+	require.NoError(t, err)
+
+	series, _, err := c.QueryRangeSeries("rate(http_requests_total[5m])", client.Range{Start: time.Unix(1700000000, 0), End: time.Unix(1700000060, 0), Step: time.Minute}, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []client.Series{{
+		Labels: map[string]string{"job": "api"},
+		Points: []client.Point{{Timestamp: time.Unix(1700000000, 0), Value: 1.5}, {Timestamp: time.Unix(1700000060, 0), Value: 2.5}},
+	}}, series)
+}
+
+func TestClientSeriesAndLabels(t *testing.T) {
+	address := newAPIServer(t, map[string]string{
+		"/api/v1/series":            `[{"__name__": "up", "job": "api"}, {"__name__": "up", "job": "db"}]`,
+		"/api/v1/labels":            `["__name__", "instance", "job"]`,
+		"/api/v1/label/job/values":  `["api", "db"]`,
+		"/api/v1/metadata":          `{"up": [{"type": "gauge", "help": "Target is up.", "unit": ""}]}`,
+		"/api/v1/label/path/values": `[]`,
+	})
+
+	c, err := client.NewClient(address)
+	require.NoError(t, err)
+
+	start, end := time.Now().Add(-time.Hour), time.Now()
+
+	series, _, err := c.Series([]string{"up"}, start, end, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"__name__": "up", "job": "api"}, {"__name__": "up", "job": "db"}}, series)
+
+	names, _, err := c.LabelNames(nil, start, end, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"__name__", "instance", "job"}, names)
+
+	values, _, err := c.LabelValues("job", []string{"up"}, start, end, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "db"}, values)
+
+	values, _, err = c.LabelValues("path", nil, start, end, 10*time.Second)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	metadata, err := c.Metadata("up", 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "Target is up.", metadata["up"][0].Help)
+}
+
+func TestClientAlertsRulesTargets(t *testing.T) {
+	address := newAPIServer(t, map[string]string{
+		"/api/v1/alerts": `{"alerts": [{"labels": {"alertname": "InstanceDown", "instance": "b:9090"}, "annotations": {"summary": "down"},
+			"state": "firing", "activeAt": "2024-01-01T00:00:00Z", "value": "0e+00"}]}`,
+		"/api/v1/rules": `{"groups": [{"name": "availability", "file": "/etc/prometheus/rules.yml", "interval": 30, "rules": [
+			{"type": "alerting", "name": "InstanceDown", "query": "up == 0", "duration": 300, "labels": {"severity": "page"},
+			 "annotations": {"summary": "down"}, "alerts": [], "health": "ok", "evaluationTime": 0.001,
+			 "lastEvaluation": "2024-01-01T00:00:00Z", "state": "inactive"},
+			{"type": "recording", "name": "job:up:sum", "query": "sum by (job) (up)", "health": "err", "lastError": "bad",
+			 "evaluationTime": 0.5, "lastEvaluation": "2024-01-01T00:00:00Z"}
+		]}]}`,
+		"/api/v1/targets": `{"activeTargets": [{"discoveredLabels": {"__address__": "b:9090"}, "labels": {"job": "api", "instance": "b:9090"},
+			"scrapePool": "api", "scrapeUrl": "http://b:9090/metrics", "globalUrl": "http://b:9090/metrics", "lastError": "connection refused",
+			"lastScrape": "2024-01-01T00:00:00Z", "lastScrapeDuration": 0.25, "health": "down"}], "droppedTargets": []}`,
+	})
+
+	c, err := client.NewClient(address)
+	require.NoError(t, err)
+
+	alerts, err := c.Alerts(10 * time.Second)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "InstanceDown", alerts[0].Labels["alertname"])
+	assert.Equal(t, "firing", alerts[0].State)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), alerts[0].ActiveAt)
+
+	groups, err := c.Rules(10 * time.Second)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, 30*time.Second, groups[0].Interval)
+	require.Len(t, groups[0].Rules, 2)
+	assert.Equal(t, "alerting", groups[0].Rules[0].Type)
+	assert.Equal(t, 5*time.Minute, groups[0].Rules[0].Duration)
+	assert.Equal(t, "page", groups[0].Rules[0].Labels["severity"])
+	assert.Equal(t, "recording", groups[0].Rules[1].Type)
+	assert.Equal(t, "err", groups[0].Rules[1].Health)
+	assert.Equal(t, 500*time.Millisecond, groups[0].Rules[1].EvaluationTime)
+
+	targets, err := c.Targets(10 * time.Second)
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, "down", targets[0].Health)
+	assert.Equal(t, "connection refused", targets[0].LastError)
+	assert.Equal(t, 250*time.Millisecond, targets[0].LastScrapeDuration)
+	assert.Equal(t, "b:9090", targets[0].DiscoveredLabels["__address__"])
+}
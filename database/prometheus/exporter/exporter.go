@@ -7,7 +7,12 @@
 //   - Automatic metric collection and exposition
 //   - Built-in HTTP server for /metrics endpoint
 //   - Support for multiple collectors
+//   - Additional handlers (e.g., a remote-write receiver) on the same server
 //   - Graceful server shutdown
+//   - Independent Exporter instances, each with its own registry
+//   - Counter, gauge, histogram and native histogram helpers with exemplars
+//   - Basic auth, bearer token and mTLS for the metrics endpoint
+//   - Liveness (/healthz) and readiness (/readyz) endpoints
 //
 // Example:
 //
@@ -133,6 +138,28 @@ func UnRegisterCollector(collectors ...prometheus.Collector) bool {
 	return true
 }
 
+// RegisterHandler registers an additional HTTP handler on the exporter server, so that
+// endpoints such as a remote-write receiver are served next to the metrics endpoint.
+//
+// Handlers must be registered before Start.
+//
+// Parameters:
+//   - method: HTTP method (http.MethodGet, http.MethodPost, etc.)
+//   - urlPath: URL path of the handler (e.g., "/api/v1/write")
+//   - handler: HTTP handler to handle requests
+//
+// Example:
+//
+//	exporter.RegisterHandler(http.MethodPost, "/api/v1/write", remotewrite.NewHandler(
+//	    func(series []remotewrite.TimeSeries) error {
+//	        return store(series)
+//	    },
+//	))
+//	exporter.Start(":9090", "/metrics", func(err error) { log.Println(err) })
+func RegisterHandler(method, urlPath string, handler net_http.Handler) {
+	server.RegisterHandler(method, urlPath, handler)
+}
+
 // Start starts the HTTP server that exposes Prometheus metrics at the specified endpoint.
 //
 // The server runs in the current goroutine and blocks until it's stopped via the
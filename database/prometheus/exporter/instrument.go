@@ -0,0 +1,222 @@
+package exporter
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Counter is a counter with optional labels, registered with an Exporter.
+type Counter struct {
+	vec *prometheus.CounterVec
+}
+
+// Gauge is a gauge with optional labels, registered with an Exporter.
+type Gauge struct {
+	vec *prometheus.GaugeVec
+}
+
+// Histogram is a classic or native histogram with optional labels, registered with an Exporter.
+type Histogram struct {
+	vec *prometheus.HistogramVec
+}
+
+// NewCounter creates a counter and registers it with the exporter.
+//
+// Parameters:
+//   - opts: Counter name, help text and constant labels
+//   - labelNames: Names of the variable labels, whose values are passed to each method
+//
+// Returns:
+//   - *Counter: Registered counter
+//   - error: Error if registration fails (e.g., duplicate metric name)
+//
+// Example:
+//
+//	requests, err := e.NewCounter(prometheus.CounterOpts{
+//	    Name: "app_requests_total",
+//	    Help: "Total number of requests.",
+//	}, "method", "status")
+//
+//	requests.Inc("GET", "200")
+//	requests.AddWithExemplar(1, prometheus.Labels{"trace_id": traceID}, "GET", "500")
+func (e *Exporter) NewCounter(opts prometheus.CounterOpts, labelNames ...string) (*Counter, error) {
+	vec := prometheus.NewCounterVec(opts, labelNames)
+	if err := e.registry.Register(vec); err != nil {
+		return nil, err
+	}
+
+	return &Counter{vec: vec}, nil
+}
+
+// Inc increments the counter of labelValues by 1.
+func (c *Counter) Inc(labelValues ...string) {
+	c.vec.WithLabelValues(labelValues...).Inc()
+}
+
+// Add adds value, which must not be negative, to the counter of labelValues.
+func (c *Counter) Add(value float64, labelValues ...string) {
+	c.vec.WithLabelValues(labelValues...).Add(value)
+}
+
+// AddWithExemplar adds value to the counter of labelValues and attaches an exemplar, such
+// as a trace ID, which is exposed in the OpenMetrics format.
+func (c *Counter) AddWithExemplar(value float64, exemplar prometheus.Labels, labelValues ...string) {
+	c.vec.WithLabelValues(labelValues...).(prometheus.ExemplarAdder).AddWithExemplar(value, exemplar)
+}
+
+// Delete removes the counter of labelValues, e.g., of a label value that no longer exists.
+func (c *Counter) Delete(labelValues ...string) bool {
+	return c.vec.DeleteLabelValues(labelValues...)
+}
+
+// NewGauge creates a gauge and registers it with the exporter.
+//
+// Parameters:
+//   - opts: Gauge name, help text and constant labels
+//   - labelNames: Names of the variable labels, whose values are passed to each method
+//
+// Returns:
+//   - *Gauge: Registered gauge
+//   - error: Error if registration fails (e.g., duplicate metric name)
+//
+// Example:
+//
+//	queueLength, err := e.NewGauge(prometheus.GaugeOpts{
+//	    Name: "app_queue_length",
+//	    Help: "Number of queued jobs.",
+//	}, "queue")
+//
+//	queueLength.Set(42, "email")
+func (e *Exporter) NewGauge(opts prometheus.GaugeOpts, labelNames ...string) (*Gauge, error) {
+	vec := prometheus.NewGaugeVec(opts, labelNames)
+	if err := e.registry.Register(vec); err != nil {
+		return nil, err
+	}
+
+	return &Gauge{vec: vec}, nil
+}
+
+// Set sets the gauge of labelValues to value.
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.vec.WithLabelValues(labelValues...).Set(value)
+}
+
+// Inc increments the gauge of labelValues by 1.
+func (g *Gauge) Inc(labelValues ...string) {
+	g.vec.WithLabelValues(labelValues...).Inc()
+}
+
+// Dec decrements the gauge of labelValues by 1.
+func (g *Gauge) Dec(labelValues ...string) {
+	g.vec.WithLabelValues(labelValues...).Dec()
+}
+
+// Add adds value, which may be negative, to the gauge of labelValues.
+func (g *Gauge) Add(value float64, labelValues ...string) {
+	g.vec.WithLabelValues(labelValues...).Add(value)
+}
+
+// SetToCurrentTime sets the gauge of labelValues to the current Unix time in seconds.
+func (g *Gauge) SetToCurrentTime(labelValues ...string) {
+	g.vec.WithLabelValues(labelValues...).SetToCurrentTime()
+}
+
+// Delete removes the gauge of labelValues.
+func (g *Gauge) Delete(labelValues ...string) bool {
+	return g.vec.DeleteLabelValues(labelValues...)
+}
+
+// NewHistogram creates a classic histogram with fixed buckets and registers it with the
+// exporter.
+//
+// Parameters:
+//   - opts: Histogram name, help text and buckets; nil Buckets uses prometheus.DefBuckets
+//   - labelNames: Names of the variable labels, whose values are passed to each method
+//
+// Returns:
+//   - *Histogram: Registered histogram
+//   - error: Error if registration fails (e.g., duplicate metric name)
+//
+// Example:
+//
+//	latency, err := e.NewHistogram(prometheus.HistogramOpts{
+//	    Name:    "app_request_duration_seconds",
+//	    Help:    "Request latency.",
+//	    Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1},
+//	}, "method")
+//
+//	latency.Observe(0.042, "GET")
+func (e *Exporter) NewHistogram(opts prometheus.HistogramOpts, labelNames ...string) (*Histogram, error) {
+	vec := prometheus.NewHistogramVec(opts, labelNames)
+	if err := e.registry.Register(vec); err != nil {
+		return nil, err
+	}
+
+	return &Histogram{vec: vec}, nil
+}
+
+// NewNativeHistogram creates a native histogram, whose exponential buckets adapt to the
+// observed values, and registers it with the exporter.
+//
+// Unset native histogram options default to a bucket factor of 1.1, at most 160 buckets and
+// a reset after one hour once the limit is reached. If opts.Buckets is set, the classic
+// buckets are exposed as well, for scrapers that do not support native histograms.
+//
+// Native histograms are only scraped in the protobuf format and require Prometheus to run
+// with native histograms enabled.
+//
+// Parameters:
+//   - opts: Histogram name, help text and optional native histogram settings
+//   - labelNames: Names of the variable labels, whose values are passed to each method
+//
+// Returns:
+//   - *Histogram: Registered histogram
+//   - error: Error if registration fails (e.g., duplicate metric name)
+//
+// Example:
+//
+//	latency, err := e.NewNativeHistogram(prometheus.HistogramOpts{
+//	    Name: "app_request_duration_seconds",
+//	    Help: "Request latency.",
+//	}, "method")
+//
+//	latency.ObserveWithExemplar(0.042, prometheus.Labels{"trace_id": traceID}, "GET")
+func (e *Exporter) NewNativeHistogram(opts prometheus.HistogramOpts, labelNames ...string) (*Histogram, error) {
+	if opts.NativeHistogramBucketFactor <= 1 {
+		opts.NativeHistogramBucketFactor = 1.1
+	}
+	if opts.NativeHistogramMaxBucketNumber == 0 {
+		opts.NativeHistogramMaxBucketNumber = 160
+	}
+	if opts.NativeHistogramMinResetDuration == 0 {
+		opts.NativeHistogramMinResetDuration = time.Hour
+	}
+
+	return e.NewHistogram(opts, labelNames...)
+}
+
+// Observe adds an observation to the histogram of labelValues.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Observe(value)
+}
+
+// ObserveWithExemplar adds an observation to the histogram of labelValues and attaches an
+// exemplar, such as a trace ID, which is exposed in the OpenMetrics and protobuf formats.
+func (h *Histogram) ObserveWithExemplar(value float64, exemplar prometheus.Labels, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).(prometheus.ExemplarObserver).ObserveWithExemplar(value, exemplar)
+}
+
+// ObserveDuration observes the time elapsed since start in seconds.
+//
+// Example:
+//
+//	defer latency.ObserveDuration(time.Now(), "GET")
+func (h *Histogram) ObserveDuration(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+// Delete removes the histogram of labelValues.
+func (h *Histogram) Delete(labelValues ...string) bool {
+	return h.vec.DeleteLabelValues(labelValues...)
+}
@@ -0,0 +1,96 @@
+package exporter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/prometheus/exporter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gather(t *testing.T, e *exporter.Exporter, name string) *dto.MetricFamily {
+	t.Helper()
+
+	families, err := e.Registry().Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() == name {
+			return family
+		}
+	}
+	require.Failf(t, "metric family not found", name)
+
+	return nil
+}
+
+func TestExporterCounterAndGauge(t *testing.T) {
+	e, err := exporter.NewExporter(exporter.Config{})
+	require.NoError(t, err)
+
+	counter, err := e.NewCounter(prometheus.CounterOpts{Name: "test_requests_total", Help: "Requests."}, "method")
+	require.NoError(t, err)
+
+	counter.Inc("GET")
+	counter.Add(2, "GET")
+	counter.AddWithExemplar(1, prometheus.Labels{"trace_id": "abc"}, "POST")
+
+	family := gather(t, e, "test_requests_total")
+	require.Len(t, family.GetMetric(), 2)
+	assert.Equal(t, 3.0, family.GetMetric()[0].GetCounter().GetValue())
+	assert.Equal(t, "abc", family.GetMetric()[1].GetCounter().GetExemplar().GetLabel()[0].GetValue())
+
+	assert.True(t, counter.Delete("GET"))
+	assert.Len(t, gather(t, e, "test_requests_total").GetMetric(), 1)
+
+	_, err = e.NewCounter(prometheus.CounterOpts{Name: "test_requests_total", Help: "Requests."}, "method")
+	assert.Error(t, err)
+
+	gauge, err := e.NewGauge(prometheus.GaugeOpts{Name: "test_queue_length", Help: "Queue length."})
+	require.NoError(t, err)
+
+	gauge.Set(10)
+	gauge.Inc()
+	gauge.Add(-3)
+	gauge.Dec()
+	assert.Equal(t, 7.0, gather(t, e, "test_queue_length").GetMetric()[0].GetGauge().GetValue())
+
+	gauge.SetToCurrentTime()
+	assert.InDelta(t, float64(time.Now().Unix()), gather(t, e, "test_queue_length").GetMetric()[0].GetGauge().GetValue(), 5)
+}
+
+func TestExporterHistograms(t *testing.T) {
+	e, err := exporter.NewExporter(exporter.Config{})
+	require.NoError(t, err)
+
+	classic, err := e.NewHistogram(prometheus.HistogramOpts{Name: "test_classic_seconds", Help: "Classic.", Buckets: []float64{0.1, 1}}, "method")
+	require.NoError(t, err)
+
+	classic.Observe(0.05, "GET")
+	classic.ObserveWithExemplar(0.5, prometheus.Labels{"trace_id": "abc"}, "GET")
+	classic.ObserveDuration(time.Now(), "GET")
+
+	histogram := gather(t, e, "test_classic_seconds").GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(3), histogram.GetSampleCount())
+	assert.Len(t, histogram.GetBucket(), 2)
+	assert.Equal(t, "abc", histogram.GetBucket()[1].GetExemplar().GetLabel()[0].GetValue())
+
+	native, err := e.NewNativeHistogram(prometheus.HistogramOpts{Name: "test_native_seconds", Help: "Native."})
+	require.NoError(t, err)
+
+	native.Observe(0.042)
+	native.ObserveWithExemplar(1.5, prometheus.Labels{"trace_id": "def"})
+
+	histogram = gather(t, e, "test_native_seconds").GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(2), histogram.GetSampleCount())
+	assert.Equal(t, int32(3), histogram.GetSchema())
+	assert.NotEmpty(t, histogram.GetPositiveSpan())
+	require.Len(t, histogram.GetExemplars(), 1)
+	assert.Equal(t, "def", histogram.GetExemplars()[0].GetLabel()[0].GetValue())
+
+	assert.Equal(t, 2, testutil.CollectAndCount(e.Registry(), "test_classic_seconds", "test_native_seconds"))
+}
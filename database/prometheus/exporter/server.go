@@ -0,0 +1,402 @@
+package exporter
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	net_http "net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Config is the configuration of an Exporter.
+type Config struct {
+	// Address is the server bind address (e.g., ":9090"; ":0" picks a free port)
+	Address string
+
+	// MetricsPath is the path of the metrics endpoint (default "/metrics")
+	MetricsPath string
+
+	// HealthPath is the path of the liveness endpoint (default "/healthz")
+	HealthPath string
+
+	// ReadyPath is the path of the readiness endpoint (default "/readyz")
+	ReadyPath string
+
+	// Registry holds the metrics of the exporter; nil creates a new registry
+	Registry *prometheus.Registry
+
+	// Username and Password require basic authentication
+	Username string
+	Password string
+
+	// BearerToken requires an "Authorization: Bearer <token>" header
+	BearerToken string
+
+	// CertFile and KeyFile serve over TLS
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile requires client certificates signed by these CAs (mTLS) on the metrics endpoint
+	// and registered handlers; requires CertFile and KeyFile
+	ClientCAFile string
+
+	// ReadinessCheck is called on each readiness request; an error reports the exporter as not ready
+	ReadinessCheck func() error
+}
+
+// Exporter is an independent metrics server with its own registry.
+//
+// Unlike the package-level functions, which serve the default registry, any number of
+// exporters can run in one process.
+type Exporter struct {
+	config    Config
+	registry  *prometheus.Registry
+	handler   *net_http.ServeMux
+	tlsConfig *tls.Config
+
+	ready   atomic.Bool
+	running atomic.Bool
+
+	mutex    sync.Mutex
+	server   *net_http.Server
+	listener net.Listener
+}
+
+// NewExporter creates an exporter serving the metrics of its registry, a liveness endpoint
+// and a readiness endpoint.
+//
+// Basic authentication, bearer token and client certificates protect the metrics endpoint
+// and handlers added with RegisterHandler. The liveness and readiness endpoints stay open
+// for orchestrator probes.
+//
+// Parameters:
+//   - config: Exporter configuration
+//
+// Returns:
+//   - *Exporter: Exporter to register metrics with and start
+//   - error: Error if the TLS files cannot be loaded or the configuration is inconsistent
+//
+// Example:
+//
+//	e, err := exporter.NewExporter(exporter.Config{
+//	    Address:      ":9443",
+//	    BearerToken:  os.Getenv("METRICS_TOKEN"),
+//	    CertFile:     "/etc/tls/tls.crt",
+//	    KeyFile:      "/etc/tls/tls.key",
+//	    ClientCAFile: "/etc/tls/ca.crt",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	requests, err := e.NewCounter(prometheus.CounterOpts{Name: "app_requests_total", Help: "Requests."}, "method")
+//	e.Start(func(err error) { log.Println(err) })
+//	defer e.Stop(10 * time.Second)
+func NewExporter(config Config) (*Exporter, error) {
+	if config.MetricsPath == "" {
+		config.MetricsPath = "/metrics"
+	}
+	if config.HealthPath == "" {
+		config.HealthPath = "/healthz"
+	}
+	if config.ReadyPath == "" {
+		config.ReadyPath = "/readyz"
+	}
+	if config.Registry == nil {
+		config.Registry = prometheus.NewRegistry()
+	}
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, errors.New("CertFile and KeyFile must be set together")
+	}
+	if config.ClientCAFile != "" && config.CertFile == "" {
+		return nil, errors.New("ClientCAFile requires CertFile and KeyFile")
+	}
+
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Exporter{config: config, registry: config.Registry, handler: net_http.NewServeMux(), tlsConfig: tlsConfig}
+	e.ready.Store(true)
+
+	metricsHandler := promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{
+		Registry:          e.registry,
+		EnableOpenMetrics: true,
+	})
+	e.handler.Handle(net_http.MethodGet+" "+config.MetricsPath, e.authenticate(metricsHandler))
+	e.handler.HandleFunc(net_http.MethodGet+" "+config.HealthPath, e.serveHealth)
+	e.handler.HandleFunc(net_http.MethodGet+" "+config.ReadyPath, e.serveReady)
+
+	return e, nil
+}
+
+// Registry returns the registry of the exporter, for collectors that are registered directly.
+func (e *Exporter) Registry() *prometheus.Registry {
+	return e.registry
+}
+
+// RegisterCollector registers one or more collectors with the registry of the exporter.
+//
+// Parameters:
+//   - collectors: Collectors such as those created by NewCollector
+//
+// Returns:
+//   - error: Error if registration fails (e.g., duplicate collector)
+//
+// Example:
+//
+//	err := e.RegisterCollector(exporter.NewCollector(metrics), collectors.NewGoCollector())
+func (e *Exporter) RegisterCollector(collectors ...prometheus.Collector) error {
+	for _, collector := range collectors {
+		if err := e.registry.Register(collector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnRegisterCollector unregisters one or more collectors from the registry of the exporter.
+//
+// Returns:
+//   - bool: true if all collectors were unregistered, false otherwise
+func (e *Exporter) UnRegisterCollector(collectors ...prometheus.Collector) bool {
+	for _, collector := range collectors {
+		if !e.registry.Unregister(collector) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RegisterHandler registers an additional HTTP handler, protected by the same
+// authentication as the metrics endpoint.
+//
+// Parameters:
+//   - method: HTTP method (http.MethodGet, http.MethodPost, etc.)
+//   - urlPath: URL path of the handler (e.g., "/api/v1/write")
+//   - handler: HTTP handler to handle requests
+func (e *Exporter) RegisterHandler(method, urlPath string, handler net_http.Handler) {
+	e.handler.Handle(method+" "+urlPath, e.authenticate(handler))
+}
+
+// SetReady sets whether the readiness endpoint reports the exporter as ready.
+//
+// Exporters are ready by default; Stop marks them as not ready before shutting down and Start
+// marks them as ready again.
+//
+// Example:
+//
+//	e.SetReady(false)
+//	warmUpCaches()
+//	e.SetReady(true)
+func (e *Exporter) SetReady(ready bool) {
+	e.ready.Store(ready)
+}
+
+// Handler returns the HTTP handler of the exporter, to be mounted on an existing server.
+func (e *Exporter) Handler() net_http.Handler {
+	return e.handler
+}
+
+// Start binds the configured address, marks the exporter as ready and serves in the background.
+//
+// Parameters:
+//   - listenAndServeFailureFunc: Callback invoked when serving fails after the server started
+//
+// Returns:
+//   - error: Error if the exporter is already running or the address cannot be bound
+//
+// Example:
+//
+//	if err := e.Start(func(err error) { log.Println(err) }); err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Println("metrics on", e.Address())
+func (e *Exporter) Start(listenAndServeFailureFunc func(err error)) error {
+	if !e.running.CompareAndSwap(false, true) {
+		return errors.New("server already started")
+	}
+
+	listener, err := net.Listen("tcp", e.config.Address)
+	if err != nil {
+		e.running.Store(false)
+		return err
+	}
+	if e.tlsConfig != nil {
+		listener = tls.NewListener(listener, e.tlsConfig)
+	}
+
+	server := &net_http.Server{Handler: e.handler, ReadHeaderTimeout: 10 * time.Second}
+
+	e.mutex.Lock()
+	e.server = server
+	e.listener = listener
+	e.mutex.Unlock()
+
+	e.ready.Store(true)
+
+	go func() {
+		if err := server.Serve(listener); err != nil && err != net_http.ErrServerClosed {
+			e.running.Store(false)
+			if listenAndServeFailureFunc != nil {
+				listenAndServeFailureFunc(err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop marks the exporter as not ready and gracefully shuts it down.
+//
+// Parameters:
+//   - timeout: Maximum duration to wait for active requests to complete
+//
+// Returns:
+//   - error: Error if shutdown fails or times out
+func (e *Exporter) Stop(timeout time.Duration) error {
+	e.mutex.Lock()
+	server := e.server
+	e.server = nil
+	e.listener = nil
+	e.mutex.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	defer e.running.Store(false)
+	e.ready.Store(false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return server.Shutdown(ctx)
+}
+
+// IsRunning returns whether the exporter is serving.
+func (e *Exporter) IsRunning() bool {
+	return e.running.Load()
+}
+
+// Address returns the address the exporter listens on, or an empty string if it is not
+// running. It resolves ":0" to the chosen port.
+func (e *Exporter) Address() string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.listener == nil {
+		return ""
+	}
+
+	return e.listener.Addr().String()
+}
+
+func (e *Exporter) serveHealth(w net_http.ResponseWriter, _ *net_http.Request) {
+	w.Write([]byte("ok"))
+}
+
+func (e *Exporter) serveReady(w net_http.ResponseWriter, _ *net_http.Request) {
+	if !e.ready.Load() {
+		net_http.Error(w, "not ready", net_http.StatusServiceUnavailable)
+		return
+	}
+
+	if e.config.ReadinessCheck != nil {
+		if err := e.config.ReadinessCheck(); err != nil {
+			net_http.Error(w, "not ready: "+err.Error(), net_http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	w.Write([]byte("ok"))
+}
+
+func (e *Exporter) authenticate(handler net_http.Handler) net_http.Handler {
+	if e.config.ClientCAFile != "" {
+		handler = e.requireClientCertificate(handler)
+	}
+
+	if e.config.Username == "" && e.config.Password == "" && e.config.BearerToken == "" {
+		return handler
+	}
+
+	return net_http.HandlerFunc(func(w net_http.ResponseWriter, r *net_http.Request) {
+		if username, password, ok := r.BasicAuth(); ok && (e.config.Username != "" || e.config.Password != "") {
+			if equal(username, e.config.Username) && equal(password, e.config.Password) {
+				handler.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		if e.config.BearerToken != "" && equal(r.Header.Get("Authorization"), "Bearer "+e.config.BearerToken) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		if e.config.Username != "" || e.config.Password != "" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="metrics"`)
+		}
+		net_http.Error(w, "unauthorized", net_http.StatusUnauthorized)
+	})
+}
+
+// requireClientCertificate rejects requests without a verified client certificate. The TLS
+// handshake only verifies certificates that are given, so that probes reach the liveness and
+// readiness endpoints without one.
+func (e *Exporter) requireClientCertificate(handler net_http.Handler) net_http.Handler {
+	return net_http.HandlerFunc(func(w net_http.ResponseWriter, r *net_http.Request) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+			net_http.Error(w, "client certificate required", net_http.StatusUnauthorized)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func newTLSConfig(config Config) (*tls.Config, error) {
+	if config.CertFile == "" {
+		return nil, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12}
+
+	if config.ClientCAFile != "" {
+		pem, err := os.ReadFile(config.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in ClientCAFile")
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
+
+func equal(actual, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(actual), []byte(expected)) == 1
+}
@@ -0,0 +1,250 @@
+package exporter_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/prometheus/exporter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startExporter(t *testing.T, config exporter.Config) *exporter.Exporter {
+	t.Helper()
+
+	config.Address = "127.0.0.1:0"
+	e, err := exporter.NewExporter(config)
+	require.NoError(t, err)
+	require.NoError(t, e.Start(func(err error) { t.Log(err) }))
+	t.Cleanup(func() { e.Stop(5 * time.Second) })
+
+	return e
+}
+
+func get(t *testing.T, client *http.Client, url string, header http.Header) (int, string) {
+	t.Helper()
+
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for key, values := range header {
+		request.Header[key] = values
+	}
+
+	response, err := client.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	return response.StatusCode, string(body)
+}
+
+func TestExporterInstances(t *testing.T) {
+	first := startExporter(t, exporter.Config{})
+	second := startExporter(t, exporter.Config{MetricsPath: "/custom"})
+
+	counter, err := first.NewCounter(prometheus.CounterOpts{Name: "first_total", Help: "First."})
+	require.NoError(t, err)
+	counter.Inc()
+
+	gauge, err := second.NewGauge(prometheus.GaugeOpts{Name: "second_value", Help: "Second."})
+	require.NoError(t, err)
+	gauge.Set(2)
+
+	_, err = second.NewCounter(prometheus.CounterOpts{Name: "first_total", Help: "First."})
+	require.NoError(t, err)
+
+	status, body := get(t, http.DefaultClient, "http://"+first.Address()+"/metrics", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "first_total 1")
+	assert.NotContains(t, body, "second_value")
+
+	status, body = get(t, http.DefaultClient, "http://"+second.Address()+"/custom", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "second_value 2")
+	assert.NotContains(t, body, "first_total 1")
+
+	assert.Error(t, first.Start(nil))
+	assert.True(t, first.IsRunning())
+}
+
+func TestExporterHealthAndReadiness(t *testing.T) {
+	reachable := atomic.Bool{}
+	reachable.Store(true)
+	e := startExporter(t, exporter.Config{BearerToken: "token", ReadinessCheck: func() error {
+		if !reachable.Load() {
+			return errors.New("database unreachable")
+		}
+		return nil
+	}})
+	address := "http://" + e.Address()
+
+	status, _ := get(t, http.DefaultClient, address+"/healthz", nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	status, _ = get(t, http.DefaultClient, address+"/readyz", nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	e.SetReady(false)
+	status, _ = get(t, http.DefaultClient, address+"/readyz", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	e.SetReady(true)
+	reachable.Store(false)
+	status, body := get(t, http.DefaultClient, address+"/readyz", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Contains(t, body, "database unreachable")
+
+	require.NoError(t, e.Stop(5*time.Second))
+	assert.False(t, e.IsRunning())
+	assert.Empty(t, e.Address())
+
+	reachable.Store(true)
+	require.NoError(t, e.Start(nil))
+	status, _ = get(t, http.DefaultClient, "http://"+e.Address()+"/readyz", nil)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestExporterAuthentication(t *testing.T) {
+	e := startExporter(t, exporter.Config{Username: "prometheus", Password: "secret", BearerToken: "token"})
+	e.RegisterHandler(http.MethodGet, "/extra", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("extra"))
+	}))
+	address := "http://" + e.Address()
+
+	basic := func(username, password string) http.Header {
+		request, _ := http.NewRequest(http.MethodGet, "/", nil)
+		request.SetBasicAuth(username, password)
+		return request.Header
+	}
+
+	for _, test := range []struct {
+		header   http.Header
+		expected int
+	}{
+		{nil, http.StatusUnauthorized},
+		{basic("prometheus", "wrong"), http.StatusUnauthorized},
+		{http.Header{"Authorization": {"Bearer wrong"}}, http.StatusUnauthorized},
+		{basic("prometheus", "secret"), http.StatusOK},
+		{http.Header{"Authorization": {"Bearer token"}}, http.StatusOK},
+	} {
+		status, _ := get(t, http.DefaultClient, address+"/metrics", test.header)
+		assert.Equal(t, test.expected, status, test.header)
+
+		status, _ = get(t, http.DefaultClient, address+"/extra", test.header)
+		assert.Equal(t, test.expected, status, test.header)
+	}
+
+	status, _ := get(t, http.DefaultClient, address+"/healthz", nil)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func writeCertificate(t *testing.T, directory, name string, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(directory, name+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(directory, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certificate, key
+}
+
+func TestExporterMutualTLS(t *testing.T) {
+	directory := t.TempDir()
+	notAfter := time.Now().Add(time.Hour)
+
+	ca, caKey := writeCertificate(t, directory, "ca", &x509.Certificate{
+		SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "test-ca"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: notAfter,
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}, nil, nil)
+	writeCertificate(t, directory, "server", &x509.Certificate{
+		SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "localhost"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: notAfter,
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	writeCertificate(t, directory, "client", &x509.Certificate{
+		SerialNumber: big.NewInt(3), Subject: pkix.Name{CommonName: "prometheus"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: notAfter,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	e := startExporter(t, exporter.Config{
+		CertFile:     filepath.Join(directory, "server.crt"),
+		KeyFile:      filepath.Join(directory, "server.key"),
+		ClientCAFile: filepath.Join(directory, "ca.crt"),
+	})
+	address := "https://" + e.Address()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	clientCertificate, err := tls.LoadX509KeyPair(filepath.Join(directory, "client.crt"), filepath.Join(directory, "client.key"))
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, Certificates: []tls.Certificate{clientCertificate}}}}
+	status, _ := get(t, client, address+"/metrics", nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	anonymous := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+	status, _ = get(t, anonymous, address+"/metrics", nil)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = get(t, anonymous, address+"/healthz", nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	status, _ = get(t, anonymous, address+"/readyz", nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	_, err = exporter.NewExporter(exporter.Config{
+		CertFile:     filepath.Join(directory, "server.crt"),
+		KeyFile:      filepath.Join(directory, "server.key"),
+		ClientCAFile: filepath.Join(directory, "server.key"),
+	})
+	assert.Error(t, err)
+}
+
+func TestNewExporterInvalidConfig(t *testing.T) {
+	_, err := exporter.NewExporter(exporter.Config{CertFile: "server.crt"})
+	assert.Error(t, err)
+
+	_, err = exporter.NewExporter(exporter.Config{ClientCAFile: "ca.crt"})
+	assert.Error(t, err)
+
+	_, err = exporter.NewExporter(exporter.Config{Address: "127.0.0.1:0", CertFile: "missing.crt", KeyFile: "missing.key"})
+	assert.Error(t, err)
+
+	e, err := exporter.NewExporter(exporter.Config{Address: "invalid:address:1"})
+	require.NoError(t, err)
+	err = e.Start(nil)
+	assert.Error(t, err)
+	assert.False(t, strings.Contains(err.Error(), "already started"))
+}
@@ -0,0 +1,182 @@
+// Package pushgateway provides a Prometheus Pushgateway client for batch and cron jobs that
+// finish before Prometheus scrapes them.
+//
+// Features:
+//   - Push (replace all metrics of a group), add (replace metrics of the same name) and delete
+//   - Grouping by job and additional labels
+//   - Collectors from exporter.NewCollector or any prometheus.Collector
+//   - Whole registries, such as the registry of an exporter.Exporter
+//   - Basic authentication and request timeout
+//
+// Example:
+//
+//	c := pushgateway.NewClient("http://pushgateway:9091", 10*time.Second)
+//
+//	completion := prometheus.NewGauge(prometheus.GaugeOpts{
+//	    Name: "backup_last_completion_timestamp_seconds",
+//	    Help: "Time of the last successful backup.",
+//	})
+//	completion.SetToCurrentTime()
+//
+//	err := c.Push("backup", map[string]string{"instance": "db-1"}, completion)
+package pushgateway
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// Client pushes metrics to a Pushgateway.
+type Client struct {
+	address    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClient creates a Pushgateway client.
+//
+// Parameters:
+//   - address: Pushgateway URL (e.g., "http://localhost:9091")
+//   - timeout: Timeout of each request; zero means no timeout
+//
+// Returns:
+//   - *Client: Pushgateway client
+//
+// Example:
+//
+//	c := pushgateway.NewClient("http://localhost:9091", 10*time.Second)
+func NewClient(address string, timeout time.Duration) *Client {
+	return &Client{address: address, httpClient: &http.Client{Timeout: timeout}}
+}
+
+// NewClientWithBasicAuth creates a Pushgateway client with basic authentication.
+//
+// Parameters:
+//   - address: Pushgateway URL (e.g., "http://localhost:9091")
+//   - username: Basic auth username
+//   - password: Basic auth password
+//   - timeout: Timeout of each request; zero means no timeout
+//
+// Returns:
+//   - *Client: Pushgateway client
+//
+// Example:
+//
+//	c := pushgateway.NewClientWithBasicAuth("https://pushgateway.example.com", "jobs", "secret", 10*time.Second)
+func NewClientWithBasicAuth(address, username, password string, timeout time.Duration) *Client {
+	c := NewClient(address, timeout)
+	c.username = username
+	c.password = password
+
+	return c
+}
+
+// Push replaces all metrics of the group identified by job and grouping with the metrics of
+// collectors (HTTP PUT).
+//
+// Parameters:
+//   - job: Job label of the group
+//   - grouping: Additional grouping labels (e.g., {"instance": "db-1"}); may be nil
+//   - collectors: Collectors such as those created by exporter.NewCollector
+//
+// Returns:
+//   - error: Error if a collector fails, a metric carries a grouping label, or the push fails
+//
+// Example:
+//
+//	collector := exporter.NewCollector([]exporter.Metric{processedRecords})
+//	err := c.Push("etl", map[string]string{"dataset": "orders"}, collector)
+func (c *Client) Push(job string, grouping map[string]string, collectors ...prometheus.Collector) error {
+	return c.pusher(job, grouping, collectors...).PushContext(context.Background())
+}
+
+// Add replaces the metrics with the same names as the metrics of collectors in the group and
+// keeps the others (HTTP POST).
+//
+// Parameters:
+//   - job: Job label of the group
+//   - grouping: Additional grouping labels; may be nil
+//   - collectors: Collectors such as those created by exporter.NewCollector
+//
+// Returns:
+//   - error: Error if a collector fails, a metric carries a grouping label, or the push fails
+//
+// Example:
+//
+//	// Each stage of a pipeline adds its own metrics to the group
+//	err := c.Add("etl", map[string]string{"dataset": "orders"}, stageDuration)
+func (c *Client) Add(job string, grouping map[string]string, collectors ...prometheus.Collector) error {
+	return c.pusher(job, grouping, collectors...).AddContext(context.Background())
+}
+
+// PushGatherer replaces all metrics of the group with the metrics of gatherer, such as a
+// prometheus.Registry or the registry of an exporter.Exporter.
+//
+// Parameters:
+//   - job: Job label of the group
+//   - grouping: Additional grouping labels; may be nil
+//   - gatherer: Source of the metrics
+//
+// Returns:
+//   - error: Error if gathering or the push fails
+//
+// Example:
+//
+//	err := c.PushGatherer("backup", nil, e.Registry())
+func (c *Client) PushGatherer(job string, grouping map[string]string, gatherer prometheus.Gatherer) error {
+	return c.pusher(job, grouping).Gatherer(gatherer).PushContext(context.Background())
+}
+
+// AddGatherer replaces the metrics with the same names as the metrics of gatherer in the
+// group and keeps the others.
+//
+// Parameters:
+//   - job: Job label of the group
+//   - grouping: Additional grouping labels; may be nil
+//   - gatherer: Source of the metrics
+//
+// Returns:
+//   - error: Error if gathering or the push fails
+func (c *Client) AddGatherer(job string, grouping map[string]string, gatherer prometheus.Gatherer) error {
+	return c.pusher(job, grouping).Gatherer(gatherer).AddContext(context.Background())
+}
+
+// Delete deletes all metrics of the group identified by job and grouping.
+//
+// Parameters:
+//   - job: Job label of the group
+//   - grouping: Additional grouping labels; may be nil
+//
+// Returns:
+//   - error: Error if the request fails
+//
+// Example:
+//
+//	// Remove the metrics of a decommissioned instance
+//	err := c.Delete("backup", map[string]string{"instance": "db-1"})
+func (c *Client) Delete(job string, grouping map[string]string) error {
+	return c.pusher(job, grouping).Delete()
+}
+
+func (c *Client) pusher(job string, grouping map[string]string, collectors ...prometheus.Collector) *push.Pusher {
+	pusher := push.New(c.address, job).Client(c.httpClient)
+
+	for name, value := range grouping {
+		pusher = pusher.Grouping(name, value)
+	}
+
+	for _, collector := range collectors {
+		pusher = pusher.Collector(collector)
+	}
+
+	if c.username != "" || c.password != "" {
+		pusher = pusher.BasicAuth(c.username, c.password)
+	}
+
+	return pusher
+}
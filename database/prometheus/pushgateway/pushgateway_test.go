@@ -0,0 +1,149 @@
+package pushgateway_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/prometheus/exporter"
+	"github.com/common-library/go/database/prometheus/pushgateway"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type request struct {
+	method   string
+	path     string
+	username string
+	password string
+	families map[string]*dto.MetricFamily
+}
+
+type pushgatewayServer struct {
+	mutex    sync.Mutex
+	requests []request
+}
+
+func newPushgateway(t *testing.T, handler http.HandlerFunc) (*pushgatewayServer, string) {
+	t.Helper()
+
+	p := &pushgatewayServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := request{method: r.Method, path: r.URL.Path, families: map[string]*dto.MetricFamily{}}
+		req.username, req.password, _ = r.BasicAuth()
+
+		decoder := expfmt.NewDecoder(r.Body, expfmt.ResponseFormat(r.Header))
+		for {
+			family := &dto.MetricFamily{}
+			if err := decoder.Decode(family); err != nil {
+				break
+			}
+			req.families[family.GetName()] = family
+		}
+
+		p.mutex.Lock()
+		p.requests = append(p.requests, req)
+		p.mutex.Unlock()
+
+		if handler != nil {
+			handler(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	return p, server.URL
+}
+
+type testMetric struct {
+	desc *prometheus.Desc
+}
+
+func (m *testMetric) GetDesc() *prometheus.Desc {
+	return m.desc
+}
+
+func (m *testMetric) GetValueType() prometheus.ValueType {
+	return prometheus.GaugeValue
+}
+
+func (m *testMetric) GetValues() []exporter.Value {
+	return []exporter.Value{{Value: 42, LabelValues: []string{"orders"}}}
+}
+
+func TestClientPushAndAdd(t *testing.T) {
+	p, address := newPushgateway(t, nil)
+	c := pushgateway.NewClient(address, 5*time.Second)
+
+	collector := exporter.NewCollector([]exporter.Metric{&testMetric{
+		desc: prometheus.NewDesc("etl_processed_records", "Processed records.", []string{"table"}, nil),
+	}})
+	require.NoError(t, c.Push("etl", map[string]string{"instance": "db-1"}, collector))
+
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "etl_duration_seconds", Help: "Duration."})
+	gauge.Set(12.5)
+	require.NoError(t, c.Add("etl", nil, gauge))
+
+	require.Len(t, p.requests, 2)
+	assert.Equal(t, http.MethodPut, p.requests[0].method)
+	assert.Equal(t, "/metrics/job/etl/instance/db-1", p.requests[0].path)
+	assert.Equal(t, 42.0, p.requests[0].families["etl_processed_records"].GetMetric()[0].GetGauge().GetValue())
+
+	assert.Equal(t, http.MethodPost, p.requests[1].method)
+	assert.Equal(t, "/metrics/job/etl", p.requests[1].path)
+	assert.Equal(t, 12.5, p.requests[1].families["etl_duration_seconds"].GetMetric()[0].GetGauge().GetValue())
+	assert.Empty(t, p.requests[1].username)
+}
+
+func TestClientGathererAndDelete(t *testing.T) {
+	p, address := newPushgateway(t, nil)
+	c := pushgateway.NewClientWithBasicAuth(address, "jobs", "secret", 5*time.Second)
+
+	e, err := exporter.NewExporter(exporter.Config{})
+	require.NoError(t, err)
+	counter, err := e.NewCounter(prometheus.CounterOpts{Name: "backup_files_total", Help: "Files."})
+	require.NoError(t, err)
+	counter.Add(3)
+
+	require.NoError(t, c.PushGatherer("backup", map[string]string{"path": "/var/lib/data"}, e.Registry()))
+	require.NoError(t, c.AddGatherer("backup", nil, e.Registry()))
+	require.NoError(t, c.Delete("backup", nil))
+
+	require.Len(t, p.requests, 3)
+	assert.Equal(t, http.MethodPut, p.requests[0].method)
+	assert.Equal(t, "/metrics/job/backup/path@base64/L3Zhci9saWIvZGF0YQ", p.requests[0].path)
+	assert.Equal(t, 3.0, p.requests[0].families["backup_files_total"].GetMetric()[0].GetCounter().GetValue())
+	assert.Equal(t, http.MethodPost, p.requests[1].method)
+	assert.Equal(t, http.MethodDelete, p.requests[2].method)
+	assert.Equal(t, "/metrics/job/backup", p.requests[2].path)
+
+	for _, request := range p.requests {
+		assert.Equal(t, "jobs", request.username)
+		assert.Equal(t, "secret", request.password)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	_, address := newPushgateway(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "pushed metrics are invalid", http.StatusBadRequest)
+	})
+	c := pushgateway.NewClient(address, 5*time.Second)
+
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "job_value", Help: "Value."})
+	assert.ErrorContains(t, c.Push("job", nil, gauge), "pushed metrics are invalid")
+
+	labeled := prometheus.NewGauge(prometheus.GaugeOpts{Name: "job_value", Help: "Value.", ConstLabels: prometheus.Labels{"job": "other"}})
+	assert.Error(t, c.Push("job", nil, labeled))
+
+	_, slow := newPushgateway(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	})
+	c = pushgateway.NewClient(slow, 50*time.Millisecond)
+	assert.ErrorContains(t, c.Delete("job", nil), "Timeout")
+}
@@ -0,0 +1,461 @@
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+)
+
+// Config is the configuration of a remote-write Client.
+//
+// Zero values are replaced by the defaults noted on each field.
+type Config struct {
+	// URL is the remote-write endpoint (e.g., "http://prometheus:9090/api/v1/write")
+	URL string
+
+	// BatchSize is the maximum number of samples per request (default 500)
+	BatchSize int
+
+	// FlushInterval is how often a partial batch is sent (default 5s)
+	FlushInterval time.Duration
+
+	// MaxRetries is the number of retries of a failed request; negative disables retries (default 3)
+	MaxRetries int
+
+	// MinBackoff is the delay before the first retry, doubled on each retry (default 100ms)
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the retry delay (default 5s)
+	MaxBackoff time.Duration
+
+	// Timeout is the timeout of each request (default 30s)
+	Timeout time.Duration
+
+	// MaxQueuedBatches caps the queue; the oldest batches are dropped beyond it (default 1000)
+	MaxQueuedBatches int
+
+	// WALDirectory stores queued batches on disk so they survive restarts; empty keeps them in memory
+	WALDirectory string
+
+	// Username and Password enable basic authentication
+	Username string
+	Password string
+
+	// BearerToken enables bearer token authentication
+	BearerToken string
+
+	// Headers are added to every request (e.g., "X-Scope-OrgID" for multi-tenant receivers)
+	Headers map[string]string
+
+	// TLSConfig is used for https endpoints
+	TLSConfig *tls.Config
+
+	// ErrorHandler is invoked for errors of background sends and for dropped batches
+	ErrorHandler func(err error)
+}
+
+// Client is a remote-write sender that batches samples and sends them in the background.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+	queue      queue
+
+	mutex          sync.Mutex
+	pending        []TimeSeries
+	pendingSamples int
+	closed         bool
+
+	sendMutex sync.Mutex
+	notify    chan struct{}
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewClient creates a remote-write client and starts its background sender.
+//
+// Batches left in Config.WALDirectory by a previous process are sent first.
+//
+// Parameters:
+//   - config: Client configuration; URL is required
+//
+// Returns:
+//   - *Client: Running client, to be closed with Close
+//   - error: Error if the URL is empty or the WAL directory cannot be opened
+//
+// Example:
+//
+//	sender, err := remotewrite.NewClient(remotewrite.Config{
+//	    URL:           "http://prometheus:9090/api/v1/write",
+//	    BatchSize:     1000,
+//	    FlushInterval: 10 * time.Second,
+//	    WALDirectory:  "/var/lib/job/wal",
+//	    BearerToken:   token,
+//	    ErrorHandler:  func(err error) { log.Println(err) },
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer sender.Close()
+func NewClient(config Config) (*Client, error) {
+	if config.URL == "" {
+		return nil, errors.New("remote-write URL is required")
+	}
+
+	if config.BatchSize <= 0 {
+		config.BatchSize = 500
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 5 * time.Second
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = 3
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = 100 * time.Millisecond
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = 5 * time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.MaxQueuedBatches <= 0 {
+		config.MaxQueuedBatches = 1000
+	}
+
+	var q queue = newMemoryQueue(config.MaxQueuedBatches)
+	if config.WALDirectory != "" {
+		walQueue, err := newWALQueue(config.WALDirectory, config.MaxQueuedBatches)
+		if err != nil {
+			return nil, err
+		}
+		q = walQueue
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.TLSConfig != nil {
+		transport.TLSClientConfig = config.TLSConfig
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &Client{
+		config:     config,
+		httpClient: &http.Client{Transport: transport, Timeout: config.Timeout},
+		queue:      q,
+		notify:     make(chan struct{}, 1),
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+
+	go c.run()
+	if q.length() != 0 {
+		c.signal()
+	}
+
+	return c, nil
+}
+
+// Append queues series to be sent; a batch is cut as soon as BatchSize samples are pending.
+//
+// Parameters:
+//   - series: Time series with their samples and exemplars
+//
+// Returns:
+//   - error: Error if the client is closed or the batch cannot be written to the WAL
+//
+// Example:
+//
+//	err := sender.Append(remotewrite.TimeSeries{
+//	    Labels:  map[string]string{"__name__": "edge_temperature_celsius", "device": "d-17"},
+//	    Samples: []remotewrite.Sample{{Value: 21.5, Timestamp: time.Now()}},
+//	})
+func (c *Client) Append(series ...TimeSeries) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return errors.New("client is closed")
+	}
+
+	for _, ts := range series {
+		c.pending = append(c.pending, ts)
+		c.pendingSamples += len(ts.Samples)
+
+		if c.pendingSamples >= c.config.BatchSize {
+			if err := c.cut(); err != nil {
+				return err
+			}
+			c.signal()
+		}
+	}
+
+	return nil
+}
+
+// AppendSample queues a single sample of the series identified by labels.
+//
+// Parameters:
+//   - labels: Series labels including "__name__"
+//   - value: Sample value
+//   - timestamp: Sample time
+//
+// Returns:
+//   - error: Error if the client is closed or the batch cannot be written to the WAL
+//
+// Example:
+//
+//	sender.AppendSample(map[string]string{"__name__": "backup_last_success_timestamp_seconds", "job": "backup"},
+//	    float64(time.Now().Unix()), time.Now())
+func (c *Client) AppendSample(labels map[string]string, value float64, timestamp time.Time) error {
+	return c.Append(TimeSeries{Labels: labels, Samples: []Sample{{Value: value, Timestamp: timestamp}}})
+}
+
+// Flush cuts the pending samples into a batch and sends every queued batch, retrying as
+// configured.
+//
+// Batches that fail with a retryable error stay queued for the next attempt.
+//
+// Returns:
+//   - error: Errors of batches that failed or were dropped, joined
+//
+// Example:
+//
+//	// Before a short-lived job exits
+//	if err := sender.Flush(); err != nil {
+//	    log.Printf("remote write failed: %v", err)
+//	}
+func (c *Client) Flush() error {
+	c.mutex.Lock()
+	err := c.cut()
+	c.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return c.send(context.Background())
+}
+
+// Close stops the background sender and flushes the remaining samples.
+//
+// With a WAL directory, batches that could not be sent are kept for the next client.
+//
+// Returns:
+//   - error: Error of the final flush
+//
+// Example:
+//
+//	defer sender.Close()
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mutex.Unlock()
+
+	c.cancel()
+	<-c.done
+
+	return c.Flush()
+}
+
+// Queued returns the number of batches waiting to be sent.
+func (c *Client) Queued() int {
+	return c.queue.length()
+}
+
+func (c *Client) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			c.mutex.Lock()
+			err := c.cut()
+			c.mutex.Unlock()
+			if err != nil {
+				c.handleError(err)
+			}
+		case <-c.notify:
+		}
+
+		if err := c.send(c.ctx); err != nil && c.ctx.Err() == nil {
+			c.handleError(err)
+		}
+	}
+}
+
+func (c *Client) signal() {
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+// cut moves the pending series into a compressed batch; the caller must hold c.mutex.
+func (c *Client) cut() error {
+	if len(c.pending) == 0 {
+		return nil
+	}
+
+	// The pending series are kept until the batch is queued, so a failed WAL write loses nothing.
+	dropped, err := c.queue.push(snappy.Encode(nil, marshalWriteRequest(c.pending)))
+	if err != nil {
+		return err
+	}
+	c.pending = nil
+	c.pendingSamples = 0
+
+	if dropped != 0 {
+		c.handleError(fmt.Errorf("queue is full, dropped %d oldest batches", dropped))
+	}
+
+	return nil
+}
+
+func (c *Client) send(ctx context.Context) error {
+	c.sendMutex.Lock()
+	defer c.sendMutex.Unlock()
+
+	errs := []error{}
+	for ctx.Err() == nil {
+		id, batch, ok, err := c.queue.peek()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !ok {
+			break
+		}
+
+		err = c.sendWithRetry(ctx, batch)
+		if err == nil {
+			if err := c.queue.remove(id); err != nil {
+				return errors.Join(append(errs, err)...)
+			}
+			continue
+		}
+
+		var permanent *permanentError
+		if !errors.As(err, &permanent) {
+			return errors.Join(append(errs, err)...)
+		}
+
+		errs = append(errs, fmt.Errorf("dropped batch: %w", err))
+		if err := c.queue.remove(id); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Client) sendWithRetry(ctx context.Context, batch []byte) error {
+	backoff := c.config.MinBackoff
+
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := c.post(ctx, batch)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) || attempt >= c.config.MaxRetries {
+			return err
+		}
+
+		delay := backoff
+		if retryAfter > 0 {
+			delay = min(retryAfter, c.config.MaxBackoff)
+		}
+		backoff = min(backoff*2, c.config.MaxBackoff)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (c *Client) post(ctx context.Context, batch []byte) (time.Duration, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL, bytes.NewReader(batch))
+	if err != nil {
+		return 0, &permanentError{err: err}
+	}
+
+	request.Header.Set("Content-Encoding", "snappy")
+	request.Header.Set("Content-Type", "application/x-protobuf")
+	request.Header.Set("User-Agent", "common-library-remote-write")
+	request.Header.Set(versionHeader, version)
+	for key, value := range c.config.Headers {
+		request.Header.Set(key, value)
+	}
+	if c.config.Username != "" || c.config.Password != "" {
+		request.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+	if c.config.BearerToken != "" {
+		request.Header.Set("Authorization", "Bearer "+c.config.BearerToken)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 == 2 {
+		io.Copy(io.Discard, response.Body)
+		return 0, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	err = fmt.Errorf("remote write returned %s: %s", response.Status, bytes.TrimSpace(body))
+
+	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode/100 == 5 {
+		retryAfter := time.Duration(0)
+		if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return retryAfter, err
+	}
+
+	return 0, &permanentError{err: err}
+}
+
+func (c *Client) handleError(err error) {
+	if c.config.ErrorHandler != nil {
+		c.config.ErrorHandler(err)
+	}
+}
+
+// permanentError is an error that retrying the same request cannot fix (e.g., 400 Bad Request).
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
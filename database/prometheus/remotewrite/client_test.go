@@ -0,0 +1,242 @@
+package remotewrite_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/prometheus/remotewrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receiver struct {
+	mutex    sync.Mutex
+	requests int
+	series   []remotewrite.TimeSeries
+	headers  http.Header
+}
+
+func newReceiver(t *testing.T, status func(attempt int) int) (*receiver, string) {
+	t.Helper()
+
+	r := &receiver{}
+	handler := remotewrite.NewHandler(func(series []remotewrite.TimeSeries) error {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		r.series = append(r.series, series...)
+		return nil
+	})
+
+	attempts := atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		if status != nil {
+			if code := status(int(attempts.Add(1))); code != 0 {
+				http.Error(w, http.StatusText(code), code)
+				return
+			}
+		}
+
+		r.mutex.Lock()
+		r.requests++
+		r.headers = request.Header.Clone()
+		r.mutex.Unlock()
+
+		handler.ServeHTTP(w, request)
+	}))
+	t.Cleanup(server.Close)
+
+	return r, server.URL
+}
+
+func (r *receiver) received() (int, []remotewrite.TimeSeries) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.requests, r.series
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	r, url := newReceiver(t, nil)
+
+	c, err := remotewrite.NewClient(remotewrite.Config{URL: url, BearerToken: "token", Headers: map[string]string{"X-Scope-OrgID": "edge"}})
+	require.NoError(t, err)
+	defer c.Close()
+
+	now := time.UnixMilli(1700000000123)
+	series := remotewrite.TimeSeries{
+		Labels: map[string]string{"__name__": "http_request_duration_seconds", "job": "api", "path": "/v1/items"},
+		Samples: []remotewrite.Sample{
+			{Value: 0.25, Timestamp: now},
+			{Value: -1.5, Timestamp: now.Add(time.Second)},
+		},
+		Exemplars: []remotewrite.Exemplar{{Labels: map[string]string{"trace_id": "abc"}, Value: 0.25, Timestamp: now}},
+	}
+	require.NoError(t, c.Append(series))
+	require.NoError(t, c.AppendSample(map[string]string{"__name__": "up"}, 1, now))
+	require.NoError(t, c.Flush())
+
+	requests, received := r.received()
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, []remotewrite.TimeSeries{series, {Labels: map[string]string{"__name__": "up"}, Samples: []remotewrite.Sample{{Value: 1, Timestamp: now}}}}, received)
+
+	assert.Equal(t, "snappy", r.headers.Get("Content-Encoding"))
+	assert.Equal(t, "application/x-protobuf", r.headers.Get("Content-Type"))
+	assert.Equal(t, "0.1.0", r.headers.Get("X-Prometheus-Remote-Write-Version"))
+	assert.Equal(t, "Bearer token", r.headers.Get("Authorization"))
+	assert.Equal(t, "edge", r.headers.Get("X-Scope-OrgID"))
+}
+
+func TestClientBatching(t *testing.T) {
+	r, url := newReceiver(t, nil)
+
+	c, err := remotewrite.NewClient(remotewrite.Config{URL: url, BatchSize: 2, FlushInterval: time.Hour})
+	require.NoError(t, err)
+	defer c.Close()
+
+	for i := range 5 {
+		require.NoError(t, c.AppendSample(map[string]string{"__name__": "jobs_total"}, float64(i), time.Now()))
+	}
+
+	assert.Eventually(t, func() bool {
+		requests, _ := r.received()
+		return requests == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, c.Flush())
+	requests, received := r.received()
+	assert.Equal(t, 3, requests)
+	assert.Len(t, received, 5)
+}
+
+func TestClientFlushInterval(t *testing.T) {
+	r, url := newReceiver(t, nil)
+
+	c, err := remotewrite.NewClient(remotewrite.Config{URL: url, FlushInterval: 20 * time.Millisecond})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.AppendSample(map[string]string{"__name__": "up"}, 1, time.Now()))
+
+	assert.Eventually(t, func() bool {
+		_, received := r.received()
+		return len(received) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClientRetry(t *testing.T) {
+	r, url := newReceiver(t, func(attempt int) int {
+		if attempt <= 2 {
+			return http.StatusServiceUnavailable
+		}
+		return 0
+	})
+
+	c, err := remotewrite.NewClient(remotewrite.Config{URL: url, FlushInterval: time.Hour, MinBackoff: time.Millisecond})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.AppendSample(map[string]string{"__name__": "up"}, 1, time.Now()))
+	require.NoError(t, c.Flush())
+
+	requests, received := r.received()
+	assert.Equal(t, 1, requests)
+	assert.Len(t, received, 1)
+	assert.Equal(t, 0, c.Queued())
+}
+
+func TestClientPermanentError(t *testing.T) {
+	_, url := newReceiver(t, func(attempt int) int { return http.StatusBadRequest })
+
+	errs := make(chan error, 10)
+	c, err := remotewrite.NewClient(remotewrite.Config{URL: url, FlushInterval: time.Hour, ErrorHandler: func(err error) { errs <- err }})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.AppendSample(map[string]string{"__name__": "up"}, 1, time.Now()))
+	err = c.Flush()
+	assert.ErrorContains(t, err, "dropped batch")
+	assert.ErrorContains(t, err, "400 Bad Request")
+	assert.Equal(t, 0, c.Queued())
+}
+
+func TestClientWAL(t *testing.T) {
+	directory := t.TempDir()
+
+	_, failing := newReceiver(t, func(attempt int) int { return http.StatusServiceUnavailable })
+	c, err := remotewrite.NewClient(remotewrite.Config{URL: failing, FlushInterval: time.Hour, MaxRetries: -1, WALDirectory: directory})
+	require.NoError(t, err)
+
+	require.NoError(t, c.AppendSample(map[string]string{"__name__": "backup_success"}, 1, time.UnixMilli(1700000000000)))
+	require.NoError(t, c.AppendSample(map[string]string{"__name__": "backup_success"}, 0, time.UnixMilli(1700000060000)))
+	assert.ErrorContains(t, c.Flush(), "503 Service Unavailable")
+	assert.Equal(t, 1, c.Queued())
+	assert.Error(t, c.Close())
+	assert.Error(t, c.Append())
+
+	r, url := newReceiver(t, nil)
+	c, err = remotewrite.NewClient(remotewrite.Config{URL: url, FlushInterval: time.Hour, WALDirectory: directory})
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.Eventually(t, func() bool {
+		_, received := r.received()
+		return len(received) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return c.Queued() == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	_, received := r.received()
+	assert.Equal(t, 1.0, received[0].Samples[0].Value)
+	assert.Equal(t, time.UnixMilli(1700000060000), received[1].Samples[0].Timestamp)
+}
+
+func TestClientWALWriteFailure(t *testing.T) {
+	directory := t.TempDir()
+
+	r, url := newReceiver(t, nil)
+	c, err := remotewrite.NewClient(remotewrite.Config{URL: url, FlushInterval: time.Hour, WALDirectory: directory})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, os.Remove(directory))
+	require.NoError(t, c.AppendSample(map[string]string{"__name__": "up"}, 1, time.UnixMilli(1700000000000)))
+	assert.Error(t, c.Flush())
+	assert.Equal(t, 0, c.Queued())
+
+	require.NoError(t, os.Mkdir(directory, 0o755))
+	require.NoError(t, c.Flush())
+
+	requests, received := r.received()
+	assert.Equal(t, 1, requests)
+	require.Len(t, received, 1)
+	assert.Equal(t, 1.0, received[0].Samples[0].Value)
+}
+
+func TestClientQueueCapacity(t *testing.T) {
+	_, url := newReceiver(t, func(attempt int) int { return http.StatusServiceUnavailable })
+
+	dropped := atomic.Int64{}
+	c, err := remotewrite.NewClient(remotewrite.Config{
+		URL: url, BatchSize: 1, FlushInterval: time.Hour, MaxRetries: -1, MaxQueuedBatches: 2, WALDirectory: t.TempDir(),
+		ErrorHandler: func(err error) { dropped.Add(1) },
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	for i := range 5 {
+		require.NoError(t, c.AppendSample(map[string]string{"__name__": "up"}, float64(i), time.Now()))
+	}
+
+	assert.LessOrEqual(t, c.Queued(), 2)
+	assert.Positive(t, dropped.Load())
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := remotewrite.NewClient(remotewrite.Config{})
+	assert.Error(t, err)
+}
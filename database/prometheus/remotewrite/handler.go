@@ -0,0 +1,134 @@
+package remotewrite
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/golang/snappy"
+)
+
+const (
+	versionHeader = "X-Prometheus-Remote-Write-Version"
+	version       = "0.1.0"
+
+	// DefaultMaxRequestSize is the largest compressed request body NewHandler accepts.
+	DefaultMaxRequestSize = 32 << 20
+
+	// DefaultMaxDecodedSize is the largest decompressed request body NewHandler accepts.
+	DefaultMaxDecodedSize = 256 << 20
+)
+
+type handler struct {
+	callback       func(series []TimeSeries) error
+	maxRequestSize int64
+	maxDecodedSize int
+}
+
+// NewHandler returns an http.Handler that receives remote-write requests and hands the
+// decoded series to callback.
+//
+// The handler responds 204 No Content on success, 400 Bad Request if the body cannot be
+// decoded, 405 for methods other than POST, 413 for bodies over DefaultMaxRequestSize or
+// decompressing to more than DefaultMaxDecodedSize, 415 for content encodings other than
+// snappy, and 500 if callback returns an error, which makes remote-write senders retry.
+// Use NewHandlerWithLimits for other size limits.
+//
+// Parameters:
+//   - callback: Function receiving the series of each request
+//
+// Returns:
+//   - http.Handler: Remote-write receiver
+//
+// Example:
+//
+//	// Mount next to /metrics on the exporter server
+//	exporter.RegisterHandler(http.MethodPost, "/api/v1/write", remotewrite.NewHandler(
+//	    func(series []remotewrite.TimeSeries) error {
+//	        for _, ts := range series {
+//	            log.Println(ts.Labels["__name__"], len(ts.Samples))
+//	        }
+//	        return nil
+//	    },
+//	))
+//	exporter.Start(":9090", "/metrics", func(err error) { log.Println(err) })
+func NewHandler(callback func(series []TimeSeries) error) http.Handler {
+	return NewHandlerWithLimits(callback, DefaultMaxRequestSize, DefaultMaxDecodedSize)
+}
+
+// NewHandlerWithLimits is NewHandler with the size limits of the request bodies.
+//
+// Parameters:
+//   - callback: Function receiving the series of each request
+//   - maxRequestSize: Largest compressed body in bytes (zero or less uses DefaultMaxRequestSize)
+//   - maxDecodedSize: Largest decompressed body in bytes (zero or less uses DefaultMaxDecodedSize)
+//
+// Returns:
+//   - http.Handler: Remote-write receiver
+//
+// Example:
+//
+//	handler := remotewrite.NewHandlerWithLimits(callback, 4<<20, 32<<20)
+func NewHandlerWithLimits(callback func(series []TimeSeries) error, maxRequestSize int64, maxDecodedSize int) http.Handler {
+	if maxRequestSize <= 0 {
+		maxRequestSize = DefaultMaxRequestSize
+	}
+	if maxDecodedSize <= 0 {
+		maxDecodedSize = DefaultMaxDecodedSize
+	}
+
+	return &handler{callback: callback, maxRequestSize: maxRequestSize, maxDecodedSize: maxDecodedSize}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if encoding := r.Header.Get("Content-Encoding"); encoding != "" && encoding != "snappy" {
+		http.Error(w, fmt.Sprintf("unsupported content encoding %q", encoding), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	compressed, err := io.ReadAll(io.LimitReader(r.Body, h.maxRequestSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if int64(len(compressed)) > h.maxRequestSize {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// The decoded length is declared by the sender, so it is checked before allocating.
+	decodedSize, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		http.Error(w, "snappy: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if decodedSize > h.maxDecodedSize {
+		http.Error(w, "decoded request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		http.Error(w, "snappy: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	series, err := unmarshalWriteRequest(data)
+	if err != nil {
+		http.Error(w, "protobuf: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.callback(series); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
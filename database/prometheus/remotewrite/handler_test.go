@@ -0,0 +1,64 @@
+package remotewrite_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/common-library/go/database/prometheus/remotewrite"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerErrors(t *testing.T) {
+	handler := remotewrite.NewHandler(func(series []remotewrite.TimeSeries) error {
+		return errors.New("storage unavailable")
+	})
+
+	for _, test := range []struct {
+		method   string
+		encoding string
+		body     []byte
+		expected int
+	}{
+		{http.MethodGet, "snappy", nil, http.StatusMethodNotAllowed},
+		{http.MethodPost, "gzip", snappy.Encode(nil, nil), http.StatusUnsupportedMediaType},
+		{http.MethodPost, "snappy", []byte("not snappy"), http.StatusBadRequest},
+		{http.MethodPost, "snappy", snappy.Encode(nil, []byte{0x0a, 0xff}), http.StatusBadRequest},
+		{http.MethodPost, "snappy", snappy.Encode(nil, nil), http.StatusInternalServerError},
+		// A 6-byte body declaring a decoded length of 4 GiB - 1
+		{http.MethodPost, "snappy", []byte{0xff, 0xff, 0xff, 0xff, 0x0f, 0x00}, http.StatusRequestEntityTooLarge},
+	} {
+		request := httptest.NewRequest(test.method, "/api/v1/write", bytes.NewReader(test.body))
+		request.Header.Set("Content-Encoding", test.encoding)
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, test.expected, recorder.Code, test)
+	}
+}
+
+func TestHandlerWithLimits(t *testing.T) {
+	handler := remotewrite.NewHandlerWithLimits(func(series []remotewrite.TimeSeries) error {
+		return nil
+	}, 8, 16)
+
+	for _, test := range []struct {
+		body     []byte
+		expected int
+	}{
+		{snappy.Encode(nil, nil), http.StatusNoContent},
+		{bytes.Repeat([]byte{0}, 9), http.StatusRequestEntityTooLarge},
+		// A 3-byte body declaring a decoded length of 17
+		{[]byte{0x11, 0x00, 0x00}, http.StatusRequestEntityTooLarge},
+	} {
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/write", bytes.NewReader(test.body))
+		request.Header.Set("Content-Encoding", "snappy")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, test.expected, recorder.Code, test)
+	}
+}
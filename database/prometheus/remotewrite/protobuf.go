@@ -0,0 +1,216 @@
+package remotewrite
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the remote-write 1.0 protobuf messages (prometheus/prompb).
+const (
+	writeRequestTimeseries protowire.Number = 1
+
+	timeSeriesLabels    protowire.Number = 1
+	timeSeriesSamples   protowire.Number = 2
+	timeSeriesExemplars protowire.Number = 3
+
+	labelName  protowire.Number = 1
+	labelValue protowire.Number = 2
+
+	sampleValue     protowire.Number = 1
+	sampleTimestamp protowire.Number = 2
+
+	exemplarLabels    protowire.Number = 1
+	exemplarValue     protowire.Number = 2
+	exemplarTimestamp protowire.Number = 3
+)
+
+func marshalWriteRequest(series []TimeSeries) []byte {
+	data := []byte{}
+	for _, ts := range series {
+		data = appendMessage(data, writeRequestTimeseries, marshalTimeSeries(ts))
+	}
+
+	return data
+}
+
+func marshalTimeSeries(ts TimeSeries) []byte {
+	data := appendLabels(nil, timeSeriesLabels, ts.Labels)
+
+	for _, sample := range ts.Samples {
+		message := appendDouble(nil, sampleValue, sample.Value)
+		message = appendInt64(message, sampleTimestamp, sample.Timestamp.UnixMilli())
+		data = appendMessage(data, timeSeriesSamples, message)
+	}
+
+	for _, exemplar := range ts.Exemplars {
+		message := appendLabels(nil, exemplarLabels, exemplar.Labels)
+		message = appendDouble(message, exemplarValue, exemplar.Value)
+		message = appendInt64(message, exemplarTimestamp, exemplar.Timestamp.UnixMilli())
+		data = appendMessage(data, timeSeriesExemplars, message)
+	}
+
+	return data
+}
+
+// appendLabels appends labels sorted by name, as remote-write receivers require.
+func appendLabels(data []byte, number protowire.Number, labels map[string]string) []byte {
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		message := protowire.AppendTag(nil, labelName, protowire.BytesType)
+		message = protowire.AppendString(message, name)
+		message = protowire.AppendTag(message, labelValue, protowire.BytesType)
+		message = protowire.AppendString(message, labels[name])
+		data = appendMessage(data, number, message)
+	}
+
+	return data
+}
+
+func appendMessage(data []byte, number protowire.Number, message []byte) []byte {
+	data = protowire.AppendTag(data, number, protowire.BytesType)
+	return protowire.AppendBytes(data, message)
+}
+
+func appendDouble(data []byte, number protowire.Number, value float64) []byte {
+	data = protowire.AppendTag(data, number, protowire.Fixed64Type)
+	return protowire.AppendFixed64(data, math.Float64bits(value))
+}
+
+func appendInt64(data []byte, number protowire.Number, value int64) []byte {
+	data = protowire.AppendTag(data, number, protowire.VarintType)
+	return protowire.AppendVarint(data, uint64(value))
+}
+
+func unmarshalWriteRequest(data []byte) ([]TimeSeries, error) {
+	series := []TimeSeries{}
+
+	err := walkFields(data, func(number protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+		if number != writeRequestTimeseries || typ != protowire.BytesType {
+			return nil
+		}
+
+		ts, err := unmarshalTimeSeries(value)
+		if err != nil {
+			return err
+		}
+		series = append(series, ts)
+
+		return nil
+	})
+
+	return series, err
+}
+
+func unmarshalTimeSeries(data []byte) (TimeSeries, error) {
+	ts := TimeSeries{Labels: map[string]string{}}
+
+	err := walkFields(data, func(number protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+
+		switch number {
+		case timeSeriesLabels:
+			return unmarshalLabel(value, ts.Labels)
+		case timeSeriesSamples:
+			sample := Sample{}
+			err := walkFields(value, func(number protowire.Number, typ protowire.Type, _ []byte, scalar uint64) error {
+				switch {
+				case number == sampleValue && typ == protowire.Fixed64Type:
+					sample.Value = math.Float64frombits(scalar)
+				case number == sampleTimestamp && typ == protowire.VarintType:
+					sample.Timestamp = time.UnixMilli(int64(scalar))
+				}
+				return nil
+			})
+			ts.Samples = append(ts.Samples, sample)
+			return err
+		case timeSeriesExemplars:
+			exemplar := Exemplar{Labels: map[string]string{}}
+			err := walkFields(value, func(number protowire.Number, typ protowire.Type, value []byte, scalar uint64) error {
+				switch {
+				case number == exemplarLabels && typ == protowire.BytesType:
+					return unmarshalLabel(value, exemplar.Labels)
+				case number == exemplarValue && typ == protowire.Fixed64Type:
+					exemplar.Value = math.Float64frombits(scalar)
+				case number == exemplarTimestamp && typ == protowire.VarintType:
+					exemplar.Timestamp = time.UnixMilli(int64(scalar))
+				}
+				return nil
+			})
+			ts.Exemplars = append(ts.Exemplars, exemplar)
+			return err
+		}
+
+		return nil
+	})
+
+	return ts, err
+}
+
+func unmarshalLabel(data []byte, labels map[string]string) error {
+	name, value := "", ""
+
+	err := walkFields(data, func(number protowire.Number, typ protowire.Type, field []byte, _ uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+
+		switch number {
+		case labelName:
+			name = string(field)
+		case labelValue:
+			value = string(field)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	labels[name] = value
+
+	return nil
+}
+
+// walkFields calls field for each field of a protobuf message; length-delimited fields are
+// passed as value and varint and fixed-width fields as scalar, other fields are skipped.
+func walkFields(data []byte, field func(number protowire.Number, typ protowire.Type, value []byte, scalar uint64) error) error {
+	for len(data) > 0 {
+		number, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		var value []byte
+		var scalar uint64
+		switch typ {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(data)
+		case protowire.VarintType:
+			scalar, n = protowire.ConsumeVarint(data)
+		case protowire.Fixed64Type:
+			scalar, n = protowire.ConsumeFixed64(data)
+		case protowire.Fixed32Type:
+			var fixed32 uint32
+			fixed32, n = protowire.ConsumeFixed32(data)
+			scalar = uint64(fixed32)
+		default:
+			n = protowire.ConsumeFieldValue(number, typ, data)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		if err := field(number, typ, value, scalar); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package remotewrite
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const walFileSuffix = ".batch"
+
+// queue holds compressed batches in order until they are sent.
+type queue interface {
+	// push appends a batch and reports how many of the oldest batches were dropped to respect
+	// the capacity.
+	push(batch []byte) (int, error)
+
+	// peek returns the oldest batch and its id, or ok false if the queue is empty.
+	peek() (id uint64, batch []byte, ok bool, err error)
+
+	// remove deletes the batch with id, if it is still queued.
+	remove(id uint64) error
+
+	length() int
+}
+
+type memoryEntry struct {
+	id    uint64
+	batch []byte
+}
+
+type memoryQueue struct {
+	mutex    sync.Mutex
+	capacity int
+	next     uint64
+	entries  []memoryEntry
+}
+
+func newMemoryQueue(capacity int) *memoryQueue {
+	return &memoryQueue{capacity: capacity}
+}
+
+func (q *memoryQueue) push(batch []byte) (int, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.entries = append(q.entries, memoryEntry{id: q.next, batch: batch})
+	q.next++
+
+	dropped := max(len(q.entries)-q.capacity, 0)
+	q.entries = q.entries[dropped:]
+
+	return dropped, nil
+}
+
+func (q *memoryQueue) peek() (uint64, []byte, bool, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.entries) == 0 {
+		return 0, nil, false, nil
+	}
+
+	return q.entries[0].id, q.entries[0].batch, true, nil
+}
+
+func (q *memoryQueue) remove(id uint64) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.entries = slices.DeleteFunc(q.entries, func(entry memoryEntry) bool { return entry.id == id })
+
+	return nil
+}
+
+func (q *memoryQueue) length() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.entries)
+}
+
+// walQueue stores each batch as a file named by its sequence number, written to a temporary
+// file, synced and renamed, so a crash never leaves a partial batch behind. Batches left by a
+// previous process are replayed in order.
+type walQueue struct {
+	mutex     sync.Mutex
+	directory string
+	capacity  int
+	next      uint64
+	ids       []uint64
+}
+
+func newWALQueue(directory string, capacity int) (*walQueue, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &walQueue{directory: directory, capacity: capacity}
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if strings.HasSuffix(name, ".tmp") {
+			if err := os.Remove(filepath.Join(directory, name)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, walFileSuffix), 10, 64)
+		if err != nil || !strings.HasSuffix(name, walFileSuffix) {
+			continue
+		}
+
+		q.ids = append(q.ids, id)
+		q.next = max(q.next, id+1)
+	}
+	slices.Sort(q.ids)
+
+	return q, nil
+}
+
+func (q *walQueue) push(batch []byte) (int, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	id := q.next
+	path := q.path(id)
+
+	file, err := os.OpenFile(path+".tmp", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := file.Write(batch); err != nil {
+		file.Close()
+		return 0, err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return 0, err
+	}
+	if err := file.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return 0, err
+	}
+
+	q.next++
+	q.ids = append(q.ids, id)
+
+	dropped := 0
+	for len(q.ids) > q.capacity {
+		if err := os.Remove(q.path(q.ids[0])); err != nil && !os.IsNotExist(err) {
+			return dropped, err
+		}
+		q.ids = q.ids[1:]
+		dropped++
+	}
+
+	return dropped, nil
+}
+
+func (q *walQueue) peek() (uint64, []byte, bool, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.ids) == 0 {
+		return 0, nil, false, nil
+	}
+
+	id := q.ids[0]
+	batch, err := os.ReadFile(q.path(id))
+	if err != nil {
+		q.ids = q.ids[1:]
+		return 0, nil, false, fmt.Errorf("read WAL batch %d: %w", id, err)
+	}
+
+	return id, batch, true, nil
+}
+
+func (q *walQueue) remove(id uint64) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	index := slices.Index(q.ids, id)
+	if index < 0 {
+		return nil
+	}
+	q.ids = slices.Delete(q.ids, index, index+1)
+
+	if err := os.Remove(q.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (q *walQueue) length() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.ids)
+}
+
+func (q *walQueue) path(id uint64) string {
+	return filepath.Join(q.directory, fmt.Sprintf("%020d%s", id, walFileSuffix))
+}
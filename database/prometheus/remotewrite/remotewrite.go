@@ -0,0 +1,49 @@
+// Package remotewrite provides a Prometheus remote-write sender and receiver for pushing
+// metrics from short-lived jobs and edge devices that Prometheus cannot scrape.
+//
+// Features:
+//   - Remote-write 1.0 protocol (snappy-compressed protobuf WriteRequest)
+//   - Sender that batches samples by size and flush interval
+//   - Retries with exponential backoff on network errors, 5xx and 429 responses
+//   - In-memory or WAL-backed queue that survives process restarts
+//   - Basic auth, bearer token and custom headers
+//   - Receiver http.Handler that decodes requests and hands series to a callback
+//
+// Example:
+//
+//	sender, err := remotewrite.NewClient(remotewrite.Config{
+//	    URL:          "http://prometheus:9090/api/v1/write",
+//	    WALDirectory: "/var/lib/job/wal",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer sender.Close()
+//
+//	sender.AppendSample(map[string]string{"__name__": "job_duration_seconds", "job": "backup"}, 12.5, time.Now())
+package remotewrite
+
+import (
+	"time"
+)
+
+// TimeSeries is a series of samples identified by its labels, including the metric name
+// as the "__name__" label.
+type TimeSeries struct {
+	Labels    map[string]string
+	Samples   []Sample
+	Exemplars []Exemplar
+}
+
+// Sample is a single value of a time series.
+type Sample struct {
+	Value     float64
+	Timestamp time.Time
+}
+
+// Exemplar is a sample with its own labels (e.g., trace_id) attached to a time series.
+type Exemplar struct {
+	Labels    map[string]string
+	Value     float64
+	Timestamp time.Time
+}
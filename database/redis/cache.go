@@ -0,0 +1,474 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+	"github.com/vmihailenco/msgpack/v5"
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrNotFound is returned by a Cache loader when the value does not exist, and by Cache.Get
+// when a missing value is cached.
+var ErrNotFound = errors.New("not found")
+
+const (
+	cacheValueMarker    = 'v'
+	cacheNotFoundMarker = 'n'
+)
+
+// Codec serializes cached values.
+type Codec interface {
+	// Marshal encodes a value
+	Marshal(value any) ([]byte, error)
+
+	// Unmarshal decodes data into the value pointed to by value
+	Unmarshal(data []byte, value any) error
+}
+
+// JSONCodec is a Codec using encoding/json.
+type JSONCodec struct{}
+
+// Marshal encodes a value as JSON.
+func (JSONCodec) Marshal(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Unmarshal decodes JSON data into value.
+func (JSONCodec) Unmarshal(data []byte, value any) error {
+	return json.Unmarshal(data, value)
+}
+
+// GobCodec is a Codec using encoding/gob.
+type GobCodec struct{}
+
+// Marshal encodes a value with gob.
+func (GobCodec) Marshal(value any) ([]byte, error) {
+	buffer := bytes.Buffer{}
+	if err := gob.NewEncoder(&buffer).Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Unmarshal decodes gob data into value.
+func (GobCodec) Unmarshal(data []byte, value any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(value)
+}
+
+// MsgpackCodec is a Codec using MessagePack.
+type MsgpackCodec struct{}
+
+// Marshal encodes a value with MessagePack.
+func (MsgpackCodec) Marshal(value any) ([]byte, error) {
+	return msgpack.Marshal(value)
+}
+
+// Unmarshal decodes MessagePack data into value.
+func (MsgpackCodec) Unmarshal(data []byte, value any) error {
+	return msgpack.Unmarshal(data, value)
+}
+
+type cacheInvalidation struct {
+	Instance string   `json:"instance"`
+	Keys     []string `json:"keys"`
+}
+
+type localEntry[T any] struct {
+	value      T
+	notFound   bool
+	expiration time.Time
+}
+
+// Cache is a typed cache-aside helper storing values of type T in Redis.
+type Cache[T any] struct {
+	client *Client
+	prefix string
+	codec  Codec
+
+	ttl         time.Duration
+	negativeTTL time.Duration
+	jitter      float64
+
+	group singleflight.Group
+
+	mutex           sync.Mutex
+	local           map[string]localEntry[T]
+	localTTL        time.Duration
+	localMaxEntries int
+	instance        string
+	cancel          context.CancelFunc
+	done            chan struct{}
+}
+
+// Initialize initializes the cache.
+//
+// Parameters:
+//   - client: Initialized Redis client
+//   - prefix: Prefix of the Redis keys of the cache (e.g., "cache:user:")
+//   - codec: Serialization of values (JSONCodec, GobCodec, MsgpackCodec or a custom Codec)
+//   - ttl: Expiration of cached values
+//   - negativeTTL: Expiration of cached ErrNotFound results, or 0 to not cache them
+//   - jitter: Fraction of the TTL by which each expiration is randomly shortened or lengthened
+//     (e.g., 0.1 for ±10%), so that keys cached together do not expire together
+//
+// Returns:
+//   - error: Error if client is nil, codec is nil or the durations are invalid, nil on success
+//
+// Example:
+//
+//	var cache redis.Cache[User]
+//	err := cache.Initialize(&client, "cache:user:", redis.JSONCodec{}, 10*time.Minute, 30*time.Second, 0.1)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer cache.Finalize()
+func (c *Cache[T]) Initialize(client *Client, prefix string, codec Codec, ttl, negativeTTL time.Duration, jitter float64) error {
+	if client == nil || codec == nil {
+		return errors.New("client and codec are required")
+	} else if ttl < time.Millisecond || negativeTTL < 0 || jitter < 0 || jitter >= 1 {
+		return errors.New("ttl must be at least 1ms, negativeTTL not negative and jitter in [0, 1)")
+	}
+
+	c.client = client
+	c.prefix = prefix
+	c.codec = codec
+	c.ttl = ttl
+	c.negativeTTL = negativeTTL
+	c.jitter = jitter
+
+	return nil
+}
+
+// EnableLocalCache adds an in-memory tier in front of Redis, invalidated through Redis pub/sub.
+//
+// Parameters:
+//   - ttl: Maximum time a value stays in memory; bounds staleness if an invalidation is lost
+//   - maxEntries: Maximum number of values kept in memory
+//
+// Returns:
+//   - error: Error if the cache is not initialized, the parameters are invalid or the subscription fails
+//
+// Set and Delete of every Cache publish the changed keys on the channel prefix + "invalidate";
+// every Cache with the same prefix and the local tier enabled drops them from memory. Delivery
+// is not guaranteed while a subscription reconnects, so keep ttl short.
+//
+// Example:
+//
+//	err := cache.EnableLocalCache(5*time.Second, 10000)
+func (c *Cache[T]) EnableLocalCache(ttl time.Duration, maxEntries int) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	} else if ttl <= 0 || maxEntries <= 0 {
+		return errors.New("ttl and maxEntries must be positive")
+	} else if c.cancel != nil {
+		return errors.New("local cache is already enabled")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	messages, err := c.client.Subscribe(ctx, c.invalidationChannel())
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	c.mutex.Lock()
+	c.local = map[string]localEntry[T]{}
+	c.localTTL = ttl
+	c.localMaxEntries = maxEntries
+	c.instance = randomToken()
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	c.mutex.Unlock()
+
+	go func() {
+		defer close(c.done)
+
+		for message := range messages {
+			invalidation := cacheInvalidation{}
+			if err := json.Unmarshal(message.Data, &invalidation); err != nil || invalidation.Instance == c.instance {
+				continue
+			}
+
+			c.mutex.Lock()
+			for _, key := range invalidation.Keys {
+				delete(c.local, key)
+			}
+			c.mutex.Unlock()
+		}
+	}()
+
+	return nil
+}
+
+// Finalize stops the invalidation subscription of the local tier.
+//
+// Returns:
+//   - error: Always returns nil
+//
+// Example:
+//
+//	defer cache.Finalize()
+func (c *Cache[T]) Finalize() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+		c.cancel = nil
+	}
+
+	return nil
+}
+
+// Get returns the cached value of key, loading and caching it on a miss.
+//
+// Parameters:
+//   - ctx: Context that cancels waiting for the value
+//   - key: Key of the value, without the cache prefix
+//   - load: Function that loads the value on a miss; it returns ErrNotFound if the value does not exist
+//
+// Returns:
+//   - T: Cached or loaded value
+//   - error: ErrNotFound if the value does not exist, or the error of load
+//
+// Concurrent misses of the same key in one process share a single call of load. load gets a
+// context that is not canceled when the caller's ctx is, so one canceled caller does not fail
+// the others. Redis errors are treated as misses, and failing to store a loaded value does not
+// fail Get, so the cache never makes reads unavailable. A cached value that cannot be decoded,
+// such as one written with another codec or an older version of T, is deleted and loaded again.
+//
+// Example:
+//
+//	user, err := cache.Get(ctx, strconv.Itoa(id), func(ctx context.Context) (User, error) {
+//	    user, err := repository.FindUser(ctx, id)
+//	    if errors.Is(err, sql.ErrNoRows) {
+//	        return User{}, redis.ErrNotFound
+//	    }
+//	    return user, err
+//	})
+func (c *Cache[T]) Get(ctx context.Context, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if c.client == nil {
+		return zero, errors.New("please call Initialize first")
+	}
+
+	if entry, ok := c.getLocal(key); ok {
+		if entry.notFound {
+			return zero, ErrNotFound
+		}
+		return entry.value, nil
+	}
+
+	results := c.group.DoChan(key, func() (any, error) {
+		if data, err := redigo_redis.Bytes(c.client.do("GET", c.prefix+key)); err == nil {
+			if value, notFound, err := c.decode(data); err != nil {
+				c.client.do("DEL", c.prefix+key)
+			} else {
+				c.setLocal(key, value, notFound)
+				if notFound {
+					return nil, ErrNotFound
+				}
+				return value, nil
+			}
+		}
+
+		value, err := load(context.WithoutCancel(ctx))
+		if errors.Is(err, ErrNotFound) {
+			if c.negativeTTL > 0 {
+				c.store(key, []byte{cacheNotFoundMarker}, c.negativeTTL)
+				c.setLocal(key, zero, true)
+			}
+			return nil, ErrNotFound
+		} else if err != nil {
+			return nil, err
+		}
+
+		if data, err := c.encode(value); err == nil {
+			c.store(key, data, c.ttl)
+			c.setLocal(key, value, false)
+		}
+
+		return value, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return zero, result.Err
+		}
+		// a nil value of an interface T is not a T
+		value, _ := result.Val.(T)
+		return value, nil
+	}
+}
+
+// Set stores a value in the cache, replacing any cached value.
+//
+// Parameters:
+//   - key: Key of the value, without the cache prefix
+//   - value: Value to store
+//
+// Returns:
+//   - error: Error if encoding or the SET command fails, nil on success
+//
+// Call Set (or Delete) after changing the source of truth, so other instances stop serving the old value.
+//
+// Example:
+//
+//	err := cache.Set(strconv.Itoa(user.ID), user)
+func (c *Cache[T]) Set(key string, value T) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	data, err := c.encode(value)
+	if err != nil {
+		return err
+	}
+
+	if err := c.store(key, data, c.ttl); err != nil {
+		return err
+	}
+
+	c.setLocal(key, value, false)
+
+	return c.invalidate(key)
+}
+
+// Delete removes values from the cache.
+//
+// Parameters:
+//   - keys: Keys of the values, without the cache prefix
+//
+// Returns:
+//   - error: Error if the DEL command fails, nil on success
+//
+// Example:
+//
+//	err := cache.Delete(strconv.Itoa(user.ID))
+func (c *Cache[T]) Delete(keys ...string) error {
+	if c.client == nil {
+		return errors.New("please call Initialize first")
+	} else if len(keys) == 0 {
+		return nil
+	}
+
+	// One DEL per key, so keys in different cluster slots can be deleted together
+	for _, key := range keys {
+		if _, err := c.client.do("DEL", c.prefix+key); err != nil {
+			return err
+		}
+	}
+
+	c.mutex.Lock()
+	for _, key := range keys {
+		delete(c.local, key)
+	}
+	c.mutex.Unlock()
+
+	return c.invalidate(keys...)
+}
+
+func (c *Cache[T]) store(key string, data []byte, ttl time.Duration) error {
+	if c.jitter > 0 {
+		ttl += time.Duration((rand.Float64()*2 - 1) * c.jitter * float64(ttl))
+	}
+
+	_, err := c.client.do("SET", c.prefix+key, data, "PX", max(ttl.Milliseconds(), 1))
+
+	return err
+}
+
+func (c *Cache[T]) encode(value T) ([]byte, error) {
+	data, err := c.codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{cacheValueMarker}, data...), nil
+}
+
+func (c *Cache[T]) decode(data []byte) (T, bool, error) {
+	var value T
+	if len(data) == 0 {
+		return value, false, errors.New("invalid cached value")
+	} else if data[0] == cacheNotFoundMarker {
+		return value, true, nil
+	} else if data[0] != cacheValueMarker {
+		return value, false, errors.New("invalid cached value")
+	}
+
+	err := c.codec.Unmarshal(data[1:], &value)
+
+	return value, false, err
+}
+
+func (c *Cache[T]) getLocal(key string) (localEntry[T], bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.local[key]
+	if ok && time.Now().After(entry.expiration) {
+		delete(c.local, key)
+		return entry, false
+	}
+
+	return entry, ok
+}
+
+func (c *Cache[T]) setLocal(key string, value T, notFound bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.local == nil {
+		return
+	}
+
+	if _, ok := c.local[key]; !ok && len(c.local) >= c.localMaxEntries {
+		now := time.Now()
+		for existing, entry := range c.local {
+			if now.After(entry.expiration) {
+				delete(c.local, existing)
+			}
+		}
+
+		// Evict an arbitrary entry if none expired
+		for existing := range c.local {
+			if len(c.local) < c.localMaxEntries {
+				break
+			}
+			delete(c.local, existing)
+		}
+	}
+
+	c.local[key] = localEntry[T]{value: value, notFound: notFound, expiration: time.Now().Add(c.localTTL)}
+}
+
+// invalidate tells the local tiers of other instances that keys changed.
+func (c *Cache[T]) invalidate(keys ...string) error {
+	c.mutex.Lock()
+	instance := c.instance
+	c.mutex.Unlock()
+
+	message, err := json.Marshal(cacheInvalidation{Instance: instance, Keys: keys})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.Publish(c.invalidationChannel(), message)
+
+	return err
+}
+
+func (c *Cache[T]) invalidationChannel() string {
+	return c.prefix + "invalidate"
+}
@@ -0,0 +1,245 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cachedUser struct {
+	ID   int
+	Name string
+	Tags []string
+}
+
+func TestCache_Codecs(t *testing.T) {
+	for name, codec := range map[string]redis.Codec{"json": redis.JSONCodec{}, "gob": redis.GobCodec{}, "msgpack": redis.MsgpackCodec{}} {
+		t.Run(name, func(t *testing.T) {
+			setupTest(t)
+
+			cache := redis.Cache[cachedUser]{}
+			require.NoError(t, cache.Initialize(client, "cache:user:", codec, time.Minute, 0, 0))
+			defer cache.Finalize()
+
+			user := cachedUser{ID: 1, Name: "alice", Tags: []string{"admin"}}
+			require.NoError(t, cache.Set("1", user))
+
+			value, err := cache.Get(context.Background(), "1", func(ctx context.Context) (cachedUser, error) {
+				return cachedUser{}, errors.New("unexpected load")
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, user, value)
+		})
+	}
+}
+
+func TestCache_Get(t *testing.T) {
+	setupTest(t)
+
+	cache := redis.Cache[cachedUser]{}
+	require.NoError(t, cache.Initialize(client, "cache:user:", redis.JSONCodec{}, time.Minute, 0, 0.1))
+	defer cache.Finalize()
+
+	loads := atomic.Int32{}
+	load := func(ctx context.Context) (cachedUser, error) {
+		loads.Add(1)
+		return cachedUser{ID: 1, Name: "alice"}, nil
+	}
+
+	for range 3 {
+		value, err := cache.Get(context.Background(), "1", load)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", value.Name)
+	}
+	assert.Equal(t, int32(1), loads.Load())
+
+	ttl, err := client.Ttl("cache:user:1")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, ttl, 53)
+	assert.LessOrEqual(t, ttl, 66)
+
+	require.NoError(t, cache.Delete("1"))
+	_, err = cache.Get(context.Background(), "1", load)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), loads.Load())
+
+	_, err = cache.Get(context.Background(), "2", func(ctx context.Context) (cachedUser, error) {
+		return cachedUser{}, errors.New("database down")
+	})
+	assert.EqualError(t, err, "database down")
+}
+
+func TestCache_GetStampede(t *testing.T) {
+	setupTest(t)
+
+	cache := redis.Cache[cachedUser]{}
+	require.NoError(t, cache.Initialize(client, "cache:user:", redis.JSONCodec{}, time.Minute, 0, 0))
+	defer cache.Finalize()
+
+	loads := atomic.Int32{}
+	wg := sync.WaitGroup{}
+	for range 50 {
+		wg.Go(func() {
+			value, err := cache.Get(context.Background(), "1", func(ctx context.Context) (cachedUser, error) {
+				loads.Add(1)
+				time.Sleep(100 * time.Millisecond)
+				return cachedUser{ID: 1}, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, value.ID)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestCache_GetCanceled(t *testing.T) {
+	setupTest(t)
+
+	cache := redis.Cache[cachedUser]{}
+	require.NoError(t, cache.Initialize(client, "cache:user:", redis.JSONCodec{}, time.Minute, 0, 0))
+	defer cache.Finalize()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := cache.Get(ctx, "1", func(ctx context.Context) (cachedUser, error) {
+		time.Sleep(200 * time.Millisecond)
+		return cachedUser{ID: 1}, ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.Eventually(t, func() bool {
+		exists, err := client.Exists("cache:user:1")
+		return err == nil && exists
+	}, time.Second, 20*time.Millisecond)
+}
+
+func TestCache_NegativeCaching(t *testing.T) {
+	setupTest(t)
+
+	cache := redis.Cache[cachedUser]{}
+	require.NoError(t, cache.Initialize(client, "cache:user:", redis.JSONCodec{}, time.Minute, 200*time.Millisecond, 0))
+	defer cache.Finalize()
+
+	loads := atomic.Int32{}
+	load := func(ctx context.Context) (cachedUser, error) {
+		loads.Add(1)
+		return cachedUser{}, redis.ErrNotFound
+	}
+
+	for range 3 {
+		_, err := cache.Get(context.Background(), "404", load)
+		assert.ErrorIs(t, err, redis.ErrNotFound)
+	}
+	assert.Equal(t, int32(1), loads.Load())
+
+	time.Sleep(300 * time.Millisecond)
+
+	_, err := cache.Get(context.Background(), "404", load)
+	assert.ErrorIs(t, err, redis.ErrNotFound)
+	assert.Equal(t, int32(2), loads.Load())
+}
+
+func TestCache_LocalCache(t *testing.T) {
+	setupTest(t)
+
+	reader := redis.Cache[cachedUser]{}
+	require.NoError(t, reader.Initialize(client, "cache:user:", redis.JSONCodec{}, time.Minute, 0, 0))
+	require.NoError(t, reader.EnableLocalCache(time.Minute, 100))
+	defer reader.Finalize()
+
+	writer := redis.Cache[cachedUser]{}
+	require.NoError(t, writer.Initialize(client, "cache:user:", redis.JSONCodec{}, time.Minute, 0, 0))
+	defer writer.Finalize()
+
+	require.NoError(t, writer.Set("1", cachedUser{ID: 1, Name: "alice"}))
+
+	load := func(ctx context.Context) (cachedUser, error) {
+		return cachedUser{}, errors.New("unexpected load")
+	}
+
+	value, err := reader.Get(context.Background(), "1", load)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", value.Name)
+
+	// Served from memory while Redis changes without an invalidation
+	require.NoError(t, client.Del("cache:user:1"))
+	value, err = reader.Get(context.Background(), "1", load)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", value.Name)
+
+	require.NoError(t, writer.Set("1", cachedUser{ID: 1, Name: "bob"}))
+	assert.Eventually(t, func() bool {
+		value, err := reader.Get(context.Background(), "1", load)
+		return err == nil && value.Name == "bob"
+	}, 2*time.Second, 20*time.Millisecond)
+
+	require.NoError(t, writer.Delete("1"))
+	assert.Eventually(t, func() bool {
+		_, err := reader.Get(context.Background(), "1", load)
+		return err != nil
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestCache_GetUndecodable(t *testing.T) {
+	setupTest(t)
+
+	cache := redis.Cache[cachedUser]{}
+	require.NoError(t, cache.Initialize(client, "cache:user:", redis.JSONCodec{}, time.Minute, 0, 0))
+	defer cache.Finalize()
+
+	require.NoError(t, client.Set("cache:user:1", "garbage"))
+	_, err := cache.Get(context.Background(), "1", func(ctx context.Context) (cachedUser, error) {
+		return cachedUser{}, errors.New("database down")
+	})
+	assert.EqualError(t, err, "database down")
+
+	exists, err := client.Exists("cache:user:1")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, client.Set("cache:user:1", "v{not json"))
+	value, err := cache.Get(context.Background(), "1", func(ctx context.Context) (cachedUser, error) {
+		return cachedUser{ID: 1, Name: "alice"}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", value.Name)
+}
+
+func TestCache_GetNilInterface(t *testing.T) {
+	setupTest(t)
+
+	cache := redis.Cache[any]{}
+	require.NoError(t, cache.Initialize(client, "cache:any:", redis.JSONCodec{}, time.Minute, 0, 0))
+	defer cache.Finalize()
+
+	for range 2 {
+		value, err := cache.Get(context.Background(), "1", func(ctx context.Context) (any, error) {
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+	}
+}
+
+func TestCache_Errors(t *testing.T) {
+	cache := redis.Cache[cachedUser]{}
+
+	_, err := cache.Get(context.Background(), "1", nil)
+	assert.EqualError(t, err, "please call Initialize first")
+	assert.EqualError(t, cache.Set("1", cachedUser{}), "please call Initialize first")
+	assert.EqualError(t, cache.EnableLocalCache(time.Second, 1), "please call Initialize first")
+
+	assert.Error(t, cache.Initialize(nil, "cache:", redis.JSONCodec{}, time.Minute, 0, 0))
+	assert.Error(t, cache.Initialize(client, "cache:", redis.JSONCodec{}, time.Minute, 0, 1))
+	assert.Error(t, cache.Initialize(client, "cache:", redis.JSONCodec{}, 0, 0, 0))
+}
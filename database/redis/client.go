@@ -11,6 +11,18 @@
 //   - Database selection
 //   - Key expiration (TTL) management
 //   - Batch operations (MGET, MSET)
+//   - Hashes (with struct mapping), lists, sets and sorted sets
+//   - SCAN-family iterators
+//   - Pipelining and MULTI/EXEC transactions
+//   - Pub/Sub with automatic reconnect
+//   - Streams with consumer groups
+//   - Lua scripts with EVALSHA
+//   - Distributed locks with fencing tokens and Redlock
+//   - Sliding window and token bucket rate limiters
+//   - Typed cache-aside Cache[T] with stampede protection and a local tier
+//   - Sentinel master discovery with failover
+//   - Cluster slot routing with MOVED/ASK redirection
+//   - TLS and ACL username authentication
 //
 // Example:
 //
@@ -22,6 +34,7 @@
 package redis
 
 import (
+	"crypto/tls"
 	"errors"
 	"strings"
 	"time"
@@ -31,7 +44,8 @@ import (
 
 // Client is a struct that provides client related methods.
 type Client struct {
-	pool *redigo_redis.Pool
+	pool    *redigo_redis.Pool
+	cluster *cluster
 
 	connection redigo_redis.Conn
 }
@@ -59,21 +73,41 @@ type Client struct {
 //	}
 //	defer client.Finalize()
 func (c *Client) Initialize(address, password string, maxConnection int, timeout time.Duration) error {
-	c.pool = &redigo_redis.Pool{
-		Dial: func() (redigo_redis.Conn, error) {
-			dialOption := redigo_redis.DialPassword(password)
-			return redigo_redis.Dial("tcp", address, dialOption)
-		},
-
-		TestOnBorrow: func(connection redigo_redis.Conn, t time.Time) error {
-			_, err := connection.Do("PING")
-			return err
-		},
-
-		MaxIdle: maxConnection,
+	return c.InitializeWithAuth(address, "", password, nil, maxConnection, timeout)
+}
 
-		IdleTimeout: timeout * time.Second,
-	}
+// InitializeWithAuth initializes the Redis client with ACL authentication and optional TLS.
+//
+// Parameters:
+//   - address: Redis server address in the format "host:port" (e.g., "localhost:6379")
+//   - username: ACL username (Redis 6+). Use empty string "" for the default user
+//   - password: Authentication password. Use empty string "" for no authentication
+//   - tlsConfig: TLS configuration, or nil for a plain TCP connection
+//   - maxConnection: Maximum number of idle connections in the pool
+//   - timeout: Idle connection timeout duration. Connections idle longer than this will be closed
+//
+// Returns:
+//   - error: Error if connection test fails, nil on success
+//
+// Every new connection sends AUTH with the username and password. If tlsConfig has no
+// ServerName, the host of address is used to verify the server certificate.
+//
+// Example:
+//
+//	var client redis.Client
+//	err := client.InitializeWithAuth("redis.example.com:6380", "app", "secret",
+//	    &tls.Config{MinVersion: tls.VersionTLS12}, 10, 60)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer client.Finalize()
+func (c *Client) InitializeWithAuth(address, username, password string, tlsConfig *tls.Config, maxConnection int, timeout time.Duration) error {
+	c.pool = newPool(func() (redigo_redis.Conn, error) {
+		return dial(address, username, password, tlsConfig)
+	}, func(connection redigo_redis.Conn) error {
+		_, err := connection.Do("PING")
+		return err
+	}, maxConnection, timeout)
 
 	return c.Ping()
 }
@@ -96,6 +130,10 @@ func (c *Client) Finalize() error {
 		c.connection.Close()
 	}
 
+	if c.cluster != nil {
+		c.cluster.close()
+	}
+
 	if c.pool != nil {
 		c.pool.Close()
 	}
@@ -571,8 +609,64 @@ func (c *Client) RandomKey() (string, error) {
 }
 
 func (c *Client) do(command string, args ...any) (any, error) {
+	if c.cluster != nil {
+		return c.cluster.do(command, args...)
+	}
+
 	connection := c.pool.Get()
 	defer connection.Close()
 
 	return connection.Do(command, args...)
 }
+
+// withConnection runs fn on a pooled connection; in cluster mode, on a connection to the node
+// serving the key of command and args, following redirections.
+func (c *Client) withConnection(command string, args []any, fn func(connection redigo_redis.Conn) (any, error)) (any, error) {
+	if c.cluster != nil {
+		return c.cluster.withSlot(c.cluster.slot(command, args), fn)
+	}
+
+	connection := c.pool.Get()
+	defer connection.Close()
+
+	return fn(connection)
+}
+
+func newPool(dial func() (redigo_redis.Conn, error), test func(connection redigo_redis.Conn) error, maxConnection int, timeout time.Duration) *redigo_redis.Pool {
+	return &redigo_redis.Pool{
+		Dial: dial,
+
+		TestOnBorrow: func(connection redigo_redis.Conn, t time.Time) error {
+			return test(connection)
+		},
+
+		MaxIdle: maxConnection,
+
+		IdleTimeout: timeout * time.Second,
+	}
+}
+
+// dial connects to address, optionally over TLS, and authenticates with AUTH.
+func dial(address, username, password string, tlsConfig *tls.Config) (redigo_redis.Conn, error) {
+	options := []redigo_redis.DialOption{}
+	if tlsConfig != nil {
+		options = append(options, redigo_redis.DialUseTLS(true), redigo_redis.DialTLSConfig(tlsConfig))
+	}
+
+	connection, err := redigo_redis.Dial("tcp", address, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(username) != 0 {
+		_, err = connection.Do("AUTH", username, password)
+	} else if len(password) != 0 {
+		_, err = connection.Do("AUTH", password)
+	}
+	if err != nil {
+		connection.Close()
+		return nil, err
+	}
+
+	return connection, nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/common-library/go/database/redis"
 	"github.com/common-library/go/testutil"
+	redigo_redis "github.com/gomodule/redigo/redis"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	redismodule "github.com/testcontainers/testcontainers-go/modules/redis"
@@ -88,6 +89,26 @@ func setupTest(t *testing.T) {
 	require.NoError(t, client.FlushDB())
 }
 
+// newRedisClient starts an additional Redis container for tests that need independent servers.
+func newRedisClient(t *testing.T) *redis.Client {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := redismodule.Run(ctx, testutil.RedisImage)
+	require.NoError(t, err)
+	t.Cleanup(func() { container.Terminate(ctx) })
+
+	address, err := container.Endpoint(ctx, "")
+	require.NoError(t, err)
+
+	redisClient := &redis.Client{}
+	require.NoError(t, redisClient.Initialize(address, "", 10, 60))
+	t.Cleanup(func() { redisClient.Finalize() })
+
+	return redisClient
+}
+
 func TestClient_Ping(t *testing.T) {
 	setupTest(t)
 
@@ -344,3 +365,47 @@ func TestClient_FlushAll(t *testing.T) {
 		assert.Equal(t, 0, size)
 	}
 }
+
+func TestClient_InitializeWithAuth(t *testing.T) {
+	connection, err := redigo_redis.Dial("tcp", redisAddress)
+	require.NoError(t, err)
+	defer connection.Close()
+
+	_, err = connection.Do("ACL", "SETUSER", "app", "on", ">secret", "~*", "&*", "+@all")
+	require.NoError(t, err)
+	defer connection.Do("ACL", "DELUSER", "app")
+
+	authClient := redis.Client{}
+	assert.Error(t, authClient.InitializeWithAuth(redisAddress, "app", "wrong", nil, 10, 60))
+
+	authClient = redis.Client{}
+	require.NoError(t, authClient.InitializeWithAuth(redisAddress, "app", "secret", nil, 10, 60))
+	defer authClient.Finalize()
+
+	assert.NoError(t, authClient.Set("auth_key", "value"))
+	assert.NoError(t, authClient.Del("auth_key"))
+}
+
+func TestClient_InitializeWithAuthTLS(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := redismodule.Run(ctx, testutil.RedisImage, redismodule.WithTLS())
+	require.NoError(t, err)
+	defer container.Terminate(ctx)
+
+	address, err := container.Endpoint(ctx, "")
+	require.NoError(t, err)
+
+	tlsClient := redis.Client{}
+	assert.Error(t, tlsClient.InitializeWithAuth(address, "", "", nil, 10, 60))
+
+	tlsClient = redis.Client{}
+	require.NoError(t, tlsClient.InitializeWithAuth(address, "", "", container.TLSConfig(), 10, 60))
+	defer tlsClient.Finalize()
+
+	assert.NoError(t, tlsClient.Set("tls_key", "value"))
+
+	value, err := tlsClient.Get("tls_key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
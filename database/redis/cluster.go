@@ -0,0 +1,555 @@
+package redis
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+const (
+	clusterSlotCount     = 16384
+	clusterMaxRedirects  = 5
+	clusterTryAgainDelay = 100 * time.Millisecond
+)
+
+// cluster routes commands to the nodes of a Redis Cluster.
+type cluster struct {
+	dial          func(address string) (redigo_redis.Conn, error)
+	maxConnection int
+	timeout       time.Duration
+
+	seeds []string
+
+	// commands maps lower-case command names ("get", "xgroup|create") to the position of their
+	// first key argument, counted from the command name as in COMMAND INFO (0 for no key).
+	commands map[string]int
+
+	mutex   sync.RWMutex
+	pools   map[string]*redigo_redis.Pool
+	slots   []string
+	masters []string
+
+	refreshMutex sync.Mutex
+}
+
+// InitializeCluster initializes the Redis client for a Redis Cluster.
+//
+// Parameters:
+//   - addresses: Addresses of one or more cluster nodes in the format "host:port", used to discover the others
+//   - username: ACL username. Use empty string "" for the default user
+//   - password: Authentication password. Use empty string "" for no authentication
+//   - tlsConfig: TLS configuration, or nil for plain TCP connections
+//   - maxConnection: Maximum number of idle connections in the pool of each node
+//   - timeout: Idle connection timeout duration. Connections idle longer than this will be closed
+//
+// Returns:
+//   - error: Error if the slot map cannot be loaded from any node, nil on success
+//
+// The client loads the slot map with CLUSTER SLOTS and sends each command to the master serving
+// the hash slot of its key, keeping a connection pool per node. A MOVED reply updates the slot
+// map and resends the command to the new owner; an ASK reply resends it once with ASKING during
+// slot migration; TRYAGAIN and CLUSTERDOWN replies are retried after a short delay. Connection
+// errors reload the slot map, so the next command reaches a replica promoted after a failover.
+//
+// Commands without a key (PING, INFO, RANDOMKEY, PUBLISH) run on any master. FLUSHDB, FLUSHALL,
+// SCRIPT LOAD and SCRIPT FLUSH run on all masters, DBSIZE returns the sum over all masters and
+// Scan iterates over the keys of all masters. Pipeline groups its commands by node.
+//
+// As in Redis Cluster itself, the keys of a multi-key command (MGET, SINTER, ...), a Lua
+// script, a lock's fencing counter or a transaction must share a hash slot; use hash tags
+// (e.g., "{user:1}:profile" and "{user:1}:settings"). Select is not supported.
+//
+// Example:
+//
+//	var client redis.Client
+//	err := client.InitializeCluster([]string{"node-1:6379", "node-2:6379", "node-3:6379"},
+//	    "app", "secret", nil, 10, 60)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer client.Finalize()
+func (c *Client) InitializeCluster(addresses []string, username, password string, tlsConfig *tls.Config, maxConnection int, timeout time.Duration) error {
+	if len(addresses) == 0 {
+		return errors.New("at least one address is required")
+	}
+
+	c.cluster = &cluster{
+		dial: func(address string) (redigo_redis.Conn, error) {
+			return dial(address, username, password, tlsConfig)
+		},
+		maxConnection: maxConnection,
+		timeout:       timeout,
+		seeds:         addresses,
+		commands:      map[string]int{},
+		pools:         map[string]*redigo_redis.Pool{},
+		slots:         make([]string, clusterSlotCount),
+	}
+
+	if err := c.cluster.refresh(); err != nil {
+		c.cluster = nil
+		return err
+	}
+
+	c.cluster.loadCommands()
+
+	// The client pool reaches any master; subscriptions use it to dial.
+	c.pool = newPool(func() (redigo_redis.Conn, error) {
+		return c.cluster.dial(c.cluster.address(-1))
+	}, func(connection redigo_redis.Conn) error {
+		_, err := connection.Do("PING")
+		return err
+	}, maxConnection, timeout)
+
+	return c.Ping()
+}
+
+// KeySlot returns the Redis Cluster hash slot of a key.
+//
+// Parameters:
+//   - key: Key; if it contains a non-empty hash tag ("{...}"), only the tag is hashed
+//
+// Returns:
+//   - int: Hash slot between 0 and 16383
+//
+// Keys with the same hash tag are in the same slot, so they can be used together in
+// multi-key commands, scripts and transactions.
+//
+// Example:
+//
+//	redis.KeySlot("{user:1}:profile") == redis.KeySlot("{user:1}:settings") // true
+func KeySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start != -1 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+
+	crc := uint16(0)
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return int(crc) % clusterSlotCount
+}
+
+// do runs a command on the node serving its key, or on all masters for the commands that apply
+// to the whole cluster.
+func (c *cluster) do(command string, args ...any) (any, error) {
+	name := strings.ToLower(command)
+	subcommand := ""
+	if len(args) != 0 {
+		subcommand = strings.ToLower(keyString(args[0]))
+	}
+
+	switch {
+	case name == "flushdb" || name == "flushall" || (name == "script" && (subcommand == "load" || subcommand == "flush")):
+		var reply any
+		for _, address := range c.getMasters() {
+			var err error
+			if reply, err = c.withAddress(address, false, func(connection redigo_redis.Conn) (any, error) {
+				return connection.Do(command, args...)
+			}); err != nil {
+				return nil, err
+			}
+		}
+		return reply, nil
+	case name == "dbsize":
+		sum := int64(0)
+		for _, address := range c.getMasters() {
+			size, err := redigo_redis.Int64(c.withAddress(address, false, func(connection redigo_redis.Conn) (any, error) {
+				return connection.Do(command)
+			}))
+			if err != nil {
+				return nil, err
+			}
+			sum += size
+		}
+		return sum, nil
+	}
+
+	return c.withSlot(c.slot(command, args), func(connection redigo_redis.Conn) (any, error) {
+		return connection.Do(command, args...)
+	})
+}
+
+// withSlot runs fn on a connection to the master serving slot (any master if slot is -1),
+// following MOVED and ASK redirections.
+func (c *cluster) withSlot(slot int, fn func(connection redigo_redis.Conn) (any, error)) (any, error) {
+	address := c.address(slot)
+	asking := false
+
+	var err error
+	for range clusterMaxRedirects {
+		var reply any
+		reply, err = c.withAddress(address, asking, fn)
+
+		redisErr, ok := err.(redigo_redis.Error)
+		if !ok {
+			if err != nil {
+				c.tryRefresh()
+			}
+			return reply, err
+		}
+
+		fields := strings.Fields(string(redisErr))
+		switch {
+		case len(fields) == 3 && fields[0] == "MOVED":
+			address = redirectAddress(fields[2], address)
+			asking = false
+			if movedSlot, err := strconv.Atoi(fields[1]); err == nil && movedSlot >= 0 && movedSlot < clusterSlotCount {
+				c.mutex.Lock()
+				c.slots[movedSlot] = address
+				c.mutex.Unlock()
+			}
+			c.tryRefresh()
+		case len(fields) == 3 && fields[0] == "ASK":
+			address = redirectAddress(fields[2], address)
+			asking = true
+		case len(fields) != 0 && (fields[0] == "TRYAGAIN" || fields[0] == "CLUSTERDOWN"):
+			time.Sleep(clusterTryAgainDelay)
+		default:
+			return reply, err
+		}
+	}
+
+	return nil, fmt.Errorf("too many cluster redirections: %w", err)
+}
+
+// withAddress runs fn on a pooled connection to address, sending ASKING first if asking is set.
+func (c *cluster) withAddress(address string, asking bool, fn func(connection redigo_redis.Conn) (any, error)) (any, error) {
+	connection := c.pool(address).Get()
+	defer connection.Close()
+
+	if asking {
+		if _, err := connection.Do("ASKING"); err != nil {
+			return nil, err
+		}
+	}
+
+	return fn(connection)
+}
+
+// pipeline sends the commands to their nodes, one pipeline per node, and resends redirected commands one by one.
+func (c *cluster) pipeline(commands []batchCommand) ([]any, error) {
+	groups := map[string][]int{}
+	for i, command := range commands {
+		address := c.address(c.slot(command.name, command.args))
+		groups[address] = append(groups[address], i)
+	}
+
+	replies := make([]any, len(commands))
+	for address, indexes := range groups {
+		group := make([]batchCommand, len(indexes))
+		for i, index := range indexes {
+			group[i] = commands[index]
+		}
+
+		groupReplies, err := c.withAddress(address, false, func(connection redigo_redis.Conn) (any, error) {
+			return pipeline(connection, group)
+		})
+		if err != nil {
+			c.tryRefresh()
+			return nil, err
+		}
+
+		for i, reply := range groupReplies.([]any) {
+			replies[indexes[i]] = reply
+		}
+	}
+
+	for i, reply := range replies {
+		if redisErr, ok := reply.(redigo_redis.Error); ok && isRedirect(redisErr) {
+			reply, err := c.do(commands[i].name, commands[i].args...)
+			if redisErr, ok := err.(redigo_redis.Error); ok {
+				replies[i] = redisErr
+			} else if err != nil {
+				return nil, err
+			} else {
+				replies[i] = reply
+			}
+		}
+	}
+
+	return replies, nil
+}
+
+// slot returns the hash slot of the key of a command, or -1 if the command has no key.
+func (c *cluster) slot(command string, args []any) int {
+	name := strings.ToLower(command)
+
+	switch name {
+	case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro":
+		if len(args) > 2 {
+			if count, err := strconv.Atoi(keyString(args[1])); err == nil && count > 0 {
+				return KeySlot(keyString(args[2]))
+			}
+		}
+		return -1
+	case "xread", "xreadgroup":
+		for i, arg := range args {
+			if strings.EqualFold(keyString(arg), "STREAMS") && i+1 < len(args) {
+				return KeySlot(keyString(args[i+1]))
+			}
+		}
+		return -1
+	}
+
+	position, ok := -1, false
+	if len(args) != 0 {
+		position, ok = c.commands[name+"|"+strings.ToLower(keyString(args[0]))]
+	}
+	if !ok {
+		position, ok = c.commands[name]
+	}
+
+	if !ok {
+		// Unknown command: most commands take their key first
+		position = 1
+	}
+
+	if position < 1 || position > len(args) {
+		return -1
+	}
+
+	return KeySlot(keyString(args[position-1]))
+}
+
+// address returns the master serving slot, or a random master if slot is -1 or not served.
+func (c *cluster) address(slot int) string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if slot >= 0 && slot < clusterSlotCount && len(c.slots[slot]) != 0 {
+		return c.slots[slot]
+	} else if len(c.masters) != 0 {
+		return c.masters[rand.N(len(c.masters))]
+	}
+
+	return c.seeds[rand.N(len(c.seeds))]
+}
+
+// pool returns the connection pool of a node, creating it on first use.
+func (c *cluster) pool(address string) *redigo_redis.Pool {
+	c.mutex.RLock()
+	pool, ok := c.pools[address]
+	c.mutex.RUnlock()
+	if ok {
+		return pool
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if pool, ok := c.pools[address]; ok {
+		return pool
+	}
+
+	pool = newPool(func() (redigo_redis.Conn, error) {
+		return c.dial(address)
+	}, func(connection redigo_redis.Conn) error {
+		_, err := connection.Do("PING")
+		return err
+	}, c.maxConnection, c.timeout)
+	c.pools[address] = pool
+
+	return pool
+}
+
+func (c *cluster) getMasters() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return append([]string{}, c.masters...)
+}
+
+// refresh loads the slot map from the first known node that answers CLUSTER SLOTS.
+func (c *cluster) refresh() error {
+	addresses := append(c.getMasters(), c.seeds...)
+
+	errs := []error{}
+	for _, address := range addresses {
+		reply, err := redigo_redis.Values(c.withAddress(address, false, func(connection redigo_redis.Conn) (any, error) {
+			return connection.Do("CLUSTER", "SLOTS")
+		}))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", address, err))
+			continue
+		}
+
+		slots, masters, err := parseClusterSlots(reply, address)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", address, err))
+			continue
+		}
+
+		c.mutex.Lock()
+		c.slots = slots
+		c.masters = masters
+		c.mutex.Unlock()
+
+		return nil
+	}
+
+	return errors.Join(errs...)
+}
+
+// tryRefresh refreshes the slot map unless a refresh is already running.
+func (c *cluster) tryRefresh() {
+	if !c.refreshMutex.TryLock() {
+		return
+	}
+	defer c.refreshMutex.Unlock()
+
+	c.refresh()
+}
+
+// loadCommands loads the key positions of the server's commands with COMMAND. Without them
+// (e.g. COMMAND denied by ACL), the first argument of a command is used as its key.
+func (c *cluster) loadCommands() {
+	reply, err := redigo_redis.Values(c.withSlot(-1, func(connection redigo_redis.Conn) (any, error) {
+		return connection.Do("COMMAND")
+	}))
+	if err != nil {
+		return
+	}
+
+	var load func(entries []any)
+	load = func(entries []any) {
+		for _, entry := range entries {
+			fields, err := redigo_redis.Values(entry, nil)
+			if err != nil || len(fields) < 6 {
+				continue
+			}
+
+			name, err := redigo_redis.String(fields[0], nil)
+			if err != nil {
+				continue
+			}
+
+			position, err := redigo_redis.Int(fields[3], nil)
+			if err != nil {
+				continue
+			}
+
+			c.commands[strings.ToLower(name)] = position
+
+			if len(fields) > 9 {
+				if subcommands, err := redigo_redis.Values(fields[9], nil); err == nil {
+					load(subcommands)
+				}
+			}
+		}
+	}
+	load(reply)
+}
+
+func (c *cluster) close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, pool := range c.pools {
+		pool.Close()
+	}
+	c.pools = map[string]*redigo_redis.Pool{}
+}
+
+// parseClusterSlots converts a CLUSTER SLOTS reply into the master address of each slot.
+func parseClusterSlots(reply []any, queried string) ([]string, []string, error) {
+	slots := make([]string, clusterSlotCount)
+	masters := []string{}
+
+	for _, entry := range reply {
+		fields, err := redigo_redis.Values(entry, nil)
+		if err != nil {
+			return nil, nil, err
+		} else if len(fields) < 3 {
+			return nil, nil, errors.New("unexpected CLUSTER SLOTS reply")
+		}
+
+		start, err := redigo_redis.Int(fields[0], nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		end, err := redigo_redis.Int(fields[1], nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		node, err := redigo_redis.Values(fields[2], nil)
+		if err != nil {
+			return nil, nil, err
+		} else if len(node) < 2 {
+			return nil, nil, errors.New("unexpected CLUSTER SLOTS node")
+		}
+
+		host, _ := redigo_redis.String(node[0], nil)
+		port, err := redigo_redis.Int(node[1], nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		address := redirectAddress(net.JoinHostPort(host, strconv.Itoa(port)), queried)
+		if !slices.Contains(masters, address) {
+			masters = append(masters, address)
+		}
+
+		for slot := max(start, 0); slot <= end && slot < clusterSlotCount; slot++ {
+			slots[slot] = address
+		}
+	}
+
+	if len(masters) == 0 {
+		return nil, nil, errors.New("no slots are served")
+	}
+
+	return slots, masters, nil
+}
+
+// redirectAddress returns address, taking the host from current when the node reports no
+// host ("" or "?"), which means the node is reached through the same host.
+func redirectAddress(address, current string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || (len(host) != 0 && host != "?") {
+		return address
+	}
+
+	currentHost, _, err := net.SplitHostPort(current)
+	if err != nil {
+		return address
+	}
+
+	return net.JoinHostPort(currentHost, port)
+}
+
+func isRedirect(err redigo_redis.Error) bool {
+	return strings.HasPrefix(string(err), "MOVED ") || strings.HasPrefix(string(err), "ASK ")
+}
+
+// keyString formats a command argument the way it is sent to the server.
+func keyString(arg any) string {
+	switch value := arg.(type) {
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,236 @@
+package redis_test
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/redis"
+	"github.com/common-library/go/testutil"
+	redigo_redis "github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	redismodule "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+func TestKeySlot(t *testing.T) {
+	assert.Equal(t, 12182, redis.KeySlot("foo"))
+	assert.Equal(t, 12739, redis.KeySlot("123456789"))
+	assert.Equal(t, redis.KeySlot("user1000"), redis.KeySlot("{user1000}.following"))
+	assert.Equal(t, redis.KeySlot("{user1000}.following"), redis.KeySlot("{user1000}.followers"))
+	assert.Equal(t, redis.KeySlot("{}user"), redis.KeySlot("{}user"))
+	assert.NotEqual(t, redis.KeySlot("{}user1"), redis.KeySlot("{}user2"))
+}
+
+// runCluster starts a Redis Cluster of three masters and returns their addresses.
+func runCluster(t *testing.T) []string {
+	t.Helper()
+
+	ctx := context.Background()
+
+	addresses := []string{}
+	for range 3 {
+		container, err := redismodule.Run(ctx, testutil.RedisImage, testcontainers.WithCmdArgs("--cluster-enabled", "yes"))
+		require.NoError(t, err)
+		t.Cleanup(func() { container.Terminate(ctx) })
+
+		ip, err := container.ContainerIP(ctx)
+		require.NoError(t, err)
+		addresses = append(addresses, net.JoinHostPort(ip, "6379"))
+
+		if len(addresses) == 3 {
+			code, _, err := container.Exec(ctx, append(append([]string{"redis-cli", "--cluster", "create"}, addresses...), "--cluster-yes"))
+			require.NoError(t, err)
+			require.Equal(t, 0, code)
+		}
+	}
+
+	for _, address := range addresses {
+		require.Eventually(t, func() bool {
+			info, err := redigo_redis.String(clusterNodeDo(t, address, "CLUSTER", "INFO"))
+			return err == nil && strings.Contains(info, "cluster_state:ok")
+		}, 30*time.Second, 200*time.Millisecond)
+	}
+
+	return addresses
+}
+
+// clusterNodeDo runs a command on one cluster node without redirection.
+func clusterNodeDo(t *testing.T, address, command string, args ...any) (any, error) {
+	t.Helper()
+
+	connection, err := redigo_redis.Dial("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	return connection.Do(command, args...)
+}
+
+// clusterSlotOwner returns the address and node ID of the master serving slot.
+func clusterSlotOwner(t *testing.T, address string, slot int) (string, string) {
+	t.Helper()
+
+	entries, err := redigo_redis.Values(clusterNodeDo(t, address, "CLUSTER", "SLOTS"))
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		var start, end int
+		var node []any
+		_, err := redigo_redis.Scan(entry.([]any), &start, &end, &node)
+		require.NoError(t, err)
+
+		if start <= slot && slot <= end {
+			var host, id string
+			var port int
+			_, err := redigo_redis.Scan(node, &host, &port, &id)
+			require.NoError(t, err)
+
+			return net.JoinHostPort(host, strconv.Itoa(port)), id
+		}
+	}
+
+	require.FailNow(t, "slot is not served")
+	return "", ""
+}
+
+func TestClient_InitializeCluster(t *testing.T) {
+	addresses := runCluster(t)
+
+	clusterClient := redis.Client{}
+	assert.Error(t, clusterClient.InitializeCluster(nil, "", "", nil, 10, 60))
+
+	clusterClient = redis.Client{}
+	require.NoError(t, clusterClient.InitializeCluster(addresses[:1], "", "", nil, 10, 60))
+	defer clusterClient.Finalize()
+
+	keys := []string{}
+	for i := range 30 {
+		key := "cluster_key_" + strconv.Itoa(i)
+		keys = append(keys, key)
+		require.NoError(t, clusterClient.Set(key, i))
+	}
+
+	for i, key := range keys {
+		value, err := clusterClient.Get(key)
+		assert.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(i), value)
+	}
+
+	size, err := clusterClient.DBsize()
+	assert.NoError(t, err)
+	assert.Equal(t, len(keys), size)
+
+	scanned := []string{}
+	for key, err := range clusterClient.Scan("cluster_key_*", 0) {
+		require.NoError(t, err)
+		scanned = append(scanned, key)
+	}
+	assert.ElementsMatch(t, keys, scanned)
+
+	batch := redis.Batch{}
+	for _, key := range keys {
+		batch.Add("INCR", key)
+	}
+	replies, err := clusterClient.Pipeline(&batch)
+	require.NoError(t, err)
+	for i, reply := range replies {
+		value, err := redigo_redis.Int(reply, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, i+1, value)
+	}
+
+	require.NoError(t, clusterClient.MSet("{user:1}:name", "alice", "{user:1}:email", "alice@example.com"))
+	values, err := clusterClient.MGet("{user:1}:name", "{user:1}:email")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice", "alice@example.com"}, values)
+
+	transaction := redis.Batch{}
+	transaction.Add("INCR", "{user:1}:visits").Add("INCR", "{user:1}:visits")
+	replies, err = clusterClient.Transaction(&transaction)
+	require.NoError(t, err)
+	assert.Equal(t, []any{int64(1), int64(2)}, replies)
+
+	value, err := redigo_redis.Int(clusterClient.RunScript(incrementBy, []any{"{user:1}:score"}, 5))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, value)
+
+	lock, err := clusterClient.TryLock("{jobs}:lock", time.Second)
+	require.NoError(t, err)
+	assert.NoError(t, lock.Unlock())
+
+	received, err := clusterClient.Subscribe(t.Context(), "cluster_channel")
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		count, err := clusterClient.Publish("cluster_channel", "hello")
+		return err == nil && count == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, "hello", string((<-received).Data))
+
+	require.NoError(t, clusterClient.FlushDB())
+	size, err = clusterClient.DBsize()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, size)
+}
+
+func TestClient_ClusterRedirection(t *testing.T) {
+	addresses := runCluster(t)
+
+	clusterClient := redis.Client{}
+	require.NoError(t, clusterClient.InitializeCluster(addresses, "", "", nil, 10, 60))
+	defer clusterClient.Finalize()
+
+	require.NoError(t, clusterClient.Set("{migrating}:a", "a"))
+
+	slot := redis.KeySlot("{migrating}:a")
+	source, sourceID := clusterSlotOwner(t, addresses[0], slot)
+
+	target := ""
+	for _, address := range addresses {
+		if address != source {
+			target = address
+			break
+		}
+	}
+
+	targetID, err := redigo_redis.String(clusterNodeDo(t, target, "CLUSTER", "MYID"))
+	require.NoError(t, err)
+
+	// During migration, keys missing on the source are redirected with ASK
+	_, err = clusterNodeDo(t, target, "CLUSTER", "SETSLOT", slot, "IMPORTING", sourceID)
+	require.NoError(t, err)
+	_, err = clusterNodeDo(t, source, "CLUSTER", "SETSLOT", slot, "MIGRATING", targetID)
+	require.NoError(t, err)
+
+	require.NoError(t, clusterClient.Set("{migrating}:b", "b"))
+
+	exists, err := redigo_redis.Bool(clusterNodeDo(t, source, "EXISTS", "{migrating}:b"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	value, err := clusterClient.Get("{migrating}:b")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", value)
+
+	// After migration, the source answers MOVED
+	targetHost, _, err := net.SplitHostPort(target)
+	require.NoError(t, err)
+	_, err = clusterNodeDo(t, source, "MIGRATE", targetHost, 6379, "", 0, 5000, "KEYS", "{migrating}:a")
+	require.NoError(t, err)
+	for _, address := range addresses {
+		_, err = clusterNodeDo(t, address, "CLUSTER", "SETSLOT", slot, "NODE", targetID)
+		require.NoError(t, err)
+	}
+
+	value, err = clusterClient.Get("{migrating}:a")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", value)
+
+	owner, _ := clusterSlotOwner(t, target, slot)
+	assert.Equal(t, target, owner)
+}
@@ -0,0 +1,226 @@
+package redis
+
+import (
+	"errors"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+// ErrNil is returned when a key, field or element does not exist.
+var ErrNil = redigo_redis.ErrNil
+
+// HSet sets fields of a hash.
+//
+// Parameters:
+//   - key: Hash key
+//   - fieldsAndValues: Alternating field-value pairs (field1, value1, field2, value2, ...)
+//
+// Returns:
+//   - int: Number of fields that were added (fields that already existed and were updated are not counted)
+//   - error: Error if client not initialized or HSET command fails, nil on success
+//
+// Example:
+//
+//	added, err := client.HSet("user:1", "name", "Alice", "age", 30)
+func (c *Client) HSet(key any, fieldsAndValues ...any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("HSET", redigo_redis.Args{key}.Add(fieldsAndValues...)...))
+}
+
+// HSetStruct stores the exported fields of a struct as the fields of a hash.
+//
+// Parameters:
+//   - key: Hash key
+//   - value: Struct or pointer to struct; the field name is taken from the `redis` tag, or the Go field name
+//
+// Returns:
+//   - error: Error if client not initialized or HSET command fails, nil on success
+//
+// Integer, float, boolean, string and []byte fields are supported. Fields tagged `redis:"-"`
+// are skipped, and a field tagged `redis:",omitempty"` is skipped when it holds its zero value.
+//
+// Example:
+//
+//	type User struct {
+//	    Name  string `redis:"name"`
+//	    Email string `redis:"email"`
+//	    Age   int    `redis:"age"`
+//	}
+//
+//	err := client.HSetStruct("user:1", User{Name: "Alice", Email: "alice@example.com", Age: 30})
+func (c *Client) HSetStruct(key any, value any) error {
+	if c.pool == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	args := redigo_redis.Args{key}.AddFlat(value)
+	if len(args) == 1 {
+		return errors.New("no fields to set")
+	}
+
+	_, err := c.do("HSET", args...)
+
+	return err
+}
+
+// HGet returns the value of a field of a hash.
+//
+// Parameters:
+//   - key: Hash key
+//   - field: Field name
+//
+// Returns:
+//   - string: Value of the field
+//   - error: ErrNil if the key or field does not exist, other errors if the HGET command fails
+//
+// Example:
+//
+//	name, err := client.HGet("user:1", "name")
+func (c *Client) HGet(key, field any) (string, error) {
+	if c.pool == nil {
+		return "", errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.String(c.do("HGET", key, field))
+}
+
+// HGetAll returns all fields and values of a hash.
+//
+// Parameters:
+//   - key: Hash key
+//
+// Returns:
+//   - map[string]string: Fields and values, empty if the key does not exist
+//   - error: Error if client not initialized or HGETALL command fails, nil on success
+//
+// Example:
+//
+//	fields, err := client.HGetAll("user:1")
+//	fmt.Println(fields["name"])
+func (c *Client) HGetAll(key any) (map[string]string, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.StringMap(c.do("HGETALL", key))
+}
+
+// HGetAllStruct reads all fields of a hash into a struct.
+//
+// Parameters:
+//   - key: Hash key
+//   - destination: Pointer to a struct; fields are matched by the `redis` tag, or the Go field name
+//
+// Returns:
+//   - error: ErrNil if the key does not exist, other errors if HGETALL fails or a value cannot be converted
+//
+// Hash fields without a matching struct field are ignored, and struct fields without a
+// matching hash field keep their value.
+//
+// Example:
+//
+//	var user User
+//	if err := client.HGetAllStruct("user:1", &user); errors.Is(err, redis.ErrNil) {
+//	    fmt.Println("not found")
+//	}
+func (c *Client) HGetAllStruct(key any, destination any) error {
+	if c.pool == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	values, err := redigo_redis.Values(c.do("HGETALL", key))
+	if err != nil {
+		return err
+	} else if len(values) == 0 {
+		return ErrNil
+	}
+
+	return redigo_redis.ScanStruct(values, destination)
+}
+
+// HDel deletes fields of a hash.
+//
+// Parameters:
+//   - key: Hash key
+//   - fields: Fields to delete
+//
+// Returns:
+//   - int: Number of fields that were removed
+//   - error: Error if client not initialized or HDEL command fails, nil on success
+//
+// Example:
+//
+//	removed, err := client.HDel("user:1", "email")
+func (c *Client) HDel(key any, fields ...any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("HDEL", redigo_redis.Args{key}.Add(fields...)...))
+}
+
+// HExists checks if a field exists in a hash.
+//
+// Parameters:
+//   - key: Hash key
+//   - field: Field name
+//
+// Returns:
+//   - bool: true if the field exists
+//   - error: Error if client not initialized or HEXISTS command fails, nil on success
+//
+// Example:
+//
+//	exists, err := client.HExists("user:1", "email")
+func (c *Client) HExists(key, field any) (bool, error) {
+	if c.pool == nil {
+		return false, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Bool(c.do("HEXISTS", key, field))
+}
+
+// HIncrBy increments the integer value of a field of a hash.
+//
+// Parameters:
+//   - key: Hash key
+//   - field: Field name; a missing field is treated as 0
+//   - increment: Amount to add (negative to decrement)
+//
+// Returns:
+//   - int64: Value after the increment
+//   - error: Error if client not initialized, the field is not an integer or HINCRBY fails
+//
+// Example:
+//
+//	visits, err := client.HIncrBy("page:home", "visits", 1)
+func (c *Client) HIncrBy(key, field any, increment int64) (int64, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int64(c.do("HINCRBY", key, field, increment))
+}
+
+// HLen returns the number of fields of a hash.
+//
+// Parameters:
+//   - key: Hash key
+//
+// Returns:
+//   - int: Number of fields, 0 if the key does not exist
+//   - error: Error if client not initialized or HLEN command fails, nil on success
+//
+// Example:
+//
+//	count, err := client.HLen("user:1")
+func (c *Client) HLen(key any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("HLEN", key))
+}
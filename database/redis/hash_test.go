@@ -0,0 +1,79 @@
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/common-library/go/database/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testUser struct {
+	Name   string  `redis:"name"`
+	Email  string  `redis:"email,omitempty"`
+	Age    int     `redis:"age"`
+	Score  float64 `redis:"score"`
+	Active bool    `redis:"active"`
+	Secret string  `redis:"-"`
+}
+
+func TestClient_HSetAndHGet(t *testing.T) {
+	setupTest(t)
+
+	added, err := client.HSet("user:1", "name", "Alice", "age", 30)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	added, err = client.HSet("user:1", "age", 31)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, added)
+
+	name, err := client.HGet("user:1", "name")
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", name)
+
+	_, err = client.HGet("user:1", "missing")
+	assert.ErrorIs(t, err, redis.ErrNil)
+
+	fields, err := client.HGetAll("user:1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "Alice", "age": "31"}, fields)
+
+	exists, err := client.HExists("user:1", "name")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	value, err := client.HIncrBy("user:1", "age", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(33), value)
+
+	length, err := client.HLen("user:1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, length)
+
+	removed, err := client.HDel("user:1", "name", "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	exists, err = client.HExists("user:1", "name")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestClient_HSetStruct(t *testing.T) {
+	setupTest(t)
+
+	user := testUser{Name: "Alice", Age: 30, Score: 9.5, Active: true, Secret: "secret"}
+	require.NoError(t, client.HSetStruct("user:1", &user))
+
+	fields, err := client.HGetAll("user:1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "Alice", "age": "30", "score": "9.5", "active": "1"}, fields)
+
+	result := testUser{}
+	require.NoError(t, client.HGetAllStruct("user:1", &result))
+	assert.Equal(t, testUser{Name: "Alice", Age: 30, Score: 9.5, Active: true}, result)
+
+	assert.ErrorIs(t, client.HGetAllStruct("user:missing", &result), redis.ErrNil)
+	assert.Error(t, client.HSetStruct("user:2", struct{}{}))
+}
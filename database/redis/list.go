@@ -0,0 +1,180 @@
+package redis
+
+import (
+	"errors"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+// LPush inserts values at the head of a list.
+//
+// Parameters:
+//   - key: List key; the list is created if it does not exist
+//   - values: Values to insert, each one inserted at the head in turn
+//
+// Returns:
+//   - int: Length of the list after the push
+//   - error: Error if client not initialized or LPUSH command fails, nil on success
+//
+// Example:
+//
+//	length, err := client.LPush("jobs", "job1", "job2") // list is now job2, job1
+func (c *Client) LPush(key any, values ...any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("LPUSH", redigo_redis.Args{key}.Add(values...)...))
+}
+
+// RPush appends values to the tail of a list.
+//
+// Parameters:
+//   - key: List key; the list is created if it does not exist
+//   - values: Values to append, in order
+//
+// Returns:
+//   - int: Length of the list after the push
+//   - error: Error if client not initialized or RPUSH command fails, nil on success
+//
+// Example:
+//
+//	length, err := client.RPush("jobs", "job1", "job2") // list is now job1, job2
+func (c *Client) RPush(key any, values ...any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("RPUSH", redigo_redis.Args{key}.Add(values...)...))
+}
+
+// LPop removes and returns the first element of a list.
+//
+// Parameters:
+//   - key: List key
+//
+// Returns:
+//   - string: Removed element
+//   - error: ErrNil if the list is empty or does not exist, other errors if the LPOP command fails
+//
+// Example:
+//
+//	job, err := client.LPop("jobs")
+//	if errors.Is(err, redis.ErrNil) {
+//	    fmt.Println("no jobs")
+//	}
+func (c *Client) LPop(key any) (string, error) {
+	if c.pool == nil {
+		return "", errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.String(c.do("LPOP", key))
+}
+
+// RPop removes and returns the last element of a list.
+//
+// Parameters:
+//   - key: List key
+//
+// Returns:
+//   - string: Removed element
+//   - error: ErrNil if the list is empty or does not exist, other errors if the RPOP command fails
+//
+// Example:
+//
+//	job, err := client.RPop("jobs")
+func (c *Client) RPop(key any) (string, error) {
+	if c.pool == nil {
+		return "", errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.String(c.do("RPOP", key))
+}
+
+// LRange returns a range of elements of a list.
+//
+// Parameters:
+//   - key: List key
+//   - start: Index of the first element (0 is the head, negative counts from the tail)
+//   - stop: Index of the last element, inclusive (-1 is the tail)
+//
+// Returns:
+//   - []string: Elements in the range, empty if the key does not exist
+//   - error: Error if client not initialized or LRANGE command fails, nil on success
+//
+// Example:
+//
+//	all, err := client.LRange("jobs", 0, -1)
+func (c *Client) LRange(key any, start, stop int) ([]string, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Strings(c.do("LRANGE", key, start, stop))
+}
+
+// LLen returns the length of a list.
+//
+// Parameters:
+//   - key: List key
+//
+// Returns:
+//   - int: Length of the list, 0 if the key does not exist
+//   - error: Error if client not initialized or LLEN command fails, nil on success
+//
+// Example:
+//
+//	length, err := client.LLen("jobs")
+func (c *Client) LLen(key any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("LLEN", key))
+}
+
+// LTrim trims a list to the specified range.
+//
+// Parameters:
+//   - key: List key
+//   - start: Index of the first element to keep
+//   - stop: Index of the last element to keep, inclusive
+//
+// Returns:
+//   - error: Error if client not initialized or LTRIM command fails, nil on success
+//
+// Example:
+//
+//	// Keep only the 100 most recent entries pushed with LPush
+//	err := client.LTrim("recent", 0, 99)
+func (c *Client) LTrim(key any, start, stop int) error {
+	if c.pool == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	_, err := c.do("LTRIM", key, start, stop)
+
+	return err
+}
+
+// LRem removes elements equal to value from a list.
+//
+// Parameters:
+//   - key: List key
+//   - count: Number of occurrences to remove; positive from the head, negative from the tail, 0 for all
+//   - value: Value to remove
+//
+// Returns:
+//   - int: Number of removed elements
+//   - error: Error if client not initialized or LREM command fails, nil on success
+//
+// Example:
+//
+//	removed, err := client.LRem("jobs", 0, "job1")
+func (c *Client) LRem(key any, count int, value any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("LREM", key, count, value))
+}
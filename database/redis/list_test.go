@@ -0,0 +1,52 @@
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/common-library/go/database/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_List(t *testing.T) {
+	setupTest(t)
+
+	length, err := client.RPush("list", "b", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, length)
+
+	length, err = client.LPush("list", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, length)
+
+	values, err := client.LRange("list", 0, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, values)
+
+	value, err := client.LPop("list")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", value)
+
+	value, err = client.RPop("list")
+	assert.NoError(t, err)
+	assert.Equal(t, "c", value)
+
+	length, err = client.LLen("list")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, length)
+
+	_, err = client.RPush("list", "x", "b", "x")
+	assert.NoError(t, err)
+
+	removed, err := client.LRem("list", 0, "x")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	assert.NoError(t, client.LTrim("list", 0, 0))
+
+	values, err = client.LRange("list", 0, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, values)
+
+	_, err = client.LPop("missing")
+	assert.ErrorIs(t, err, redis.ErrNil)
+}
@@ -0,0 +1,338 @@
+package redis
+
+import (
+	"context"
+	crypto_rand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+const (
+	lockRetryDelay  = 50 * time.Millisecond
+	lockDriftFactor = 0.01
+)
+
+var (
+	// ErrLockNotAcquired is returned when a lock is held by another owner.
+	ErrLockNotAcquired = errors.New("lock not acquired")
+
+	// ErrLockNotHeld is returned when a lock has expired or has been taken over by another owner.
+	ErrLockNotHeld = errors.New("lock not held")
+)
+
+var (
+	acquireLockScript = NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0`)
+
+	releaseLockScript = NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+	extendLockScript = NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+)
+
+// DistributedLock is a lock held on one Redis server, or on a majority of servers with Redlock.
+type DistributedLock struct {
+	clients []*Client
+	quorum  int
+
+	key          string
+	token        string
+	fencingToken int64
+	expiration   time.Time
+}
+
+// Redlock acquires locks on a majority of independent Redis servers.
+type Redlock struct {
+	clients []*Client
+}
+
+// TryLock acquires a lock without waiting.
+//
+// Parameters:
+//   - key: Lock key; the fencing counter is stored at key + ":fencing"
+//   - ttl: Lease time after which the lock expires unless extended
+//
+// Returns:
+//   - *DistributedLock: Acquired lock
+//   - error: ErrLockNotAcquired if another owner holds the lock, other errors if the command fails
+//
+// The lock stores a random token, so only its owner can release or extend it. Every successful
+// acquisition increments a fencing counter; pass GetFencingToken to the protected resource and
+// reject writes carrying an older token, which protects against an owner that paused past its
+// lease. In Redis Cluster, use a hash tag in key (e.g. "{orders}:lock") so both keys share a slot.
+//
+// Example:
+//
+//	lock, err := client.TryLock("jobs:cleanup", 30*time.Second)
+//	if errors.Is(err, redis.ErrLockNotAcquired) {
+//	    return // another replica is running the job
+//	} else if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer lock.Unlock()
+func (c *Client) TryLock(key string, ttl time.Duration) (*DistributedLock, error) {
+	return NewRedlock(c).TryLock(key, ttl)
+}
+
+// Lock acquires a lock, retrying until it is acquired or ctx is done.
+//
+// Parameters:
+//   - ctx: Context that cancels waiting for the lock
+//   - key: Lock key; the fencing counter is stored at key + ":fencing"
+//   - ttl: Lease time after which the lock expires unless extended
+//
+// Returns:
+//   - *DistributedLock: Acquired lock
+//   - error: ctx.Err() if ctx is done first, other errors if the command fails
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//
+//	lock, err := client.Lock(ctx, "orders:42", 30*time.Second)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer lock.Unlock()
+func (c *Client) Lock(ctx context.Context, key string, ttl time.Duration) (*DistributedLock, error) {
+	return NewRedlock(c).Lock(ctx, key, ttl)
+}
+
+// NewRedlock creates a Redlock over independent Redis servers.
+//
+// Parameters:
+//   - clients: Initialized clients of independent servers (not replicas of each other), usually 3 or 5
+//
+// Returns:
+//   - *Redlock: Redlock acquiring locks on a majority of the servers
+//
+// A lock is acquired when it is set on a majority of the servers within its lease time, so it
+// survives the failure of a minority of them. The fencing token is the highest counter among
+// the servers that granted the lock; it only increases as long as those servers keep their
+// data across restarts.
+//
+// Example:
+//
+//	redlock := redis.NewRedlock(&client1, &client2, &client3)
+//
+//	lock, err := redlock.Lock(ctx, "orders:42", 30*time.Second)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer lock.Unlock()
+func NewRedlock(clients ...*Client) *Redlock {
+	return &Redlock{clients: clients}
+}
+
+// TryLock acquires a lock on a majority of the servers without waiting.
+//
+// Parameters:
+//   - key: Lock key
+//   - ttl: Lease time after which the lock expires unless extended
+//
+// Returns:
+//   - *DistributedLock: Acquired lock
+//   - error: ErrLockNotAcquired if no majority granted the lock in time, other errors if no server could be reached
+//
+// Example:
+//
+//	lock, err := redlock.TryLock("orders:42", 30*time.Second)
+func (r *Redlock) TryLock(key string, ttl time.Duration) (*DistributedLock, error) {
+	if len(r.clients) == 0 {
+		return nil, errors.New("at least one client is required")
+	}
+
+	lock := &DistributedLock{clients: r.clients, quorum: len(r.clients)/2 + 1, key: key, token: randomToken()}
+
+	start := time.Now()
+
+	acquired, errs := 0, []error{}
+	for _, client := range r.clients {
+		fencingToken, err := redigo_redis.Int64(client.RunScript(acquireLockScript, []any{key, key + ":fencing"}, lock.token, ttl.Milliseconds()))
+		if err != nil {
+			errs = append(errs, err)
+		} else if fencingToken > 0 {
+			acquired++
+			lock.fencingToken = max(lock.fencingToken, fencingToken)
+		}
+	}
+
+	drift := time.Duration(float64(ttl)*lockDriftFactor) + 2*time.Millisecond
+	lock.expiration = start.Add(ttl - drift)
+
+	if acquired >= lock.quorum && time.Now().Before(lock.expiration) {
+		return lock, nil
+	}
+
+	lock.release()
+
+	if len(errs) == len(r.clients) {
+		return nil, errors.Join(errs...)
+	}
+
+	return nil, ErrLockNotAcquired
+}
+
+// Lock acquires a lock on a majority of the servers, retrying until it is acquired or ctx is done.
+//
+// Parameters:
+//   - ctx: Context that cancels waiting for the lock
+//   - key: Lock key
+//   - ttl: Lease time after which the lock expires unless extended
+//
+// Returns:
+//   - *DistributedLock: Acquired lock
+//   - error: ctx.Err() if ctx is done first, other errors if no server could be reached
+//
+// Retries wait a short random delay so that competing owners do not keep splitting the votes.
+//
+// Example:
+//
+//	lock, err := redlock.Lock(ctx, "orders:42", 30*time.Second)
+func (r *Redlock) Lock(ctx context.Context, key string, ttl time.Duration) (*DistributedLock, error) {
+	for {
+		lock, err := r.TryLock(key, ttl)
+		if !errors.Is(err, ErrLockNotAcquired) {
+			return lock, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryDelay + rand.N(lockRetryDelay)):
+		}
+	}
+}
+
+// Unlock releases the lock if it is still held by this owner.
+//
+// Returns:
+//   - error: ErrLockNotHeld if the lock expired or was taken over (on a majority of servers with Redlock)
+//
+// Example:
+//
+//	if err := lock.Unlock(); errors.Is(err, redis.ErrLockNotHeld) {
+//	    log.Println("lease expired before the work finished")
+//	}
+func (l *DistributedLock) Unlock() error {
+	if released, err := l.release(); err != nil {
+		return err
+	} else if released < l.quorum {
+		return ErrLockNotHeld
+	}
+
+	return nil
+}
+
+// Extend resets the lease of the lock if it is still held by this owner.
+//
+// Parameters:
+//   - ttl: New lease time from now
+//
+// Returns:
+//   - error: ErrLockNotHeld if the lock expired or was taken over (on a majority of servers with Redlock)
+//
+// Long-running owners call Extend periodically, well before the lease ends.
+//
+// Example:
+//
+//	ticker := time.NewTicker(10 * time.Second)
+//	defer ticker.Stop()
+//	for range ticker.C {
+//	    if err := lock.Extend(30 * time.Second); err != nil {
+//	        cancelWork()
+//	        return
+//	    }
+//	}
+func (l *DistributedLock) Extend(ttl time.Duration) error {
+	start := time.Now()
+
+	extended, errs := 0, []error{}
+	for _, client := range l.clients {
+		if result, err := redigo_redis.Int(client.RunScript(extendLockScript, []any{l.key}, l.token, ttl.Milliseconds())); err != nil {
+			errs = append(errs, err)
+		} else {
+			extended += result
+		}
+	}
+
+	drift := time.Duration(float64(ttl)*lockDriftFactor) + 2*time.Millisecond
+	expiration := start.Add(ttl - drift)
+
+	if extended >= l.quorum && time.Now().Before(expiration) {
+		l.expiration = expiration
+		return nil
+	} else if len(errs) == len(l.clients) {
+		return errors.Join(errs...)
+	}
+
+	return ErrLockNotHeld
+}
+
+// GetKey returns the key of the lock.
+//
+// Returns:
+//   - string: Lock key
+func (l *DistributedLock) GetKey() string {
+	return l.key
+}
+
+// GetFencingToken returns the fencing token of the lock.
+//
+// Returns:
+//   - int64: Token that increases with every acquisition of the key
+//
+// Example:
+//
+//	// The storage rejects writes whose token is lower than the last one it accepted
+//	err := storage.Write(data, lock.GetFencingToken())
+func (l *DistributedLock) GetFencingToken() int64 {
+	return l.fencingToken
+}
+
+// GetExpiration returns the time until which the lock is held, allowing for clock drift.
+//
+// Returns:
+//   - time.Time: Local time after which the lock must be considered lost unless extended
+func (l *DistributedLock) GetExpiration() time.Time {
+	return l.expiration
+}
+
+func (l *DistributedLock) release() (int, error) {
+	released, errs := 0, []error{}
+	for _, client := range l.clients {
+		if result, err := redigo_redis.Int(client.RunScript(releaseLockScript, []any{l.key}, l.token)); err != nil {
+			errs = append(errs, err)
+		} else {
+			released += result
+		}
+	}
+
+	if len(errs) == len(l.clients) {
+		return 0, errors.Join(errs...)
+	}
+
+	return released, nil
+}
+
+func randomToken() string {
+	token := make([]byte, 16)
+	crypto_rand.Read(token)
+
+	return hex.EncodeToString(token)
+}
@@ -0,0 +1,131 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_TryLock(t *testing.T) {
+	setupTest(t)
+
+	lock, err := client.TryLock("lock:job", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "lock:job", lock.GetKey())
+	assert.Equal(t, int64(1), lock.GetFencingToken())
+	assert.True(t, lock.GetExpiration().After(time.Now()))
+
+	_, err = client.TryLock("lock:job", time.Second)
+	assert.ErrorIs(t, err, redis.ErrLockNotAcquired)
+
+	require.NoError(t, lock.Unlock())
+	assert.ErrorIs(t, lock.Unlock(), redis.ErrLockNotHeld)
+
+	lock, err = client.TryLock("lock:job", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), lock.GetFencingToken())
+	assert.NoError(t, lock.Unlock())
+}
+
+func TestClient_TryLockExpiration(t *testing.T) {
+	setupTest(t)
+
+	lock, err := client.TryLock("lock:job", 100*time.Millisecond)
+	require.NoError(t, err)
+
+	time.Sleep(200 * time.Millisecond)
+
+	other, err := client.TryLock("lock:job", time.Second)
+	require.NoError(t, err)
+	assert.Greater(t, other.GetFencingToken(), lock.GetFencingToken())
+
+	assert.ErrorIs(t, lock.Extend(time.Second), redis.ErrLockNotHeld)
+	assert.ErrorIs(t, lock.Unlock(), redis.ErrLockNotHeld)
+	assert.NoError(t, other.Unlock())
+}
+
+func TestDistributedLock_Extend(t *testing.T) {
+	setupTest(t)
+
+	lock, err := client.TryLock("lock:job", 200*time.Millisecond)
+	require.NoError(t, err)
+
+	expiration := lock.GetExpiration()
+	require.NoError(t, lock.Extend(time.Second))
+	assert.True(t, lock.GetExpiration().After(expiration))
+
+	time.Sleep(300 * time.Millisecond)
+
+	_, err = client.TryLock("lock:job", time.Second)
+	assert.ErrorIs(t, err, redis.ErrLockNotAcquired)
+	assert.NoError(t, lock.Unlock())
+}
+
+func TestClient_Lock(t *testing.T) {
+	setupTest(t)
+
+	lock, err := client.TryLock("lock:job", 200*time.Millisecond)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	other, err := client.Lock(ctx, "lock:job", time.Second)
+	require.NoError(t, err)
+	assert.Greater(t, other.GetFencingToken(), lock.GetFencingToken())
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Lock(ctx, "lock:job", time.Second)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NoError(t, other.Unlock())
+}
+
+func TestClient_TryLockErrors(t *testing.T) {
+	uninitialized := redis.Client{}
+	_, err := uninitialized.TryLock("lock:job", time.Second)
+	assert.EqualError(t, err, "please call Initialize first")
+
+	_, err = redis.NewRedlock().TryLock("lock:job", time.Second)
+	assert.EqualError(t, err, "at least one client is required")
+}
+
+func TestRedlock(t *testing.T) {
+	setupTest(t)
+
+	clients := []*redis.Client{client, newRedisClient(t), newRedisClient(t)}
+	redlock := redis.NewRedlock(clients...)
+
+	// A lock held on one server leaves a majority
+	held, err := clients[1].TryLock("lock:job", time.Second)
+	require.NoError(t, err)
+
+	lock, err := redlock.TryLock("lock:job", time.Second)
+	require.NoError(t, err)
+	require.NoError(t, lock.Extend(time.Second))
+	require.NoError(t, lock.Unlock())
+
+	// A lock held on two servers does not
+	other, err := clients[2].TryLock("lock:job", time.Second)
+	require.NoError(t, err)
+
+	_, err = redlock.TryLock("lock:job", time.Second)
+	assert.ErrorIs(t, err, redis.ErrLockNotAcquired)
+
+	exists, err := client.Exists("lock:job")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, held.Unlock())
+	require.NoError(t, other.Unlock())
+
+	lock, err = redlock.TryLock("lock:job", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), lock.GetFencingToken())
+	assert.NoError(t, lock.Unlock())
+}
@@ -0,0 +1,253 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+// ErrTransactionAborted is returned by WatchTransaction when EXEC is aborted because a watched key changed.
+var ErrTransactionAborted = errors.New("transaction aborted")
+
+type batchCommand struct {
+	name string
+	args []any
+}
+
+// Batch is a list of commands sent together with Pipeline, Transaction or WatchTransaction.
+type Batch struct {
+	commands []batchCommand
+}
+
+// Add appends a command to the batch.
+//
+// Parameters:
+//   - command: Redis command name (e.g. "SET", "HINCRBY")
+//   - args: Command arguments
+//
+// Returns:
+//   - *Batch: The batch, so calls can be chained
+//
+// Example:
+//
+//	batch := redis.Batch{}
+//	batch.Add("SET", "key", "value").Add("INCR", "counter")
+func (b *Batch) Add(command string, args ...any) *Batch {
+	b.commands = append(b.commands, batchCommand{name: command, args: args})
+
+	return b
+}
+
+// Len returns the number of commands in the batch.
+//
+// Returns:
+//   - int: Number of commands
+func (b *Batch) Len() int {
+	return len(b.commands)
+}
+
+// Pipeline sends all commands of a batch in one round trip and reads their replies.
+//
+// Parameters:
+//   - batch: Commands to send
+//
+// Returns:
+//   - []any: Reply of each command in order; a failed command's reply is its error
+//   - error: Error if client not initialized or the connection fails, otherwise the first command error (nil if all succeeded)
+//
+// Commands are not atomic: other clients' commands may run between them. Convert replies
+// with the redigo reply helpers (e.g. Int, String).
+//
+// Example:
+//
+//	batch := redis.Batch{}
+//	batch.Add("INCR", "page:home").Add("INCR", "page:about").Add("GET", "page:home")
+//
+//	replies, err := client.Pipeline(&batch)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	views, _ := redigo_redis.Int(replies[2], nil)
+func (c *Client) Pipeline(batch *Batch) ([]any, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	var replies []any
+	var err error
+	if c.cluster != nil {
+		replies, err = c.cluster.pipeline(batch.commands)
+	} else {
+		connection := c.pool.Get()
+		defer connection.Close()
+
+		replies, err = pipeline(connection, batch.commands)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, reply := range replies {
+		if redisErr, ok := reply.(redigo_redis.Error); ok {
+			return replies, fmt.Errorf("command %d (%s): %w", i, batch.commands[i].name, redisErr)
+		}
+	}
+
+	return replies, nil
+}
+
+// pipeline sends commands on one connection and reads their replies, keeping command errors as replies.
+func pipeline(connection redigo_redis.Conn, commands []batchCommand) ([]any, error) {
+	for _, command := range commands {
+		if err := connection.Send(command.name, command.args...); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := connection.Flush(); err != nil {
+		return nil, err
+	}
+
+	replies := make([]any, len(commands))
+	for i := range commands {
+		reply, err := connection.Receive()
+		if redisErr, ok := err.(redigo_redis.Error); ok {
+			replies[i] = redisErr
+		} else if err != nil {
+			return nil, err
+		} else {
+			replies[i] = reply
+		}
+	}
+
+	return replies, nil
+}
+
+// Transaction runs all commands of a batch atomically with MULTI and EXEC.
+//
+// Parameters:
+//   - batch: Commands to run
+//
+// Returns:
+//   - []any: Reply of each command in order; a failed command's reply is its error
+//   - error: Error if a command was rejected when queued (no command runs then),
+//     otherwise the first command error (nil if all succeeded)
+//
+// Commands of a transaction run one after another with no other client's command in between.
+// As in Redis, a command that fails at run time (e.g. wrong type) does not roll back the others.
+//
+// Example:
+//
+//	batch := redis.Batch{}
+//	batch.Add("DECRBY", "account:1", 100).Add("INCRBY", "account:2", 100)
+//
+//	replies, err := client.Transaction(&batch)
+func (c *Client) Transaction(batch *Batch) ([]any, error) {
+	return c.WatchTransaction(nil, func() (*Batch, error) { return batch, nil })
+}
+
+// WatchTransaction runs an optimistic transaction: it watches keys, builds a batch and runs it with MULTI and EXEC.
+//
+// Parameters:
+//   - watchKeys: Keys to WATCH before build is called
+//   - build: Function that reads the current state (with any Client method) and returns the commands to run
+//
+// Returns:
+//   - []any: Reply of each command in order; a failed command's reply is its error
+//   - error: ErrTransactionAborted if a watched key changed after WATCH, the error returned by build,
+//     an error if build returned a nil batch, or the errors described in Transaction
+//
+// If any watched key is modified by another client between WATCH and EXEC, no command runs and
+// ErrTransactionAborted is returned; the caller usually retries.
+//
+// Example:
+//
+//	for {
+//	    replies, err := client.WatchTransaction([]any{"balance"}, func() (*redis.Batch, error) {
+//	        value, err := client.Get("balance")
+//	        if err != nil {
+//	            return nil, err
+//	        }
+//	        balance, _ := strconv.Atoi(value)
+//
+//	        batch := redis.Batch{}
+//	        return batch.Add("SET", "balance", balance*2), nil
+//	    })
+//	    if errors.Is(err, redis.ErrTransactionAborted) {
+//	        continue
+//	    }
+//	    ...
+//	}
+func (c *Client) WatchTransaction(watchKeys []any, build func() (*Batch, error)) ([]any, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	buildBatch := func() (*Batch, error) {
+		if batch, err := build(); err != nil {
+			return nil, err
+		} else if batch == nil {
+			return nil, errors.New("build returned a nil batch")
+		} else {
+			return batch, nil
+		}
+	}
+
+	// In cluster mode the transaction runs on the node of the first watched key, or of the first
+	// command's key when no key is watched.
+	var batch *Batch
+	route := batchCommand{name: "WATCH", args: watchKeys}
+	if len(watchKeys) == 0 {
+		var err error
+		if batch, err = buildBatch(); err != nil {
+			return nil, err
+		} else if len(batch.commands) != 0 {
+			route = batch.commands[0]
+		}
+	}
+
+	reply, err := c.withConnection(route.name, route.args, func(connection redigo_redis.Conn) (any, error) {
+		if len(watchKeys) != 0 {
+			if _, err := connection.Do("WATCH", watchKeys...); err != nil {
+				return nil, err
+			}
+
+			var err error
+			if batch, err = buildBatch(); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := connection.Send("MULTI"); err != nil {
+			return nil, err
+		}
+
+		for _, command := range batch.commands {
+			if err := connection.Send(command.name, command.args...); err != nil {
+				return nil, err
+			}
+		}
+
+		return connection.Do("EXEC")
+	})
+	if err != nil {
+		return nil, err
+	} else if reply == nil {
+		return nil, ErrTransactionAborted
+	}
+
+	values, err := redigo_redis.Values(reply, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	for i, value := range values {
+		if redisErr, ok := value.(redigo_redis.Error); ok && firstErr == nil {
+			firstErr = fmt.Errorf("command %d (%s): %w", i, batch.commands[i].name, redisErr)
+		}
+	}
+
+	return values, firstErr
+}
@@ -0,0 +1,116 @@
+package redis_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/common-library/go/database/redis"
+	redigo_redis "github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Pipeline(t *testing.T) {
+	setupTest(t)
+
+	batch := redis.Batch{}
+	batch.Add("SET", "key", "value").Add("INCR", "counter").Add("INCR", "counter").Add("GET", "key")
+	assert.Equal(t, 4, batch.Len())
+
+	replies, err := client.Pipeline(&batch)
+	require.NoError(t, err)
+	require.Len(t, replies, 4)
+	assert.Equal(t, "OK", replies[0])
+	assert.Equal(t, int64(2), replies[2])
+
+	value, err := redigo_redis.String(replies[3], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	failing := redis.Batch{}
+	failing.Add("INCR", "key").Add("SET", "after", "value")
+
+	replies, err = client.Pipeline(&failing)
+	assert.ErrorContains(t, err, "command 0 (INCR)")
+	assert.IsType(t, redigo_redis.Error(""), replies[0])
+
+	exists, err := client.Exists("after")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestClient_Transaction(t *testing.T) {
+	setupTest(t)
+
+	require.NoError(t, client.MSet("account:1", 500, "account:2", 0))
+
+	batch := redis.Batch{}
+	batch.Add("DECRBY", "account:1", 100).Add("INCRBY", "account:2", 100)
+
+	replies, err := client.Transaction(&batch)
+	require.NoError(t, err)
+	assert.Equal(t, []any{int64(400), int64(100)}, replies)
+
+	rejected := redis.Batch{}
+	rejected.Add("SET", "key").Add("SET", "other", "value")
+
+	_, err = client.Transaction(&rejected)
+	assert.Error(t, err)
+
+	exists, err := client.Exists("other")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestClient_WatchTransaction(t *testing.T) {
+	setupTest(t)
+
+	require.NoError(t, client.Set("balance", 10))
+
+	double := func() (*redis.Batch, error) {
+		value, err := client.Get("balance")
+		if err != nil {
+			return nil, err
+		}
+
+		balance, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+
+		batch := redis.Batch{}
+		return batch.Add("SET", "balance", balance*2), nil
+	}
+
+	_, err := client.WatchTransaction([]any{"balance"}, double)
+	require.NoError(t, err)
+
+	balance, err := client.Get("balance")
+	assert.NoError(t, err)
+	assert.Equal(t, "20", balance)
+
+	_, err = client.WatchTransaction([]any{"balance"}, func() (*redis.Batch, error) {
+		batch, err := double()
+		if err == nil {
+			err = client.Set("balance", 100)
+		}
+
+		return batch, err
+	})
+	assert.ErrorIs(t, err, redis.ErrTransactionAborted)
+
+	balance, err = client.Get("balance")
+	assert.NoError(t, err)
+	assert.Equal(t, "100", balance)
+
+	nilBatch := func() (*redis.Batch, error) { return nil, nil }
+
+	_, err = client.WatchTransaction([]any{"balance"}, nilBatch)
+	assert.EqualError(t, err, "build returned a nil batch")
+
+	_, err = client.WatchTransaction(nil, nilBatch)
+	assert.EqualError(t, err, "build returned a nil batch")
+
+	_, err = client.Transaction(nil)
+	assert.Error(t, err)
+}
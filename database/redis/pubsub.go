@@ -0,0 +1,248 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+const (
+	pubSubHealthCheckInterval = 30 * time.Second
+	pubSubSubscribeTimeout    = 10 * time.Second
+	pubSubMinReconnectDelay   = 100 * time.Millisecond
+	pubSubMaxReconnectDelay   = 30 * time.Second
+)
+
+// Message is a message received through Subscribe or PSubscribe.
+type Message struct {
+	// Pattern is the pattern that matched the channel (empty for Subscribe)
+	Pattern string
+
+	// Channel is the channel the message was published to
+	Channel string
+
+	// Data is the message payload
+	Data []byte
+}
+
+// Publish posts a message to a channel.
+//
+// Parameters:
+//   - channel: Channel to publish to
+//   - message: Message payload (can be string, []byte, int, or any type convertible to string)
+//
+// Returns:
+//   - int: Number of subscribers that received the message
+//   - error: Error if client not initialized or PUBLISH command fails, nil on success
+//
+// Example:
+//
+//	receivers, err := client.Publish("events", "user:1:created")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) Publish(channel string, message any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("PUBLISH", channel, message))
+}
+
+// Subscribe subscribes to channels and delivers their messages on the returned channel.
+//
+// Parameters:
+//   - ctx: Context that ends the subscription; the returned channel is closed when it is done
+//   - channels: Channels to subscribe to
+//
+// Returns:
+//   - <-chan Message: Messages in the order they were received
+//   - error: Error if client not initialized or the initial subscription fails, nil on success
+//
+// The subscription is confirmed by the server before Subscribe returns, so messages published
+// afterwards are delivered. The subscription holds a dedicated connection outside the pool. If the
+// connection is lost, or the server stops answering the periodic PING, the client reconnects
+// with exponential backoff and subscribes again; messages published while disconnected are lost,
+// as with any Redis Pub/Sub subscriber. The reader must keep receiving from the channel, since a
+// slow reader holds up the connection.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//
+//	messages, err := client.Subscribe(ctx, "events")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for message := range messages {
+//	    fmt.Println(message.Channel, string(message.Data))
+//	}
+func (c *Client) Subscribe(ctx context.Context, channels ...string) (<-chan Message, error) {
+	return c.subscribe(ctx, false, channels)
+}
+
+// PSubscribe subscribes to channel patterns and delivers their messages on the returned channel.
+//
+// Parameters:
+//   - ctx: Context that ends the subscription; the returned channel is closed when it is done
+//   - patterns: Glob-style patterns (e.g. "events:*")
+//
+// Returns:
+//   - <-chan Message: Messages in the order they were received, with Pattern set
+//   - error: Error if client not initialized or the initial subscription fails, nil on success
+//
+// PSubscribe reconnects in the same way as Subscribe.
+//
+// Example:
+//
+//	messages, err := client.PSubscribe(ctx, "user:*")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for message := range messages {
+//	    fmt.Println(message.Pattern, message.Channel, string(message.Data))
+//	}
+func (c *Client) PSubscribe(ctx context.Context, patterns ...string) (<-chan Message, error) {
+	return c.subscribe(ctx, true, patterns)
+}
+
+func (c *Client) subscribe(ctx context.Context, pattern bool, names []string) (<-chan Message, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	} else if len(names) == 0 {
+		return nil, errors.New("at least one channel is required")
+	}
+
+	connection, pending, err := c.subscribeConnection(pattern, names)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(chan Message)
+
+	go func() {
+		defer close(messages)
+
+		for {
+			c.receive(ctx, connection, pending, messages)
+
+			delay := pubSubMinReconnectDelay
+			for connection.Conn = nil; connection.Conn == nil; {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+
+				if connection, pending, err = c.subscribeConnection(pattern, names); err != nil {
+					delay = min(delay*2, pubSubMaxReconnectDelay)
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+// subscribeConnection dials a dedicated connection and waits for the subscriptions to be confirmed.
+// It returns the messages of the channels confirmed first that arrived while waiting for the others.
+//
+// A pooled connection is not used since it cannot be closed while another goroutine is blocked in Receive.
+func (c *Client) subscribeConnection(pattern bool, names []string) (redigo_redis.PubSubConn, []Message, error) {
+	conn, err := c.pool.Dial()
+	if err != nil {
+		return redigo_redis.PubSubConn{}, nil, err
+	}
+	connection := redigo_redis.PubSubConn{Conn: conn}
+
+	args := make([]any, len(names))
+	for i, name := range names {
+		args[i] = name
+	}
+
+	subscribe := connection.Subscribe
+	if pattern {
+		subscribe = connection.PSubscribe
+	}
+
+	if err := subscribe(args...); err != nil {
+		connection.Close()
+		return redigo_redis.PubSubConn{}, nil, err
+	}
+
+	pending := []Message{}
+	for confirmed := 0; confirmed < len(names); {
+		switch reply := connection.ReceiveWithTimeout(pubSubSubscribeTimeout).(type) {
+		case redigo_redis.Subscription:
+			confirmed++
+		case redigo_redis.Message:
+			pending = append(pending, Message{Pattern: reply.Pattern, Channel: reply.Channel, Data: reply.Data})
+		case error:
+			connection.Close()
+			return redigo_redis.PubSubConn{}, nil, reply
+		}
+	}
+
+	return connection, pending, nil
+}
+
+// receive delivers the pending messages and then the received ones until the connection fails or
+// ctx is done, then closes the connection.
+func (c *Client) receive(ctx context.Context, connection redigo_redis.PubSubConn, pending []Message, messages chan<- Message) {
+	done := make(chan struct{})
+	defer close(done)
+
+	lastReceived := atomic.Int64{}
+	lastReceived.Store(time.Now().UnixNano())
+
+	go func() {
+		defer connection.Close()
+
+		ticker := time.NewTicker(pubSubHealthCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if time.Since(time.Unix(0, lastReceived.Load())) > 2*pubSubHealthCheckInterval {
+					return
+				} else if err := connection.Ping(""); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for _, message := range pending {
+		select {
+		case messages <- message:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	for {
+		switch reply := connection.Receive().(type) {
+		case redigo_redis.Message:
+			lastReceived.Store(time.Now().UnixNano())
+
+			select {
+			case messages <- Message{Pattern: reply.Pattern, Channel: reply.Channel, Data: reply.Data}:
+			case <-ctx.Done():
+				return
+			}
+		case redigo_redis.Subscription, redigo_redis.Pong:
+			lastReceived.Store(time.Now().UnixNano())
+		case error:
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/redis"
+	redigo_redis "github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receiveMessage(t *testing.T, messages <-chan redis.Message) redis.Message {
+	t.Helper()
+
+	select {
+	case message, ok := <-messages:
+		require.True(t, ok, "channel closed")
+		return message
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for message")
+		return redis.Message{}
+	}
+}
+
+func TestClient_Subscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, err := client.Subscribe(ctx, "test_channel_1", "test_channel_2")
+	require.NoError(t, err)
+
+	receivers, err := client.Publish("test_channel_1", "message1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, receivers)
+
+	_, err = client.Publish("test_channel_2", "message2")
+	assert.NoError(t, err)
+
+	assert.Equal(t, redis.Message{Channel: "test_channel_1", Data: []byte("message1")}, receiveMessage(t, messages))
+	assert.Equal(t, redis.Message{Channel: "test_channel_2", Data: []byte("message2")}, receiveMessage(t, messages))
+
+	cancel()
+
+	select {
+	case _, ok := <-messages:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "channel not closed after cancel")
+	}
+}
+
+func TestClient_PSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, err := client.PSubscribe(ctx, "test_pattern:*")
+	require.NoError(t, err)
+
+	_, err = client.Publish("test_pattern:1", "message")
+	assert.NoError(t, err)
+
+	assert.Equal(t, redis.Message{Pattern: "test_pattern:*", Channel: "test_pattern:1", Data: []byte("message")}, receiveMessage(t, messages))
+}
+
+func TestClient_SubscribeReconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, err := client.Subscribe(ctx, "test_reconnect")
+	require.NoError(t, err)
+
+	connection, err := redigo_redis.Dial("tcp", redisAddress)
+	require.NoError(t, err)
+	defer connection.Close()
+
+	_, err = connection.Do("CLIENT", "KILL", "TYPE", "pubsub")
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		receivers, err := client.Publish("test_reconnect", "after reconnect")
+		return err == nil && receivers == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	assert.Equal(t, "after reconnect", string(receiveMessage(t, messages).Data))
+}
+
+func TestClient_SubscribeErrors(t *testing.T) {
+	_, err := client.Subscribe(context.Background())
+	assert.Error(t, err)
+
+	uninitialized := redis.Client{}
+
+	_, err = uninitialized.Subscribe(context.Background(), "channel")
+	assert.EqualError(t, err, "please call Initialize first")
+
+	_, err = uninitialized.Publish("channel", "message")
+	assert.EqualError(t, err, "please call Initialize first")
+}
@@ -0,0 +1,145 @@
+package redis
+
+import (
+	"errors"
+	"time"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+var (
+	slidingWindowScript = NewScript(`
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+
+local count = redis.call("ZCARD", KEYS[1])
+if count < limit then
+	redis.call("ZADD", KEYS[1], now, ARGV[3])
+	redis.call("PEXPIRE", KEYS[1], window)
+	return {1, limit - count - 1, 0}
+end
+
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+return {0, 0, tonumber(oldest[2]) + window - now}`)
+
+	tokenBucketScript = NewScript(`
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local requested = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "timestamp")
+local tokens = tonumber(bucket[1]) or capacity
+local timestamp = tonumber(bucket[2]) or now
+
+tokens = math.min(capacity, tokens + math.max(0, now - timestamp) * rate / 1000)
+
+local allowed = 0
+local retry = 0
+if tokens >= requested then
+	tokens = tokens - requested
+	allowed = 1
+else
+	retry = math.ceil((requested - tokens) * 1000 / rate)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "timestamp", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(capacity * 1000 / rate) + 1000)
+
+return {allowed, math.floor(tokens), retry}`)
+)
+
+// RateLimit is the outcome of a rate limiter call.
+type RateLimit struct {
+	// Allowed reports whether the request is allowed
+	Allowed bool
+
+	// Remaining is the number of requests (or tokens) still available
+	Remaining int
+
+	// RetryAfter is the time until a request would be allowed (0 when allowed)
+	RetryAfter time.Duration
+}
+
+// SlidingWindowAllow counts a request against a sliding window rate limit.
+//
+// Parameters:
+//   - key: Key of the limited subject (e.g. "ratelimit:api:" + userID)
+//   - limit: Maximum number of requests within window
+//   - window: Length of the sliding window
+//
+// Returns:
+//   - RateLimit: Whether the request is allowed, the remaining requests and when to retry
+//   - error: Error if client not initialized or the script fails, nil on success
+//
+// Every allowed request is recorded with its time in a sorted set, so the limit holds over any
+// window-long interval, without the bursts at window boundaries of fixed windows. Memory grows
+// with limit. Rejected requests are not counted. The check runs as one Lua script on the server
+// clock, so it is atomic across replicas and unaffected by their clock skew.
+//
+// Example:
+//
+//	result, err := client.SlidingWindowAllow("ratelimit:login:"+ip, 5, time.Minute)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if !result.Allowed {
+//	    w.Header().Set("Retry-After", strconv.Itoa(int(result.RetryAfter.Seconds())+1))
+//	    w.WriteHeader(http.StatusTooManyRequests)
+//	}
+func (c *Client) SlidingWindowAllow(key string, limit int, window time.Duration) (RateLimit, error) {
+	if c.pool == nil {
+		return RateLimit{}, errors.New("please call Initialize first")
+	} else if limit <= 0 || window < time.Millisecond {
+		return RateLimit{}, errors.New("limit must be positive and window at least 1ms")
+	}
+
+	return rateLimit(c.RunScript(slidingWindowScript, []any{key}, window.Milliseconds(), limit, randomToken()))
+}
+
+// TokenBucketAllow takes tokens from a token bucket rate limit.
+//
+// Parameters:
+//   - key: Key of the limited subject (e.g. "ratelimit:api:" + userID)
+//   - capacity: Maximum number of tokens, which is the largest allowed burst
+//   - refillPerSecond: Tokens added per second
+//   - tokens: Tokens taken by this request (usually 1)
+//
+// Returns:
+//   - RateLimit: Whether the tokens were taken, the tokens left and when enough tokens will be available
+//   - error: Error if client not initialized or the script fails, nil on success
+//
+// A bucket starts full and refills continuously, allowing bursts up to capacity and a sustained
+// rate of refillPerSecond. When the bucket holds fewer tokens than requested none are taken.
+// The bucket is a small hash that expires once it would be full again. The check runs as one
+// Lua script on the server clock.
+//
+// Example:
+//
+//	// Bursts of 20 requests, 5 requests per second sustained
+//	result, err := client.TokenBucketAllow("ratelimit:api:"+userID, 20, 5, 1)
+func (c *Client) TokenBucketAllow(key string, capacity int, refillPerSecond float64, tokens int) (RateLimit, error) {
+	if c.pool == nil {
+		return RateLimit{}, errors.New("please call Initialize first")
+	} else if capacity <= 0 || refillPerSecond <= 0 || tokens <= 0 {
+		return RateLimit{}, errors.New("capacity, refillPerSecond and tokens must be positive")
+	}
+
+	return rateLimit(c.RunScript(tokenBucketScript, []any{key}, capacity, refillPerSecond, tokens))
+}
+
+func rateLimit(reply any, err error) (RateLimit, error) {
+	values, err := redigo_redis.Int64s(reply, err)
+	if err != nil {
+		return RateLimit{}, err
+	} else if len(values) != 3 {
+		return RateLimit{}, errors.New("unexpected rate limit reply")
+	}
+
+	return RateLimit{Allowed: values[0] == 1, Remaining: int(values[1]), RetryAfter: time.Duration(values[2]) * time.Millisecond}, nil
+}
@@ -0,0 +1,73 @@
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SlidingWindowAllow(t *testing.T) {
+	setupTest(t)
+
+	for i := range 3 {
+		result, err := client.SlidingWindowAllow("ratelimit:user", 3, 300*time.Millisecond)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2-i, result.Remaining)
+		assert.Zero(t, result.RetryAfter)
+	}
+
+	result, err := client.SlidingWindowAllow("ratelimit:user", 3, 300*time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Greater(t, result.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, result.RetryAfter, 300*time.Millisecond)
+
+	time.Sleep(result.RetryAfter + 50*time.Millisecond)
+
+	result, err = client.SlidingWindowAllow("ratelimit:user", 3, 300*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestClient_TokenBucketAllow(t *testing.T) {
+	setupTest(t)
+
+	result, err := client.TokenBucketAllow("ratelimit:api", 5, 10, 5)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, err = client.TokenBucketAllow("ratelimit:api", 5, 10, 2)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Greater(t, result.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, result.RetryAfter, 200*time.Millisecond)
+
+	time.Sleep(result.RetryAfter + 50*time.Millisecond)
+
+	result, err = client.TokenBucketAllow("ratelimit:api", 5, 10, 2)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestClient_RateLimitErrors(t *testing.T) {
+	setupTest(t)
+
+	_, err := client.SlidingWindowAllow("ratelimit:user", 0, time.Second)
+	assert.Error(t, err)
+
+	_, err = client.TokenBucketAllow("ratelimit:api", 5, 0, 1)
+	assert.Error(t, err)
+
+	uninitialized := redis.Client{}
+	_, err = uninitialized.SlidingWindowAllow("ratelimit:user", 3, time.Second)
+	assert.EqualError(t, err, "please call Initialize first")
+
+	_, err = uninitialized.TokenBucketAllow("ratelimit:api", 5, 10, 1)
+	assert.EqualError(t, err, "please call Initialize first")
+}
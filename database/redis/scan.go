@@ -0,0 +1,238 @@
+package redis
+
+import (
+	"errors"
+	"iter"
+	"strconv"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+// HashField is a field of a hash with its value.
+type HashField struct {
+	// Field is the field name
+	Field string
+
+	// Value is the field value
+	Value string
+}
+
+// Scan iterates over the keys of the currently selected database with SCAN.
+//
+// Parameters:
+//   - match: Glob-style pattern the keys must match (empty for all keys)
+//   - count: Number of keys the server examines per call (0 uses the server default)
+//
+// Returns:
+//   - iter.Seq2[string, error]: Keys; iteration stops after yielding an error
+//
+// SCAN does not block the server like KEYS. A key may be returned more than once, and keys
+// added or removed during the iteration may or may not be returned.
+//
+// Example:
+//
+//	for key, err := range client.Scan("session:*", 100) {
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    fmt.Println(key)
+//	}
+func (c *Client) Scan(match string, count int) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for values, err := range c.scan("SCAN", nil, match, count) {
+			if err != nil {
+				yield("", err)
+				return
+			}
+
+			for _, value := range values {
+				if !yield(value, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SScan iterates over the members of a set with SSCAN.
+//
+// Parameters:
+//   - key: Set key
+//   - match: Glob-style pattern the members must match (empty for all members)
+//   - count: Number of members the server examines per call (0 uses the server default)
+//
+// Returns:
+//   - iter.Seq2[string, error]: Members; iteration stops after yielding an error
+//
+// Example:
+//
+//	for member, err := range client.SScan("tags", "", 0) {
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    fmt.Println(member)
+//	}
+func (c *Client) SScan(key any, match string, count int) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for values, err := range c.scan("SSCAN", key, match, count) {
+			if err != nil {
+				yield("", err)
+				return
+			}
+
+			for _, value := range values {
+				if !yield(value, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// HScan iterates over the fields of a hash with HSCAN.
+//
+// Parameters:
+//   - key: Hash key
+//   - match: Glob-style pattern the field names must match (empty for all fields)
+//   - count: Number of fields the server examines per call (0 uses the server default)
+//
+// Returns:
+//   - iter.Seq2[HashField, error]: Fields with their values; iteration stops after yielding an error
+//
+// Example:
+//
+//	for field, err := range client.HScan("user:1", "", 0) {
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    fmt.Println(field.Field, field.Value)
+//	}
+func (c *Client) HScan(key any, match string, count int) iter.Seq2[HashField, error] {
+	return func(yield func(HashField, error) bool) {
+		for values, err := range c.scan("HSCAN", key, match, count) {
+			if err != nil {
+				yield(HashField{}, err)
+				return
+			}
+
+			for i := 0; i+1 < len(values); i += 2 {
+				if !yield(HashField{Field: values[i], Value: values[i+1]}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ZScan iterates over the members of a sorted set with ZSCAN.
+//
+// Parameters:
+//   - key: Sorted set key
+//   - match: Glob-style pattern the members must match (empty for all members)
+//   - count: Number of members the server examines per call (0 uses the server default)
+//
+// Returns:
+//   - iter.Seq2[ScoredMember, error]: Members with their scores, not in score order; iteration stops after yielding an error
+//
+// Example:
+//
+//	for member, err := range client.ZScan("leaderboard", "", 0) {
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    fmt.Println(member.Member, member.Score)
+//	}
+func (c *Client) ZScan(key any, match string, count int) iter.Seq2[ScoredMember, error] {
+	return func(yield func(ScoredMember, error) bool) {
+		for values, err := range c.scan("ZSCAN", key, match, count) {
+			if err != nil {
+				yield(ScoredMember{}, err)
+				return
+			}
+
+			for i := 0; i+1 < len(values); i += 2 {
+				score, err := strconv.ParseFloat(values[i+1], 64)
+				if err != nil {
+					yield(ScoredMember{}, err)
+					return
+				}
+
+				if !yield(ScoredMember{Member: values[i], Score: score}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// scan yields the values of each page of a SCAN-family command until the cursor returns to 0.
+// In cluster mode, SCAN runs on every master in turn.
+func (c *Client) scan(command string, key any, match string, count int) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		if c.pool == nil {
+			yield(nil, errors.New("please call Initialize first"))
+			return
+		}
+
+		if c.cluster == nil || key != nil {
+			scanPages(c.do, command, key, match, count, yield)
+			return
+		}
+
+		for _, address := range c.cluster.getMasters() {
+			do := func(command string, args ...any) (any, error) {
+				return c.cluster.withAddress(address, false, func(connection redigo_redis.Conn) (any, error) {
+					return connection.Do(command, args...)
+				})
+			}
+
+			if !scanPages(do, command, key, match, count, yield) {
+				return
+			}
+		}
+	}
+}
+
+// scanPages yields the pages of a SCAN-family command run with do, and reports whether the iteration may continue.
+func scanPages(do func(command string, args ...any) (any, error), command string, key any, match string, count int, yield func([]string, error) bool) bool {
+	cursor := "0"
+	for {
+		args := redigo_redis.Args{}
+		if key != nil {
+			args = args.Add(key)
+		}
+		args = args.Add(cursor)
+		if len(match) != 0 {
+			args = args.Add("MATCH", match)
+		}
+		if count > 0 {
+			args = args.Add("COUNT", count)
+		}
+
+		reply, err := redigo_redis.Values(do(command, args...))
+		if err != nil {
+			yield(nil, err)
+			return false
+		} else if len(reply) != 2 {
+			yield(nil, errors.New("unexpected "+command+" reply"))
+			return false
+		}
+
+		if cursor, err = redigo_redis.String(reply[0], nil); err != nil {
+			yield(nil, err)
+			return false
+		}
+
+		values, err := redigo_redis.Strings(reply[1], nil)
+		if err != nil {
+			yield(nil, err)
+			return false
+		}
+
+		if !yield(values, nil) {
+			return false
+		} else if cursor == "0" {
+			return true
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package redis_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/common-library/go/database/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Scan(t *testing.T) {
+	setupTest(t)
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, client.Set("scan:"+strconv.Itoa(i), i))
+	}
+	require.NoError(t, client.Set("other", "value"))
+
+	keys := map[string]bool{}
+	for key, err := range client.Scan("scan:*", 10) {
+		require.NoError(t, err)
+		keys[key] = true
+	}
+	assert.Len(t, keys, 50)
+	assert.False(t, keys["other"])
+
+	count := 0
+	for range client.Scan("", 0) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, count)
+}
+
+func TestClient_SScanHScanZScan(t *testing.T) {
+	setupTest(t)
+
+	_, err := client.SAdd("set", "a", "b", "c")
+	require.NoError(t, err)
+	_, err = client.HSet("hash", "field1", "value1", "field2", "value2")
+	require.NoError(t, err)
+	_, err = client.ZAdd("zset", redis.ScoredMember{Member: "a", Score: 1}, redis.ScoredMember{Member: "b", Score: 2})
+	require.NoError(t, err)
+
+	members := []string{}
+	for member, err := range client.SScan("set", "", 0) {
+		require.NoError(t, err)
+		members = append(members, member)
+	}
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, members)
+
+	fields := []redis.HashField{}
+	for field, err := range client.HScan("hash", "field*", 0) {
+		require.NoError(t, err)
+		fields = append(fields, field)
+	}
+	assert.ElementsMatch(t, []redis.HashField{{Field: "field1", Value: "value1"}, {Field: "field2", Value: "value2"}}, fields)
+
+	scored := []redis.ScoredMember{}
+	for member, err := range client.ZScan("zset", "", 0) {
+		require.NoError(t, err)
+		scored = append(scored, member)
+	}
+	assert.ElementsMatch(t, []redis.ScoredMember{{Member: "a", Score: 1}, {Member: "b", Score: 2}}, scored)
+
+	uninitialized := redis.Client{}
+	for _, err := range uninitialized.Scan("", 0) {
+		assert.EqualError(t, err, "please call Initialize first")
+	}
+}
@@ -0,0 +1,170 @@
+package redis
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+// Script is a Lua script identified by the SHA1 digest of its source.
+type Script struct {
+	source string
+	hash   string
+}
+
+// NewScript creates a Script from Lua source.
+//
+// Parameters:
+//   - source: Lua source of the script; keys are read from KEYS and arguments from ARGV
+//
+// Returns:
+//   - *Script: Script to pass to LoadScript and RunScript
+//
+// Example:
+//
+//	var incrementBy = redis.NewScript(`
+//	    local value = redis.call("INCRBY", KEYS[1], ARGV[1])
+//	    redis.call("EXPIRE", KEYS[1], ARGV[2])
+//	    return value
+//	`)
+func NewScript(source string) *Script {
+	hash := sha1.Sum([]byte(source))
+
+	return &Script{source: source, hash: hex.EncodeToString(hash[:])}
+}
+
+// GetHash returns the SHA1 digest used by EVALSHA.
+//
+// Returns:
+//   - string: Lowercase hex SHA1 digest of the source
+func (s *Script) GetHash() string {
+	return s.hash
+}
+
+// LoadScript loads a script into the script cache of the server without running it.
+//
+// Parameters:
+//   - script: Script to load
+//
+// Returns:
+//   - error: Error if client not initialized or SCRIPT LOAD fails (e.g. syntax error), nil on success
+//
+// Loading is optional since RunScript loads the script on first use; calling it at startup
+// reports syntax errors early.
+//
+// Example:
+//
+//	if err := client.LoadScript(incrementBy); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) LoadScript(script *Script) error {
+	if c.pool == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	_, err := c.do("SCRIPT", "LOAD", script.source)
+
+	return err
+}
+
+// RunScript runs a script with EVALSHA, loading it with SCRIPT LOAD when the server replies NOSCRIPT.
+//
+// Parameters:
+//   - script: Script to run
+//   - keys: Keys passed to the script as KEYS
+//   - args: Arguments passed to the script as ARGV
+//
+// Returns:
+//   - any: Raw reply of the script; convert it with the redigo reply helpers (e.g. Int, Strings)
+//   - error: Error if client not initialized or the script fails, nil on success
+//
+// Only the SHA1 digest is sent while the script is cached on the server. After a restart, a
+// failover or SCRIPT FLUSH the server replies NOSCRIPT; the script is then loaded and run again.
+//
+// Example:
+//
+//	reply, err := client.RunScript(incrementBy, []any{"counter"}, 5, 60)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	value, _ := redigo_redis.Int(reply, nil)
+func (c *Client) RunScript(script *Script, keys []any, args ...any) (any, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	evalArgs := make([]any, 0, 2+len(keys)+len(args))
+	evalArgs = append(evalArgs, script.hash, len(keys))
+	evalArgs = append(evalArgs, keys...)
+	evalArgs = append(evalArgs, args...)
+
+	return c.withConnection("EVALSHA", evalArgs, func(connection redigo_redis.Conn) (any, error) {
+		reply, err := connection.Do("EVALSHA", evalArgs...)
+		if redisErr, ok := err.(redigo_redis.Error); !ok || !strings.HasPrefix(string(redisErr), "NOSCRIPT") {
+			return reply, err
+		}
+
+		if _, err := connection.Do("SCRIPT", "LOAD", script.source); err != nil {
+			return nil, err
+		}
+
+		return connection.Do("EVALSHA", evalArgs...)
+	})
+}
+
+// ScriptExists reports whether scripts are present in the script cache of the server.
+//
+// Parameters:
+//   - scripts: Scripts to check
+//
+// Returns:
+//   - []bool: Whether each script is cached, in the order given
+//   - error: Error if client not initialized or SCRIPT EXISTS fails, nil on success
+//
+// Example:
+//
+//	exists, err := client.ScriptExists(incrementBy)
+func (c *Client) ScriptExists(scripts ...*Script) ([]bool, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	args := make([]any, 0, 1+len(scripts))
+	args = append(args, "EXISTS")
+	for _, script := range scripts {
+		args = append(args, script.hash)
+	}
+
+	values, err := redigo_redis.Ints(c.do("SCRIPT", args...))
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make([]bool, len(values))
+	for i, value := range values {
+		exists[i] = value == 1
+	}
+
+	return exists, nil
+}
+
+// FlushScripts removes all scripts from the script cache of the server.
+//
+// Returns:
+//   - error: Error if client not initialized or SCRIPT FLUSH fails, nil on success
+//
+// Example:
+//
+//	err := client.FlushScripts()
+func (c *Client) FlushScripts() error {
+	if c.pool == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	_, err := c.do("SCRIPT", "FLUSH")
+
+	return err
+}
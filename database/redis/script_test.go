@@ -0,0 +1,61 @@
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/common-library/go/database/redis"
+	redigo_redis "github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var incrementBy = redis.NewScript(`return redis.call("INCRBY", KEYS[1], ARGV[1])`)
+
+func TestNewScript(t *testing.T) {
+	assert.Equal(t, "e0e1f9fabfc9d4800c877a703b823ac0578ff8db", redis.NewScript("return 1").GetHash())
+}
+
+func TestClient_RunScript(t *testing.T) {
+	setupTest(t)
+	require.NoError(t, client.FlushScripts())
+
+	exists, err := client.ScriptExists(incrementBy)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false}, exists)
+
+	value, err := redigo_redis.Int(client.RunScript(incrementBy, []any{"counter"}, 5))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, value)
+
+	exists, err = client.ScriptExists(incrementBy)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true}, exists)
+
+	value, err = redigo_redis.Int(client.RunScript(incrementBy, []any{"counter"}, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, 8, value)
+}
+
+func TestClient_RunScriptAfterFlush(t *testing.T) {
+	setupTest(t)
+
+	require.NoError(t, client.LoadScript(incrementBy))
+	require.NoError(t, client.FlushScripts())
+
+	value, err := redigo_redis.Int(client.RunScript(incrementBy, []any{"counter"}, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, value)
+}
+
+func TestClient_RunScriptErrors(t *testing.T) {
+	setupTest(t)
+
+	assert.Error(t, client.LoadScript(redis.NewScript("return (")))
+
+	_, err := client.RunScript(redis.NewScript(`return redis.error_reply("failed")`), nil)
+	assert.ErrorContains(t, err, "failed")
+
+	uninitialized := redis.Client{}
+	_, err = uninitialized.RunScript(incrementBy, []any{"counter"}, 1)
+	assert.EqualError(t, err, "please call Initialize first")
+}
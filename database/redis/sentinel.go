@@ -0,0 +1,113 @@
+package redis
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+// InitializeSentinel initializes the Redis client for a master monitored by Redis Sentinel.
+//
+// Parameters:
+//   - masterName: Name of the master set in the Sentinel configuration (e.g., "mymaster")
+//   - sentinelAddresses: Sentinel addresses in the format "host:port", tried in order
+//   - sentinelUsername: ACL username for the Sentinels. Use empty string "" for the default user
+//   - sentinelPassword: Password for the Sentinels. Use empty string "" for no authentication
+//   - username: ACL username for the master. Use empty string "" for the default user
+//   - password: Password for the master. Use empty string "" for no authentication
+//   - tlsConfig: TLS configuration for the Sentinels and the master, or nil for plain TCP
+//   - maxConnection: Maximum number of idle connections in the pool
+//   - timeout: Idle connection timeout duration. Connections idle longer than this will be closed
+//
+// Returns:
+//   - error: Error if no Sentinel knows the master or the connection test fails, nil on success
+//
+// Every new connection asks the Sentinels for the current master address and checks with ROLE
+// that the server is a master. Pooled connections are checked with ROLE when borrowed, so after a
+// failover connections to the demoted or unreachable master are discarded and new ones are made
+// to the promoted replica. Commands running during the failover fail and can be retried.
+// Subscriptions reconnect to the new master the same way.
+//
+// Example:
+//
+//	var client redis.Client
+//	err := client.InitializeSentinel("mymaster",
+//	    []string{"sentinel-1:26379", "sentinel-2:26379", "sentinel-3:26379"},
+//	    "", "", "app", "secret", nil, 10, 60)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer client.Finalize()
+func (c *Client) InitializeSentinel(masterName string, sentinelAddresses []string, sentinelUsername, sentinelPassword, username, password string, tlsConfig *tls.Config, maxConnection int, timeout time.Duration) error {
+	if len(sentinelAddresses) == 0 {
+		return errors.New("at least one sentinel address is required")
+	}
+
+	discover := func() (string, error) {
+		errs := []error{}
+		for _, address := range sentinelAddresses {
+			connection, err := dial(address, sentinelUsername, sentinelPassword, tlsConfig)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			reply, err := redigo_redis.Strings(connection.Do("SENTINEL", "get-master-addr-by-name", masterName))
+			connection.Close()
+			if errors.Is(err, redigo_redis.ErrNil) {
+				errs = append(errs, fmt.Errorf("%s: unknown master %s", address, masterName))
+			} else if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", address, err))
+			} else if len(reply) != 2 {
+				errs = append(errs, fmt.Errorf("%s: unexpected SENTINEL reply", address))
+			} else {
+				return net.JoinHostPort(reply[0], reply[1]), nil
+			}
+		}
+
+		return "", errors.Join(errs...)
+	}
+
+	c.pool = newPool(func() (redigo_redis.Conn, error) {
+		address, err := discover()
+		if err != nil {
+			return nil, err
+		}
+
+		connection, err := dial(address, username, password, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkMaster(connection); err != nil {
+			connection.Close()
+			return nil, fmt.Errorf("%s: %w", address, err)
+		}
+
+		return connection, nil
+	}, checkMaster, maxConnection, timeout)
+
+	return c.Ping()
+}
+
+// checkMaster returns an error unless the server of connection is a master.
+func checkMaster(connection redigo_redis.Conn) error {
+	reply, err := redigo_redis.Values(connection.Do("ROLE"))
+	if err != nil {
+		return err
+	} else if len(reply) == 0 {
+		return errors.New("unexpected ROLE reply")
+	}
+
+	if role, err := redigo_redis.String(reply[0], nil); err != nil {
+		return err
+	} else if role != "master" {
+		return fmt.Errorf("server is not a master (role %s)", role)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/redis"
+	"github.com/common-library/go/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	redismodule "github.com/testcontainers/testcontainers-go/modules/redis"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestClient_InitializeSentinel(t *testing.T) {
+	ctx := context.Background()
+
+	master, err := redismodule.Run(ctx, testutil.RedisImage)
+	require.NoError(t, err)
+	defer master.Terminate(ctx)
+
+	masterIP, err := master.ContainerIP(ctx)
+	require.NoError(t, err)
+
+	replica, err := redismodule.Run(ctx, testutil.RedisImage, testcontainers.WithCmdArgs("--replicaof", masterIP, "6379"))
+	require.NoError(t, err)
+	defer replica.Terminate(ctx)
+
+	configuration := fmt.Sprintf("sentinel monitor mymaster %s 6379 1\n"+
+		"sentinel down-after-milliseconds mymaster 1000\n"+
+		"sentinel failover-timeout mymaster 5000\n", masterIP)
+
+	sentinel, err := testcontainers.Run(ctx, testutil.RedisImage,
+		testcontainers.WithCmd("sh", "-c", fmt.Sprintf("printf '%s' > /tmp/sentinel.conf && redis-sentinel /tmp/sentinel.conf", configuration)),
+		testcontainers.WithExposedPorts("26379/tcp"),
+		testcontainers.WithWaitStrategy(wait.ForLog("+monitor master")),
+	)
+	require.NoError(t, err)
+	defer sentinel.Terminate(ctx)
+
+	sentinelAddress, err := sentinel.PortEndpoint(ctx, "26379/tcp", "")
+	require.NoError(t, err)
+
+	sentinelClient := redis.Client{}
+	assert.Error(t, sentinelClient.InitializeSentinel("unknown", []string{sentinelAddress}, "", "", "", "", nil, 10, 60))
+
+	sentinelClient = redis.Client{}
+	require.NoError(t, sentinelClient.InitializeSentinel("mymaster", []string{"127.0.0.1:1", sentinelAddress}, "", "", "", "", nil, 10, 60))
+	defer sentinelClient.Finalize()
+
+	require.NoError(t, sentinelClient.Set("sentinel_key", "before"))
+
+	before, err := sentinelClient.Info("server")
+	require.NoError(t, err)
+
+	timeout := time.Second
+	require.NoError(t, master.Stop(ctx, &timeout))
+
+	assert.Eventually(t, func() bool {
+		return sentinelClient.Set("sentinel_key", "after") == nil
+	}, 60*time.Second, 500*time.Millisecond)
+
+	value, err := sentinelClient.Get("sentinel_key")
+	assert.NoError(t, err)
+	assert.Equal(t, "after", value)
+
+	info, err := sentinelClient.Info("replication")
+	require.NoError(t, err)
+	assert.Contains(t, info, "role:master")
+
+	after, err := sentinelClient.Info("server")
+	require.NoError(t, err)
+	assert.NotEqual(t, runID(before), runID(after))
+}
+
+// runID returns the run_id field of INFO server.
+func runID(info string) string {
+	for line := range strings.Lines(info) {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "run_id:"); ok {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,170 @@
+package redis
+
+import (
+	"errors"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+// SAdd adds members to a set.
+//
+// Parameters:
+//   - key: Set key; the set is created if it does not exist
+//   - members: Members to add
+//
+// Returns:
+//   - int: Number of members that were added (members already in the set are not counted)
+//   - error: Error if client not initialized or SADD command fails, nil on success
+//
+// Example:
+//
+//	added, err := client.SAdd("tags", "go", "redis")
+func (c *Client) SAdd(key any, members ...any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("SADD", redigo_redis.Args{key}.Add(members...)...))
+}
+
+// SRem removes members from a set.
+//
+// Parameters:
+//   - key: Set key
+//   - members: Members to remove
+//
+// Returns:
+//   - int: Number of members that were removed
+//   - error: Error if client not initialized or SREM command fails, nil on success
+//
+// Example:
+//
+//	removed, err := client.SRem("tags", "redis")
+func (c *Client) SRem(key any, members ...any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("SREM", redigo_redis.Args{key}.Add(members...)...))
+}
+
+// SMembers returns all members of a set.
+//
+// Parameters:
+//   - key: Set key
+//
+// Returns:
+//   - []string: Members in no particular order, empty if the key does not exist
+//   - error: Error if client not initialized or SMEMBERS command fails, nil on success
+//
+// Example:
+//
+//	tags, err := client.SMembers("tags")
+func (c *Client) SMembers(key any) ([]string, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Strings(c.do("SMEMBERS", key))
+}
+
+// SIsMember checks if a value is a member of a set.
+//
+// Parameters:
+//   - key: Set key
+//   - member: Value to check
+//
+// Returns:
+//   - bool: true if member is in the set
+//   - error: Error if client not initialized or SISMEMBER command fails, nil on success
+//
+// Example:
+//
+//	ok, err := client.SIsMember("tags", "go")
+func (c *Client) SIsMember(key, member any) (bool, error) {
+	if c.pool == nil {
+		return false, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Bool(c.do("SISMEMBER", key, member))
+}
+
+// SCard returns the number of members of a set.
+//
+// Parameters:
+//   - key: Set key
+//
+// Returns:
+//   - int: Number of members, 0 if the key does not exist
+//   - error: Error if client not initialized or SCARD command fails, nil on success
+//
+// Example:
+//
+//	count, err := client.SCard("tags")
+func (c *Client) SCard(key any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("SCARD", key))
+}
+
+// SInter returns the members present in all of the given sets.
+//
+// Parameters:
+//   - keys: Set keys
+//
+// Returns:
+//   - []string: Intersection of the sets
+//   - error: Error if client not initialized or SINTER command fails, nil on success
+//
+// Example:
+//
+//	common, err := client.SInter("tags:post:1", "tags:post:2")
+func (c *Client) SInter(keys ...any) ([]string, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Strings(c.do("SINTER", keys...))
+}
+
+// SUnion returns the members present in any of the given sets.
+//
+// Parameters:
+//   - keys: Set keys
+//
+// Returns:
+//   - []string: Union of the sets
+//   - error: Error if client not initialized or SUNION command fails, nil on success
+//
+// Example:
+//
+//	all, err := client.SUnion("tags:post:1", "tags:post:2")
+func (c *Client) SUnion(keys ...any) ([]string, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Strings(c.do("SUNION", keys...))
+}
+
+// SDiff returns the members of the first set that are not in any of the following sets.
+//
+// Parameters:
+//   - keys: Set keys; the first set is compared to the rest
+//
+// Returns:
+//   - []string: Difference of the sets
+//   - error: Error if client not initialized or SDIFF command fails, nil on success
+//
+// Example:
+//
+//	onlyFirst, err := client.SDiff("tags:post:1", "tags:post:2")
+func (c *Client) SDiff(keys ...any) ([]string, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Strings(c.do("SDIFF", keys...))
+}
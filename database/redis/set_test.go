@@ -0,0 +1,50 @@
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Set(t *testing.T) {
+	setupTest(t)
+
+	added, err := client.SAdd("set1", "a", "b", "c", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, added)
+
+	_, err = client.SAdd("set2", "b", "c", "d")
+	assert.NoError(t, err)
+
+	members, err := client.SMembers("set1")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, members)
+
+	isMember, err := client.SIsMember("set1", "a")
+	assert.NoError(t, err)
+	assert.True(t, isMember)
+
+	count, err := client.SCard("set1")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	inter, err := client.SInter("set1", "set2")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"b", "c"}, inter)
+
+	union, err := client.SUnion("set1", "set2")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "c", "d"}, union)
+
+	diff, err := client.SDiff("set1", "set2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, diff)
+
+	removed, err := client.SRem("set1", "a", "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	isMember, err = client.SIsMember("set1", "a")
+	assert.NoError(t, err)
+	assert.False(t, isMember)
+}
@@ -0,0 +1,269 @@
+package redis
+
+import (
+	"errors"
+	"strconv"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+// ScoredMember is a member of a sorted set with its score.
+type ScoredMember struct {
+	// Member is the member value
+	Member string
+
+	// Score is the score the set is ordered by
+	Score float64
+}
+
+// ZAdd adds members to a sorted set, or updates the scores of existing members.
+//
+// Parameters:
+//   - key: Sorted set key; the set is created if it does not exist
+//   - members: Members with their scores
+//
+// Returns:
+//   - int: Number of members that were added (members whose score was updated are not counted)
+//   - error: Error if client not initialized or ZADD command fails, nil on success
+//
+// Example:
+//
+//	added, err := client.ZAdd("leaderboard",
+//	    redis.ScoredMember{Member: "alice", Score: 100},
+//	    redis.ScoredMember{Member: "bob", Score: 85},
+//	)
+func (c *Client) ZAdd(key any, members ...ScoredMember) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	args := redigo_redis.Args{key}
+	for _, member := range members {
+		args = args.Add(member.Score, member.Member)
+	}
+
+	return redigo_redis.Int(c.do("ZADD", args...))
+}
+
+// ZIncrBy increments the score of a member of a sorted set.
+//
+// Parameters:
+//   - key: Sorted set key
+//   - increment: Amount to add to the score (negative to decrement)
+//   - member: Member to update; a missing member is added with score increment
+//
+// Returns:
+//   - float64: Score after the increment
+//   - error: Error if client not initialized or ZINCRBY command fails, nil on success
+//
+// Example:
+//
+//	score, err := client.ZIncrBy("leaderboard", 10, "bob")
+func (c *Client) ZIncrBy(key any, increment float64, member any) (float64, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Float64(c.do("ZINCRBY", key, increment, member))
+}
+
+// ZScore returns the score of a member of a sorted set.
+//
+// Parameters:
+//   - key: Sorted set key
+//   - member: Member
+//
+// Returns:
+//   - float64: Score of the member
+//   - error: ErrNil if the key or member does not exist, other errors if the ZSCORE command fails
+//
+// Example:
+//
+//	score, err := client.ZScore("leaderboard", "alice")
+func (c *Client) ZScore(key, member any) (float64, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Float64(c.do("ZSCORE", key, member))
+}
+
+// ZRank returns the rank of a member of a sorted set, ordered from the lowest score.
+//
+// Parameters:
+//   - key: Sorted set key
+//   - member: Member
+//
+// Returns:
+//   - int: Zero-based rank of the member
+//   - error: ErrNil if the key or member does not exist, other errors if the ZRANK command fails
+//
+// Example:
+//
+//	rank, err := client.ZRank("leaderboard", "alice")
+func (c *Client) ZRank(key, member any) (int, error) {
+	if c.pool == nil {
+		return -1, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("ZRANK", key, member))
+}
+
+// ZRem removes members from a sorted set.
+//
+// Parameters:
+//   - key: Sorted set key
+//   - members: Members to remove
+//
+// Returns:
+//   - int: Number of members that were removed
+//   - error: Error if client not initialized or ZREM command fails, nil on success
+//
+// Example:
+//
+//	removed, err := client.ZRem("leaderboard", "bob")
+func (c *Client) ZRem(key any, members ...any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("ZREM", redigo_redis.Args{key}.Add(members...)...))
+}
+
+// ZCard returns the number of members of a sorted set.
+//
+// Parameters:
+//   - key: Sorted set key
+//
+// Returns:
+//   - int: Number of members, 0 if the key does not exist
+//   - error: Error if client not initialized or ZCARD command fails, nil on success
+//
+// Example:
+//
+//	count, err := client.ZCard("leaderboard")
+func (c *Client) ZCard(key any) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("ZCARD", key))
+}
+
+// ZRangeWithScores returns a range of members of a sorted set by rank, with their scores.
+//
+// Parameters:
+//   - key: Sorted set key
+//   - start: Rank of the first member (0 is the lowest score, negative counts from the highest)
+//   - stop: Rank of the last member, inclusive (-1 is the highest score)
+//
+// Returns:
+//   - []ScoredMember: Members ordered from the lowest score
+//   - error: Error if client not initialized or ZRANGE command fails, nil on success
+//
+// Example:
+//
+//	bottom3, err := client.ZRangeWithScores("leaderboard", 0, 2)
+func (c *Client) ZRangeWithScores(key any, start, stop int) ([]ScoredMember, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	return scoredMembers(c.do("ZRANGE", key, start, stop, "WITHSCORES"))
+}
+
+// ZRevRangeWithScores returns a range of members of a sorted set by rank from the highest score, with their scores.
+//
+// Parameters:
+//   - key: Sorted set key
+//   - start: Rank of the first member (0 is the highest score)
+//   - stop: Rank of the last member, inclusive (-1 is the lowest score)
+//
+// Returns:
+//   - []ScoredMember: Members ordered from the highest score
+//   - error: Error if client not initialized or ZREVRANGE command fails, nil on success
+//
+// Example:
+//
+//	top10, err := client.ZRevRangeWithScores("leaderboard", 0, 9)
+func (c *Client) ZRevRangeWithScores(key any, start, stop int) ([]ScoredMember, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	return scoredMembers(c.do("ZREVRANGE", key, start, stop, "WITHSCORES"))
+}
+
+// ZRangeByScore returns the members of a sorted set with a score between min and max, with their scores.
+//
+// Parameters:
+//   - key: Sorted set key
+//   - min: Minimum score, inclusive; prefix with "(" for exclusive, or use "-inf"
+//   - max: Maximum score, inclusive; prefix with "(" for exclusive, or use "+inf"
+//   - offset: Number of matching members to skip
+//   - count: Maximum number of members to return (negative for all)
+//
+// Returns:
+//   - []ScoredMember: Members ordered from the lowest score
+//   - error: Error if client not initialized or ZRANGEBYSCORE command fails, nil on success
+//
+// Example:
+//
+//	// Jobs scheduled up to now
+//	due, err := client.ZRangeByScore("schedule", "-inf", strconv.FormatInt(time.Now().Unix(), 10), 0, 100)
+func (c *Client) ZRangeByScore(key any, min, max string, offset, count int) ([]ScoredMember, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	args := redigo_redis.Args{key, min, max, "WITHSCORES"}
+	if offset != 0 || count >= 0 {
+		args = args.Add("LIMIT", offset, count)
+	}
+
+	return scoredMembers(c.do("ZRANGEBYSCORE", args...))
+}
+
+// ZRemRangeByScore removes the members of a sorted set with a score between min and max.
+//
+// Parameters:
+//   - key: Sorted set key
+//   - min: Minimum score, inclusive; prefix with "(" for exclusive, or use "-inf"
+//   - max: Maximum score, inclusive; prefix with "(" for exclusive, or use "+inf"
+//
+// Returns:
+//   - int: Number of members that were removed
+//   - error: Error if client not initialized or ZREMRANGEBYSCORE command fails, nil on success
+//
+// Example:
+//
+//	removed, err := client.ZRemRangeByScore("events", "-inf", "1700000000")
+func (c *Client) ZRemRangeByScore(key any, min, max string) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("ZREMRANGEBYSCORE", key, min, max))
+}
+
+// scoredMembers converts an alternating member-score reply.
+func scoredMembers(reply any, err error) ([]ScoredMember, error) {
+	values, err := redigo_redis.Strings(reply, err)
+	if err != nil {
+		return nil, err
+	} else if len(values)%2 != 0 {
+		return nil, errors.New("expected an even number of values for member and score pairs")
+	}
+
+	members := make([]ScoredMember, 0, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		score, err := strconv.ParseFloat(values[i+1], 64)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, ScoredMember{Member: values[i], Score: score})
+	}
+
+	return members, nil
+}
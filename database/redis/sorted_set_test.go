@@ -0,0 +1,67 @@
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/common-library/go/database/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SortedSet(t *testing.T) {
+	setupTest(t)
+
+	added, err := client.ZAdd("leaderboard",
+		redis.ScoredMember{Member: "alice", Score: 100},
+		redis.ScoredMember{Member: "bob", Score: 85},
+		redis.ScoredMember{Member: "carol", Score: 92.5},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, added)
+
+	score, err := client.ZIncrBy("leaderboard", 10, "bob")
+	assert.NoError(t, err)
+	assert.Equal(t, 95.0, score)
+
+	score, err = client.ZScore("leaderboard", "carol")
+	assert.NoError(t, err)
+	assert.Equal(t, 92.5, score)
+
+	_, err = client.ZScore("leaderboard", "missing")
+	assert.ErrorIs(t, err, redis.ErrNil)
+
+	rank, err := client.ZRank("leaderboard", "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rank)
+
+	members, err := client.ZRangeWithScores("leaderboard", 0, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.ScoredMember{{Member: "carol", Score: 92.5}, {Member: "bob", Score: 95}, {Member: "alice", Score: 100}}, members)
+
+	members, err = client.ZRevRangeWithScores("leaderboard", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.ScoredMember{{Member: "alice", Score: 100}}, members)
+
+	members, err = client.ZRangeByScore("leaderboard", "93", "+inf", 0, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.ScoredMember{{Member: "bob", Score: 95}, {Member: "alice", Score: 100}}, members)
+
+	members, err = client.ZRangeByScore("leaderboard", "-inf", "(100", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.ScoredMember{{Member: "bob", Score: 95}}, members)
+
+	count, err := client.ZCard("leaderboard")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	removed, err := client.ZRemRangeByScore("leaderboard", "-inf", "95")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	removed, err = client.ZRem("leaderboard", "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	count, err = client.ZCard("leaderboard")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,386 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	redigo_redis "github.com/gomodule/redigo/redis"
+)
+
+const (
+	streamConsumeBlock      = time.Second
+	streamConsumeRetryDelay = time.Second
+)
+
+// StreamEntry is an entry of a Redis stream.
+type StreamEntry struct {
+	// ID is the entry ID (e.g. "1700000000000-0")
+	ID string
+
+	// Fields are the field-value pairs of the entry
+	Fields map[string]string
+}
+
+// XAdd appends an entry to a stream.
+//
+// Parameters:
+//   - stream: Stream key
+//   - id: Entry ID, or "*" to let the server generate one
+//   - fieldsAndValues: Alternating field-value pairs (field1, value1, field2, value2, ...)
+//
+// Returns:
+//   - string: ID of the added entry
+//   - error: Error if client not initialized or XADD command fails, nil on success
+//
+// The stream is created if it does not exist.
+//
+// Example:
+//
+//	id, err := client.XAdd("orders", "*", "order_id", 42, "status", "created")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) XAdd(stream, id string, fieldsAndValues ...any) (string, error) {
+	if c.pool == nil {
+		return "", errors.New("please call Initialize first")
+	}
+
+	args := make([]any, 0, 2+len(fieldsAndValues))
+	args = append(args, stream, id)
+	args = append(args, fieldsAndValues...)
+
+	return redigo_redis.String(c.do("XADD", args...))
+}
+
+// XLen returns the number of entries in a stream.
+//
+// Parameters:
+//   - stream: Stream key
+//
+// Returns:
+//   - int: Number of entries, 0 if the stream does not exist
+//   - error: Error if client not initialized or XLEN command fails, nil on success
+//
+// Example:
+//
+//	length, err := client.XLen("orders")
+func (c *Client) XLen(stream string) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	return redigo_redis.Int(c.do("XLEN", stream))
+}
+
+// XGroupCreate creates a consumer group, creating the stream if it does not exist.
+//
+// Parameters:
+//   - stream: Stream key
+//   - group: Consumer group name
+//   - start: ID of the last entry considered delivered ("0" for the whole stream, "$" for new entries only)
+//
+// Returns:
+//   - error: Error if client not initialized or XGROUP CREATE fails, nil on success
+//
+// Creating a group that already exists is not an error and leaves the group unchanged,
+// so every replica can call XGroupCreate at startup.
+//
+// Example:
+//
+//	err := client.XGroupCreate("orders", "billing", "0")
+func (c *Client) XGroupCreate(stream, group, start string) error {
+	if c.pool == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	_, err := c.do("XGROUP", "CREATE", stream, group, start, "MKSTREAM")
+	if redisErr, ok := err.(redigo_redis.Error); ok && strings.HasPrefix(string(redisErr), "BUSYGROUP") {
+		return nil
+	}
+
+	return err
+}
+
+// XReadGroup reads entries from a stream as a consumer of a consumer group.
+//
+// Parameters:
+//   - group: Consumer group name
+//   - consumer: Consumer name, unique per worker (e.g. the host name)
+//   - stream: Stream key
+//   - id: ">" for entries never delivered to the group, or an ID to re-read this consumer's pending entries
+//   - count: Maximum number of entries to return
+//   - block: Time to wait for new entries when none are available (0 does not wait)
+//
+// Returns:
+//   - []StreamEntry: Entries read, empty if none arrived before block elapsed
+//   - error: Error if client not initialized or XREADGROUP command fails, nil on success
+//
+// Entries read with ">" stay pending for the consumer until they are acknowledged with XAck.
+//
+// Example:
+//
+//	entries, err := client.XReadGroup("billing", "worker-1", "orders", ">", 10, 5*time.Second)
+//	for _, entry := range entries {
+//	    process(entry)
+//	    client.XAck("orders", "billing", entry.ID)
+//	}
+func (c *Client) XReadGroup(group, consumer, stream, id string, count int, block time.Duration) ([]StreamEntry, error) {
+	if c.pool == nil {
+		return nil, errors.New("please call Initialize first")
+	}
+
+	args := []any{"GROUP", group, consumer, "COUNT", count}
+	if block > 0 {
+		args = append(args, "BLOCK", block.Milliseconds())
+	}
+	args = append(args, "STREAMS", stream, id)
+
+	streams, err := redigo_redis.Values(c.do("XREADGROUP", args...))
+	if err == redigo_redis.ErrNil {
+		return []StreamEntry{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	entries := []StreamEntry{}
+	for _, reply := range streams {
+		values, err := redigo_redis.Values(reply, nil)
+		if err != nil {
+			return nil, err
+		} else if len(values) != 2 {
+			return nil, errors.New("unexpected XREADGROUP reply")
+		}
+
+		streamEntries, err := parseStreamEntries(values[1])
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, streamEntries...)
+	}
+
+	return entries, nil
+}
+
+// XAck acknowledges entries, removing them from the pending entries list of a consumer group.
+//
+// Parameters:
+//   - stream: Stream key
+//   - group: Consumer group name
+//   - ids: IDs of the entries to acknowledge
+//
+// Returns:
+//   - int: Number of entries acknowledged
+//   - error: Error if client not initialized or XACK command fails, nil on success
+//
+// Example:
+//
+//	acknowledged, err := client.XAck("orders", "billing", entry.ID)
+func (c *Client) XAck(stream, group string, ids ...string) (int, error) {
+	if c.pool == nil {
+		return 0, errors.New("please call Initialize first")
+	}
+
+	args := make([]any, 0, 2+len(ids))
+	args = append(args, stream, group)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	return redigo_redis.Int(c.do("XACK", args...))
+}
+
+// XAutoClaim transfers pending entries idle for at least minIdle to a consumer.
+//
+// Parameters:
+//   - stream: Stream key
+//   - group: Consumer group name
+//   - consumer: Consumer that takes over the entries
+//   - minIdle: Minimum time since the entries were last delivered
+//   - start: ID to start scanning from ("0-0" for the beginning)
+//   - count: Maximum number of entries to claim
+//
+// Returns:
+//   - string: ID to pass as start of the next call, "0-0" when the scan is complete
+//   - []StreamEntry: Claimed entries; entries deleted from the stream are skipped
+//   - error: Error if client not initialized or XAUTOCLAIM command fails, nil on success
+//
+// XAutoClaim recovers entries left pending by consumers that crashed before acknowledging them.
+//
+// Example:
+//
+//	for start := "0-0"; ; {
+//	    next, entries, err := client.XAutoClaim("orders", "billing", "worker-1", time.Minute, start, 100)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    handle(entries)
+//	    if next == "0-0" {
+//	        break
+//	    }
+//	    start = next
+//	}
+func (c *Client) XAutoClaim(stream, group, consumer string, minIdle time.Duration, start string, count int) (string, []StreamEntry, error) {
+	if c.pool == nil {
+		return "", nil, errors.New("please call Initialize first")
+	}
+
+	values, err := redigo_redis.Values(c.do("XAUTOCLAIM", stream, group, consumer, minIdle.Milliseconds(), start, "COUNT", count))
+	if err != nil {
+		return "", nil, err
+	} else if len(values) < 2 {
+		return "", nil, errors.New("unexpected XAUTOCLAIM reply")
+	}
+
+	next, err := redigo_redis.String(values[0], nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	entries, err := parseStreamEntries(values[1])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return next, entries, nil
+}
+
+// ConsumeStream runs a consumer group worker loop until ctx is done.
+//
+// Parameters:
+//   - ctx: Context that stops the loop
+//   - stream: Stream key
+//   - group: Consumer group name, created from the start of the stream if it does not exist
+//   - consumer: Consumer name, unique per worker
+//   - count: Maximum number of entries read per call
+//   - minIdle: Time after which entries pending on any consumer are claimed and retried (0 disables claiming)
+//   - handler: Function called for each entry; the entry is acknowledged when it returns nil
+//   - errorHandler: Function called with handler and Redis errors (can be nil)
+//
+// Returns:
+//   - error: Error if client not initialized or the consumer group cannot be created, nil when ctx is done
+//
+// The loop reads new entries with XREADGROUP, blocking for up to one second at a time so that
+// cancellation is noticed promptly. Every minIdle it claims entries that stayed pending for
+// minIdle with XAUTOCLAIM, which retries entries whose handler failed as well as entries
+// left by crashed workers. Delivery is therefore at least once and handlers should be idempotent.
+//
+// Example:
+//
+//	err := client.ConsumeStream(ctx, "orders", "billing", hostname, 10, time.Minute,
+//	    func(ctx context.Context, entry redis.StreamEntry) error {
+//	        return bill(ctx, entry.Fields["order_id"])
+//	    },
+//	    func(err error) { log.Println(err) },
+//	)
+func (c *Client) ConsumeStream(ctx context.Context, stream, group, consumer string, count int, minIdle time.Duration, handler func(ctx context.Context, entry StreamEntry) error, errorHandler func(err error)) error {
+	if c.pool == nil {
+		return errors.New("please call Initialize first")
+	}
+
+	if err := c.XGroupCreate(stream, group, "0"); err != nil {
+		return err
+	}
+
+	reportError := func(err error) {
+		if errorHandler != nil {
+			errorHandler(err)
+		}
+	}
+
+	handle := func(entries []StreamEntry) {
+		for _, entry := range entries {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err := handler(ctx, entry); err != nil {
+				reportError(fmt.Errorf("%s: %w", entry.ID, err))
+			} else if _, err := c.XAck(stream, group, entry.ID); err != nil {
+				reportError(err)
+			}
+		}
+	}
+
+	wait := func() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(streamConsumeRetryDelay):
+		}
+	}
+
+	lastClaim := time.Now()
+	for ctx.Err() == nil {
+		if minIdle > 0 && time.Since(lastClaim) >= minIdle {
+			lastClaim = time.Now()
+
+			for start := "0-0"; ctx.Err() == nil; {
+				next, entries, err := c.XAutoClaim(stream, group, consumer, minIdle, start, count)
+				if err != nil {
+					reportError(err)
+					break
+				}
+
+				handle(entries)
+
+				if next == "0-0" {
+					break
+				}
+				start = next
+			}
+		}
+
+		entries, err := c.XReadGroup(group, consumer, stream, ">", count, streamConsumeBlock)
+		if err != nil {
+			reportError(err)
+			wait()
+			continue
+		}
+
+		handle(entries)
+	}
+
+	return nil
+}
+
+func parseStreamEntries(reply any) ([]StreamEntry, error) {
+	values, err := redigo_redis.Values(reply, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]StreamEntry, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+
+		entry, err := redigo_redis.Values(value, nil)
+		if err != nil {
+			return nil, err
+		} else if len(entry) != 2 {
+			return nil, errors.New("unexpected stream entry")
+		}
+
+		id, err := redigo_redis.String(entry[0], nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if entry[1] == nil {
+			continue
+		}
+
+		fields, err := redigo_redis.StringMap(entry[1], nil)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, StreamEntry{ID: id, Fields: fields})
+	}
+
+	return entries, nil
+}
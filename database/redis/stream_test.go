@@ -0,0 +1,136 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/common-library/go/database/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_XAddAndXReadGroup(t *testing.T) {
+	setupTest(t)
+
+	require.NoError(t, client.XGroupCreate("test_stream", "group", "0"))
+	require.NoError(t, client.XGroupCreate("test_stream", "group", "0"))
+
+	id1, err := client.XAdd("test_stream", "*", "field", "value1")
+	require.NoError(t, err)
+	id2, err := client.XAdd("test_stream", "*", "field", "value2")
+	require.NoError(t, err)
+
+	length, err := client.XLen("test_stream")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, length)
+
+	entries, err := client.XReadGroup("group", "consumer", "test_stream", ">", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []redis.StreamEntry{
+		{ID: id1, Fields: map[string]string{"field": "value1"}},
+		{ID: id2, Fields: map[string]string{"field": "value2"}},
+	}, entries)
+
+	entries, err = client.XReadGroup("group", "consumer", "test_stream", ">", 10, 100*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = client.XReadGroup("group", "consumer", "test_stream", "0", 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	acknowledged, err := client.XAck("test_stream", "group", id1, id2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, acknowledged)
+
+	entries, err = client.XReadGroup("group", "consumer", "test_stream", "0", 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestClient_XAutoClaim(t *testing.T) {
+	setupTest(t)
+
+	require.NoError(t, client.XGroupCreate("test_stream", "group", "0"))
+
+	id, err := client.XAdd("test_stream", "*", "field", "value")
+	require.NoError(t, err)
+
+	_, err = client.XReadGroup("group", "crashed", "test_stream", ">", 10, 0)
+	require.NoError(t, err)
+
+	next, entries, err := client.XAutoClaim("test_stream", "group", "consumer", time.Hour, "0-0", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "0-0", next)
+	assert.Empty(t, entries)
+
+	time.Sleep(50 * time.Millisecond)
+
+	next, entries, err = client.XAutoClaim("test_stream", "group", "consumer", 10*time.Millisecond, "0-0", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "0-0", next)
+	assert.Equal(t, []redis.StreamEntry{{ID: id, Fields: map[string]string{"field": "value"}}}, entries)
+}
+
+func TestClient_ConsumeStream(t *testing.T) {
+	setupTest(t)
+
+	for _, value := range []string{"value1", "value2", "fail"} {
+		_, err := client.XAdd("test_stream", "*", "field", value)
+		require.NoError(t, err)
+	}
+
+	mutex := sync.Mutex{}
+	handled := map[string]int{}
+	errs := []error{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- client.ConsumeStream(ctx, "test_stream", "group", "consumer", 10, 200*time.Millisecond,
+			func(ctx context.Context, entry redis.StreamEntry) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				value := entry.Fields["field"]
+				handled[value]++
+				if value == "fail" && handled[value] == 1 {
+					return errors.New("temporary failure")
+				}
+
+				return nil
+			},
+			func(err error) {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				errs = append(errs, err)
+			},
+		)
+	}()
+
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return handled["fail"] == 2
+	}, 10*time.Second, 50*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	assert.Equal(t, map[string]int{"value1": 1, "value2": 1, "fail": 2}, handled)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "temporary failure")
+
+	entries, err := client.XReadGroup("group", "consumer", "test_stream", "0", 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
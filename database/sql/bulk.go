@@ -0,0 +1,251 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/lib/pq"
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+const bulkInsertMaxRows = 1000
+
+var bulkInsertMaxParameters = map[Driver]int{
+	DriverMySQL:  65535,
+	DriverOracle: 65535,
+	DriverSQLite: 32766,
+}
+
+// BulkInsert inserts all rows produced by rows into table using the fastest path of the driver.
+//
+// The rows are written inside a single transaction, so either all rows are inserted or none:
+//   - PostgreSQL: COPY FROM STDIN
+//   - SQL Server: bulk copy
+//   - ClickHouse: native batch (one prepared INSERT sent as a single block)
+//   - MySQL and SQLite: multi-row INSERT statements of up to 1000 rows each
+//   - Oracle: INSERT ALL statements of up to 1000 rows each
+//   - DynamoDB: one PartiQL INSERT per row, without a transaction
+//
+// The table and column names are written into the statement verbatim and must not come
+// from untrusted input.
+//
+// Parameters:
+//   - ctx: Context that cancels the insert
+//   - table: Name of the table, optionally qualified with a schema (schema.table)
+//   - columns: Names of the columns to insert
+//   - rows: Sequence of rows, each holding one value per column
+//
+// Returns:
+//   - int64: Number of rows inserted
+//   - error: Returns an error if a row has the wrong number of values or the insert fails
+//
+// Example:
+//
+//	rows := func(yield func([]any) bool) {
+//		for i := range 100000 {
+//			if !yield([]any{i, fmt.Sprintf("user_%d", i)}) {
+//				return
+//			}
+//		}
+//	}
+//
+//	count, err := client.BulkInsert(ctx, "users", []string{"id", "name"}, rows)
+func (c *Client) BulkInsert(ctx context.Context, table string, columns []string, rows iter.Seq[[]any]) (int64, error) {
+	if c.connection == nil {
+		return 0, errors.New("please call Open first")
+	} else if len(columns) == 0 {
+		return 0, errors.New("columns must not be empty")
+	}
+
+	count := int64(0)
+	err := c.instrument(ctx, QueryEvent{Operation: OperationBulkInsert, Query: table}, func(ctx context.Context) error {
+		var err error
+		count, err = c.bulkInsert(ctx, table, columns, rows)
+		return err
+	})
+
+	return count, err
+}
+
+func (c *Client) bulkInsert(ctx context.Context, table string, columns []string, rows iter.Seq[[]any]) (int64, error) {
+	switch c.driver {
+	case DriverPostgreSQL:
+		statement := pq.CopyIn(table, columns...)
+		if schema, name, ok := strings.Cut(table, "."); ok {
+			statement = pq.CopyInSchema(schema, name, columns...)
+		}
+
+		return c.bulkCopy(ctx, statement, columns, rows, true)
+	case DriverMicrosoftSQLServer:
+		return c.bulkCopy(ctx, mssql.CopyIn(table, mssql.BulkOptions{}, columns...), columns, rows, true)
+	case DriverClickHouse:
+		return c.bulkCopy(ctx, "INSERT INTO "+table+" ("+strings.Join(columns, ", ")+")", columns, rows, false)
+	case DriverAmazonDynamoDB:
+		return c.bulkInsertEach(ctx, table, columns, rows)
+	default:
+		return c.bulkInsertBatches(ctx, table, columns, rows)
+	}
+}
+
+// bulkCopy streams rows through a prepared statement inside a transaction.
+// Drivers implementing COPY semantics need a final Exec without arguments to flush the buffered rows.
+func (c *Client) bulkCopy(ctx context.Context, statement string, columns []string, rows iter.Seq[[]any], flush bool) (int64, error) {
+	count := int64(0)
+
+	err := c.bulkTransaction(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, statement)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for row := range rows {
+			if err := checkBulkRow(columns, row, count); err != nil {
+				return err
+			} else if _, err := stmt.ExecContext(ctx, row...); err != nil {
+				return err
+			}
+
+			count++
+		}
+
+		if flush {
+			if _, err := stmt.ExecContext(ctx); err != nil {
+				return err
+			}
+		}
+
+		return stmt.Close()
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (c *Client) bulkInsertBatches(ctx context.Context, table string, columns []string, rows iter.Seq[[]any]) (int64, error) {
+	batchSize := bulkInsertMaxRows
+	if maxParameters, ok := bulkInsertMaxParameters[c.driver]; ok {
+		batchSize = max(1, min(batchSize, maxParameters/len(columns)))
+	}
+
+	count := int64(0)
+
+	err := c.bulkTransaction(ctx, func(tx *sql.Tx) error {
+		args := make([]any, 0, batchSize*len(columns))
+		size := 0
+
+		execute := func() error {
+			if size == 0 {
+				return nil
+			}
+
+			_, err := tx.ExecContext(ctx, bulkInsertQuery(c.driver, table, columns, size), args...)
+
+			args = args[:0]
+			size = 0
+
+			return err
+		}
+
+		for row := range rows {
+			if err := checkBulkRow(columns, row, count); err != nil {
+				return err
+			}
+
+			args = append(args, row...)
+			size++
+			count++
+
+			if size == batchSize {
+				if err := execute(); err != nil {
+					return err
+				}
+			}
+		}
+
+		return execute()
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (c *Client) bulkInsertEach(ctx context.Context, table string, columns []string, rows iter.Seq[[]any]) (int64, error) {
+	fields := make([]string, len(columns))
+	for i, column := range columns {
+		fields[i] = "'" + column + "': ?"
+	}
+
+	query := `INSERT INTO "` + table + `" VALUE {` + strings.Join(fields, ", ") + `}`
+
+	count := int64(0)
+	for row := range rows {
+		if err := checkBulkRow(columns, row, count); err != nil {
+			return count, err
+		} else if _, err := c.connection.ExecContext(ctx, query, row...); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func (c *Client) bulkTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := c.connection.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	return tx.Commit()
+}
+
+func bulkInsertQuery(driver Driver, table string, columns []string, size int) string {
+	columnList := "(" + strings.Join(columns, ", ") + ")"
+
+	values := make([]string, size)
+	for i := range size {
+		placeholders := make([]string, len(columns))
+		for j := range columns {
+			placeholders[j] = placeholder(driver, i*len(columns)+j+1)
+		}
+
+		values[i] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	if driver == DriverOracle {
+		builder := strings.Builder{}
+		builder.WriteString("INSERT ALL")
+		for _, value := range values {
+			builder.WriteString(" INTO " + table + " " + columnList + " VALUES " + value)
+		}
+		builder.WriteString(" SELECT 1 FROM DUAL")
+
+		return builder.String()
+	}
+
+	return "INSERT INTO " + table + " " + columnList + " VALUES " + strings.Join(values, ", ")
+}
+
+func checkBulkRow(columns []string, row []any, index int64) error {
+	if len(row) != len(columns) {
+		return fmt.Errorf("row %d has %d values, expected %d", index, len(row), len(columns))
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package sql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sqlclient "github.com/common-library/go/database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bulkRows(count int) func(yield func([]any) bool) {
+	return func(yield func([]any) bool) {
+		for i := range count {
+			if !yield([]any{100 + i, fmt.Sprintf("bulk_%d", i)}) {
+				return
+			}
+		}
+	}
+}
+
+func TestBulkInsert(t *testing.T) {
+	client := openScanTestClient(t)
+
+	count, err := client.BulkInsert(context.Background(), "users", []string{"id", "name"}, bulkRows(2500))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), count)
+
+	total, err := sqlclient.QueryOne[int](client, "SELECT COUNT(*) FROM users WHERE name LIKE 'bulk_%'")
+	require.NoError(t, err)
+	assert.Equal(t, 2500, total)
+
+	name, err := sqlclient.QueryOne[string](client, "SELECT name FROM users WHERE id = ?", 2599)
+	require.NoError(t, err)
+	assert.Equal(t, "bulk_2499", name)
+
+	count, err = client.BulkInsert(context.Background(), "users", []string{"id", "name"}, bulkRows(0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestBulkInsertRollback(t *testing.T) {
+	client := openScanTestClient(t)
+
+	rows := func(yield func([]any) bool) {
+		if yield([]any{100, "first"}) {
+			yield([]any{101})
+		}
+	}
+
+	_, err := client.BulkInsert(context.Background(), "users", []string{"id", "name"}, rows)
+	assert.EqualError(t, err, "row 1 has 1 values, expected 2")
+
+	_, err = client.BulkInsert(context.Background(), "users", []string{"id", "name"}, bulkRows(1))
+	require.NoError(t, err)
+	_, err = client.BulkInsert(context.Background(), "users", []string{"id", "name"}, bulkRows(2))
+	assert.Error(t, err)
+
+	total, err := sqlclient.QueryOne[int](client, "SELECT COUNT(*) FROM users")
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+
+	_, err = client.BulkInsert(context.Background(), "users", nil, bulkRows(1))
+	assert.Error(t, err)
+
+	_, err = (&sqlclient.Client{}).BulkInsert(context.Background(), "users", []string{"id"}, bulkRows(1))
+	assert.Error(t, err)
+}
@@ -7,7 +7,13 @@
 //   - Support for 7 database drivers (MySQL, PostgreSQL, SQLite, ClickHouse, DynamoDB, SQL Server, Oracle)
 //   - Transaction management with Begin/End pattern
 //   - Prepared statement support for both regular and transactional queries
+//   - Struct scanning with db tags and :name named parameters
 //   - Connection pooling configuration
+//   - Read replica routing with health and replication lag checks
+//   - Retry of transient errors (deadlocks, serialization failures, connection resets)
+//   - Bulk insert using COPY, bulk copy, native batches or multi-row INSERT
+//   - Query hooks with OpenTelemetry tracing, Prometheus metrics and slow query logging
+//   - Schema inspection and diffing for drift detection
 //   - Consistent API across all database types
 //
 // Example:
@@ -19,8 +25,11 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"sync/atomic"
+	"time"
 
 	_ "github.com/ClickHouse/clickhouse-go/v2"
 	_ "github.com/btnguyen2k/godynamo"
@@ -47,12 +56,26 @@ const (
 type Client struct {
 	driver Driver
 
-	tx     *sql.Tx
-	txStmt *sql.Stmt
+	tx          *sql.Tx
+	txStmt      *sql.Stmt
+	txStmtQuery string
 
-	stmt *sql.Stmt
+	stmt      *sql.Stmt
+	stmtQuery string
 
 	connection *sql.DB
+
+	replicas     []*replica
+	replicaIndex atomic.Uint64
+
+	healthCheckCancel context.CancelFunc
+	healthCheckDone   chan struct{}
+
+	retryMaxAttempts    int
+	retryInitialBackoff time.Duration
+	retryMaxBackoff     time.Duration
+
+	hooks []Hook
 }
 
 // Open establishes a connection to the specified database.
@@ -73,23 +96,11 @@ type Client struct {
 //		log.Fatal(err)
 //	}
 func (c *Client) Open(driver Driver, dsn string, maxOpenConnection int) error {
-	c.driver = driver
-
-	if err := c.Close(); err != nil {
-		return err
-	} else if connection, err := sql.Open(string(driver), dsn); err != nil {
-		return err
-	} else {
-		c.connection = connection
-	}
-
-	c.connection.SetMaxOpenConns(maxOpenConnection)
-
-	return c.connection.Ping()
+	return c.OpenWithReplicas(driver, dsn, nil, maxOpenConnection)
 }
 
-// Close closes the database connection and releases all resources.
-// It is safe to call Close multiple times.
+// Close closes the database connections, including replicas, and releases all resources.
+// It also stops the health check started by StartHealthCheck. It is safe to call Close multiple times.
 //
 // Returns:
 //   - error: Returns an error if closing the connection fails, nil otherwise
@@ -98,19 +109,30 @@ func (c *Client) Open(driver Driver, dsn string, maxOpenConnection int) error {
 //
 //	defer client.Close()
 func (c *Client) Close() error {
-	if c.connection == nil {
-		return nil
+	c.stopHealthCheck()
+
+	errs := []error{}
+	for _, r := range c.replicas {
+		errs = append(errs, r.connection.Close())
 	}
+	c.replicas = nil
 
-	err := c.connection.Close()
-	c.connection = nil
+	if c.connection != nil {
+		errs = append(errs, c.connection.Close())
+		c.connection = nil
+	}
 
-	return err
+	return errors.Join(errs...)
 }
 
 // Query executes a SQL query and returns the result rows.
 // The caller is responsible for closing the returned rows.
 //
+// When replicas are configured, the query always runs on a healthy replica and only falls back
+// to the primary if none is healthy. A replica may not have caught up with writes made just
+// before, and statements that write, such as INSERT ... RETURNING, fail on it. Use QueryPrimary
+// for those.
+//
 // Parameters:
 //   - query: SQL query string (use ? for parameter placeholders)
 //   - args: Optional query parameters
@@ -137,15 +159,49 @@ func (c *Client) Close() error {
 //		fmt.Printf("ID: %d, Name: %s\n", id, name)
 //	}
 func (c *Client) Query(query string, args ...any) (*sql.Rows, error) {
+	return c.query(c.reader, query, args...)
+}
+
+// QueryPrimary is Query run on the primary even when replicas are configured.
+// Use it to read your own writes and for statements that write and return rows,
+// such as INSERT ... RETURNING on PostgreSQL.
+//
+// Example:
+//
+//	rows, err := client.QueryPrimary("INSERT INTO users (name) VALUES ($1) RETURNING id", "Alice")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer rows.Close()
+func (c *Client) QueryPrimary(query string, args ...any) (*sql.Rows, error) {
+	return c.query(c.primary, query, args...)
+}
+
+func (c *Client) query(route func() (*sql.DB, *replica), query string, args ...any) (*sql.Rows, error) {
 	if c.connection == nil {
 		return nil, errors.New("please call Open first")
 	}
 
-	return c.connection.Query(query, args...)
+	var rows *sql.Rows
+	err := c.instrument(context.Background(), QueryEvent{Operation: OperationQuery, Query: query, Args: args}, func(context.Context) error {
+		return c.withRetry(func() error {
+			connection, r := route()
+
+			var err error
+			rows, err = connection.Query(query, args...)
+			c.markReplica(r, err)
+
+			return err
+		})
+	})
+
+	return rows, err
 }
 
 // QueryRow executes a query and scans the first row into the provided variables.
 // This is a convenience method for queries that return a single row.
+// Like Query, it runs on a healthy replica when replicas are configured; use QueryRowPrimary
+// to read from the primary.
 //
 // Parameters:
 //   - query: SQL query string
@@ -164,11 +220,34 @@ func (c *Client) Query(query string, args ...any) (*sql.Rows, error) {
 //	}
 //	fmt.Printf("Name: %s, Age: %d\n", name, age)
 func (c *Client) QueryRow(query string, result ...any) error {
+	return c.queryRow(c.reader, query, result...)
+}
+
+// QueryRowPrimary is QueryRow run on the primary even when replicas are configured.
+//
+// Example:
+//
+//	var count int
+//	err := client.QueryRowPrimary("SELECT COUNT(*) FROM users", &count)
+func (c *Client) QueryRowPrimary(query string, result ...any) error {
+	return c.queryRow(c.primary, query, result...)
+}
+
+func (c *Client) queryRow(route func() (*sql.DB, *replica), query string, result ...any) error {
 	if c.connection == nil {
 		return errors.New("please call Open first")
 	}
 
-	return c.connection.QueryRow(query).Scan(result...)
+	return c.instrument(context.Background(), QueryEvent{Operation: OperationQueryRow, Query: query}, func(context.Context) error {
+		return c.withRetry(func() error {
+			connection, r := route()
+
+			err := connection.QueryRow(query).Scan(result...)
+			c.markReplica(r, err)
+
+			return err
+		})
+	})
 }
 
 // Execute executes a SQL statement (INSERT, UPDATE, DELETE, etc.) that doesn't return rows.
@@ -196,12 +275,16 @@ func (c *Client) Execute(query string, args ...any) error {
 		return errors.New("please call Open first")
 	}
 
-	if result, err := c.connection.Exec(query, args...); err != nil {
-		return err
-	} else {
-		_, err = result.RowsAffected()
-		return err
-	}
+	return c.instrument(context.Background(), QueryEvent{Operation: OperationExecute, Query: query, Args: args}, func(context.Context) error {
+		return c.withRetry(func() error {
+			if result, err := c.connection.Exec(query, args...); err != nil {
+				return err
+			} else {
+				_, err = result.RowsAffected()
+				return err
+			}
+		})
+	})
 }
 
 // SetPrepare creates a prepared statement for later execution.
@@ -229,6 +312,7 @@ func (c *Client) SetPrepare(query string) error {
 		return err
 	} else {
 		c.stmt = stmt
+		c.stmtQuery = query
 		return nil
 	}
 }
@@ -263,7 +347,14 @@ func (c *Client) QueryPrepare(args ...any) (*sql.Rows, error) {
 		return nil, errors.New("please call SetPrepare first")
 	}
 
-	return c.stmt.Query(args...)
+	var rows *sql.Rows
+	err := c.instrument(context.Background(), QueryEvent{Operation: OperationQuery, Query: c.stmtQuery, Args: args, Prepared: true}, func(context.Context) error {
+		var err error
+		rows, err = c.stmt.Query(args...)
+		return err
+	})
+
+	return rows, err
 }
 
 // QueryRowPrepare executes a prepared statement and returns a single row.
@@ -292,9 +383,13 @@ func (c *Client) QueryRowPrepare(args ...any) (*sql.Row, error) {
 		return nil, errors.New("please call SetPrepare first")
 	}
 
-	row := c.stmt.QueryRow(args...)
+	var row *sql.Row
+	err := c.instrument(context.Background(), QueryEvent{Operation: OperationQueryRow, Query: c.stmtQuery, Args: args, Prepared: true}, func(context.Context) error {
+		row = c.stmt.QueryRow(args...)
+		return row.Err()
+	})
 
-	return row, row.Err()
+	return row, err
 }
 
 // ExecutePrepare executes a prepared statement that doesn't return rows.
@@ -321,8 +416,10 @@ func (c *Client) ExecutePrepare(args ...any) error {
 		return errors.New("please call SetPrepare first")
 	}
 
-	_, err := c.stmt.Exec(args...)
-	return err
+	return c.instrument(context.Background(), QueryEvent{Operation: OperationExecute, Query: c.stmtQuery, Args: args, Prepared: true}, func(context.Context) error {
+		_, err := c.stmt.Exec(args...)
+		return err
+	})
 }
 
 // BeginTransaction starts a new database transaction.
@@ -422,7 +519,14 @@ func (c *Client) QueryTransaction(query string, args ...any) (*sql.Rows, error)
 		return nil, errors.New("please call BeginTransaction first")
 	}
 
-	return c.tx.Query(query, args...)
+	var rows *sql.Rows
+	err := c.instrument(context.Background(), QueryEvent{Operation: OperationQuery, Query: query, Args: args, Transaction: true}, func(context.Context) error {
+		var err error
+		rows, err = c.tx.Query(query, args...)
+		return err
+	})
+
+	return rows, err
 }
 
 // QueryRowTransaction executes a query within the current transaction and scans the first row.
@@ -450,7 +554,9 @@ func (c *Client) QueryRowTransaction(query string, result ...any) error {
 		return errors.New("please call BeginTransaction first")
 	}
 
-	return c.tx.QueryRow(query).Scan(result...)
+	return c.instrument(context.Background(), QueryEvent{Operation: OperationQueryRow, Query: query, Transaction: true}, func(context.Context) error {
+		return c.tx.QueryRow(query).Scan(result...)
+	})
 }
 
 // ExecuteTransaction executes a SQL statement within the current transaction.
@@ -481,12 +587,14 @@ func (c *Client) ExecuteTransaction(query string, args ...any) error {
 		return errors.New("please call BeginTransaction first")
 	}
 
-	if result, err := c.tx.Exec(query, args...); err != nil {
-		return err
-	} else {
-		_, err = result.RowsAffected()
-		return err
-	}
+	return c.instrument(context.Background(), QueryEvent{Operation: OperationExecute, Query: query, Args: args, Transaction: true}, func(context.Context) error {
+		if result, err := c.tx.Exec(query, args...); err != nil {
+			return err
+		} else {
+			_, err = result.RowsAffected()
+			return err
+		}
+	})
 }
 
 // SetPrepareTransaction creates a prepared statement within the current transaction.
@@ -515,6 +623,7 @@ func (c *Client) SetPrepareTransaction(query string) error {
 		return err
 	} else {
 		c.txStmt = txStmt
+		c.txStmtQuery = query
 		return nil
 	}
 }
@@ -551,7 +660,14 @@ func (c *Client) QueryPrepareTransaction(args ...any) (*sql.Rows, error) {
 		return nil, errors.New("please call SetPrepareTransaction first")
 	}
 
-	return c.txStmt.Query(args...)
+	var rows *sql.Rows
+	err := c.instrument(context.Background(), QueryEvent{Operation: OperationQuery, Query: c.txStmtQuery, Args: args, Prepared: true, Transaction: true}, func(context.Context) error {
+		var err error
+		rows, err = c.txStmt.Query(args...)
+		return err
+	})
+
+	return rows, err
 }
 
 // QueryRowPrepareTransaction executes a prepared statement within a transaction and returns a single row.
@@ -582,9 +698,13 @@ func (c *Client) QueryRowPrepareTransaction(args ...any) (*sql.Row, error) {
 		return nil, errors.New("please call SetPrepareTransaction first")
 	}
 
-	row := c.txStmt.QueryRow(args...)
+	var row *sql.Row
+	err := c.instrument(context.Background(), QueryEvent{Operation: OperationQueryRow, Query: c.txStmtQuery, Args: args, Prepared: true, Transaction: true}, func(context.Context) error {
+		row = c.txStmt.QueryRow(args...)
+		return row.Err()
+	})
 
-	return row, row.Err()
+	return row, err
 }
 
 // ExecutePrepareTransaction executes a prepared statement within a transaction.
@@ -614,8 +734,10 @@ func (c *Client) ExecutePrepareTransaction(args ...any) error {
 		return errors.New("please call SetPrepareTransaction first")
 	}
 
-	_, err := c.txStmt.Exec(args...)
-	return err
+	return c.instrument(context.Background(), QueryEvent{Operation: OperationExecute, Query: c.txStmtQuery, Args: args, Prepared: true, Transaction: true}, func(context.Context) error {
+		_, err := c.txStmt.Exec(args...)
+		return err
+	})
 }
 
 // GetDriver returns the database driver currently in use.
@@ -635,3 +757,19 @@ func (c *Client) ExecutePrepareTransaction(args ...any) error {
 func (c *Client) GetDriver() Driver {
 	return c.driver
 }
+
+// GetConnection returns the connection pool of the primary database.
+// It can be used to hand the connection to libraries that work on *sql.DB.
+// Statements run directly on the pool bypass hooks, retries and replica routing.
+//
+// Returns:
+//   - *sql.DB: The primary connection pool, or nil if Open has not been called
+//
+// Example:
+//
+//	db := client.GetConnection()
+//	stats := db.Stats()
+//	fmt.Println(stats.OpenConnections)
+func (c *Client) GetConnection() *sql.DB {
+	return c.connection
+}
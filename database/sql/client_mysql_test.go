@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	sqlclient "github.com/common-library/go/database/sql"
@@ -319,6 +320,58 @@ func (suite *MySQLTestSuite) TestClose() {
 	assert.Error(suite.T(), err)
 }
 
+func (suite *MySQLTestSuite) TestBulkInsert() {
+	rows := func(yield func([]any) bool) {
+		for i := range 2500 {
+			if !yield([]any{fmt.Sprintf("user_%d", i), fmt.Sprintf("user_%d@example.com", i)}) {
+				return
+			}
+		}
+	}
+
+	count, err := suite.client.BulkInsert(context.Background(), "test_users", []string{"name", "email"}, rows)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(2500), count)
+
+	total, err := sqlclient.QueryOne[int](suite.client, "SELECT COUNT(*) FROM test_users")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 2502, total)
+}
+
+func (suite *MySQLTestSuite) TestInspectSchema() {
+	schema, err := suite.client.InspectSchema(context.Background())
+	suite.Require().NoError(err)
+
+	table, ok := schema.GetTable("test_users")
+	suite.Require().True(ok)
+	suite.Require().Len(table.Columns, 4)
+	assert.Equal(suite.T(), sqlclient.Column{Name: "name", Type: "varchar(100)"}, table.Columns[1])
+	assert.Contains(suite.T(), table.Constraints, sqlclient.Constraint{Name: "PRIMARY", Type: sqlclient.ConstraintPrimaryKey, Columns: []string{"id"}})
+	assert.Contains(suite.T(), table.Constraints, sqlclient.Constraint{Name: "email", Type: sqlclient.ConstraintUnique, Columns: []string{"email"}})
+	assert.Empty(suite.T(), table.Indexes)
+}
+
+func (suite *MySQLTestSuite) TestInspectMigrationsBackslashEscapes() {
+	client := &sqlclient.Client{}
+	suite.Require().NoError(client.Open(sqlclient.DriverMySQL, suite.dsn, 1))
+	defer client.Close()
+
+	client.Execute("DROP TABLE IF EXISTS test_notes")
+	defer client.Execute("DROP TABLE IF EXISTS test_notes")
+
+	_, err := client.InspectMigrations(context.Background(), fstest.MapFS{
+		"1_notes.sql": &fstest.MapFile{Data: []byte(`-- migrate:up
+CREATE TABLE test_notes (body VARCHAR(100) NOT NULL);
+INSERT INTO test_notes (body) VALUES ('it\'s; x'); INSERT INTO test_notes (body) VALUES ("say \"a;b\"");
+`)},
+	})
+	suite.Require().NoError(err)
+
+	bodies, err := sqlclient.QueryAll[string](client, "SELECT body FROM test_notes ORDER BY body")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []string{"it's; x", `say "a;b"`}, bodies)
+}
+
 func TestMySQLClientSuite(t *testing.T) {
 	t.Parallel()
 
@@ -2,6 +2,7 @@ package sql_test
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -171,6 +172,40 @@ func (suite *PostgreSQLTestSuite) TestTransaction() {
 	assert.Equal(suite.T(), 1, count)
 }
 
+func (suite *PostgreSQLTestSuite) TestBulkInsert() {
+	rows := func(yield func([]any) bool) {
+		for i := range 3000 {
+			if !yield([]any{fmt.Sprintf("user_%d", i), fmt.Sprintf("user_%d@example.com", i), i % 100}) {
+				return
+			}
+		}
+	}
+
+	count, err := suite.client.BulkInsert(context.Background(), "public.test_users", []string{"name", "email", "age"}, rows)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(3000), count)
+
+	total, err := sqlclient.QueryOne[int](suite.client, "SELECT COUNT(*) FROM test_users")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 3000, total)
+}
+
+func (suite *PostgreSQLTestSuite) TestInspectSchema() {
+	schema, err := suite.client.InspectSchema(context.Background())
+	suite.Require().NoError(err)
+
+	table, ok := schema.GetTable("test_users")
+	suite.Require().True(ok)
+	suite.Require().Len(table.Columns, 5)
+	assert.Equal(suite.T(), sqlclient.Column{Name: "name", Type: "character varying(100)"}, table.Columns[1])
+	assert.True(suite.T(), table.Columns[3].Nullable)
+	assert.Contains(suite.T(), table.Constraints, sqlclient.Constraint{Name: "test_users_pkey", Type: sqlclient.ConstraintPrimaryKey, Columns: []string{"id"}})
+	assert.Contains(suite.T(), table.Constraints, sqlclient.Constraint{Name: "test_users_email_key", Type: sqlclient.ConstraintUnique, Columns: []string{"email"}})
+	assert.Empty(suite.T(), table.Indexes)
+
+	assert.Empty(suite.T(), sqlclient.DiffSchema(sqlclient.DriverPostgreSQL, schema, schema))
+}
+
 func TestPostgreSQLSuite(t *testing.T) {
 	if os.Getenv("SKIP_INTEGRATION_TESTS") == "true" {
 		t.Skip("Integration tests are skipped")
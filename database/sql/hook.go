@@ -0,0 +1,375 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Operation is the kind of client call reported in a QueryEvent and used as the operation label
+// of the Prometheus metrics.
+type Operation string
+
+const (
+	// OperationQuery is a call returning rows: Query, QueryPrepare, QueryTransaction and
+	// QueryPrepareTransaction
+	OperationQuery = Operation("query")
+
+	// OperationQueryRow is a call scanning a single row: QueryRow, QueryRowPrepare,
+	// QueryRowTransaction and QueryRowPrepareTransaction
+	OperationQueryRow = Operation("query_row")
+
+	// OperationExecute is a statement returning no rows: Execute, ExecutePrepare,
+	// ExecuteTransaction and ExecutePrepareTransaction
+	OperationExecute = Operation("execute")
+
+	// OperationBulkInsert is a BulkInsert, reported once per call with the table name as the query
+	OperationBulkInsert = Operation("bulk_insert")
+)
+
+// QueryEvent describes a single query passed to the hooks.
+//
+// BeforeQuery receives the event with Start set; Duration and Err are filled in before AfterQuery.
+// For Query and the prepared and transactional query methods, Duration covers the execution of the
+// statement only, not the iteration over the returned rows.
+type QueryEvent struct {
+	// Driver is the driver of the client running the query
+	Driver Driver
+
+	// Operation is the kind of call (query, query_row, execute, bulk_insert)
+	Operation Operation
+
+	// Query is the statement text as sent to the driver (the table name for bulk inserts)
+	Query string
+
+	// Args are the bound arguments; hooks that export them should redact them
+	Args []any
+
+	// Prepared reports whether the statement ran through a prepared statement
+	Prepared bool
+
+	// Transaction reports whether the statement ran inside a transaction
+	Transaction bool
+
+	// Start is the time the query started
+	Start time.Time
+
+	// Duration is the time the query took
+	Duration time.Duration
+
+	// Err is the error returned by the query, if any
+	Err error
+}
+
+// Failed reports whether the query failed.
+// sql.ErrNoRows is not treated as a failure.
+//
+// Returns:
+//   - bool: true if Err is set and is not sql.ErrNoRows
+func (e *QueryEvent) Failed() bool {
+	return e.Err != nil && !errors.Is(e.Err, sql.ErrNoRows)
+}
+
+// Hook is an interface that is called before and after each query of a Client.
+//
+// BeforeQuery may return a derived context (for example one carrying a tracing span);
+// the returned context is passed to the query where the method accepts one and to AfterQuery.
+// Hooks are called in the order they were added before the query and in reverse order after it.
+type Hook interface {
+	BeforeQuery(ctx context.Context, event *QueryEvent) context.Context
+	AfterQuery(ctx context.Context, event *QueryEvent)
+}
+
+// AddHook adds hooks that are called before and after every query of the client.
+//
+// Hooks apply to Query, QueryRow, Execute, the prepared and transactional variants,
+// BulkInsert and the functions built on them such as QueryAll and ExecuteNamed.
+// AddHook must not be called concurrently with queries.
+//
+// Parameters:
+//   - hooks: Hooks to add
+//
+// Example:
+//
+//	metrics, err := sql.NewMetricsHook("app", nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	client.AddHook(
+//		sql.NewTracingHook(otel.Tracer("app")),
+//		metrics,
+//		sql.NewSlowQueryHook(slog.Default(), 500*time.Millisecond),
+//	)
+func (c *Client) AddHook(hooks ...Hook) {
+	c.hooks = append(c.hooks, hooks...)
+}
+
+func (c *Client) instrument(ctx context.Context, event QueryEvent, fn func(ctx context.Context) error) error {
+	if len(c.hooks) == 0 {
+		return fn(ctx)
+	}
+
+	event.Driver = c.driver
+	event.Start = time.Now()
+
+	for _, hook := range c.hooks {
+		ctx = hook.BeforeQuery(ctx, &event)
+	}
+
+	err := fn(ctx)
+
+	event.Duration = time.Since(event.Start)
+	event.Err = err
+
+	for i := len(c.hooks) - 1; i >= 0; i-- {
+		c.hooks[i].AfterQuery(ctx, &event)
+	}
+
+	return err
+}
+
+// TracingHook is a Hook that records an OpenTelemetry client span for each query.
+type TracingHook struct {
+	tracer trace.Tracer
+}
+
+// NewTracingHook creates a Hook that records an OpenTelemetry span for each query.
+//
+// Spans carry the db.system.name, db.operation.name and db.query.text attributes.
+// Bound arguments are never recorded. Failed queries set the span status to error.
+//
+// Parameters:
+//   - tracer: Tracer creating the spans (nil uses the global tracer provider)
+//
+// Returns:
+//   - *TracingHook: Hook to pass to AddHook
+//
+// Example:
+//
+//	client.AddHook(sql.NewTracingHook(otel.Tracer("my-service")))
+func NewTracingHook(tracer trace.Tracer) *TracingHook {
+	if tracer == nil {
+		tracer = otel.Tracer("github.com/common-library/go/database/sql")
+	}
+
+	return &TracingHook{tracer: tracer}
+}
+
+// BeforeQuery starts the span of the query.
+func (h *TracingHook) BeforeQuery(ctx context.Context, event *QueryEvent) context.Context {
+	name := statementKeyword(event.Query)
+	if event.Operation == OperationBulkInsert || len(name) == 0 {
+		name = string(event.Operation)
+	}
+
+	ctx, _ = h.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(event.Start),
+		trace.WithAttributes(
+			attribute.String("db.system.name", databaseSystem(event.Driver)),
+			attribute.String("db.operation.name", name),
+			attribute.String("db.query.text", event.Query),
+			attribute.Bool("db.prepared", event.Prepared),
+			attribute.Bool("db.transaction", event.Transaction),
+		),
+	)
+
+	return ctx
+}
+
+// AfterQuery ends the span of the query.
+func (h *TracingHook) AfterQuery(ctx context.Context, event *QueryEvent) {
+	span := trace.SpanFromContext(ctx)
+
+	if event.Failed() {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	}
+
+	span.End(trace.WithTimestamp(event.Start.Add(event.Duration)))
+}
+
+// MetricsHook is a Hook that observes query durations in a Prometheus histogram.
+type MetricsHook struct {
+	registerer prometheus.Registerer
+	duration   *prometheus.HistogramVec
+}
+
+// NewMetricsHook creates a Hook that observes query durations and registers its histogram
+// with registerer.
+//
+// The histogram is named <namespace>_sql_query_duration_seconds and labeled by driver,
+// operation and status (success or error).
+//
+// Parameters:
+//   - registerer: Registry of the histogram (nil uses the default registry served by
+//     exporter.Start, the one exporter.RegisterCollector registers with)
+//   - namespace: Metric namespace (empty for none)
+//   - buckets: Histogram buckets in seconds (nil uses prometheus.DefBuckets)
+//
+// Returns:
+//   - *MetricsHook: Hook to pass to AddHook
+//   - error: Returns an error if the histogram cannot be registered (e.g., already registered)
+//
+// Example:
+//
+//	metrics, err := sql.NewMetricsHook(nil, "app", []float64{0.001, 0.01, 0.1, 1})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer metrics.Unregister()
+//
+//	client.AddHook(metrics)
+//	exporter.Start(":9090", "/metrics", func(err error) { log.Println(err) })
+//
+//	// or with the registry of an exporter.Exporter
+//	metrics, err = sql.NewMetricsHook(e.Registry(), "app", nil)
+func NewMetricsHook(registerer prometheus.Registerer, namespace string, buckets []float64) (*MetricsHook, error) {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "sql",
+		Name:      "query_duration_seconds",
+		Help:      "Duration of database/sql queries in seconds.",
+		Buckets:   buckets,
+	}, []string{"driver", "operation", "status"})
+
+	if err := registerer.Register(duration); err != nil {
+		return nil, err
+	}
+
+	return &MetricsHook{registerer: registerer, duration: duration}, nil
+}
+
+// BeforeQuery does nothing; the duration is taken from the event.
+func (h *MetricsHook) BeforeQuery(ctx context.Context, event *QueryEvent) context.Context {
+	return ctx
+}
+
+// AfterQuery observes the duration of the query.
+func (h *MetricsHook) AfterQuery(ctx context.Context, event *QueryEvent) {
+	status := "success"
+	if event.Failed() {
+		status = "error"
+	}
+
+	h.duration.WithLabelValues(string(event.Driver), string(event.Operation), status).Observe(event.Duration.Seconds())
+}
+
+// Unregister removes the histogram from the registerer given to NewMetricsHook.
+//
+// Returns:
+//   - bool: true if the histogram was unregistered, false otherwise
+func (h *MetricsHook) Unregister() bool {
+	return h.registerer.Unregister(h.duration)
+}
+
+// SlowQueryLogger is the logger used by SlowQueryHook.
+// It is satisfied by *slog.Logger of the standard library and by *slog.Log of
+// github.com/common-library/go/log/slog.
+type SlowQueryLogger interface {
+	Warn(message string, arguments ...any)
+}
+
+// SlowQueryHook is a Hook that logs queries taking longer than a threshold.
+type SlowQueryHook struct {
+	logger    SlowQueryLogger
+	threshold time.Duration
+}
+
+// NewSlowQueryHook creates a Hook that logs queries taking at least threshold at warn level.
+//
+// The log entry holds the driver, operation, query text, duration and error. Bound arguments
+// are redacted: only their count and types are logged, never their values.
+//
+// Parameters:
+//   - logger: Logger receiving the entries (*slog.Logger or *slog.Log)
+//   - threshold: Minimum duration of a query to be logged
+//
+// Returns:
+//   - *SlowQueryHook: Hook to pass to AddHook
+//
+// Example:
+//
+//	client.AddHook(sql.NewSlowQueryHook(slog.Default(), 500*time.Millisecond))
+func NewSlowQueryHook(logger SlowQueryLogger, threshold time.Duration) *SlowQueryHook {
+	return &SlowQueryHook{logger: logger, threshold: threshold}
+}
+
+// BeforeQuery does nothing; the duration is taken from the event.
+func (h *SlowQueryHook) BeforeQuery(ctx context.Context, event *QueryEvent) context.Context {
+	return ctx
+}
+
+// AfterQuery logs the query if it took at least the threshold.
+func (h *SlowQueryHook) AfterQuery(ctx context.Context, event *QueryEvent) {
+	if event.Duration < h.threshold {
+		return
+	}
+
+	arguments := []any{
+		"driver", string(event.Driver),
+		"operation", string(event.Operation),
+		"query", event.Query,
+		"args", redactArguments(event.Args),
+		"duration", event.Duration.String(),
+		"threshold", h.threshold.String(),
+	}
+
+	if event.Err != nil {
+		arguments = append(arguments, "error", event.Err.Error())
+	}
+
+	h.logger.Warn("slow query", arguments...)
+}
+
+func redactArguments(args []any) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = fmt.Sprintf("<redacted %T>", arg)
+	}
+
+	return redacted
+}
+
+// statementKeyword returns the leading keyword of a statement (SELECT, INSERT, ...).
+func statementKeyword(query string) string {
+	query = strings.TrimLeft(query, " \t\r\n(")
+
+	end := strings.IndexFunc(query, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	if end < 0 {
+		end = len(query)
+	}
+
+	return strings.ToUpper(query[:end])
+}
+
+func databaseSystem(driver Driver) string {
+	switch driver {
+	case DriverAmazonDynamoDB:
+		return "aws.dynamodb"
+	case DriverMicrosoftSQLServer:
+		return "microsoft.sql_server"
+	case DriverOracle:
+		return "oracle.db"
+	case DriverPostgreSQL:
+		return "postgresql"
+	default:
+		return string(driver)
+	}
+}
@@ -0,0 +1,196 @@
+package sql_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	sqlclient "github.com/common-library/go/database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdk_trace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type recordingHook struct {
+	name   string
+	calls  *[]string
+	events []sqlclient.QueryEvent
+}
+
+func (h *recordingHook) BeforeQuery(ctx context.Context, event *sqlclient.QueryEvent) context.Context {
+	*h.calls = append(*h.calls, "before "+h.name)
+	return ctx
+}
+
+func (h *recordingHook) AfterQuery(ctx context.Context, event *sqlclient.QueryEvent) {
+	*h.calls = append(*h.calls, "after "+h.name)
+	h.events = append(h.events, *event)
+}
+
+func TestAddHook(t *testing.T) {
+	client := openScanTestClient(t)
+
+	calls := []string{}
+	first := &recordingHook{name: "first", calls: &calls}
+	second := &recordingHook{name: "second", calls: &calls}
+	client.AddHook(first, second)
+
+	require.NoError(t, client.Execute("UPDATE users SET age = ? WHERE id = ?", 31, 1))
+	assert.Equal(t, []string{"before first", "before second", "after second", "after first"}, calls)
+
+	_, err := sqlclient.QueryAll[scanUser](client, "SELECT * FROM users")
+	require.NoError(t, err)
+	assert.Error(t, client.Execute("INSERT INTO missing VALUES (1)"))
+
+	require.NoError(t, client.BeginTransaction())
+	require.NoError(t, client.SetPrepareTransaction("SELECT name FROM users WHERE id = ?"))
+	_, err = client.QueryRowPrepareTransaction(1)
+	require.NoError(t, err)
+	require.NoError(t, client.EndTransaction(nil))
+
+	_, err = client.BulkInsert(context.Background(), "users", []string{"id", "name"}, bulkRows(3))
+	require.NoError(t, err)
+
+	require.Len(t, first.events, 5)
+
+	event := first.events[0]
+	assert.Equal(t, sqlclient.DriverSQLite, event.Driver)
+	assert.Equal(t, sqlclient.OperationExecute, event.Operation)
+	assert.Equal(t, "UPDATE users SET age = ? WHERE id = ?", event.Query)
+	assert.Equal(t, []any{31, 1}, event.Args)
+	assert.False(t, event.Start.IsZero())
+	assert.False(t, event.Failed())
+
+	assert.Equal(t, sqlclient.OperationQuery, first.events[1].Operation)
+	assert.True(t, first.events[2].Failed())
+
+	event = first.events[3]
+	assert.Equal(t, sqlclient.OperationQueryRow, event.Operation)
+	assert.Equal(t, "SELECT name FROM users WHERE id = ?", event.Query)
+	assert.True(t, event.Prepared)
+	assert.True(t, event.Transaction)
+
+	assert.Equal(t, sqlclient.OperationBulkInsert, first.events[4].Operation)
+	assert.Equal(t, "users", first.events[4].Query)
+}
+
+func TestTracingHook(t *testing.T) {
+	client := openScanTestClient(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdk_trace.NewTracerProvider(sdk_trace.WithSpanProcessor(recorder))
+	client.AddHook(sqlclient.NewTracingHook(provider.Tracer("test")))
+
+	_, err := sqlclient.QueryOne[string](client, "SELECT name FROM users WHERE id = ?", 1)
+	require.NoError(t, err)
+	assert.Error(t, client.Execute("DELETE FROM missing"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "SELECT", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.system.name", "sqlite"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.query.text", "SELECT name FROM users WHERE id = ?"))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, "DELETE", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Len(t, spans[1].Events(), 1)
+}
+
+func TestMetricsHook(t *testing.T) {
+	client := openScanTestClient(t)
+
+	hook, err := sqlclient.NewMetricsHook(nil, "hook_test", []float64{0.1, 1})
+	require.NoError(t, err)
+	defer hook.Unregister()
+
+	_, err = sqlclient.NewMetricsHook(nil, "hook_test", nil)
+	assert.Error(t, err)
+
+	client.AddHook(hook)
+
+	require.NoError(t, client.Execute("UPDATE users SET age = age + 1"))
+	require.NoError(t, client.Execute("UPDATE users SET age = age + 1"))
+	assert.Error(t, client.Execute("DELETE FROM missing"))
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	counts := map[string]uint64{}
+	for _, family := range families {
+		if family.GetName() != "hook_test_sql_query_duration_seconds" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			assert.Equal(t, "sqlite", labels["driver"])
+			assert.Equal(t, "execute", labels["operation"])
+			counts[labels["status"]] = metric.GetHistogram().GetSampleCount()
+		}
+	}
+	assert.Equal(t, map[string]uint64{"success": 2, "error": 1}, counts)
+
+	assert.True(t, hook.Unregister())
+	assert.False(t, hook.Unregister())
+}
+
+func TestMetricsHookRegisterer(t *testing.T) {
+	client := openScanTestClient(t)
+	registry := prometheus.NewRegistry()
+
+	hook, err := sqlclient.NewMetricsHook(registry, "hook_registerer_test", nil)
+	require.NoError(t, err)
+
+	client.AddHook(hook)
+	require.NoError(t, client.Execute("UPDATE users SET age = age + 1"))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	assert.Equal(t, "hook_registerer_test_sql_query_duration_seconds", families[0].GetName())
+
+	families, err = prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		assert.NotEqual(t, "hook_registerer_test_sql_query_duration_seconds", family.GetName())
+	}
+
+	assert.True(t, hook.Unregister())
+	families, err = registry.Gather()
+	require.NoError(t, err)
+	assert.Empty(t, families)
+}
+
+func TestSlowQueryHook(t *testing.T) {
+	client := openScanTestClient(t)
+
+	buffer := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buffer, nil))
+
+	client.AddHook(sqlclient.NewSlowQueryHook(logger, time.Hour))
+	require.NoError(t, client.Execute("UPDATE users SET name = ? WHERE id = ?", "secret-name", 1))
+	assert.Empty(t, buffer.String())
+
+	client.AddHook(sqlclient.NewSlowQueryHook(logger, 0))
+	require.NoError(t, client.Execute("UPDATE users SET name = ? WHERE id = ?", "secret-name", 1))
+
+	output := buffer.String()
+	assert.Contains(t, output, "level=WARN")
+	assert.Contains(t, output, `msg="slow query"`)
+	assert.Contains(t, output, "UPDATE users SET name = ? WHERE id = ?")
+	assert.Contains(t, output, "<redacted string>")
+	assert.Contains(t, output, "<redacted int>")
+	assert.NotContains(t, output, "secret-name")
+}
@@ -0,0 +1,294 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BindNamed rewrites a query containing :name parameters into the placeholder style of the driver.
+//
+// Placeholders are rewritten to ? for MySQL, SQLite, ClickHouse and DynamoDB, to $1, $2, ...
+// for PostgreSQL, to @p1, @p2, ... for SQL Server and to :1, :2, ... for Oracle.
+// Parameters inside quoted strings, quoted identifiers and comments are left untouched,
+// as are PostgreSQL casts such as ::text and dollar-quoted strings ($$...$$, $tag$...$tag$).
+// For MySQL and ClickHouse, quotes escaped with a backslash (\') do not end a string.
+// A parameter used more than once is bound once per occurrence.
+//
+// Parameters:
+//   - driver: Database driver whose placeholder style is used
+//   - query: SQL query string with :name parameters
+//   - arg: map[string]any or a struct (or pointer to struct) whose fields are matched by `db` tag
+//
+// Returns:
+//   - string: Query rewritten with driver placeholders
+//   - []any: Arguments in placeholder order
+//   - error: Returns an error if a parameter has no value in arg
+//
+// Example:
+//
+//	query, args, err := sql.BindNamed(
+//		sql.DriverPostgreSQL,
+//		"SELECT id FROM users WHERE name = :name AND age > :age",
+//		map[string]any{"name": "Alice", "age": 18},
+//	)
+//	// query: SELECT id FROM users WHERE name = $1 AND age > $2
+//	// args:  []any{"Alice", 18}
+func BindNamed(driver Driver, query string, arg any) (string, []any, error) {
+	lookup, err := namedLookup(arg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	builder := strings.Builder{}
+	args := []any{}
+
+	for i := 0; i < len(query); {
+		switch ch := query[i]; {
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := quoteEnd(driver, query, i)
+			builder.WriteString(query[i:end])
+			i = end
+		case ch == '$' && driver == DriverPostgreSQL:
+			end, ok := dollarQuoteEnd(query, i)
+			if !ok {
+				end = i + 1
+			}
+			builder.WriteString(query[i:end])
+			i = end
+		case ch == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			builder.WriteString(query[i : i+end])
+			i += end
+		case ch == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			builder.WriteString(query[i : i+end])
+			i += end
+		case ch == ':' && strings.HasPrefix(query[i:], "::"):
+			builder.WriteString("::")
+			i += 2
+		case ch == ':' && i+1 < len(query) && isNameStart(query[i+1]):
+			end := i + 1
+			for end < len(query) && isNamePart(query[end]) {
+				end++
+			}
+
+			name := query[i+1 : end]
+			value, ok := lookup(name)
+			if !ok {
+				return "", nil, errors.New("missing value for named parameter : " + name)
+			}
+
+			args = append(args, value)
+			builder.WriteString(placeholder(driver, len(args)))
+			i = end
+		default:
+			builder.WriteByte(ch)
+			i++
+		}
+	}
+
+	return builder.String(), args, nil
+}
+
+// quoteEnd returns the index after the quote closing the one at start, or the length of query if
+// it is not closed. MySQL and ClickHouse also escape quotes in strings with a backslash.
+func quoteEnd(driver Driver, query string, start int) int {
+	quote := query[start]
+	backslash := quote != '`' && (driver == DriverMySQL || driver == DriverClickHouse)
+
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+
+	return len(query)
+}
+
+// dollarQuoteEnd returns the index after the PostgreSQL dollar-quoted string ($$...$$ or
+// $tag$...$tag$) starting at start, or the length of query if it is not closed. It returns false
+// if no dollar quote starts at start, as for the $1 placeholder.
+func dollarQuoteEnd(query string, start int) (int, bool) {
+	if start > 0 && isNamePart(query[start-1]) {
+		return 0, false
+	}
+
+	end := strings.IndexByte(query[start+1:], '$')
+	if end < 0 {
+		return 0, false
+	}
+
+	tag := query[start : start+end+2]
+	for i := 1; i < len(tag)-1; i++ {
+		if !isNamePart(tag[i]) || (i == 1 && !isNameStart(tag[i])) {
+			return 0, false
+		}
+	}
+
+	closing := strings.Index(query[start+len(tag):], tag)
+	if closing < 0 {
+		return len(query), true
+	}
+
+	return start + len(tag) + closing + len(tag), true
+}
+
+// QueryNamed executes a query with :name parameters and returns the result rows.
+// The caller is responsible for closing the returned rows.
+//
+// Parameters:
+//   - query: SQL query string with :name parameters
+//   - arg: map[string]any or a struct whose fields are matched by `db` tag
+//
+// Returns:
+//   - *sql.Rows: Result set that can be iterated
+//   - error: Returns an error if binding or the query fails
+//
+// Example:
+//
+//	rows, err := client.QueryNamed("SELECT id, name FROM users WHERE age > :age", map[string]any{"age": 18})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer rows.Close()
+func (c *Client) QueryNamed(query string, arg any) (*sql.Rows, error) {
+	if c.connection == nil {
+		return nil, errors.New("please call Open first")
+	}
+
+	if query, args, err := BindNamed(c.driver, query, arg); err != nil {
+		return nil, err
+	} else {
+		return c.Query(query, args...)
+	}
+}
+
+// ExecuteNamed executes a SQL statement with :name parameters that doesn't return rows.
+//
+// Parameters:
+//   - query: SQL statement string with :name parameters
+//   - arg: map[string]any or a struct whose fields are matched by `db` tag
+//
+// Returns:
+//   - error: Returns an error if binding or the statement execution fails
+//
+// Example:
+//
+//	user := User{Name: "Alice", Age: 30}
+//	err := client.ExecuteNamed("INSERT INTO users (name, age) VALUES (:name, :age)", user)
+func (c *Client) ExecuteNamed(query string, arg any) error {
+	if c.connection == nil {
+		return errors.New("please call Open first")
+	}
+
+	if query, args, err := BindNamed(c.driver, query, arg); err != nil {
+		return err
+	} else {
+		return c.Execute(query, args...)
+	}
+}
+
+// QueryAllNamed is QueryAll with :name parameters bound from arg.
+//
+// Example:
+//
+//	users, err := sql.QueryAllNamed[User](&client, "SELECT id, name FROM users WHERE age > :age", map[string]any{"age": 18})
+func QueryAllNamed[T any](client *Client, query string, arg any) ([]T, error) {
+	if query, args, err := BindNamed(client.GetDriver(), query, arg); err != nil {
+		return nil, err
+	} else {
+		return QueryAll[T](client, query, args...)
+	}
+}
+
+// QueryOneNamed is QueryOne with :name parameters bound from arg.
+//
+// Example:
+//
+//	user, err := sql.QueryOneNamed[User](&client, "SELECT id, name FROM users WHERE id = :id", map[string]any{"id": 1})
+func QueryOneNamed[T any](client *Client, query string, arg any) (T, error) {
+	if query, args, err := BindNamed(client.GetDriver(), query, arg); err != nil {
+		var result T
+		return result, err
+	} else {
+		return QueryOne[T](client, query, args...)
+	}
+}
+
+func placeholder(driver Driver, position int) string {
+	switch driver {
+	case DriverPostgreSQL:
+		return "$" + strconv.Itoa(position)
+	case DriverMicrosoftSQLServer:
+		return "@p" + strconv.Itoa(position)
+	case DriverOracle:
+		return ":" + strconv.Itoa(position)
+	default:
+		return "?"
+	}
+}
+
+func namedLookup(arg any) (func(string) (any, bool), error) {
+	if arg == nil {
+		return func(string) (any, bool) { return nil, false }, nil
+	}
+
+	if values, ok := arg.(map[string]any); ok {
+		return func(name string) (any, bool) {
+			value, ok := values[name]
+			return value, ok
+		}, nil
+	}
+
+	value := reflect.ValueOf(arg)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, errors.New("named argument is a nil pointer")
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, errors.New("named argument must be a map[string]any or a struct")
+	}
+
+	indexes := fieldIndexes(value.Type())
+
+	return func(name string) (any, bool) {
+		index, ok := indexes[strings.ToLower(name)]
+		if !ok {
+			return nil, false
+		}
+
+		field, err := value.FieldByIndexErr(index)
+		if err != nil {
+			return nil, true
+		}
+
+		return field.Interface(), true
+	}, nil
+}
+
+func isNameStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isNamePart(ch byte) bool {
+	return isNameStart(ch) || (ch >= '0' && ch <= '9')
+}
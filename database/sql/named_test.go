@@ -0,0 +1,122 @@
+package sql_test
+
+import (
+	"testing"
+
+	sqlclient "github.com/common-library/go/database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindNamed(t *testing.T) {
+	query := "SELECT id FROM users WHERE name = :name AND (age > :age OR age IS NULL) AND name <> :name"
+	arg := map[string]any{"name": "Alice", "age": 18}
+
+	tests := []struct {
+		driver   sqlclient.Driver
+		expected string
+	}{
+		{sqlclient.DriverMySQL, "SELECT id FROM users WHERE name = ? AND (age > ? OR age IS NULL) AND name <> ?"},
+		{sqlclient.DriverSQLite, "SELECT id FROM users WHERE name = ? AND (age > ? OR age IS NULL) AND name <> ?"},
+		{sqlclient.DriverClickHouse, "SELECT id FROM users WHERE name = ? AND (age > ? OR age IS NULL) AND name <> ?"},
+		{sqlclient.DriverAmazonDynamoDB, "SELECT id FROM users WHERE name = ? AND (age > ? OR age IS NULL) AND name <> ?"},
+		{sqlclient.DriverPostgreSQL, "SELECT id FROM users WHERE name = $1 AND (age > $2 OR age IS NULL) AND name <> $3"},
+		{sqlclient.DriverMicrosoftSQLServer, "SELECT id FROM users WHERE name = @p1 AND (age > @p2 OR age IS NULL) AND name <> @p3"},
+		{sqlclient.DriverOracle, "SELECT id FROM users WHERE name = :1 AND (age > :2 OR age IS NULL) AND name <> :3"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.driver), func(t *testing.T) {
+			bound, args, err := sqlclient.BindNamed(test.driver, query, arg)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, bound)
+			assert.Equal(t, []any{"Alice", 18, "Alice"}, args)
+		})
+	}
+}
+
+func TestBindNamedSkipsLiterals(t *testing.T) {
+	query := `SELECT ':skip', "col:skip", id::text /* :skip */ FROM t -- :skip
+WHERE a = :a`
+
+	bound, args, err := sqlclient.BindNamed(sqlclient.DriverPostgreSQL, query, map[string]any{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT ':skip', "col:skip", id::text /* :skip */ FROM t -- :skip
+WHERE a = $1`, bound)
+	assert.Equal(t, []any{1}, args)
+}
+
+func TestBindNamedSkipsBackslashEscapes(t *testing.T) {
+	query := `SELECT 'it\'s :skip', "say \":skip\"", '\\' FROM t WHERE a = :a`
+
+	for _, driver := range []sqlclient.Driver{sqlclient.DriverMySQL, sqlclient.DriverClickHouse} {
+		bound, args, err := sqlclient.BindNamed(driver, query, map[string]any{"a": 1})
+		require.NoError(t, err)
+		assert.Equal(t, `SELECT 'it\'s :skip', "say \":skip\"", '\\' FROM t WHERE a = ?`, bound)
+		assert.Equal(t, []any{1}, args)
+	}
+
+	bound, args, err := sqlclient.BindNamed(sqlclient.DriverPostgreSQL, `SELECT 'C:\' FROM t WHERE a = :a`, map[string]any{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT 'C:\' FROM t WHERE a = $1`, bound)
+	assert.Equal(t, []any{1}, args)
+}
+
+func TestBindNamedSkipsDollarQuotes(t *testing.T) {
+	query := `SELECT $$it's :skip$$, $fn$ BEGIN RETURN ':skip'; END $fn$, a$b FROM t WHERE a = :a AND b = $1`
+
+	bound, args, err := sqlclient.BindNamed(sqlclient.DriverPostgreSQL, query, map[string]any{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT $$it's :skip$$, $fn$ BEGIN RETURN ':skip'; END $fn$, a$b FROM t WHERE a = $1 AND b = $1`, bound)
+	assert.Equal(t, []any{1}, args)
+
+	bound, args, err = sqlclient.BindNamed(sqlclient.DriverMySQL, "SELECT $$ :a $$", map[string]any{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT $$ ? $$", bound)
+	assert.Equal(t, []any{1}, args)
+}
+
+func TestBindNamedStruct(t *testing.T) {
+	user := scanUser{ScanAudit: ScanAudit{CreatedBy: "admin"}, ID: 7, Name: "Alice"}
+
+	bound, args, err := sqlclient.BindNamed(sqlclient.DriverMySQL, "INSERT INTO users (id, name, created_by) VALUES (:id, :name, :created_by)", &user)
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id, name, created_by) VALUES (?, ?, ?)", bound)
+	assert.Equal(t, []any{7, "Alice", "admin"}, args)
+
+	_, _, err = sqlclient.BindNamed(sqlclient.DriverMySQL, "SELECT :unknown", user)
+	assert.Error(t, err)
+
+	_, _, err = sqlclient.BindNamed(sqlclient.DriverMySQL, "SELECT :id", 1)
+	assert.Error(t, err)
+}
+
+func TestNamedQueries(t *testing.T) {
+	client := openScanTestClient(t)
+
+	require.NoError(t, client.ExecuteNamed(
+		"INSERT INTO users (id, name, email) VALUES (:id, :name, :email)",
+		map[string]any{"id": 3, "name": "Carol", "email": "carol@example.com"},
+	))
+
+	rows, err := client.QueryNamed("SELECT name FROM users WHERE id = :id", map[string]any{"id": 3})
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	var name string
+	require.NoError(t, rows.Scan(&name))
+	assert.Equal(t, "Carol", name)
+	require.NoError(t, rows.Close())
+
+	users, err := sqlclient.QueryAllNamed[scanUser](client, "SELECT id, name FROM users WHERE id >= :id ORDER BY id", map[string]any{"id": 2})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "Bob", users[0].Name)
+
+	user, err := sqlclient.QueryOneNamed[scanUser](client, "SELECT id, name, email FROM users WHERE name = :name", scanUser{Name: "Carol"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, user.ID)
+
+	_, err = (&sqlclient.Client{}).QueryNamed("SELECT 1", nil)
+	assert.Error(t, err)
+	assert.Error(t, (&sqlclient.Client{}).ExecuteNamed("SELECT 1", nil))
+}
@@ -0,0 +1,136 @@
+package sql_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	sqlclient "github.com/common-library/go/database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openReplicaTestClient(t *testing.T) *sqlclient.Client {
+	t.Helper()
+
+	primaryDSN := filepath.Join(t.TempDir(), "primary.db")
+	replicaDSN := filepath.Join(t.TempDir(), "replica.db")
+
+	for dsn, name := range map[string]string{primaryDSN: "primary", replicaDSN: "replica"} {
+		client := &sqlclient.Client{}
+		require.NoError(t, client.Open(sqlclient.DriverSQLite, dsn, 1))
+		require.NoError(t, client.Execute("CREATE TABLE source (name TEXT NOT NULL)"))
+		require.NoError(t, client.Execute("INSERT INTO source (name) VALUES (?)", name))
+		require.NoError(t, client.Close())
+	}
+
+	client := &sqlclient.Client{}
+	require.NoError(t, client.OpenWithReplicas(sqlclient.DriverSQLite, primaryDSN, []string{replicaDSN}, 1))
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestOpenWithReplicasRouting(t *testing.T) {
+	client := openReplicaTestClient(t)
+
+	var name string
+	require.NoError(t, client.QueryRow("SELECT name FROM source", &name))
+	assert.Equal(t, "replica", name)
+
+	names, err := sqlclient.QueryAll[string](client, "SELECT name FROM source")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"replica"}, names)
+
+	require.NoError(t, client.Execute("INSERT INTO source (name) VALUES (?)", "written"))
+	names, err = sqlclient.QueryAll[string](client, "SELECT name FROM source")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"replica"}, names)
+
+	require.NoError(t, client.QueryRowPrimary("SELECT COUNT(*) FROM source", &name))
+	assert.Equal(t, "2", name)
+
+	rows, err := client.QueryPrimary("INSERT INTO source (name) VALUES (?) RETURNING name", "returned")
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&name))
+	require.NoError(t, rows.Close())
+	assert.Equal(t, "returned", name)
+
+	require.NoError(t, client.BeginTransaction())
+	require.NoError(t, client.QueryRowTransaction("SELECT COUNT(*) FROM source", &name))
+	require.NoError(t, client.EndTransaction(nil))
+	assert.Equal(t, "3", name)
+
+	healthy, lags := client.GetReplicaStatus()
+	assert.Equal(t, []bool{true}, healthy)
+	assert.Equal(t, []time.Duration{0}, lags)
+}
+
+func TestOpenWithUnreachableReplica(t *testing.T) {
+	primaryDSN := filepath.Join(t.TempDir(), "primary.db")
+	missingDSN := "file:" + filepath.Join(t.TempDir(), "missing", "replica.db") + "?mode=ro"
+
+	client := &sqlclient.Client{}
+	require.NoError(t, client.OpenWithReplicas(sqlclient.DriverSQLite, primaryDSN, []string{missingDSN}, 1))
+	defer client.Close()
+
+	healthy, _ := client.GetReplicaStatus()
+	assert.Equal(t, []bool{false}, healthy)
+
+	count, err := sqlclient.QueryOne[int](client, "SELECT 1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestReplicaStaysHealthyOnLockErrors(t *testing.T) {
+	primaryDSN := filepath.Join(t.TempDir(), "primary.db")
+	replicaDSN := filepath.Join(t.TempDir(), "replica.db")
+
+	locker := &sqlclient.Client{}
+	require.NoError(t, locker.Open(sqlclient.DriverSQLite, replicaDSN, 1))
+	defer locker.Close()
+	require.NoError(t, locker.Execute("CREATE TABLE source (name TEXT NOT NULL)"))
+
+	client := &sqlclient.Client{}
+	require.NoError(t, client.OpenWithReplicas(sqlclient.DriverSQLite, primaryDSN, []string{replicaDSN}, 1))
+	defer client.Close()
+
+	healthy, _ := client.GetReplicaStatus()
+	require.Equal(t, []bool{true}, healthy)
+
+	require.NoError(t, locker.Execute("PRAGMA locking_mode = EXCLUSIVE"))
+	require.NoError(t, locker.Execute("INSERT INTO source (name) VALUES (?)", "locked"))
+
+	_, err := sqlclient.QueryAll[string](client, "SELECT name FROM source")
+	require.Error(t, err)
+	assert.True(t, sqlclient.IsTransientError(sqlclient.DriverSQLite, err))
+
+	healthy, _ = client.GetReplicaStatus()
+	assert.Equal(t, []bool{true}, healthy)
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := openReplicaTestClient(t)
+
+	assert.Error(t, client.StartHealthCheck(0, 0))
+	require.NoError(t, client.StartHealthCheck(10*time.Millisecond, time.Second))
+	require.NoError(t, client.StartHealthCheck(10*time.Millisecond, time.Second))
+
+	time.Sleep(50 * time.Millisecond)
+
+	healthy, _ := client.GetReplicaStatus()
+	assert.Equal(t, []bool{true}, healthy)
+
+	require.NoError(t, client.Close())
+	require.NoError(t, client.Close())
+}
+
+func TestSetConnectionPool(t *testing.T) {
+	client := &sqlclient.Client{}
+	assert.Error(t, client.SetConnectionPool(1, time.Minute, time.Minute))
+	assert.Error(t, client.StartHealthCheck(time.Second, 0))
+
+	client = openReplicaTestClient(t)
+	assert.NoError(t, client.SetConnectionPool(1, time.Minute, time.Minute))
+}
@@ -0,0 +1,261 @@
+package sql
+
+import (
+	sql_driver "database/sql/driver"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/sijms/go-ora/network"
+	"modernc.org/sqlite"
+)
+
+var (
+	mysqlTransientNumbers = []uint16{
+		1205, // ER_LOCK_WAIT_TIMEOUT
+		1213, // ER_LOCK_DEADLOCK
+	}
+
+	postgresqlTransientCodes = []pq.ErrorCode{
+		"40001", // serialization_failure
+		"40P01", // deadlock_detected
+		"55P03", // lock_not_available
+		"57P01", // admin_shutdown
+		"57P02", // crash_shutdown
+		"57P03", // cannot_connect_now
+	}
+
+	sqlServerTransientNumbers = []int32{
+		1205,  // deadlock victim
+		1222,  // lock request time out
+		40197, // service error processing request
+		40501, // service busy
+		40613, // database unavailable
+		49918, // not enough resources
+		49919, // too many create or update operations
+		49920, // too many operations
+	}
+
+	oracleTransientCodes = []int{
+		54,    // resource busy and acquire with NOWAIT
+		60,    // deadlock detected
+		3113,  // end-of-file on communication channel
+		3114,  // not connected to ORACLE
+		3135,  // connection lost contact
+		8177,  // can't serialize access for this transaction
+		12170, // connect timeout occurred
+		12541, // no listener
+	}
+
+	sqliteTransientCodes = []int{
+		5, // SQLITE_BUSY
+		6, // SQLITE_LOCKED
+	}
+
+	clickHouseTransientCodes = []int32{
+		159, // TIMEOUT_EXCEEDED
+		202, // TOO_MANY_SIMULTANEOUS_QUERIES
+		209, // SOCKET_TIMEOUT
+		210, // NETWORK_ERROR
+		252, // TOO_MANY_PARTS
+	}
+
+	dynamoDBTransientCodes = []string{
+		"InternalServerError",
+		"ProvisionedThroughputExceededException",
+		"RequestLimitExceeded",
+		"ServiceUnavailable",
+		"ThrottlingException",
+		"TransactionConflictException",
+	}
+)
+
+// IsTransientError reports whether err is a transient error that may succeed when retried.
+//
+// Connection level failures (bad connections, resets, broken pipes, network errors and
+// timeouts) are transient for every driver. In addition each driver classifies its own error codes:
+//   - MySQL: lock wait timeout (1205), deadlock (1213), invalid connection
+//   - PostgreSQL: serialization failure (40001), deadlock (40P01), lock not available (55P03),
+//     connection exceptions (class 08) and server shutdown (57P01, 57P02, 57P03)
+//   - SQL Server: deadlock victim (1205), lock timeout (1222) and Azure SQL transient errors
+//   - Oracle: deadlock (ORA-00060), serialization failure (ORA-08177), resource busy (ORA-00054)
+//     and lost connections
+//   - SQLite: SQLITE_BUSY and SQLITE_LOCKED
+//   - ClickHouse: timeouts, network errors, too many simultaneous queries and too many parts
+//   - DynamoDB: throttling, transaction conflicts and service unavailability
+//
+// Parameters:
+//   - driver: Database driver that produced the error
+//   - err: Error to classify
+//
+// Returns:
+//   - bool: true if the operation may be retried, false otherwise
+//
+// Example:
+//
+//	err := client.Execute("UPDATE accounts SET balance = balance - ? WHERE id = ?", 100, 1)
+//	if sql.IsTransientError(client.GetDriver(), err) {
+//		// retry later
+//	}
+func IsTransientError(driver Driver, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if isConnectionError(err) {
+		return true
+	}
+
+	switch driver {
+	case DriverMySQL:
+		if mysqlError := (*mysql.MySQLError)(nil); errors.As(err, &mysqlError) {
+			return slices.Contains(mysqlTransientNumbers, mysqlError.Number)
+		}
+	case DriverPostgreSQL:
+		if pqError := (*pq.Error)(nil); errors.As(err, &pqError) {
+			return slices.Contains(postgresqlTransientCodes, pqError.Code)
+		}
+	case DriverMicrosoftSQLServer:
+		if mssqlError := (mssql.Error{}); errors.As(err, &mssqlError) {
+			return slices.Contains(sqlServerTransientNumbers, mssqlError.Number)
+		}
+	case DriverOracle:
+		if oracleError := (*network.OracleError)(nil); errors.As(err, &oracleError) {
+			return slices.Contains(oracleTransientCodes, oracleError.ErrCode)
+		}
+	case DriverSQLite:
+		if sqliteError := (*sqlite.Error)(nil); errors.As(err, &sqliteError) {
+			return slices.Contains(sqliteTransientCodes, sqliteError.Code()&0xff)
+		}
+	case DriverClickHouse:
+		if exception := (*clickhouse.Exception)(nil); errors.As(err, &exception) {
+			return slices.Contains(clickHouseTransientCodes, exception.Code)
+		}
+	case DriverAmazonDynamoDB:
+		if apiError := (interface{ ErrorCode() string })(nil); errors.As(err, &apiError) {
+			return slices.Contains(dynamoDBTransientCodes, apiError.ErrorCode())
+		}
+	}
+
+	return false
+}
+
+// isConnectionError reports whether err is a connection level failure, which is transient for
+// every driver and takes a replica out of the read rotation.
+func isConnectionError(err error) bool {
+	if errors.Is(err, sql_driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	if netError := net.Error(nil); errors.As(err, &netError) {
+		return true
+	}
+
+	if pqError := (*pq.Error)(nil); errors.As(err, &pqError) {
+		return pqError.Code.Class() == "08"
+	}
+
+	return false
+}
+
+// SetRetry configures retrying of transient errors.
+//
+// Query, QueryRow, Execute (and the functions built on them such as QueryAll and ExecuteNamed)
+// and RunTransaction are retried while IsTransientError reports true, waiting an exponentially
+// growing, jittered backoff between attempts. Retrying is disabled until SetRetry is called.
+//
+// Retried statements may run more than once if a connection is lost after the server applied
+// them, so only enable retries for statements that are safe to repeat or run them through RunTransaction.
+//
+// Parameters:
+//   - maxAttempts: Maximum number of attempts including the first one (1 disables retrying)
+//   - initialBackoff: Wait before the second attempt
+//   - maxBackoff: Upper bound of the wait between attempts
+//
+// Example:
+//
+//	client.SetRetry(5, 50*time.Millisecond, 2*time.Second)
+func (c *Client) SetRetry(maxAttempts int, initialBackoff, maxBackoff time.Duration) {
+	c.retryMaxAttempts = maxAttempts
+	c.retryInitialBackoff = initialBackoff
+	c.retryMaxBackoff = maxBackoff
+}
+
+// RunTransaction runs fn inside a transaction, retrying the whole transaction on transient errors.
+//
+// The transaction is begun before fn is called and committed if fn returns nil, otherwise rolled back.
+// Inside fn use the *Transaction methods (ExecuteTransaction, QueryTransaction, ...).
+// If fn or the commit fails with a transient error such as a deadlock or serialization failure,
+// the transaction is retried according to SetRetry.
+//
+// Parameters:
+//   - fn: Function executing the statements of the transaction
+//
+// Returns:
+//   - error: Error returned by fn, or an error if beginning or committing the transaction fails
+//
+// Example:
+//
+//	err := client.RunTransaction(func() error {
+//		if err := client.ExecuteTransaction("UPDATE accounts SET balance = balance - ? WHERE id = ?", 100, 1); err != nil {
+//			return err
+//		}
+//		return client.ExecuteTransaction("UPDATE accounts SET balance = balance + ? WHERE id = ?", 100, 2)
+//	})
+func (c *Client) RunTransaction(fn func() error) error {
+	if c.connection == nil {
+		return errors.New("please call Open first")
+	}
+
+	return c.withRetry(func() error {
+		if err := c.BeginTransaction(); err != nil {
+			return err
+		}
+
+		err := fn()
+		if endErr := c.EndTransaction(err); err == nil {
+			err = endErr
+		}
+
+		return err
+	})
+}
+
+func (c *Client) withRetry(fn func() error) error {
+	err := fn()
+
+	for attempt := 1; attempt < c.retryMaxAttempts && IsTransientError(c.driver, err); attempt++ {
+		time.Sleep(c.retryBackoff(attempt))
+
+		err = fn()
+	}
+
+	return err
+}
+
+func (c *Client) retryBackoff(attempt int) time.Duration {
+	if c.retryInitialBackoff <= 0 {
+		return 0
+	}
+
+	backoff := c.retryInitialBackoff << min(attempt-1, 30)
+	if c.retryMaxBackoff > 0 && (backoff <= 0 || backoff > c.retryMaxBackoff) {
+		backoff = c.retryMaxBackoff
+	}
+
+	return backoff/2 + rand.N(backoff/2+1)
+}
@@ -0,0 +1,111 @@
+package sql_test
+
+import (
+	sql_driver "database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	sqlclient "github.com/common-library/go/database/sql"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/sijms/go-ora/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type apiError struct {
+	code string
+}
+
+func (e apiError) Error() string     { return e.code }
+func (e apiError) ErrorCode() string { return e.code }
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name      string
+		driver    sqlclient.Driver
+		err       error
+		transient bool
+	}{
+		{"nil", sqlclient.DriverMySQL, nil, false},
+		{"bad connection", sqlclient.DriverSQLite, sql_driver.ErrBadConn, true},
+		{"connection reset", sqlclient.DriverPostgreSQL, fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{"network error", sqlclient.DriverSQLite, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route to host")}, true},
+		{"plain error", sqlclient.DriverMySQL, errors.New("syntax error"), false},
+		{"mysql deadlock", sqlclient.DriverMySQL, &mysql.MySQLError{Number: 1213}, true},
+		{"mysql lock wait timeout", sqlclient.DriverMySQL, &mysql.MySQLError{Number: 1205}, true},
+		{"mysql duplicate entry", sqlclient.DriverMySQL, &mysql.MySQLError{Number: 1062}, false},
+		{"mysql invalid connection", sqlclient.DriverMySQL, mysql.ErrInvalidConn, true},
+		{"postgresql serialization failure", sqlclient.DriverPostgreSQL, &pq.Error{Code: "40001"}, true},
+		{"postgresql deadlock", sqlclient.DriverPostgreSQL, &pq.Error{Code: "40P01"}, true},
+		{"postgresql connection failure", sqlclient.DriverPostgreSQL, &pq.Error{Code: "08006"}, true},
+		{"postgresql unique violation", sqlclient.DriverPostgreSQL, &pq.Error{Code: "23505"}, false},
+		{"sqlserver deadlock", sqlclient.DriverMicrosoftSQLServer, mssql.Error{Number: 1205}, true},
+		{"sqlserver constraint", sqlclient.DriverMicrosoftSQLServer, mssql.Error{Number: 2627}, false},
+		{"oracle deadlock", sqlclient.DriverOracle, &network.OracleError{ErrCode: 60}, true},
+		{"oracle serialization failure", sqlclient.DriverOracle, &network.OracleError{ErrCode: 8177}, true},
+		{"oracle unique constraint", sqlclient.DriverOracle, &network.OracleError{ErrCode: 1}, false},
+		{"clickhouse network error", sqlclient.DriverClickHouse, &clickhouse.Exception{Code: 210}, true},
+		{"clickhouse syntax error", sqlclient.DriverClickHouse, &clickhouse.Exception{Code: 62}, false},
+		{"dynamodb throttling", sqlclient.DriverAmazonDynamoDB, fmt.Errorf("wrapped: %w", apiError{"ThrottlingException"}), true},
+		{"dynamodb validation", sqlclient.DriverAmazonDynamoDB, apiError{"ValidationException"}, false},
+		{"mismatched driver", sqlclient.DriverPostgreSQL, &mysql.MySQLError{Number: 1213}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.transient, sqlclient.IsTransientError(test.driver, test.err))
+		})
+	}
+}
+
+func TestRunTransaction(t *testing.T) {
+	client := openScanTestClient(t)
+
+	attempts := 0
+	err := client.RunTransaction(func() error {
+		attempts++
+		if err := client.ExecuteTransaction("INSERT INTO users (id, name) VALUES (?, ?)", 10+attempts, "retry"); err != nil {
+			return err
+		}
+		return sql_driver.ErrBadConn
+	})
+	assert.ErrorIs(t, err, sql_driver.ErrBadConn)
+	assert.Equal(t, 1, attempts)
+
+	client.SetRetry(3, time.Millisecond, 5*time.Millisecond)
+
+	attempts = 0
+	err = client.RunTransaction(func() error {
+		attempts++
+		if err := client.ExecuteTransaction("INSERT INTO users (id, name) VALUES (?, ?)", 20+attempts, "retry"); err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return sql_driver.ErrBadConn
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	names, err := sqlclient.QueryAll[int](client, "SELECT id FROM users WHERE name = ? ORDER BY id", "retry")
+	require.NoError(t, err)
+	assert.Equal(t, []int{23}, names)
+
+	attempts = 0
+	err = client.RunTransaction(func() error {
+		attempts++
+		return errors.New("permanent")
+	})
+	assert.EqualError(t, err, "permanent")
+	assert.Equal(t, 1, attempts)
+
+	assert.Error(t, (&sqlclient.Client{}).RunTransaction(func() error { return nil }))
+}
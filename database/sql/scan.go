@@ -0,0 +1,287 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrNoRows is returned by QueryOne and QueryOneNamed when the query returns no rows.
+var ErrNoRows = sql.ErrNoRows
+
+var fieldIndexCache sync.Map
+
+var scannerType = reflect.TypeFor[sql.Scanner]()
+
+// QueryAll executes a query and scans every result row into a value of type T.
+//
+// When T is a struct, columns are mapped to fields by their `db` tag. Fields without a tag
+// are matched by their lower-cased name, fields tagged `db:"-"` are ignored and embedded
+// structs are flattened into the parent. As with promoted fields in encoding/json, a shallower
+// field wins over a deeper one and fields of the same name at the same depth are ambiguous
+// unless exactly one of them is tagged. Fields may be of any type supported by Scan, including
+// sql.NullString, sql.NullInt64 and other sql.Scanner implementations.
+// When T is not a struct (or implements sql.Scanner), the query must return a single column.
+//
+// Parameters:
+//   - client: Client opened with Open
+//   - query: SQL query string (use the driver's placeholder style)
+//   - args: Optional query parameters
+//
+// Returns:
+//   - []T: Scanned rows, empty if the query returns no rows
+//   - error: Returns an error if the query fails or a column has no matching field
+//
+// Example:
+//
+//	type User struct {
+//		ID    int            `db:"id"`
+//		Name  string         `db:"name"`
+//		Email sql.NullString `db:"email"`
+//	}
+//
+//	users, err := sql.QueryAll[User](&client, "SELECT id, name, email FROM users WHERE age > ?", 18)
+func QueryAll[T any](client *Client, query string, args ...any) ([]T, error) {
+	rows, err := client.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAll[T](rows)
+}
+
+// QueryOne executes a query and scans the first result row into a value of type T.
+//
+// Column mapping follows the same rules as QueryAll.
+//
+// Parameters:
+//   - client: Client opened with Open
+//   - query: SQL query string (use the driver's placeholder style)
+//   - args: Optional query parameters
+//
+// Returns:
+//   - T: Scanned row
+//   - error: Returns ErrNoRows if the query returns no rows, or an error if the query or scan fails
+//
+// Example:
+//
+//	user, err := sql.QueryOne[User](&client, "SELECT id, name, email FROM users WHERE id = ?", 1)
+//	if errors.Is(err, sql.ErrNoRows) {
+//		// not found
+//	}
+func QueryOne[T any](client *Client, query string, args ...any) (T, error) {
+	var result T
+
+	rows, err := client.Query(query, args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return result, err
+		}
+
+		return result, ErrNoRows
+	}
+
+	if columns, err := rows.Columns(); err != nil {
+		return result, err
+	} else if destinations, err := scanDestinations(reflect.ValueOf(&result).Elem(), columns); err != nil {
+		return result, err
+	} else if err := rows.Scan(destinations...); err != nil {
+		return result, err
+	}
+
+	return result, rows.Close()
+}
+
+func scanAll[T any](rows *sql.Rows) ([]T, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []T{}
+	for rows.Next() {
+		var result T
+
+		if destinations, err := scanDestinations(reflect.ValueOf(&result).Elem(), columns); err != nil {
+			return nil, err
+		} else if err := rows.Scan(destinations...); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+func scanDestinations(value reflect.Value, columns []string) ([]any, error) {
+	if !isStructTarget(value.Type()) {
+		if len(columns) != 1 {
+			return nil, errors.New("non-struct destination requires exactly one column")
+		}
+
+		return []any{value.Addr().Interface()}, nil
+	}
+
+	indexes := fieldIndexes(value.Type())
+
+	destinations := make([]any, len(columns))
+	for i, column := range columns {
+		index, ok := indexes[strings.ToLower(column)]
+		if !ok {
+			return nil, errors.New("missing destination field for column : " + column)
+		}
+
+		destinations[i] = fieldByIndex(value, index).Addr().Interface()
+	}
+
+	return destinations, nil
+}
+
+func isStructTarget(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(scannerType)
+}
+
+// fieldIndexes returns the column name to field index mapping of a struct type.
+//
+// Names are resolved the way encoding/json resolves promoted fields: a shallower field takes
+// precedence over a deeper one, and fields of the same name at the same depth are ambiguous and
+// left unmapped unless exactly one of them is tagged.
+func fieldIndexes(t reflect.Type) map[string][]int {
+	if cached, ok := fieldIndexCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+
+	indexes := collectFieldIndexes(t)
+
+	fieldIndexCache.Store(t, indexes)
+
+	return indexes
+}
+
+type indexedField struct {
+	index  []int
+	tagged bool
+}
+
+// collectFieldIndexes walks the fields of t breadth-first, one embedding depth at a time.
+func collectFieldIndexes(t reflect.Type) map[string][]int {
+	type embeddedStruct struct {
+		t     reflect.Type
+		index []int
+	}
+
+	indexes := map[string][]int{}
+	resolved := map[string]bool{}
+	visited := map[reflect.Type]bool{}
+
+	for current := []embeddedStruct{{t: t}}; len(current) != 0; {
+		next := []embeddedStruct{}
+		candidates := map[string][]indexedField{}
+
+		for _, embedded := range current {
+			for i := 0; i < embedded.t.NumField(); i++ {
+				field := embedded.t.Field(i)
+				tag := field.Tag.Get("db")
+
+				if tag == "-" {
+					continue
+				}
+
+				index := append(append([]int{}, embedded.index...), i)
+
+				fieldType := field.Type
+				if fieldType.Kind() == reflect.Pointer {
+					fieldType = fieldType.Elem()
+				}
+
+				if field.Anonymous && tag == "" && isStructTarget(fieldType) {
+					// pointers to unexported embedded structs cannot be allocated
+					if field.Type.Kind() != reflect.Pointer || field.IsExported() {
+						next = append(next, embeddedStruct{t: fieldType, index: index})
+					}
+					continue
+				}
+
+				if !field.IsExported() {
+					continue
+				}
+
+				name := strings.ToLower(field.Name)
+				if tag != "" {
+					name = strings.ToLower(strings.Split(tag, ",")[0])
+				}
+
+				if !resolved[name] {
+					candidates[name] = append(candidates[name], indexedField{index: index, tagged: tag != ""})
+				}
+			}
+		}
+
+		for name, fields := range candidates {
+			resolved[name] = true
+
+			if index, ok := dominantField(fields); ok {
+				indexes[name] = index
+			}
+		}
+
+		for _, embedded := range current {
+			visited[embedded.t] = true
+		}
+
+		// a struct already walked at a shallower depth only holds shadowed fields
+		current = current[:0]
+		for _, embedded := range next {
+			if !visited[embedded.t] {
+				current = append(current, embedded)
+			}
+		}
+	}
+
+	return indexes
+}
+
+// dominantField returns the field among fields of the same name and depth that a column maps to.
+func dominantField(fields []indexedField) ([]int, bool) {
+	if len(fields) == 1 {
+		return fields[0].index, true
+	}
+
+	dominant := []indexedField{}
+	for _, field := range fields {
+		if field.tagged {
+			dominant = append(dominant, field)
+		}
+	}
+
+	if len(dominant) != 1 {
+		return nil, false
+	}
+
+	return dominant[0].index, true
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but allocates nil embedded struct pointers.
+func fieldByIndex(value reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+
+		value = value.Field(x)
+	}
+
+	return value
+}
@@ -0,0 +1,169 @@
+package sql_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	sqlclient "github.com/common-library/go/database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ScanAudit struct {
+	CreatedBy string `db:"created_by"`
+}
+
+type scanUser struct {
+	ScanAudit
+
+	ID      int            `db:"id"`
+	Name    string         `db:"name"`
+	Email   sql.NullString `db:"email"`
+	Age     sql.NullInt64
+	Ignored string `db:"-"`
+}
+
+func openScanTestClient(t *testing.T) *sqlclient.Client {
+	t.Helper()
+
+	client := &sqlclient.Client{}
+	require.NoError(t, client.Open(sqlclient.DriverSQLite, filepath.Join(t.TempDir(), "scan.db"), 1))
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, client.Execute(`
+		CREATE TABLE users (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			email TEXT,
+			age INTEGER,
+			created_by TEXT NOT NULL DEFAULT 'system'
+		)
+	`))
+	require.NoError(t, client.Execute(`
+		INSERT INTO users (id, name, email, age) VALUES
+		(1, 'Alice', 'alice@example.com', 30),
+		(2, 'Bob', NULL, NULL)
+	`))
+
+	return client
+}
+
+func TestQueryAll(t *testing.T) {
+	client := openScanTestClient(t)
+
+	users, err := sqlclient.QueryAll[scanUser](client, "SELECT id, name, email, age, created_by FROM users ORDER BY id")
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	assert.Equal(t, 1, users[0].ID)
+	assert.Equal(t, "Alice", users[0].Name)
+	assert.Equal(t, sql.NullString{String: "alice@example.com", Valid: true}, users[0].Email)
+	assert.Equal(t, sql.NullInt64{Int64: 30, Valid: true}, users[0].Age)
+	assert.Equal(t, "system", users[0].CreatedBy)
+
+	assert.False(t, users[1].Email.Valid)
+	assert.False(t, users[1].Age.Valid)
+
+	empty, err := sqlclient.QueryAll[scanUser](client, "SELECT id, name FROM users WHERE id > ?", 10)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	names, err := sqlclient.QueryAll[string](client, "SELECT name FROM users ORDER BY id")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice", "Bob"}, names)
+
+	_, err = sqlclient.QueryAll[scanUser](client, "SELECT id, name AS unknown FROM users")
+	assert.Error(t, err)
+
+	_, err = sqlclient.QueryAll[string](client, "SELECT id, name FROM users")
+	assert.Error(t, err)
+}
+
+func TestQueryAllEmbeddedPointer(t *testing.T) {
+	client := openScanTestClient(t)
+
+	type user struct {
+		*ScanAudit
+		ID int `db:"id"`
+	}
+
+	users, err := sqlclient.QueryAll[user](client, "SELECT id, created_by FROM users ORDER BY id")
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.NotNil(t, users[0].ScanAudit)
+	assert.Equal(t, "system", users[0].CreatedBy)
+}
+
+func TestQueryAllEmbeddedDepth(t *testing.T) {
+	client := openScanTestClient(t)
+
+	type inner struct {
+		Name string `db:"name"`
+	}
+	type outer struct {
+		inner
+	}
+	type sibling struct {
+		Name string `db:"name"`
+	}
+	type user struct {
+		outer
+		sibling
+	}
+
+	users, err := sqlclient.QueryAll[user](client, "SELECT name FROM users ORDER BY id")
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "Alice", users[0].sibling.Name)
+	assert.Empty(t, users[0].outer.Name)
+
+	type twin sibling
+	type ambiguous struct {
+		outer
+		sibling
+		twin
+	}
+
+	_, err = sqlclient.QueryAll[ambiguous](client, "SELECT name FROM users")
+	assert.Error(t, err)
+
+	type untagged struct {
+		Name string
+	}
+	type tagged struct {
+		sibling
+		untagged
+	}
+
+	taggedUsers, err := sqlclient.QueryAll[tagged](client, "SELECT name FROM users ORDER BY id")
+	require.NoError(t, err)
+	require.Len(t, taggedUsers, 2)
+	assert.Equal(t, "Alice", taggedUsers[0].sibling.Name)
+}
+
+func TestQueryOne(t *testing.T) {
+	client := openScanTestClient(t)
+
+	user, err := sqlclient.QueryOne[scanUser](client, "SELECT id, name, email FROM users WHERE id = ?", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", user.Name)
+	assert.Equal(t, "alice@example.com", user.Email.String)
+
+	count, err := sqlclient.QueryOne[int](client, "SELECT COUNT(*) FROM users")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	_, err = sqlclient.QueryOne[scanUser](client, "SELECT id, name FROM users WHERE id = ?", 10)
+	assert.ErrorIs(t, err, sqlclient.ErrNoRows)
+}
+
+func TestQueryAllBeforeOpen(t *testing.T) {
+	client := &sqlclient.Client{}
+
+	_, err := sqlclient.QueryAll[scanUser](client, "SELECT 1")
+	assert.Error(t, err)
+
+	_, err = sqlclient.QueryOne[scanUser](client, "SELECT 1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,468 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+)
+
+// ConstraintType is the kind of a table constraint, named as in SQL.
+type ConstraintType string
+
+const (
+	ConstraintPrimaryKey = ConstraintType("PRIMARY KEY")
+	ConstraintUnique     = ConstraintType("UNIQUE")
+	ConstraintForeignKey = ConstraintType("FOREIGN KEY")
+	ConstraintCheck      = ConstraintType("CHECK")
+)
+
+// Schema is a driver-neutral description of the tables of a database.
+type Schema struct {
+	// Tables holds the tables sorted by name
+	Tables []Table
+}
+
+// Table describes a table, its columns, indexes and constraints.
+type Table struct {
+	// Name is the table name
+	Name string
+
+	// Engine is the table engine with its settings (ClickHouse only, e.g. "MergeTree ORDER BY id")
+	Engine string
+
+	// Columns holds the columns in table order
+	Columns []Column
+
+	// Indexes holds the indexes that do not back a constraint
+	Indexes []Index
+
+	// Constraints holds the primary key, unique, foreign key and check constraints
+	Constraints []Constraint
+}
+
+// Column describes a table column.
+type Column struct {
+	// Name is the column name
+	Name string
+
+	// Type is the column type in the driver's own syntax (e.g. "varchar(100)", "Nullable(String)")
+	Type string
+
+	// Nullable reports whether the column accepts NULL
+	Nullable bool
+
+	// Default is the default expression, or nil if the column has no default
+	Default *string
+}
+
+// Index describes an index that does not back a constraint.
+type Index struct {
+	// Name is the index name
+	Name string
+
+	// Columns holds the indexed columns (the index expression for ClickHouse)
+	Columns []string
+
+	// Unique reports whether the index is unique
+	Unique bool
+
+	// Definition holds the driver specific rest of the definition (ClickHouse: "TYPE minmax GRANULARITY 1")
+	Definition string
+}
+
+// Constraint describes a table constraint.
+type Constraint struct {
+	// Name is the constraint name
+	Name string
+
+	// Type is the constraint type
+	Type ConstraintType
+
+	// Columns holds the constrained columns (empty for check constraints)
+	Columns []string
+
+	// ReferencedTable is the table referenced by a foreign key
+	ReferencedTable string
+
+	// ReferencedColumns holds the columns referenced by a foreign key
+	ReferencedColumns []string
+
+	// Expression is the expression of a check constraint
+	Expression string
+}
+
+// GetTable returns the table with the given name.
+//
+// Parameters:
+//   - name: Table name
+//
+// Returns:
+//   - Table: The table
+//   - bool: true if the table exists, false otherwise
+func (s *Schema) GetTable(name string) (Table, bool) {
+	for _, table := range s.Tables {
+		if table.Name == name {
+			return table, true
+		}
+	}
+
+	return Table{}, false
+}
+
+// Without returns a copy of the schema without the given tables.
+//
+// It is typically used to leave out bookkeeping tables such as schema_migrations before comparing.
+//
+// Parameters:
+//   - names: Names of the tables to leave out
+//
+// Returns:
+//   - *Schema: Schema without the tables
+//
+// Example:
+//
+//	statements := sql.DiffSchema(sql.DriverPostgreSQL, live.Without("schema_migrations"), expected)
+func (s *Schema) Without(names ...string) *Schema {
+	schema := &Schema{}
+	for _, table := range s.Tables {
+		if !slices.Contains(names, table.Name) {
+			schema.Tables = append(schema.Tables, table)
+		}
+	}
+
+	return schema
+}
+
+// InspectSchema reads the tables, columns, indexes and constraints of the current database or schema.
+//
+// Supported drivers are MySQL (current database), PostgreSQL and SQL Server (current schema),
+// Oracle (tables of the current user), ClickHouse (current database) and SQLite (main database).
+// Views are not included. Indexes that back a primary key, unique or foreign key constraint are
+// reported only as the constraint. For ClickHouse, data skipping indexes are reported as indexes,
+// the primary key as a constraint and the engine with its settings in Table.Engine.
+// The query always runs on the primary connection.
+//
+// Parameters:
+//   - ctx: Context that cancels the inspection
+//
+// Returns:
+//   - *Schema: The inspected schema
+//   - error: Returns an error if Open has not been called, the driver is not supported or a query fails
+//
+// Example:
+//
+//	schema, err := client.InspectSchema(ctx)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	for _, table := range schema.Tables {
+//		fmt.Println(table.Name, len(table.Columns))
+//	}
+func (c *Client) InspectSchema(ctx context.Context) (*Schema, error) {
+	if c.connection == nil {
+		return nil, errors.New("please call Open first")
+	}
+
+	queries, ok := schemaQueries[c.driver]
+	if !ok {
+		return nil, errors.New("schema inspection is not supported for driver " + string(c.driver))
+	}
+
+	builder := &schemaBuilder{tables: map[string]*Table{}}
+
+	if err := c.querySchema(ctx, queries.columns, func(rows *sql.Rows) error {
+		table, column, defaultValue := "", Column{}, sql.NullString{}
+		nullable := int64(0)
+
+		if err := rows.Scan(&table, &column.Name, &column.Type, &nullable, &defaultValue); err != nil {
+			return err
+		}
+
+		column.Nullable = nullable != 0
+		if value := strings.TrimSpace(defaultValue.String); defaultValue.Valid && len(value) != 0 {
+			column.Default = &value
+		}
+
+		builder.table(table).Columns = append(builder.table(table).Columns, column)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := c.querySchema(ctx, queries.indexes, func(rows *sql.Rows) error {
+		table, name, column, definition := "", "", "", sql.NullString{}
+		unique := int64(0)
+
+		if err := rows.Scan(&table, &name, &unique, &column, &definition); err != nil {
+			return err
+		}
+
+		builder.indexColumn(table, name, unique != 0, column, definition.String)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := c.querySchema(ctx, queries.constraints, func(rows *sql.Rows) error {
+		table, name, kind := "", "", ""
+		column, referencedTable, referencedColumn, expression := sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}
+		position := int64(0)
+
+		if err := rows.Scan(&table, &name, &kind, &column, &referencedTable, &referencedColumn, &expression, &position); err != nil {
+			return err
+		}
+
+		builder.constraintColumn(table, name, kind, column.String, referencedTable.String, referencedColumn.String, expression.String)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(queries.tables) != 0 {
+		if err := c.querySchema(ctx, queries.tables, func(rows *sql.Rows) error {
+			table, engine := "", ""
+			if err := rows.Scan(&table, &engine); err != nil {
+				return err
+			}
+
+			if t, ok := builder.tables[table]; ok {
+				t.Engine = engine
+			}
+
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.schema(), nil
+}
+
+// InspectMigrations applies the up sections of dbmate migrations and inspects the resulting schema.
+//
+// The files matching *.sql in the root of migrations are applied in name order (dbmate names start
+// with the version), running the statements between "-- migrate:up" and "-- migrate:down" one by one.
+// No schema_migrations table is written. Run it against an empty scratch database of the same
+// driver, then compare the result with the live schema using DiffSchema to detect drift.
+// Statements are split on semicolons outside of quotes, comments and, for PostgreSQL,
+// dollar-quoted strings, honouring backslash escapes in MySQL and ClickHouse strings as BindNamed
+// does. Procedural blocks containing semicolons (e.g. Oracle PL/SQL) are not supported.
+//
+// Parameters:
+//   - ctx: Context that cancels the migrations and the inspection
+//   - migrations: File system holding the migration files (e.g. an embed.FS or os.DirFS)
+//
+// Returns:
+//   - *Schema: The schema produced by the migrations
+//   - error: Returns an error if a migration cannot be read or applied, or the inspection fails
+//
+// Example:
+//
+//	//go:embed migrations/*.sql
+//	var migrations embed.FS
+//
+//	directory, _ := fs.Sub(migrations, "migrations")
+//	expected, err := scratch.InspectMigrations(ctx, directory)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (c *Client) InspectMigrations(ctx context.Context, migrations fs.FS) (*Schema, error) {
+	if c.connection == nil {
+		return nil, errors.New("please call Open first")
+	}
+
+	names, err := fs.Glob(migrations, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		data, err := fs.ReadFile(migrations, name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, statement := range splitStatements(c.driver, migrationUp(string(data))) {
+			if _, err := c.connection.ExecContext(ctx, statement); err != nil {
+				return nil, errors.New(name + ": " + err.Error())
+			}
+		}
+	}
+
+	return c.InspectSchema(ctx)
+}
+
+func (c *Client) querySchema(ctx context.Context, query string, scan func(rows *sql.Rows) error) error {
+	rows, err := c.connection.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+type schemaBuilder struct {
+	tables map[string]*Table
+}
+
+func (b *schemaBuilder) table(name string) *Table {
+	if table, ok := b.tables[name]; ok {
+		return table
+	}
+
+	table := &Table{Name: name}
+	b.tables[name] = table
+
+	return table
+}
+
+func (b *schemaBuilder) indexColumn(table, name string, unique bool, column, definition string) {
+	t := b.table(table)
+
+	for i := range t.Indexes {
+		if t.Indexes[i].Name == name {
+			t.Indexes[i].Columns = append(t.Indexes[i].Columns, column)
+			return
+		}
+	}
+
+	t.Indexes = append(t.Indexes, Index{Name: name, Columns: []string{column}, Unique: unique, Definition: definition})
+}
+
+func (b *schemaBuilder) constraintColumn(table, name, kind, column, referencedTable, referencedColumn, expression string) {
+	t := b.table(table)
+
+	index := slices.IndexFunc(t.Constraints, func(constraint Constraint) bool { return constraint.Name == name })
+	if index < 0 {
+		constraint := Constraint{Name: name, ReferencedTable: referencedTable, Expression: expression}
+
+		switch kind {
+		case "P":
+			constraint.Type = ConstraintPrimaryKey
+		case "U":
+			constraint.Type = ConstraintUnique
+		case "F", "R":
+			constraint.Type = ConstraintForeignKey
+		default:
+			constraint.Type = ConstraintCheck
+		}
+
+		t.Constraints = append(t.Constraints, constraint)
+		index = len(t.Constraints) - 1
+	}
+
+	constraint := &t.Constraints[index]
+	if constraint.Type == ConstraintCheck || len(column) == 0 {
+		return
+	}
+
+	constraint.Columns = append(constraint.Columns, column)
+	if constraint.Type == ConstraintForeignKey {
+		constraint.ReferencedColumns = append(constraint.ReferencedColumns, referencedColumn)
+	}
+}
+
+func (b *schemaBuilder) schema() *Schema {
+	schema := &Schema{}
+
+	for _, table := range b.tables {
+		table.Indexes = slices.DeleteFunc(table.Indexes, func(index Index) bool {
+			return slices.ContainsFunc(table.Constraints, func(constraint Constraint) bool {
+				return constraint.Type != ConstraintCheck && constraint.Name == index.Name
+			})
+		})
+
+		schema.Tables = append(schema.Tables, *table)
+	}
+
+	slices.SortFunc(schema.Tables, func(a, b Table) int { return strings.Compare(a.Name, b.Name) })
+
+	return schema
+}
+
+// migrationUp returns the up section of a dbmate migration.
+func migrationUp(migration string) string {
+	up := false
+	builder := strings.Builder{}
+
+	for line := range strings.Lines(migration) {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "-- migrate:up") {
+			up = true
+			continue
+		} else if strings.HasPrefix(trimmed, "-- migrate:down") {
+			up = false
+			continue
+		}
+
+		if up {
+			builder.WriteString(line)
+		}
+	}
+
+	return builder.String()
+}
+
+// splitStatements splits a script into statements on semicolons outside of quotes,
+// comments and dollar-quoted strings, reading quotes and dollar quotes as BindNamed does for driver.
+func splitStatements(driver Driver, script string) []string {
+	statements := []string{}
+	start := 0
+
+	add := func(end int) {
+		if statement := strings.TrimSpace(script[start:end]); len(statement) != 0 && !onlyComments(statement) {
+			statements = append(statements, statement)
+		}
+		start = end + 1
+	}
+
+	for i := 0; i < len(script); i++ {
+		switch ch := script[i]; {
+		case ch == '\'' || ch == '"' || ch == '`':
+			i = quoteEnd(driver, script, i) - 1
+		case ch == '-' && strings.HasPrefix(script[i:], "--"):
+			for ; i < len(script) && script[i] != '\n'; i++ {
+			}
+		case ch == '/' && strings.HasPrefix(script[i:], "/*"):
+			if end := strings.Index(script[i+2:], "*/"); end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+		case ch == '$' && driver == DriverPostgreSQL:
+			if end, ok := dollarQuoteEnd(script, i); ok {
+				i = end - 1
+			}
+		case ch == ';':
+			add(i)
+		}
+	}
+
+	add(len(script))
+
+	return statements
+}
+
+func onlyComments(statement string) bool {
+	for line := range strings.Lines(statement) {
+		if line = strings.TrimSpace(line); len(line) != 0 && !strings.HasPrefix(line, "--") {
+			return false
+		}
+	}
+
+	return true
+}
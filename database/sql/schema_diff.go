@@ -0,0 +1,365 @@
+package sql
+
+import (
+	"slices"
+	"strings"
+)
+
+// DiffSchema returns the DDL statements that turn the schema from into the schema to.
+//
+// Tables and columns are matched by name, indexes by name and constraints by their definition,
+// so constraints with generated names (e.g. SQL Server or Oracle) are not reported as drift.
+// Statements are ordered so they can be run one after another: foreign keys, indexes and
+// constraints are dropped first, then tables are dropped and created, columns added, altered
+// and dropped, and finally constraints, indexes and foreign keys are created.
+// Changes the driver cannot express as DDL (e.g. altering a column or adding a constraint on
+// SQLite) are returned as SQL comments starting with "--", so they still show up as drift.
+// Table, column and constraint names are written verbatim.
+//
+// Parameters:
+//   - driver: Driver whose DDL dialect is emitted
+//   - from: Current schema (e.g. the live database)
+//   - to: Desired schema (e.g. the schema produced by the migrations)
+//
+// Returns:
+//   - []string: DDL statements, empty if the schemas match
+//
+// Example:
+//
+//	live, err := client.InspectSchema(ctx)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	expected, err := scratch.InspectMigrations(ctx, migrations)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	if statements := sql.DiffSchema(sql.DriverPostgreSQL, live.Without("schema_migrations"), expected); len(statements) != 0 {
+//		log.Fatalf("schema drift:\n%s", strings.Join(statements, ";\n"))
+//	}
+func DiffSchema(driver Driver, from, to *Schema) []string {
+	d := ddl{driver: driver}
+
+	dropForeignKeys, dropIndexes, dropConstraints, dropTables := []string{}, []string{}, []string{}, []string{}
+	createTables, alterColumns := []string{}, []string{}
+	addConstraints, createIndexes, addForeignKeys := []string{}, []string{}, []string{}
+
+	for _, fromTable := range from.Tables {
+		if _, ok := to.GetTable(fromTable.Name); !ok {
+			dropTables = append(dropTables, "DROP TABLE "+fromTable.Name)
+		}
+	}
+
+	for _, toTable := range to.Tables {
+		fromTable, ok := from.GetTable(toTable.Name)
+		if !ok {
+			createTables = append(createTables, d.createTable(toTable))
+
+			for _, index := range toTable.Indexes {
+				if driver != DriverClickHouse {
+					createIndexes = append(createIndexes, d.createIndex(toTable.Name, index))
+				}
+			}
+
+			for _, constraint := range toTable.Constraints {
+				if constraint.Type == ConstraintForeignKey && driver != DriverSQLite {
+					addForeignKeys = append(addForeignKeys, d.addConstraint(toTable.Name, constraint))
+				}
+			}
+
+			continue
+		}
+
+		for _, constraint := range fromTable.Constraints {
+			if slices.ContainsFunc(toTable.Constraints, constraint.equal) {
+				continue
+			} else if constraint.Type == ConstraintForeignKey {
+				dropForeignKeys = append(dropForeignKeys, d.dropConstraint(fromTable.Name, constraint))
+			} else {
+				dropConstraints = append(dropConstraints, d.dropConstraint(fromTable.Name, constraint))
+			}
+		}
+
+		for _, constraint := range toTable.Constraints {
+			if slices.ContainsFunc(fromTable.Constraints, constraint.equal) {
+				continue
+			} else if constraint.Type == ConstraintForeignKey {
+				addForeignKeys = append(addForeignKeys, d.addConstraint(toTable.Name, constraint))
+			} else {
+				addConstraints = append(addConstraints, d.addConstraint(toTable.Name, constraint))
+			}
+		}
+
+		for _, index := range fromTable.Indexes {
+			if !slices.ContainsFunc(toTable.Indexes, index.equal) {
+				dropIndexes = append(dropIndexes, d.dropIndex(fromTable.Name, index))
+			}
+		}
+
+		for _, index := range toTable.Indexes {
+			if !slices.ContainsFunc(fromTable.Indexes, index.equal) {
+				createIndexes = append(createIndexes, d.createIndex(toTable.Name, index))
+			}
+		}
+
+		alterColumns = append(alterColumns, d.alterColumns(fromTable, toTable)...)
+
+		if driver == DriverClickHouse && fromTable.Engine != toTable.Engine {
+			alterColumns = append(alterColumns, "-- table "+toTable.Name+" engine changed from "+fromTable.Engine+" to "+toTable.Engine)
+		}
+	}
+
+	return slices.Concat(
+		dropForeignKeys, dropIndexes, dropConstraints, dropTables,
+		createTables, alterColumns,
+		addConstraints, createIndexes, addForeignKeys,
+	)
+}
+
+func (c Column) equal(other Column) bool {
+	return c.Name == other.Name && c.Type == other.Type && c.Nullable == other.Nullable && equalDefault(c.Default, other.Default)
+}
+
+func (i Index) equal(other Index) bool {
+	return i.Name == other.Name && i.Unique == other.Unique && i.Definition == other.Definition && slices.Equal(i.Columns, other.Columns)
+}
+
+func (c Constraint) equal(other Constraint) bool {
+	return c.Type == other.Type &&
+		c.ReferencedTable == other.ReferencedTable &&
+		c.Expression == other.Expression &&
+		slices.Equal(c.Columns, other.Columns) &&
+		slices.Equal(c.ReferencedColumns, other.ReferencedColumns)
+}
+
+func equalDefault(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+type ddl struct {
+	driver Driver
+}
+
+func (d ddl) createTable(table Table) string {
+	definitions := []string{}
+
+	for _, column := range table.Columns {
+		definitions = append(definitions, d.columnDefinition(column))
+	}
+
+	for _, constraint := range table.Constraints {
+		if d.driver == DriverClickHouse || (constraint.Type == ConstraintForeignKey && d.driver != DriverSQLite) {
+			continue
+		}
+
+		definitions = append(definitions, d.constraintDefinition(constraint))
+	}
+
+	if d.driver == DriverClickHouse {
+		for _, index := range table.Indexes {
+			definitions = append(definitions, "INDEX "+index.Name+" "+strings.Join(index.Columns, ", ")+" "+index.Definition)
+		}
+	}
+
+	statement := "CREATE TABLE " + table.Name + " (" + strings.Join(definitions, ", ") + ")"
+	if len(table.Engine) != 0 {
+		statement += " ENGINE = " + table.Engine
+	}
+
+	return statement
+}
+
+func (d ddl) alterColumns(from, to Table) []string {
+	adds, alters, drops := []string{}, []string{}, []string{}
+
+	for _, column := range to.Columns {
+		index := slices.IndexFunc(from.Columns, func(c Column) bool { return c.Name == column.Name })
+		if index < 0 {
+			adds = append(adds, d.addColumn(to.Name, column))
+		} else if !from.Columns[index].equal(column) {
+			alters = append(alters, d.alterColumn(to.Name, from.Columns[index], column)...)
+		}
+	}
+
+	for _, column := range from.Columns {
+		if !slices.ContainsFunc(to.Columns, func(c Column) bool { return c.Name == column.Name }) {
+			drops = append(drops, "ALTER TABLE "+from.Name+" DROP COLUMN "+column.Name)
+		}
+	}
+
+	return slices.Concat(adds, alters, drops)
+}
+
+func (d ddl) columnDefinition(column Column) string {
+	definition := column.Name + " " + column.Type
+
+	if column.Default != nil {
+		definition += " DEFAULT " + *column.Default
+	}
+
+	if !column.Nullable && d.driver != DriverClickHouse {
+		definition += " NOT NULL"
+	}
+
+	return definition
+}
+
+func (d ddl) addColumn(table string, column Column) string {
+	switch d.driver {
+	case DriverMicrosoftSQLServer:
+		return "ALTER TABLE " + table + " ADD " + d.columnDefinition(column)
+	case DriverOracle:
+		return "ALTER TABLE " + table + " ADD (" + d.columnDefinition(column) + ")"
+	default:
+		return "ALTER TABLE " + table + " ADD COLUMN " + d.columnDefinition(column)
+	}
+}
+
+func (d ddl) alterColumn(table string, from, to Column) []string {
+	prefix := "ALTER TABLE " + table
+
+	switch d.driver {
+	case DriverMySQL:
+		return []string{prefix + " MODIFY COLUMN " + d.columnDefinition(to)}
+	case DriverClickHouse:
+		return []string{prefix + " MODIFY COLUMN " + d.columnDefinition(to)}
+	case DriverPostgreSQL:
+		statements := []string{}
+
+		if from.Type != to.Type {
+			statements = append(statements, prefix+" ALTER COLUMN "+to.Name+" TYPE "+to.Type)
+		}
+
+		if from.Nullable != to.Nullable && to.Nullable {
+			statements = append(statements, prefix+" ALTER COLUMN "+to.Name+" DROP NOT NULL")
+		} else if from.Nullable != to.Nullable {
+			statements = append(statements, prefix+" ALTER COLUMN "+to.Name+" SET NOT NULL")
+		}
+
+		if !equalDefault(from.Default, to.Default) && to.Default == nil {
+			statements = append(statements, prefix+" ALTER COLUMN "+to.Name+" DROP DEFAULT")
+		} else if !equalDefault(from.Default, to.Default) {
+			statements = append(statements, prefix+" ALTER COLUMN "+to.Name+" SET DEFAULT "+*to.Default)
+		}
+
+		return statements
+	case DriverMicrosoftSQLServer:
+		statements := []string{}
+
+		if from.Type != to.Type || from.Nullable != to.Nullable {
+			null := " NULL"
+			if !to.Nullable {
+				null = " NOT NULL"
+			}
+
+			statements = append(statements, prefix+" ALTER COLUMN "+to.Name+" "+to.Type+null)
+		}
+
+		if !equalDefault(from.Default, to.Default) {
+			statements = append(statements, "-- default of "+table+"."+to.Name+" changed: "+d.columnDefinition(from)+" -> "+d.columnDefinition(to))
+		}
+
+		return statements
+	case DriverOracle:
+		definition := to.Name + " " + to.Type
+
+		if !equalDefault(from.Default, to.Default) && to.Default == nil {
+			definition += " DEFAULT NULL"
+		} else if !equalDefault(from.Default, to.Default) {
+			definition += " DEFAULT " + *to.Default
+		}
+
+		if from.Nullable != to.Nullable && to.Nullable {
+			definition += " NULL"
+		} else if from.Nullable != to.Nullable {
+			definition += " NOT NULL"
+		}
+
+		return []string{prefix + " MODIFY (" + definition + ")"}
+	default:
+		return []string{"-- column " + table + "." + to.Name + " changed: " + d.columnDefinition(from) + " -> " + d.columnDefinition(to)}
+	}
+}
+
+func (d ddl) constraintDefinition(constraint Constraint) string {
+	definition := ""
+
+	switch constraint.Type {
+	case ConstraintPrimaryKey:
+		definition = "PRIMARY KEY (" + strings.Join(constraint.Columns, ", ") + ")"
+	case ConstraintUnique:
+		definition = "UNIQUE (" + strings.Join(constraint.Columns, ", ") + ")"
+	case ConstraintForeignKey:
+		definition = "FOREIGN KEY (" + strings.Join(constraint.Columns, ", ") + ") REFERENCES " +
+			constraint.ReferencedTable + " (" + strings.Join(constraint.ReferencedColumns, ", ") + ")"
+	case ConstraintCheck:
+		definition = "CHECK (" + constraint.Expression + ")"
+	}
+
+	if len(constraint.Name) == 0 || d.driver == DriverSQLite || (d.driver == DriverMySQL && constraint.Type == ConstraintPrimaryKey) {
+		return definition
+	}
+
+	return "CONSTRAINT " + constraint.Name + " " + definition
+}
+
+func (d ddl) addConstraint(table string, constraint Constraint) string {
+	switch d.driver {
+	case DriverSQLite, DriverClickHouse:
+		return "-- constraint " + d.constraintDefinition(constraint) + " of table " + table + " cannot be added"
+	default:
+		return "ALTER TABLE " + table + " ADD " + d.constraintDefinition(constraint)
+	}
+}
+
+func (d ddl) dropConstraint(table string, constraint Constraint) string {
+	prefix := "ALTER TABLE " + table
+
+	switch d.driver {
+	case DriverSQLite, DriverClickHouse:
+		return "-- constraint " + constraint.Name + " of table " + table + " cannot be dropped"
+	case DriverMySQL:
+		switch constraint.Type {
+		case ConstraintPrimaryKey:
+			return prefix + " DROP PRIMARY KEY"
+		case ConstraintForeignKey:
+			return prefix + " DROP FOREIGN KEY " + constraint.Name
+		case ConstraintUnique:
+			return prefix + " DROP INDEX " + constraint.Name
+		default:
+			return prefix + " DROP CHECK " + constraint.Name
+		}
+	default:
+		return prefix + " DROP CONSTRAINT " + constraint.Name
+	}
+}
+
+func (d ddl) createIndex(table string, index Index) string {
+	if d.driver == DriverClickHouse {
+		return "ALTER TABLE " + table + " ADD INDEX " + index.Name + " " + strings.Join(index.Columns, ", ") + " " + index.Definition
+	}
+
+	unique := ""
+	if index.Unique {
+		unique = "UNIQUE "
+	}
+
+	return "CREATE " + unique + "INDEX " + index.Name + " ON " + table + " (" + strings.Join(index.Columns, ", ") + ")"
+}
+
+func (d ddl) dropIndex(table string, index Index) string {
+	switch d.driver {
+	case DriverClickHouse:
+		return "ALTER TABLE " + table + " DROP INDEX " + index.Name
+	case DriverMySQL, DriverMicrosoftSQLServer:
+		return "DROP INDEX " + index.Name + " ON " + table
+	default:
+		return "DROP INDEX " + index.Name
+	}
+}
@@ -0,0 +1,161 @@
+package sql_test
+
+import (
+	"testing"
+
+	sqlclient "github.com/common-library/go/database/sql"
+	"github.com/stretchr/testify/assert"
+)
+
+func diffTestSchemas() (*sqlclient.Schema, *sqlclient.Schema) {
+	zero := "0"
+
+	from := &sqlclient.Schema{Tables: []sqlclient.Table{
+		{
+			Name: "orders",
+			Columns: []sqlclient.Column{
+				{Name: "id", Type: "integer"},
+				{Name: "user_id", Type: "integer"},
+				{Name: "total", Type: "integer", Nullable: true},
+				{Name: "legacy", Type: "text", Nullable: true},
+			},
+			Indexes: []sqlclient.Index{{Name: "idx_orders_legacy", Columns: []string{"legacy"}}},
+			Constraints: []sqlclient.Constraint{
+				{Name: "orders_pkey", Type: sqlclient.ConstraintPrimaryKey, Columns: []string{"id"}},
+				{Name: "orders_user_fk_old", Type: sqlclient.ConstraintForeignKey, Columns: []string{"user_id"}, ReferencedTable: "accounts", ReferencedColumns: []string{"id"}},
+			},
+		},
+		{Name: "sessions", Columns: []sqlclient.Column{{Name: "id", Type: "integer"}}},
+	}}
+
+	to := &sqlclient.Schema{Tables: []sqlclient.Table{
+		{
+			Name: "orders",
+			Columns: []sqlclient.Column{
+				{Name: "id", Type: "integer"},
+				{Name: "user_id", Type: "integer"},
+				{Name: "total", Type: "bigint", Default: &zero},
+				{Name: "status", Type: "varchar(20)"},
+			},
+			Indexes: []sqlclient.Index{{Name: "idx_orders_status", Columns: []string{"status", "id"}, Unique: true}},
+			Constraints: []sqlclient.Constraint{
+				{Name: "PK__orders__generated", Type: sqlclient.ConstraintPrimaryKey, Columns: []string{"id"}},
+				{Name: "orders_user_fk", Type: sqlclient.ConstraintForeignKey, Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+				{Name: "orders_total_check", Type: sqlclient.ConstraintCheck, Expression: "total >= 0"},
+			},
+		},
+		{
+			Name:    "users",
+			Columns: []sqlclient.Column{{Name: "id", Type: "integer"}, {Name: "email", Type: "varchar(100)"}},
+			Indexes: []sqlclient.Index{{Name: "idx_users_email", Columns: []string{"email"}}},
+			Constraints: []sqlclient.Constraint{
+				{Name: "users_pkey", Type: sqlclient.ConstraintPrimaryKey, Columns: []string{"id"}},
+			},
+		},
+	}}
+
+	return from, to
+}
+
+func TestDiffSchema(t *testing.T) {
+	from, to := diffTestSchemas()
+
+	assert.Empty(t, sqlclient.DiffSchema(sqlclient.DriverPostgreSQL, to, to))
+
+	tests := []struct {
+		driver     sqlclient.Driver
+		statements []string
+	}{
+		{sqlclient.DriverPostgreSQL, []string{
+			"ALTER TABLE orders DROP CONSTRAINT orders_user_fk_old",
+			"DROP INDEX idx_orders_legacy",
+			"DROP TABLE sessions",
+			"CREATE TABLE users (id integer NOT NULL, email varchar(100) NOT NULL, CONSTRAINT users_pkey PRIMARY KEY (id))",
+			"ALTER TABLE orders ADD COLUMN status varchar(20) NOT NULL",
+			"ALTER TABLE orders ALTER COLUMN total TYPE bigint",
+			"ALTER TABLE orders ALTER COLUMN total SET NOT NULL",
+			"ALTER TABLE orders ALTER COLUMN total SET DEFAULT 0",
+			"ALTER TABLE orders DROP COLUMN legacy",
+			"ALTER TABLE orders ADD CONSTRAINT orders_total_check CHECK (total >= 0)",
+			"CREATE UNIQUE INDEX idx_orders_status ON orders (status, id)",
+			"CREATE INDEX idx_users_email ON users (email)",
+			"ALTER TABLE orders ADD CONSTRAINT orders_user_fk FOREIGN KEY (user_id) REFERENCES users (id)",
+		}},
+		{sqlclient.DriverMySQL, []string{
+			"ALTER TABLE orders DROP FOREIGN KEY orders_user_fk_old",
+			"DROP INDEX idx_orders_legacy ON orders",
+			"DROP TABLE sessions",
+			"CREATE TABLE users (id integer NOT NULL, email varchar(100) NOT NULL, PRIMARY KEY (id))",
+			"ALTER TABLE orders ADD COLUMN status varchar(20) NOT NULL",
+			"ALTER TABLE orders MODIFY COLUMN total bigint DEFAULT 0 NOT NULL",
+			"ALTER TABLE orders DROP COLUMN legacy",
+			"ALTER TABLE orders ADD CONSTRAINT orders_total_check CHECK (total >= 0)",
+			"CREATE UNIQUE INDEX idx_orders_status ON orders (status, id)",
+			"CREATE INDEX idx_users_email ON users (email)",
+			"ALTER TABLE orders ADD CONSTRAINT orders_user_fk FOREIGN KEY (user_id) REFERENCES users (id)",
+		}},
+		{sqlclient.DriverMicrosoftSQLServer, []string{
+			"ALTER TABLE orders DROP CONSTRAINT orders_user_fk_old",
+			"DROP INDEX idx_orders_legacy ON orders",
+			"DROP TABLE sessions",
+			"CREATE TABLE users (id integer NOT NULL, email varchar(100) NOT NULL, CONSTRAINT users_pkey PRIMARY KEY (id))",
+			"ALTER TABLE orders ADD status varchar(20) NOT NULL",
+			"ALTER TABLE orders ALTER COLUMN total bigint NOT NULL",
+			"-- default of orders.total changed: total integer -> total bigint DEFAULT 0 NOT NULL",
+			"ALTER TABLE orders DROP COLUMN legacy",
+			"ALTER TABLE orders ADD CONSTRAINT orders_total_check CHECK (total >= 0)",
+			"CREATE UNIQUE INDEX idx_orders_status ON orders (status, id)",
+			"CREATE INDEX idx_users_email ON users (email)",
+			"ALTER TABLE orders ADD CONSTRAINT orders_user_fk FOREIGN KEY (user_id) REFERENCES users (id)",
+		}},
+		{sqlclient.DriverOracle, []string{
+			"ALTER TABLE orders DROP CONSTRAINT orders_user_fk_old",
+			"DROP INDEX idx_orders_legacy",
+			"DROP TABLE sessions",
+			"CREATE TABLE users (id integer NOT NULL, email varchar(100) NOT NULL, CONSTRAINT users_pkey PRIMARY KEY (id))",
+			"ALTER TABLE orders ADD (status varchar(20) NOT NULL)",
+			"ALTER TABLE orders MODIFY (total bigint DEFAULT 0 NOT NULL)",
+			"ALTER TABLE orders DROP COLUMN legacy",
+			"ALTER TABLE orders ADD CONSTRAINT orders_total_check CHECK (total >= 0)",
+			"CREATE UNIQUE INDEX idx_orders_status ON orders (status, id)",
+			"CREATE INDEX idx_users_email ON users (email)",
+			"ALTER TABLE orders ADD CONSTRAINT orders_user_fk FOREIGN KEY (user_id) REFERENCES users (id)",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.driver), func(t *testing.T) {
+			assert.Equal(t, test.statements, sqlclient.DiffSchema(test.driver, from, to))
+		})
+	}
+}
+
+func TestDiffSchemaClickHouse(t *testing.T) {
+	from := &sqlclient.Schema{Tables: []sqlclient.Table{{
+		Name:    "events",
+		Engine:  "MergeTree ORDER BY id",
+		Columns: []sqlclient.Column{{Name: "id", Type: "UInt64"}},
+	}}}
+
+	to := &sqlclient.Schema{Tables: []sqlclient.Table{
+		{
+			Name:    "events",
+			Engine:  "MergeTree ORDER BY id",
+			Columns: []sqlclient.Column{{Name: "id", Type: "UInt64"}, {Name: "name", Type: "Nullable(String)", Nullable: true}},
+			Indexes: []sqlclient.Index{{Name: "idx_name", Columns: []string{"name"}, Definition: "TYPE bloom_filter GRANULARITY 1"}},
+		},
+		{
+			Name:        "logs",
+			Engine:      "MergeTree ORDER BY id",
+			Columns:     []sqlclient.Column{{Name: "id", Type: "UInt64"}},
+			Indexes:     []sqlclient.Index{{Name: "idx_id", Columns: []string{"id"}, Definition: "TYPE minmax GRANULARITY 1"}},
+			Constraints: []sqlclient.Constraint{{Name: "PRIMARY", Type: sqlclient.ConstraintPrimaryKey, Columns: []string{"id"}}},
+		},
+	}}
+
+	assert.Equal(t, []string{
+		"CREATE TABLE logs (id UInt64, INDEX idx_id id TYPE minmax GRANULARITY 1) ENGINE = MergeTree ORDER BY id",
+		"ALTER TABLE events ADD COLUMN name Nullable(String)",
+		"ALTER TABLE events ADD INDEX idx_name name TYPE bloom_filter GRANULARITY 1",
+	}, sqlclient.DiffSchema(sqlclient.DriverClickHouse, from, to))
+}
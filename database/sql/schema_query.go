@@ -0,0 +1,213 @@
+package sql
+
+// schemaQuery holds the catalog queries used by InspectSchema for one driver.
+//
+// Every driver returns the same shape:
+//   - columns: table, column, type, nullable (1 or 0), default (NULL if none)
+//   - indexes: table, index, unique (1 or 0), column, definition (NULL if none), ordered by column position
+//   - constraints: table, constraint, type (P, U, F or R, C), column, referenced table,
+//     referenced column, check expression, position; ordered by table, constraint and position
+//   - tables (optional): table, engine
+type schemaQuery struct {
+	columns     string
+	indexes     string
+	constraints string
+	tables      string
+}
+
+var schemaQueries = map[Driver]schemaQuery{
+	DriverMySQL: {
+		columns: `
+			SELECT c.TABLE_NAME, c.COLUMN_NAME, c.COLUMN_TYPE, CASE WHEN c.IS_NULLABLE = 'YES' THEN 1 ELSE 0 END, c.COLUMN_DEFAULT
+			FROM information_schema.COLUMNS c
+			JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+			WHERE c.TABLE_SCHEMA = DATABASE() AND t.TABLE_TYPE = 'BASE TABLE'
+			ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`,
+		indexes: `
+			SELECT TABLE_NAME, INDEX_NAME, CASE WHEN NON_UNIQUE = 0 THEN 1 ELSE 0 END, COLUMN_NAME, NULL
+			FROM information_schema.STATISTICS
+			WHERE TABLE_SCHEMA = DATABASE() AND INDEX_NAME <> 'PRIMARY' AND COLUMN_NAME IS NOT NULL
+			ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX`,
+		constraints: `
+			SELECT tc.TABLE_NAME, tc.CONSTRAINT_NAME,
+				CASE tc.CONSTRAINT_TYPE WHEN 'PRIMARY KEY' THEN 'P' WHEN 'UNIQUE' THEN 'U' ELSE 'F' END,
+				k.COLUMN_NAME, k.REFERENCED_TABLE_NAME, k.REFERENCED_COLUMN_NAME, NULL, k.ORDINAL_POSITION
+			FROM information_schema.TABLE_CONSTRAINTS tc
+			JOIN information_schema.KEY_COLUMN_USAGE k
+				ON k.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND k.TABLE_NAME = tc.TABLE_NAME AND k.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+			WHERE tc.TABLE_SCHEMA = DATABASE() AND tc.CONSTRAINT_TYPE IN ('PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY')
+			UNION ALL
+			SELECT tc.TABLE_NAME, tc.CONSTRAINT_NAME, 'C', NULL, NULL, NULL, cc.CHECK_CLAUSE, 0
+			FROM information_schema.TABLE_CONSTRAINTS tc
+			JOIN information_schema.CHECK_CONSTRAINTS cc
+				ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+			WHERE tc.TABLE_SCHEMA = DATABASE() AND tc.CONSTRAINT_TYPE = 'CHECK'
+			ORDER BY 1, 2, 8`,
+	},
+	DriverPostgreSQL: {
+		columns: `
+			SELECT c.relname, a.attname, format_type(a.atttypid, a.atttypmod),
+				CASE WHEN a.attnotnull THEN 0 ELSE 1 END, pg_get_expr(d.adbin, d.adrelid)
+			FROM pg_attribute a
+			JOIN pg_class c ON c.oid = a.attrelid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+			WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped
+			ORDER BY c.relname, a.attnum`,
+		indexes: `
+			SELECT t.relname, i.relname, CASE WHEN ix.indisunique THEN 1 ELSE 0 END, a.attname, NULL
+			FROM pg_index ix
+			JOIN pg_class t ON t.oid = ix.indrelid
+			JOIN pg_class i ON i.oid = ix.indexrelid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, position) ON true
+			JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+			WHERE n.nspname = current_schema() AND t.relkind IN ('r', 'p')
+			ORDER BY t.relname, i.relname, k.position`,
+		constraints: `
+			SELECT t.relname, c.conname, upper(c.contype::text), a.attname, r.relname, ra.attname,
+				CASE WHEN c.contype = 'c' THEN pg_get_expr(c.conbin, c.conrelid) END, COALESCE(k.position, 0)
+			FROM pg_constraint c
+			JOIN pg_class t ON t.oid = c.conrelid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			LEFT JOIN LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, referenced_attnum, position) ON true
+			LEFT JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+			LEFT JOIN pg_class r ON r.oid = c.confrelid
+			LEFT JOIN pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = k.referenced_attnum
+			WHERE n.nspname = current_schema() AND c.contype IN ('p', 'u', 'f', 'c')
+			ORDER BY 1, 2, 8`,
+	},
+	DriverMicrosoftSQLServer: {
+		columns: `
+			SELECT c.TABLE_NAME, c.COLUMN_NAME,
+				c.DATA_TYPE + CASE
+					WHEN c.DATA_TYPE IN ('text', 'ntext', 'image', 'xml') THEN ''
+					WHEN c.CHARACTER_MAXIMUM_LENGTH = -1 THEN '(max)'
+					WHEN c.CHARACTER_MAXIMUM_LENGTH IS NOT NULL THEN '(' + CAST(c.CHARACTER_MAXIMUM_LENGTH AS varchar(10)) + ')'
+					WHEN c.DATA_TYPE IN ('decimal', 'numeric') THEN '(' + CAST(c.NUMERIC_PRECISION AS varchar(10)) + ',' + CAST(c.NUMERIC_SCALE AS varchar(10)) + ')'
+					ELSE ''
+				END,
+				CASE WHEN c.IS_NULLABLE = 'YES' THEN 1 ELSE 0 END, c.COLUMN_DEFAULT
+			FROM INFORMATION_SCHEMA.COLUMNS c
+			JOIN INFORMATION_SCHEMA.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+			WHERE c.TABLE_SCHEMA = SCHEMA_NAME() AND t.TABLE_TYPE = 'BASE TABLE'
+			ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`,
+		indexes: `
+			SELECT t.name, i.name, CASE WHEN i.is_unique = 1 THEN 1 ELSE 0 END, c.name, NULL
+			FROM sys.indexes i
+			JOIN sys.tables t ON t.object_id = i.object_id
+			JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+			JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+			WHERE t.schema_id = SCHEMA_ID() AND i.type > 0 AND i.is_primary_key = 0 AND i.is_unique_constraint = 0 AND ic.is_included_column = 0
+			ORDER BY t.name, i.name, ic.key_ordinal`,
+		constraints: `
+			SELECT tc.TABLE_NAME, tc.CONSTRAINT_NAME, CASE tc.CONSTRAINT_TYPE WHEN 'PRIMARY KEY' THEN 'P' ELSE 'U' END,
+				k.COLUMN_NAME, NULL, NULL, NULL, k.ORDINAL_POSITION
+			FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+			JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE k ON k.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND k.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+			WHERE tc.TABLE_SCHEMA = SCHEMA_NAME() AND tc.CONSTRAINT_TYPE IN ('PRIMARY KEY', 'UNIQUE')
+			UNION ALL
+			SELECT t.name, fk.name, 'F', c.name, rt.name, rc.name, NULL, fkc.constraint_column_id
+			FROM sys.foreign_keys fk
+			JOIN sys.tables t ON t.object_id = fk.parent_object_id
+			JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+			JOIN sys.columns c ON c.object_id = fkc.parent_object_id AND c.column_id = fkc.parent_column_id
+			JOIN sys.tables rt ON rt.object_id = fkc.referenced_object_id
+			JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+			WHERE t.schema_id = SCHEMA_ID()
+			UNION ALL
+			SELECT t.name, cc.name, 'C', NULL, NULL, NULL, cc.definition, 0
+			FROM sys.check_constraints cc
+			JOIN sys.tables t ON t.object_id = cc.parent_object_id
+			WHERE t.schema_id = SCHEMA_ID()
+			ORDER BY 1, 2, 8`,
+	},
+	DriverOracle: {
+		columns: `
+			SELECT c.table_name, c.column_name,
+				c.data_type || CASE
+					WHEN c.data_type IN ('VARCHAR2', 'NVARCHAR2', 'CHAR', 'NCHAR') THEN '(' || c.char_length || ')'
+					WHEN c.data_type = 'RAW' THEN '(' || c.data_length || ')'
+					WHEN c.data_type = 'NUMBER' AND c.data_precision IS NOT NULL THEN '(' || c.data_precision || ',' || c.data_scale || ')'
+				END,
+				CASE WHEN c.nullable = 'Y' THEN 1 ELSE 0 END, c.data_default
+			FROM user_tab_columns c
+			JOIN user_tables t ON t.table_name = c.table_name
+			WHERE t.dropped = 'NO'
+			ORDER BY c.table_name, c.column_id`,
+		indexes: `
+			SELECT i.table_name, i.index_name, CASE WHEN i.uniqueness = 'UNIQUE' THEN 1 ELSE 0 END, ic.column_name, NULL
+			FROM user_indexes i
+			JOIN user_ind_columns ic ON ic.index_name = i.index_name
+			JOIN user_tables t ON t.table_name = i.table_name
+			WHERE t.dropped = 'NO' AND i.index_type IN ('NORMAL', 'BITMAP')
+				AND NOT EXISTS (SELECT 1 FROM user_constraints uc WHERE uc.index_name = i.index_name)
+			ORDER BY i.table_name, i.index_name, ic.column_position`,
+		constraints: `
+			SELECT c.table_name, c.constraint_name, c.constraint_type, cc.column_name, r.table_name, rc.column_name,
+				CASE WHEN c.constraint_type = 'C' THEN c.search_condition_vc END, NVL(cc.position, 0)
+			FROM user_constraints c
+			JOIN user_tables t ON t.table_name = c.table_name
+			LEFT JOIN user_cons_columns cc ON cc.constraint_name = c.constraint_name AND c.constraint_type <> 'C'
+			LEFT JOIN user_constraints r ON r.constraint_name = c.r_constraint_name
+			LEFT JOIN user_cons_columns rc ON rc.constraint_name = c.r_constraint_name AND rc.position = cc.position
+			WHERE t.dropped = 'NO' AND c.constraint_type IN ('P', 'U', 'R', 'C')
+				AND NOT (c.constraint_type = 'C' AND c.generated = 'GENERATED NAME' AND c.search_condition_vc LIKE '% IS NOT NULL')
+			ORDER BY 1, 2, 8`,
+	},
+	DriverClickHouse: {
+		columns: `
+			SELECT table, name, type, toInt64(startsWith(type, 'Nullable(')), if(default_kind = 'DEFAULT', default_expression, NULL)
+			FROM system.columns
+			WHERE database = currentDatabase()
+				AND table IN (SELECT name FROM system.tables WHERE database = currentDatabase() AND NOT is_temporary AND engine NOT IN ('View', 'MaterializedView'))
+			ORDER BY table, position`,
+		indexes: `
+			SELECT table, name, toInt64(0), expr, concat('TYPE ', type_full, ' GRANULARITY ', toString(granularity))
+			FROM system.data_skipping_indices
+			WHERE database = currentDatabase()
+			ORDER BY table, name`,
+		constraints: `
+			SELECT name, 'PRIMARY', 'P', column, NULL, NULL, NULL, toInt64(position)
+			FROM system.tables
+			ARRAY JOIN splitByString(', ', primary_key) AS column, arrayEnumerate(splitByString(', ', primary_key)) AS position
+			WHERE database = currentDatabase() AND primary_key != ''
+			ORDER BY 1, 2, 8`,
+		tables: `
+			SELECT name, engine_full
+			FROM system.tables
+			WHERE database = currentDatabase() AND NOT is_temporary`,
+	},
+	DriverSQLite: {
+		columns: `
+			SELECT m.name, p.name, p.type, CASE WHEN p."notnull" = 0 THEN 1 ELSE 0 END, p.dflt_value
+			FROM sqlite_master m
+			JOIN pragma_table_info(m.name) p
+			WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%'
+			ORDER BY m.name, p.cid`,
+		indexes: `
+			SELECT m.name, l.name, l."unique", i.name, NULL
+			FROM sqlite_master m
+			JOIN pragma_index_list(m.name) l
+			JOIN pragma_index_info(l.name) i
+			WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' AND l.origin = 'c'
+			ORDER BY m.name, l.name, i.seqno`,
+		constraints: `
+			SELECT m.name, 'pk_' || m.name, 'P', p.name, NULL, NULL, NULL, p.pk
+			FROM sqlite_master m
+			JOIN pragma_table_info(m.name) p
+			WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' AND p.pk > 0
+			UNION ALL
+			SELECT m.name, l.name, 'U', i.name, NULL, NULL, NULL, i.seqno
+			FROM sqlite_master m
+			JOIN pragma_index_list(m.name) l
+			JOIN pragma_index_info(l.name) i
+			WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' AND l.origin = 'u'
+			UNION ALL
+			SELECT m.name, 'fk_' || m.name || '_' || f.id, 'F', f."from", f."table", f."to", NULL, f.seq
+			FROM sqlite_master m
+			JOIN pragma_foreign_key_list(m.name) f
+			WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%'
+			ORDER BY 1, 2, 8`,
+	},
+}
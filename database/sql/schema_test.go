@@ -0,0 +1,156 @@
+package sql_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	sqlclient "github.com/common-library/go/database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var schemaTestMigrations = fstest.MapFS{
+	"20240101000000_create_teams.sql": &fstest.MapFile{Data: []byte(`-- migrate:up
+CREATE TABLE teams (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE
+);
+
+-- migrate:down
+DROP TABLE teams;
+`)},
+	"20240102000000_create_members.sql": &fstest.MapFile{Data: []byte(`-- migrate:up
+CREATE TABLE members (
+    id INTEGER PRIMARY KEY,
+    team_id INTEGER NOT NULL REFERENCES teams (id),
+    email TEXT NOT NULL,
+    role TEXT DEFAULT 'member', -- comment; with a semicolon
+    note TEXT
+);
+CREATE INDEX idx_members_team ON members (team_id, email);
+INSERT INTO teams (id, name) VALUES (1, 'a;b');
+
+-- migrate:down
+DROP TABLE members;
+`)},
+}
+
+func openSchemaTestClient(t *testing.T) *sqlclient.Client {
+	t.Helper()
+
+	client := &sqlclient.Client{}
+	require.NoError(t, client.Open(sqlclient.DriverSQLite, filepath.Join(t.TempDir(), "schema.db"), 1))
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestInspectMigrations(t *testing.T) {
+	client := openSchemaTestClient(t)
+
+	schema, err := client.InspectMigrations(context.Background(), schemaTestMigrations)
+	require.NoError(t, err)
+	require.Len(t, schema.Tables, 2)
+
+	members, ok := schema.GetTable("members")
+	require.True(t, ok)
+	assert.Equal(t, "members", schema.Tables[0].Name)
+
+	memberDefault := "'member'"
+	assert.Equal(t, []sqlclient.Column{
+		{Name: "id", Type: "INTEGER", Nullable: true},
+		{Name: "team_id", Type: "INTEGER"},
+		{Name: "email", Type: "TEXT"},
+		{Name: "role", Type: "TEXT", Nullable: true, Default: &memberDefault},
+		{Name: "note", Type: "TEXT", Nullable: true},
+	}, members.Columns)
+	assert.Equal(t, []sqlclient.Index{{Name: "idx_members_team", Columns: []string{"team_id", "email"}}}, members.Indexes)
+	assert.Equal(t, []sqlclient.Constraint{
+		{Name: "fk_members_0", Type: sqlclient.ConstraintForeignKey, Columns: []string{"team_id"}, ReferencedTable: "teams", ReferencedColumns: []string{"id"}},
+		{Name: "pk_members", Type: sqlclient.ConstraintPrimaryKey, Columns: []string{"id"}},
+	}, members.Constraints)
+
+	teams, ok := schema.GetTable("teams")
+	require.True(t, ok)
+	assert.Empty(t, teams.Indexes)
+	assert.ElementsMatch(t, []sqlclient.Constraint{
+		{Name: "pk_teams", Type: sqlclient.ConstraintPrimaryKey, Columns: []string{"id"}},
+		{Name: "sqlite_autoindex_teams_1", Type: sqlclient.ConstraintUnique, Columns: []string{"name"}},
+	}, teams.Constraints)
+
+	name, err := sqlclient.QueryOne[string](client, "SELECT name FROM teams WHERE id = 1")
+	require.NoError(t, err)
+	assert.Equal(t, "a;b", name)
+
+	_, ok = schema.Without("teams").GetTable("teams")
+	assert.False(t, ok)
+}
+
+func TestInspectSchemaDrift(t *testing.T) {
+	expected, err := openSchemaTestClient(t).InspectMigrations(context.Background(), schemaTestMigrations)
+	require.NoError(t, err)
+
+	client := openSchemaTestClient(t)
+	_, err = client.InspectMigrations(context.Background(), schemaTestMigrations)
+	require.NoError(t, err)
+
+	live, err := client.InspectSchema(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, sqlclient.DiffSchema(sqlclient.DriverSQLite, live, expected))
+
+	require.NoError(t, client.Execute("ALTER TABLE members DROP COLUMN note"))
+	require.NoError(t, client.Execute("ALTER TABLE members ADD COLUMN nickname TEXT"))
+	require.NoError(t, client.Execute("DROP INDEX idx_members_team"))
+	require.NoError(t, client.Execute("CREATE TABLE audit (id INTEGER)"))
+
+	live, err = client.InspectSchema(context.Background())
+	require.NoError(t, err)
+
+	statements := sqlclient.DiffSchema(sqlclient.DriverSQLite, live, expected)
+	assert.Equal(t, []string{
+		"DROP TABLE audit",
+		"ALTER TABLE members ADD COLUMN note TEXT",
+		"ALTER TABLE members DROP COLUMN nickname",
+		"CREATE INDEX idx_members_team ON members (team_id, email)",
+	}, statements)
+
+	for _, statement := range statements {
+		require.NoError(t, client.Execute(statement))
+	}
+
+	live, err = client.InspectSchema(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, sqlclient.DiffSchema(sqlclient.DriverSQLite, live, expected))
+}
+
+func TestInspectMigrationsQuotes(t *testing.T) {
+	client := openSchemaTestClient(t)
+
+	_, err := client.InspectMigrations(context.Background(), fstest.MapFS{
+		"1_notes.sql": &fstest.MapFile{Data: []byte(`-- migrate:up
+CREATE TABLE notes (body TEXT NOT NULL);
+INSERT INTO notes (body) VALUES ('C:\'); INSERT INTO notes (body) VALUES ('it''s; x');
+`)},
+	})
+	require.NoError(t, err)
+
+	bodies, err := sqlclient.QueryAll[string](client, "SELECT body FROM notes ORDER BY body")
+	require.NoError(t, err)
+	assert.Equal(t, []string{`C:\`, "it's; x"}, bodies)
+}
+
+func TestInspectSchemaErrors(t *testing.T) {
+	_, err := (&sqlclient.Client{}).InspectSchema(context.Background())
+	assert.Error(t, err)
+
+	_, err = (&sqlclient.Client{}).InspectMigrations(context.Background(), schemaTestMigrations)
+	assert.Error(t, err)
+
+	client := openSchemaTestClient(t)
+	_, err = client.InspectMigrations(context.Background(), fstest.MapFS{
+		"1_broken.sql": &fstest.MapFile{Data: []byte("-- migrate:up\nCREATE TABLE broken (\n")},
+	})
+	assert.ErrorContains(t, err, "1_broken.sql")
+}